@@ -0,0 +1,2 @@
+-- 権限・セッション変数の初期設定のため、取り消しできない
+-- (ステートメントを含まないロールバック用のスクリプトは、取り消し不可のマイグレーションとして扱われる)
@@ -0,0 +1 @@
+ALTER TABLE `furumane`.`admins` DROP COLUMN `phone_number`;
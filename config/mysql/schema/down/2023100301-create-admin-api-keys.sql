@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `furumane`.`admin_api_keys`;
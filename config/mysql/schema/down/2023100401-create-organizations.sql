@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `furumane`.`organization_members`;
+DROP TABLE IF EXISTS `furumane`.`organizations`;
@@ -0,0 +1,7 @@
+-- 暗号化済みの値が残っている場合は列長の変更に失敗するため、事前に復号しておくこと
+DROP INDEX `ui_admin_email_index` ON `furumane`.`admins`;
+ALTER TABLE `furumane`.`admins` DROP COLUMN `email_index`;
+ALTER TABLE `furumane`.`admins` MODIFY COLUMN `phone_number` VARCHAR(11) NULL DEFAULT NULL;
+ALTER TABLE `furumane`.`admins` MODIFY COLUMN `email` VARCHAR(256) NULL DEFAULT NULL;
+
+CREATE UNIQUE INDEX `ui_admin_email` ON `furumane`.`admins` (`exists` DESC, `email` ASC) VISIBLE;
@@ -0,0 +1,10 @@
+// Package schema - データベースのマイグレーションファイル
+//
+// 適用用のスクリプトはupディレクトリ、ロールバック用のスクリプトはdownディレクトリに同じファイル名で配置する
+// (ローカル環境ではupディレクトリのみをMySQLコンテナの初期化スクリプトとしてマウントする)
+package schema
+
+import "embed"
+
+//go:embed up/*.sql down/*.sql
+var FS embed.FS
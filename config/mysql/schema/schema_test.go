@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+	migrations, err := mysql.LoadMigrations(FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for _, migration := range migrations {
+		assert.True(t, migration.HasDown, "down script is required: %s", migration)
+	}
+	// 取り消し不可とするのは初期設定のみ
+	assert.True(t, migrations[0].Irreversible, "setup migration must be irreversible: %s", migrations[0])
+	for _, migration := range migrations[1:] {
+		assert.False(t, migration.Irreversible, "down script has no statements: %s", migration)
+	}
+}
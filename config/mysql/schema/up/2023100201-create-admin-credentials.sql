@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `furumane`.`admin_credentials` (
+  `id`            VARCHAR(22)     NOT NULL,          -- パスキーID
+  `admin_id`      VARCHAR(22)     NOT NULL,          -- 管理者ID
+  `credential_id` VARBINARY(1023) NOT NULL,          -- 認証情報ID
+  `name`          VARCHAR(64)     NOT NULL,          -- パスキー名
+  `public_key`    BLOB            NOT NULL,          -- 公開鍵（COSE_Key形式）
+  `sign_count`    INT UNSIGNED    NOT NULL,          -- 署名回数
+  `transports`    JSON            NULL DEFAULT NULL, -- 認証器との通信方法
+  `aaguid`        BINARY(16)      NULL DEFAULT NULL, -- 認証器の種別
+  `created_at`    DATETIME(3)     NOT NULL,          -- 登録日時
+  `updated_at`    DATETIME(3)     NOT NULL,          -- 更新日時
+  `last_used_at`  DATETIME(3)     NULL DEFAULT NULL, -- 最終利用日時
+  PRIMARY KEY(`id`),
+  CONSTRAINT `fk_admin_credentials_admin_id`
+    FOREIGN KEY (`admin_id`) REFERENCES `furumane`.`admins` (`id`)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX `ui_admin_credentials_credential_id` ON `furumane`.`admin_credentials` (`credential_id` ASC) VISIBLE;
+CREATE INDEX `idx_admin_credentials_admin_id` ON `furumane`.`admin_credentials` (`admin_id` ASC) VISIBLE;
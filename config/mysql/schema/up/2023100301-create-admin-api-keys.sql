@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `furumane`.`admin_api_keys` (
+  `id`           VARCHAR(22) NOT NULL,          -- APIキーID
+  `admin_id`     VARCHAR(22) NOT NULL,          -- 管理者ID
+  `name`         VARCHAR(64) NOT NULL,          -- APIキー名
+  `prefix`       VARCHAR(16) NOT NULL,          -- APIキーの接頭辞（識別表示用）
+  `key_hash`     BINARY(32)  NOT NULL,          -- APIキーのハッシュ値（SHA-256）
+  `scopes`       JSON        NOT NULL,          -- 許可するスコープ一覧
+  `allowed_ips`  JSON        NULL DEFAULT NULL, -- 接続を許可するIPアドレス一覧
+  `expires_at`   DATETIME(3) NULL DEFAULT NULL, -- 有効期限
+  `last_used_at` DATETIME(3) NULL DEFAULT NULL, -- 最終利用日時
+  `revoked_at`   DATETIME(3) NULL DEFAULT NULL, -- 失効日時
+  `created_at`   DATETIME(3) NOT NULL,          -- 登録日時
+  `updated_at`   DATETIME(3) NOT NULL,          -- 更新日時
+  PRIMARY KEY(`id`),
+  CONSTRAINT `fk_admin_api_keys_admin_id`
+    FOREIGN KEY (`admin_id`) REFERENCES `furumane`.`admins` (`id`)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX `ui_admin_api_keys_key_hash` ON `furumane`.`admin_api_keys` (`key_hash` ASC) VISIBLE;
+CREATE INDEX `idx_admin_api_keys_admin_id` ON `furumane`.`admin_api_keys` (`admin_id` ASC) VISIBLE;
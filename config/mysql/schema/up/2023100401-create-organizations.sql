@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `furumane`.`organizations` (
+  `id`         VARCHAR(22) NOT NULL, -- 組織ID
+  `code`       VARCHAR(6)  NOT NULL, -- 地方公共団体コード
+  `name`       VARCHAR(64) NOT NULL, -- 組織名
+  `created_at` DATETIME(3) NOT NULL, -- 登録日時
+  `updated_at` DATETIME(3) NOT NULL, -- 更新日時
+  PRIMARY KEY(`id`)
+);
+
+CREATE UNIQUE INDEX `ui_organizations_code` ON `furumane`.`organizations` (`code` ASC) VISIBLE;
+
+CREATE TABLE IF NOT EXISTS `furumane`.`organization_members` (
+  `organization_id` VARCHAR(22) NOT NULL, -- 組織ID
+  `admin_id`        VARCHAR(22) NOT NULL, -- 管理者ID
+  `role`            INT         NOT NULL, -- 組織内の権限
+  `created_at`      DATETIME(3) NOT NULL, -- 登録日時
+  `updated_at`      DATETIME(3) NOT NULL, -- 更新日時
+  PRIMARY KEY(`organization_id`, `admin_id`),
+  CONSTRAINT `fk_organization_members_organization_id`
+    FOREIGN KEY (`organization_id`) REFERENCES `furumane`.`organizations` (`id`)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_organization_members_admin_id`
+    FOREIGN KEY (`admin_id`) REFERENCES `furumane`.`admins` (`id`)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX `idx_organization_members_admin_id` ON `furumane`.`organization_members` (`admin_id` ASC) VISIBLE;
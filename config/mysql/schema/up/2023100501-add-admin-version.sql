@@ -0,0 +1 @@
+ALTER TABLE `furumane`.`admins` ADD COLUMN `version` BIGINT NOT NULL DEFAULT 1; -- 楽観的排他制御用のバージョン
@@ -0,0 +1,7 @@
+-- 個人情報はアプリケーションで暗号化して保存するため、暗号文を格納できる長さに拡張する
+ALTER TABLE `furumane`.`admins` MODIFY COLUMN `email` VARCHAR(1024) NULL DEFAULT NULL;        -- メールアドレス（暗号化）
+ALTER TABLE `furumane`.`admins` MODIFY COLUMN `phone_number` VARCHAR(1024) NULL DEFAULT NULL; -- 電話番号（暗号化）
+ALTER TABLE `furumane`.`admins` ADD COLUMN `email_index` VARCHAR(64) NULL DEFAULT NULL;       -- メールアドレスのブラインドインデックス
+
+DROP INDEX `ui_admin_email` ON `furumane`.`admins`;
+CREATE UNIQUE INDEX `ui_admin_email_index` ON `furumane`.`admins` (`exists` DESC, `email_index` ASC) VISIBLE;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `furumane`.`passkey_challenges` (
+  `challenge`  VARCHAR(64) NOT NULL, -- チャレンジ（base64url形式）
+  `expires_at` DATETIME(3) NOT NULL, -- 有効期限
+  `created_at` DATETIME(3) NOT NULL, -- 発行日時
+  PRIMARY KEY(`challenge`)
+);
+
+CREATE INDEX `idx_passkey_challenges_expires_at` ON `furumane`.`passkey_challenges` (`expires_at` ASC) VISIBLE;
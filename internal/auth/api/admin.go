@@ -7,15 +7,17 @@ import (
 
 	"github.com/and-period/furumane/internal/auth/database"
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/request"
-	"github.com/and-period/furumane/internal/auth/response"
 	"github.com/and-period/furumane/internal/auth/service"
 	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cognito"
 	"github.com/and-period/furumane/pkg/uuid"
 	"github.com/gin-gonic/gin"
 )
 
+// 管理者情報の更新はCognitoのアクセストークンで本人の操作であることを確認するため、APIキーでの認証は許可しない
+// (APIキーで操作できるリソースはentity.APIKeyScopeの一覧を参照)
 func (c *controller) adminRoutes(rg *gin.RouterGroup) {
 	g := rg.Group("")
 	g.POST("", c.SignUpAdmin)
@@ -34,7 +36,7 @@ func (c *controller) adminRoutes(rg *gin.RouterGroup) {
 func (c *controller) SignUpAdmin(ctx *gin.Context) {
 	req := &request.SignUpAdminRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	cognitoID := uuid.Base58Encode(c.uuid())
@@ -57,7 +59,7 @@ func (c *controller) SignUpAdmin(ctx *gin.Context) {
 	}
 	err := c.db.Admin.Create(ctx, admin, fn)
 	if err != nil && !errors.Is(err, database.ErrAlreadyExists) {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	res := &response.SignUpAdminResponse{
@@ -70,20 +72,20 @@ func (c *controller) SignUpAdmin(ctx *gin.Context) {
 func (c *controller) VerifyAdmin(ctx *gin.Context) {
 	req := &request.VerifyAdminRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.db.Admin.Get(ctx, req.AdminID, "cognito_id", "verified_at")
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	if !admin.VerifiedAt.IsZero() {
-		preconditionFailed(ctx, "this admin is already verified")
+		c.preconditionFailed(ctx, "this admin is already verified")
 		return
 	}
 	if err := c.adminAuth.ConfirmSignUp(ctx, admin.CognitoID, req.VerifyCode); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
@@ -93,12 +95,12 @@ func (c *controller) VerifyAdmin(ctx *gin.Context) {
 func (c *controller) SignUpAdminWithOAuth(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	au, err := c.adminAuth.GetUser(ctx, token)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	params := &entity.AdminParams{
@@ -112,14 +114,19 @@ func (c *controller) SignUpAdminWithOAuth(ctx *gin.Context) {
 		return nil // Cognitoへはすでに登録済みのため何もしない
 	}
 	err = c.db.Admin.Create(ctx, admin, fn)
-	if err != nil && !errors.Is(err, database.ErrAlreadyExists) {
-		httpError(ctx, err)
+	if errors.Is(err, database.ErrAlreadyExists) {
+		// 登録済みの場合は、登録済みの管理者情報を更新する
+		admin, err = c.db.Admin.GetByCognitoID(database.ReadFromPrimary(ctx), au.Username)
+	}
+	if err != nil {
+		c.httpError(ctx, err)
 		return
 	}
-	if err := c.db.Admin.UpdateVerifiedAt(ctx, admin.ID); err != nil {
-		httpError(ctx, err)
+	if err := c.db.Admin.UpdateVerifiedAt(ctx, admin.ID, admin.Version); err != nil {
+		c.httpError(ctx, err)
 		return
 	}
+	ctx.Header(etagHeader, newETag(admin.Version+1))
 	res := &response.SignUpAdminWithOAuthResponse{
 		Admin: service.NewAdmin(admin).Response(),
 	}
@@ -127,11 +134,17 @@ func (c *controller) SignUpAdminWithOAuth(ctx *gin.Context) {
 }
 
 // GetAdmin 管理者情報取得
+// If-None-Matchヘッダーが現在のETagと一致する場合は304を返す
 func (c *controller) GetAdmin(ctx *gin.Context) {
 	adminID := util.GetParam(ctx, "adminId")
 	admin, err := c.db.Admin.Get(ctx, adminID)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Header(etagHeader, newETag(admin.Version))
+	if ifNoneMatch(ctx, admin.Version) {
+		ctx.Status(http.StatusNotModified)
 		return
 	}
 	res := &response.GetAdminResponse{
@@ -144,26 +157,26 @@ func (c *controller) GetAdmin(ctx *gin.Context) {
 func (c *controller) UpdateAdminEmail(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	req := &request.UpdateAdminEmailRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	username, err := c.adminAuth.GetUsername(ctx, token)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.db.Admin.GetByCognitoID(ctx, username)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	if admin.ProviderType != entity.ProviderTypeEmail {
-		preconditionFailed(ctx, "not allow provider type to change email")
+		c.preconditionFailed(ctx, "not allow provider type to change email")
 		return
 	}
 	params := &cognito.ChangeEmailParams{
@@ -173,32 +186,41 @@ func (c *controller) UpdateAdminEmail(ctx *gin.Context) {
 		NewEmail:    req.Email,
 	}
 	if err := c.adminAuth.ChangeEmail(ctx, params); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
+	// 確認時にIf-Matchヘッダーで指定できるよう、現在のETagを返す
+	ctx.Header(etagHeader, newETag(admin.Version))
 	ctx.Status(http.StatusNoContent)
 }
 
 // VerifyAdminEmail 管理者メールアドレス更新後の確認
+// If-Matchヘッダーが指定されている場合は、管理者情報が更新されていないことを検証する
+// 更新後のETagを返し、続けて更新する場合のIf-Matchヘッダーに指定できるようにする
 func (c *controller) VerifyAdminEmail(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	req := &request.VerifyAdminEmailRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	username, err := c.adminAuth.GetUsername(ctx, token)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.db.Admin.GetByCognitoID(ctx, username)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
+		return
+	}
+	version, ok := ifMatch(ctx, admin.Version)
+	if !ok {
+		c.preconditionFailed(ctx, "admin has been modified")
 		return
 	}
 	params := &cognito.ConfirmChangeEmailParams{
@@ -208,13 +230,14 @@ func (c *controller) VerifyAdminEmail(ctx *gin.Context) {
 	}
 	email, err := c.adminAuth.ConfirmChangeEmail(ctx, params)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
-	if err := c.db.Admin.UpdateEmail(ctx, admin.ID, email); err != nil {
-		httpError(ctx, err)
+	if err := c.db.Admin.UpdateEmail(ctx, admin.ID, email, version); err != nil {
+		c.httpError(ctx, err)
 		return
 	}
+	ctx.Header(etagHeader, newETag(version+1))
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -222,12 +245,12 @@ func (c *controller) VerifyAdminEmail(ctx *gin.Context) {
 func (c *controller) UpdateAdminPassword(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	req := &request.UpdateAdminPasswordRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	params := &cognito.ChangePasswordParams{
@@ -236,7 +259,7 @@ func (c *controller) UpdateAdminPassword(ctx *gin.Context) {
 		NewPassword: req.NewPassword,
 	}
 	if err := c.adminAuth.ChangePassword(ctx, params); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
@@ -246,16 +269,16 @@ func (c *controller) UpdateAdminPassword(ctx *gin.Context) {
 func (c *controller) ForgotAdminPassword(ctx *gin.Context) {
 	req := &request.ForgotAdminPasswordRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.db.Admin.GetByEmail(ctx, req.Email, "cognito_id")
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	if err := c.adminAuth.ForgotPassword(ctx, admin.CognitoID); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
@@ -265,12 +288,12 @@ func (c *controller) ForgotAdminPassword(ctx *gin.Context) {
 func (c *controller) ResetAdminPassword(ctx *gin.Context) {
 	req := &request.ResetAdminPasswordRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.db.Admin.GetByEmail(ctx, req.Email, "cognito_id")
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	params := &cognito.ConfirmForgotPasswordParams{
@@ -279,29 +302,39 @@ func (c *controller) ResetAdminPassword(ctx *gin.Context) {
 		NewPassword: req.Password,
 	}
 	if err := c.adminAuth.ConfirmForgotPassword(ctx, params); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
 }
 
 // DeleteAdmin 管理者退会
+// If-Matchヘッダーが指定されている場合は、管理者情報が更新されていないことを検証する
 func (c *controller) DeleteAdmin(ctx *gin.Context) {
 	adminID := util.GetParam(ctx, "adminId")
 	admin, err := c.db.Admin.Get(ctx, adminID)
 	if errors.Is(err, database.ErrNotFound) {
+		if ctx.GetHeader(ifMatchHeader) != "" {
+			c.preconditionFailed(ctx, "admin does not exist")
+			return
+		}
 		ctx.Status(http.StatusNoContent) // 退会済み
 		return
 	}
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
+		return
+	}
+	version, ok := ifMatch(ctx, admin.Version)
+	if !ok {
+		c.preconditionFailed(ctx, "admin has been modified")
 		return
 	}
 	fn := func(ctx context.Context) error {
 		return c.adminAuth.DeleteUser(ctx, admin.CognitoID)
 	}
-	if err := c.db.Admin.Delete(ctx, admin.ID, fn); err != nil {
-		httpError(ctx, err)
+	if err := c.db.Admin.Delete(ctx, admin.ID, version, fn); err != nil {
+		c.httpError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
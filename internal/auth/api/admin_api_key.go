@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/auth/service"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIキーの管理はアクセストークンでの認証のみ許可する
+func (c *controller) adminAPIKeyRoutes(rg *gin.RouterGroup) {
+	g := rg.Group("/api-keys", c.authentication())
+	g.GET("", c.ListAdminAPIKeys)
+	g.POST("", c.CreateAdminAPIKey)
+	g.POST("/:apiKeyId/rotate", c.RotateAdminAPIKey)
+	g.DELETE("/:apiKeyId", c.RevokeAdminAPIKey)
+}
+
+// ListAdminAPIKeys 管理者APIキー一覧取得
+func (c *controller) ListAdminAPIKeys(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	apiKeys, err := c.db.AdminAPIKey.List(ctx, admin.ID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.AdminAPIKeysResponse{
+		APIKeys: service.NewAdminAPIKeys(apiKeys).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateAdminAPIKey 管理者APIキー発行
+func (c *controller) CreateAdminAPIKey(ctx *gin.Context) {
+	req := &request.CreateAdminAPIKeyRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(c.now()) {
+		c.httpError(ctx, status.Error(codes.InvalidArgument, "expiresAt must be in the future"))
+		return
+	}
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	scopes := make([]entity.APIKeyScope, len(req.Scopes))
+	for i := range req.Scopes {
+		scopes[i] = entity.APIKeyScope(req.Scopes[i])
+	}
+	params := &entity.AdminAPIKeyParams{
+		ID:         uuid.Base58Encode(c.uuid()),
+		AdminID:    admin.ID,
+		Name:       req.Name,
+		Scopes:     scopes,
+		AllowedIPs: req.AllowedIPs,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	apiKey, key, err := entity.NewAdminAPIKey(params)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if err := c.db.AdminAPIKey.Create(ctx, apiKey); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.CreateAdminAPIKeyResponse{
+		APIKey: service.NewAdminAPIKey(apiKey).Response(),
+		Key:    key,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RotateAdminAPIKey 管理者APIキー再発行 (スコープ・有効期限などの設定は引き継ぐ)
+func (c *controller) RotateAdminAPIKey(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	apiKey, err := c.db.AdminAPIKey.Get(ctx, admin.ID, util.GetParam(ctx, "apiKeyId"))
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if !apiKey.RevokedAt.IsZero() {
+		c.preconditionFailed(ctx, "this api key is already revoked")
+		return
+	}
+	key, err := apiKey.Rotate()
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if err := c.db.AdminAPIKey.Rotate(ctx, admin.ID, apiKey.ID, apiKey.Prefix, apiKey.KeyHash); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	apiKey.LastUsedAt, apiKey.UpdatedAt = time.Time{}, c.now()
+	res := &response.RotateAdminAPIKeyResponse{
+		APIKey: service.NewAdminAPIKey(apiKey).Response(),
+		Key:    key,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RevokeAdminAPIKey 管理者APIキー失効
+func (c *controller) RevokeAdminAPIKey(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if err := c.db.AdminAPIKey.Revoke(ctx, admin.ID, util.GetParam(ctx, "apiKeyId")); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAdminAPIKeys(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	apiKeys := entity.AdminAPIKeys{
+		{
+			ID:         "api-key-id",
+			AdminID:    "admin-id",
+			Name:       "batch",
+			Prefix:     "fm_abcdefgh",
+			KeyHash:    []byte("hash"),
+			Scopes:     []entity.APIKeyScope{entity.APIKeyScopePasskeyRead},
+			AllowedIPs: []string{"192.0.2.0/24"},
+			CreatedAt:  current,
+			UpdatedAt:  current,
+		},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().List(gomock.Any(), "admin-id").Return(apiKeys, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminAPIKeysResponse{
+					APIKeys: []*response.AdminAPIKey{
+						{
+							ID:         "api-key-id",
+							Name:       "batch",
+							Prefix:     "fm_abcdefgh",
+							Scopes:     []string{"passkey:read"},
+							AllowedIPs: []string{"192.0.2.0/24"},
+							CreatedAt:  current,
+							UpdatedAt:  current,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failed to list api keys",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().List(gomock.Any(), "admin-id").Return(nil, assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/api-keys"
+			testGet(t, tt.setup, tt.expect, path)
+		})
+	}
+}
+
+func TestCreateAdminAPIKey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	apiKeyID := uuid.New()
+	req := &request.CreateAdminAPIKeyRequest{
+		Name:       "batch",
+		Scopes:     []string{"passkey:read", "passkey:write"},
+		AllowedIPs: []string{"192.0.2.1", "198.51.100.0/24"},
+		ExpiresAt:  current.Add(24 * time.Hour),
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.CreateAdminAPIKeyRequest
+		expect int
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, apiKey *entity.AdminAPIKey) error {
+						assert.Equal(t, uuid.Base58Encode(apiKeyID), apiKey.ID)
+						assert.Equal(t, "admin-id", apiKey.AdminID)
+						assert.Equal(t, []entity.APIKeyScope{"passkey:read", "passkey:write"}, apiKey.Scopes)
+						assert.Len(t, apiKey.KeyHash, 32)
+						return nil
+					})
+			},
+			req:    req,
+			expect: http.StatusOK,
+		},
+		{
+			name: "invalid scope",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.CreateAdminAPIKeyRequest{
+				Name:   "batch",
+				Scopes: []string{"admin:write"},
+			},
+			expect: http.StatusBadRequest,
+		},
+		{
+			name: "invalid ip address",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.CreateAdminAPIKeyRequest{
+				Name:       "batch",
+				Scopes:     []string{"passkey:read"},
+				AllowedIPs: []string{"localhost"},
+			},
+			expect: http.StatusBadRequest,
+		},
+		{
+			name: "expired",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.CreateAdminAPIKeyRequest{
+				Name:      "batch",
+				Scopes:    []string{"passkey:read"},
+				ExpiresAt: current,
+			},
+			expect: http.StatusBadRequest,
+		},
+		{
+			name: "failed to create api key",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			req:    req,
+			expect: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, _ := testSetup(t, ctrl, tt.setup, withNow(current), withUUID(apiKeyID))
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			newRoutes(c, r)
+
+			r.ServeHTTP(w, newHTTPRequest(t, http.MethodPost, "/admin/api-keys", tt.req))
+			require.Equal(t, tt.expect, w.Code)
+			if tt.expect != http.StatusOK {
+				return
+			}
+			// 平文のAPIキーは発行時のレスポンスでのみ返す
+			res := &response.CreateAdminAPIKeyResponse{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+			assert.True(t, strings.HasPrefix(res.Key, entity.APIKeyPrefix))
+			assert.Equal(t, res.Key[:len(res.APIKey.Prefix)], res.APIKey.Prefix)
+			assert.Equal(t, "batch", res.APIKey.Name)
+		})
+	}
+}
+
+func TestRotateAdminAPIKey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	apiKey := func() *entity.AdminAPIKey {
+		return &entity.AdminAPIKey{
+			ID:      "api-key-id",
+			AdminID: "admin-id",
+			Prefix:  "fm_abcdefgh",
+			KeyHash: []byte("hash"),
+		}
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Get(gomock.Any(), "admin-id", "api-key-id").Return(apiKey(), nil)
+				mocks.db.adminAPIKey.EXPECT().
+					Rotate(gomock.Any(), "admin-id", "api-key-id", gomock.Not("fm_abcdefgh"), gomock.Not([]byte("hash"))).
+					Return(nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "already revoked",
+			setup: func(mocks *mocks) {
+				k := apiKey()
+				k.RevokedAt = current
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Get(gomock.Any(), "admin-id", "api-key-id").Return(k, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
+		{
+			name: "not found",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Get(gomock.Any(), "admin-id", "api-key-id").Return(nil, database.ErrNotFound)
+			},
+			expect: &testResponse{
+				code: http.StatusNotFound,
+			},
+		},
+		{
+			name: "failed to rotate",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Get(gomock.Any(), "admin-id", "api-key-id").Return(apiKey(), nil)
+				mocks.db.adminAPIKey.EXPECT().
+					Rotate(gomock.Any(), "admin-id", "api-key-id", gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/api-keys/api-key-id/rotate"
+			testPost(t, tt.setup, tt.expect, path, nil)
+		})
+	}
+}
+
+func TestRevokeAdminAPIKey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Revoke(gomock.Any(), "admin-id", "api-key-id").Return(nil)
+			},
+			expect: &testResponse{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "failed to revoke",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().Revoke(gomock.Any(), "admin-id", "api-key-id").Return(assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/api-keys/api-key-id"
+			testDelete(t, tt.setup, tt.expect, path)
+		})
+	}
+}
@@ -2,40 +2,50 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/and-period/furumane/internal/auth/database"
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/request"
-	"github.com/and-period/furumane/internal/auth/response"
 	"github.com/and-period/furumane/internal/auth/service"
 	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
+// authSessionHeader - カスタム認証の再試行で使用するセッションを返すヘッダー
+const authSessionHeader = "X-Auth-Session"
+
 func (c *controller) adminAuthRoutes(rg *gin.RouterGroup) {
 	g := rg.Group("/auth")
 	g.POST("", c.SignInAdmin)
 	g.DELETE("", c.SignOutAdmin)
 	g.GET("", c.GetAdminAuth)
 	g.POST("/refresh", c.RefreshAdminToken)
+	g.POST("/otp", c.StartAdminOTP)
+	g.POST("/otp/verify", c.VerifyAdminOTP)
+	g.POST("/passkey/options", c.BeginAdminPasskeySignIn)
+	g.POST("/passkey", c.SignInAdminWithPasskey)
 }
 
 // SignInAdmin 管理者サインイン（メールアドレス認証）
 func (c *controller) SignInAdmin(ctx *gin.Context) {
 	req := &request.SignInAdminRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	rs, err := c.adminAuth.SignIn(ctx, req.Key, req.Password)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.getAdminAuth(ctx, rs)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	res := &response.SignInAdminResponse{
@@ -48,13 +58,17 @@ func (c *controller) SignInAdmin(ctx *gin.Context) {
 func (c *controller) SignOutAdmin(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	if err := c.adminAuth.SignOut(ctx, token); err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
+	// サインアウトによりすべてのトークンが失効するため、トークンの検証結果のキャッシュも削除する
+	if claims, err := cognito.ParseAccessToken(token); err == nil {
+		c.introspection.deleteBySubject(claims.Subject)
+	}
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -62,13 +76,13 @@ func (c *controller) SignOutAdmin(ctx *gin.Context) {
 func (c *controller) GetAdminAuth(ctx *gin.Context) {
 	token, err := util.GetAuthToken(ctx)
 	if err != nil {
-		unauthorized(ctx, err.Error())
+		c.unauthorized(ctx, err.Error())
 		return
 	}
 	rs := &cognito.AuthResult{AccessToken: token}
 	admin, err := c.getAdminAuth(ctx, rs)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	res := &response.GetAdminAuthResponse{
@@ -81,17 +95,17 @@ func (c *controller) GetAdminAuth(ctx *gin.Context) {
 func (c *controller) RefreshAdminToken(ctx *gin.Context) {
 	req := &request.RefreshAdminTokenRequest{}
 	if err := c.bind(ctx, req); err != nil {
-		badRequest(ctx, err.Error())
+		c.httpError(ctx, err)
 		return
 	}
 	rs, err := c.adminAuth.RefreshToken(ctx, req.RefreshToken)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	admin, err := c.getAdminAuth(ctx, rs)
 	if err != nil {
-		httpError(ctx, err)
+		c.httpError(ctx, err)
 		return
 	}
 	res := &response.RefreshAdminTokenResponse{
@@ -100,12 +114,86 @@ func (c *controller) RefreshAdminToken(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// StartAdminOTP 管理者サインイン（ワンタイムコード送信）
+func (c *controller) StartAdminOTP(ctx *gin.Context) {
+	req := &request.StartAdminOTPRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	session, err := c.adminAuth.StartCustomAuth(ctx, &cognito.StartCustomAuthParams{Username: req.Email})
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	// パラメータ受け渡し用のチャレンジに回答し、ワンタイムコードを送信する
+	params := &cognito.RespondCustomAuthChallengeParams{
+		Username: req.Email,
+		Session:  session,
+		Answer:   entity.AuthChallengeProvideParametersAnswer,
+		Metadata: otpMetadata(ctx),
+	}
+	rs, err := c.adminAuth.RespondCustomAuthChallenge(ctx, params)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.StartAdminOTPResponse{
+		Session: rs.Session,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// VerifyAdminOTP 管理者サインイン（ワンタイムコード検証）
+// コードが誤っていて再試行が可能な場合は、次の検証で使用するセッションをX-Auth-Sessionヘッダーで返す
+func (c *controller) VerifyAdminOTP(ctx *gin.Context) {
+	req := &request.VerifyAdminOTPRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	params := &cognito.RespondCustomAuthChallengeParams{
+		Username: req.Email,
+		Session:  req.Session,
+		Answer:   req.Code,
+		Metadata: otpMetadata(ctx),
+	}
+	rs, err := c.adminAuth.RespondCustomAuthChallenge(ctx, params)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if rs.AuthResult == nil {
+		ctx.Header(authSessionHeader, rs.Session)
+		c.httpError(ctx, fmt.Errorf("%w: %w: %s", cognito.ErrInvalidArgument, cognito.ErrCodeMismatch, "one-time code is incorrect"))
+		return
+	}
+	admin, err := c.getAdminAuth(ctx, rs.AuthResult)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.VerifyAdminOTPResponse{
+		AdminAuth: service.NewAdminAuth(admin).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func otpMetadata(ctx *gin.Context) map[string]string {
+	locale := i18n.ParseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	return map[string]string{
+		entity.AuthMetadataSignInMethod: string(entity.SignInMethodEmailOTP),
+		entity.AuthMetadataLocale:       string(locale),
+	}
+}
+
 func (c *controller) getAdminAuth(ctx context.Context, rs *cognito.AuthResult) (*entity.AdminAuth, error) {
 	username, err := c.adminAuth.GetUsername(ctx, rs.AccessToken)
 	if err != nil {
 		return nil, err
 	}
-	admin, err := c.db.Admin.GetByCognitoID(ctx, username)
+	// 管理者登録直後のサインインでも参照できるよう、プライマリレプリカから取得する
+	admin, err := c.db.Admin.GetByCognitoID(database.ReadFromPrimary(ctx), username)
 	if err != nil {
 		return nil, err
 	}
@@ -5,8 +5,8 @@ import (
 	"testing"
 
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/request"
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cognito"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -315,3 +315,197 @@ func TestRefreshAdminToken(t *testing.T) {
 		})
 	}
 }
+
+func TestStartAdminOTP(t *testing.T) {
+	t.Parallel()
+	startParams := &cognito.StartCustomAuthParams{
+		Username: "test@example.com",
+	}
+	respondParams := &cognito.RespondCustomAuthChallengeParams{
+		Username: "test@example.com",
+		Session:  "session",
+		Answer:   entity.AuthChallengeProvideParametersAnswer,
+		Metadata: map[string]string{
+			entity.AuthMetadataSignInMethod: "email_otp",
+			entity.AuthMetadataLocale:       "ja",
+		},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.StartAdminOTPRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().StartCustomAuth(gomock.Any(), startParams).Return("session", nil)
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), respondParams).
+					Return(&cognito.CustomAuthResult{Session: "otp-session"}, nil)
+			},
+			req: &request.StartAdminOTPRequest{
+				Email: "test@example.com",
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.StartAdminOTPResponse{
+					Session: "otp-session",
+				},
+			},
+		},
+		{
+			name:  "bad request",
+			setup: func(mocks *mocks) {},
+			req:   &request.StartAdminOTPRequest{},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed to start custom auth",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().StartCustomAuth(gomock.Any(), startParams).Return("", assert.AnError)
+			},
+			req: &request.StartAdminOTPRequest{
+				Email: "test@example.com",
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "failed to respond custom auth challenge",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().StartCustomAuth(gomock.Any(), startParams).Return("session", nil)
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), respondParams).Return(nil, assert.AnError)
+			},
+			req: &request.StartAdminOTPRequest{
+				Email: "test@example.com",
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/auth/otp"
+			testPost(t, tt.setup, tt.expect, path, tt.req)
+		})
+	}
+}
+
+func TestVerifyAdminOTP(t *testing.T) {
+	t.Parallel()
+	params := &cognito.RespondCustomAuthChallengeParams{
+		Username: "test@example.com",
+		Session:  "otp-session",
+		Answer:   "123456",
+		Metadata: map[string]string{
+			entity.AuthMetadataSignInMethod: "email_otp",
+			entity.AuthMetadataLocale:       "ja",
+		},
+	}
+	result := &cognito.AuthResult{
+		IDToken:      "id-token",
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		ExpiresIn:    3600,
+	}
+	admin := &entity.Admin{
+		ID:           "admin-id",
+		CognitoID:    "cognito-id",
+		ProviderType: entity.ProviderTypeEmail,
+		CreatedAt:    current,
+		UpdatedAt:    current,
+	}
+	req := &request.VerifyAdminOTPRequest{
+		Email:   "test@example.com",
+		Session: "otp-session",
+		Code:    "123456",
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.VerifyAdminOTPRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), params).
+					Return(&cognito.CustomAuthResult{AuthResult: result}, nil)
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.VerifyAdminOTPResponse{
+					AdminAuth: &response.AdminAuth{
+						AdminID:      "admin-id",
+						AccessToken:  "access-token",
+						RefreshToken: "refresh-token",
+						ExpiresIn:    3600,
+					},
+				},
+			},
+		},
+		{
+			name:  "bad request",
+			setup: func(mocks *mocks) {},
+			req: &request.VerifyAdminOTPRequest{
+				Email:   "test@example.com",
+				Session: "otp-session",
+				Code:    "12345a",
+			},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed to respond custom auth challenge",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), params).Return(nil, assert.AnError)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "code mismatch",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), params).
+					Return(&cognito.CustomAuthResult{Session: "retry-session"}, nil)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed to get admin by cognito id",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), params).
+					Return(&cognito.CustomAuthResult{AuthResult: result}, nil)
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(nil, assert.AnError)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/auth/otp/verify"
+			testPost(t, tt.setup, tt.expect, path, tt.req)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/auth/service"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/and-period/furumane/pkg/webauthn"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// パスキーの登録は認証器の操作を伴うため、アクセストークンでの認証のみ許可する
+func (c *controller) adminPasskeyRoutes(rg *gin.RouterGroup) {
+	g := rg.Group("/passkeys")
+	g.GET("", c.authentication(entity.APIKeyScopePasskeyRead), c.ListAdminPasskeys)
+	g.POST("/registration/options", c.authentication(), c.BeginAdminPasskeyRegistration)
+	g.POST("/registration", c.authentication(), c.RegisterAdminPasskey)
+	g.PATCH("/:passkeyId", c.authentication(entity.APIKeyScopePasskeyWrite), c.UpdateAdminPasskey)
+	g.DELETE("/:passkeyId", c.authentication(entity.APIKeyScopePasskeyWrite), c.DeleteAdminPasskey)
+}
+
+// ListAdminPasskeys 管理者パスキー一覧取得
+func (c *controller) ListAdminPasskeys(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	credentials, err := c.db.AdminCredential.List(ctx, admin.ID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.AdminPasskeysResponse{
+		Passkeys: service.NewAdminPasskeys(credentials).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// BeginAdminPasskeyRegistration 管理者パスキー登録 (オプション取得)
+func (c *controller) BeginAdminPasskeyRegistration(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	credentials, err := c.db.AdminCredential.List(ctx, admin.ID, "credential_id", "transports")
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	options, session, err := c.webauthn.BeginRegistration(passkeyUser(admin), credentials.WebAuthn())
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.BeginAdminPasskeyRegistrationResponse{
+		Session: session,
+		Options: options,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RegisterAdminPasskey 管理者パスキー登録
+func (c *controller) RegisterAdminPasskey(ctx *gin.Context) {
+	req := &request.RegisterAdminPasskeyRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	attestation := &webauthn.AttestationResponse{
+		ID:                req.Credential.ID,
+		ClientDataJSON:    req.Credential.ClientDataJSON,
+		AttestationObject: req.Credential.AttestationObject,
+		Transports:        req.Credential.Transports,
+	}
+	credential, err := c.webauthn.FinishRegistration(req.Session, passkeyUser(admin), attestation)
+	if err != nil {
+		c.httpError(ctx, passkeyError(codes.InvalidArgument, err))
+		return
+	}
+	params := &entity.AdminCredentialParams{
+		ID:         uuid.Base58Encode(c.uuid()),
+		AdminID:    admin.ID,
+		Name:       req.Name,
+		Credential: credential,
+	}
+	passkey := entity.NewAdminCredential(params)
+	if err := c.db.AdminCredential.Create(ctx, passkey); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.AdminPasskeyResponse{
+		Passkey: service.NewAdminPasskey(passkey).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateAdminPasskey 管理者パスキー名更新
+func (c *controller) UpdateAdminPasskey(ctx *gin.Context) {
+	req := &request.UpdateAdminPasskeyRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	passkeyID := util.GetParam(ctx, "passkeyId")
+	if err := c.db.AdminCredential.UpdateName(ctx, admin.ID, passkeyID, req.Name); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteAdminPasskey 管理者パスキー削除
+func (c *controller) DeleteAdminPasskey(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	passkeyID := util.GetParam(ctx, "passkeyId")
+	if err := c.db.AdminCredential.Delete(ctx, admin.ID, passkeyID); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// BeginAdminPasskeySignIn 管理者サインイン (パスキー認証のオプション取得)
+// 認証器に保存されたパスキーから選択させるため、許可する認証情報は指定しない
+// 認証器の応答の再送を防ぐため、発行したチャレンジは保存しておき、検証に成功した時点で削除する
+func (c *controller) BeginAdminPasskeySignIn(ctx *gin.Context) {
+	options, session, err := c.webauthn.BeginLogin(nil)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	expiresAt := c.now().Add(time.Duration(options.Timeout) * time.Millisecond)
+	if err := c.db.PasskeyChallenge.Create(ctx, entity.NewPasskeyChallenge(options.Challenge, expiresAt)); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.BeginAdminPasskeySignInResponse{
+		Session: session,
+		Options: options,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SignInAdminWithPasskey 管理者サインイン (パスキー認証)
+func (c *controller) SignInAdminWithPasskey(ctx *gin.Context) {
+	req := &request.SignInAdminWithPasskeyRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	credentialID, err := base64.RawURLEncoding.DecodeString(req.Credential.ID)
+	if err != nil {
+		c.httpError(ctx, status.Error(codes.InvalidArgument, "credential id is invalid"))
+		return
+	}
+	credential, err := c.db.AdminCredential.GetByCredentialID(ctx, credentialID)
+	if errors.Is(err, database.ErrNotFound) {
+		c.unauthorized(ctx, "this passkey is not registered")
+		return
+	}
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	assertion := &webauthn.AssertionResponse{
+		ID:                req.Credential.ID,
+		ClientDataJSON:    req.Credential.ClientDataJSON,
+		AuthenticatorData: req.Credential.AuthenticatorData,
+		Signature:         req.Credential.Signature,
+	}
+	result, err := c.webauthn.FinishLogin(req.Session, credential.WebAuthn(), assertion)
+	if err != nil {
+		c.httpError(ctx, passkeyError(codes.Unauthenticated, err))
+		return
+	}
+	err = c.db.PasskeyChallenge.Consume(ctx, result.Challenge)
+	if errors.Is(err, database.ErrNotFound) {
+		c.unauthorized(ctx, "this passkey challenge has already been used or expired")
+		return
+	}
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if err := c.db.AdminCredential.UpdateSignCount(ctx, credential.ID, result.SignCount); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	admin, err := c.db.Admin.Get(ctx, credential.AdminID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	rs, err := c.signInWithPasskey(ctx, admin.CognitoID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.SignInAdminWithPasskeyResponse{
+		AdminAuth: service.NewAdminAuth(entity.NewAdminAuth(admin, rs)).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// signInWithPasskey - パスキーの検証結果をもとにCognitoのカスタム認証でトークンを発行
+func (c *controller) signInWithPasskey(ctx context.Context, username string) (*cognito.AuthResult, error) {
+	session, err := c.adminAuth.StartCustomAuth(ctx, &cognito.StartCustomAuthParams{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]string{
+		entity.AuthMetadataSignInMethod: string(entity.SignInMethodPasskey),
+	}
+	params := &cognito.RespondCustomAuthChallengeParams{
+		Username: username,
+		Session:  session,
+		Answer:   entity.AuthChallengeProvideParametersAnswer,
+		Metadata: metadata,
+	}
+	rs, err := c.adminAuth.RespondCustomAuthChallenge(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params = &cognito.RespondCustomAuthChallengeParams{
+		Username: username,
+		Session:  rs.Session,
+		Answer:   entity.NewPasskeyAnswer(c.challengeSecret, username, c.now().Add(c.challengeTTL)),
+		Metadata: metadata,
+	}
+	rs, err = c.adminAuth.RespondCustomAuthChallenge(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if rs.AuthResult == nil {
+		return nil, status.Error(codes.Unauthenticated, "failed to issue tokens with passkey")
+	}
+	return rs.AuthResult, nil
+}
+
+// passkeyUser - 認証器に登録する管理者情報 (ユーザーIDには個人情報を含めない)
+func passkeyUser(admin *entity.Admin) *webauthn.User {
+	return &webauthn.User{
+		ID:          []byte(admin.ID),
+		Name:        admin.Email,
+		DisplayName: admin.Email,
+	}
+}
+
+// passkeyError - 認証器の応答の検証エラーを指定したステータスに変換
+func passkeyError(code codes.Code, err error) error {
+	return status.Error(code, err.Error())
+}
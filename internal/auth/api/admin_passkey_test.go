@@ -0,0 +1,626 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/and-period/furumane/pkg/webauthn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAdminPasskeys(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	credentials := entity.AdminCredentials{
+		{
+			ID:           "passkey-id",
+			AdminID:      "admin-id",
+			CredentialID: []byte("credential-id"),
+			Name:         "iPhone",
+			Transports:   []string{"internal", "hybrid"},
+			CreatedAt:    current,
+			UpdatedAt:    current,
+			LastUsedAt:   current,
+		},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(credentials, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeysResponse{
+					Passkeys: []*response.AdminPasskey{
+						{
+							ID:         "passkey-id",
+							Name:       "iPhone",
+							Transports: []string{"internal", "hybrid"},
+							CreatedAt:  current,
+							UpdatedAt:  current,
+							LastUsedAt: current,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failed to get username",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("", cognito.ErrUnauthenticated)
+			},
+			expect: &testResponse{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "failed to list passkeys",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(nil, assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/passkeys"
+			testGet(t, tt.setup, tt.expect, path)
+		})
+	}
+}
+
+func TestBeginAdminPasskeyRegistration(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id", Email: "test@example.com"}
+	user := &webauthn.User{ID: []byte("admin-id"), Name: "test@example.com", DisplayName: "test@example.com"}
+	credentials := entity.AdminCredentials{
+		{CredentialID: []byte("credential-id"), Transports: []string{"internal"}},
+	}
+	exclusions := []*webauthn.Credential{
+		{ID: []byte("credential-id"), Transports: []string{"internal"}},
+	}
+	options := &webauthn.CreationOptions{
+		Challenge: "challenge",
+		RP:        &webauthn.RPEntity{ID: "localhost", Name: "furumane"},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().
+					List(gomock.Any(), "admin-id", "credential_id", "transports").
+					Return(credentials, nil)
+				mocks.webauthn.EXPECT().BeginRegistration(user, exclusions).Return(options, "session", nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.BeginAdminPasskeyRegistrationResponse{
+					Session: "session",
+					Options: options,
+				},
+			},
+		},
+		{
+			name: "failed to begin registration",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().
+					List(gomock.Any(), "admin-id", "credential_id", "transports").
+					Return(credentials, nil)
+				mocks.webauthn.EXPECT().BeginRegistration(user, exclusions).Return(nil, "", assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/passkeys/registration/options"
+			testPost(t, tt.setup, tt.expect, path, nil)
+		})
+	}
+}
+
+func TestRegisterAdminPasskey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id", Email: "test@example.com"}
+	user := &webauthn.User{ID: []byte("admin-id"), Name: "test@example.com", DisplayName: "test@example.com"}
+	attestation := &webauthn.AttestationResponse{
+		ID:                "Y3JlZGVudGlhbC1pZA",
+		ClientDataJSON:    "client-data",
+		AttestationObject: "attestation-object",
+		Transports:        []string{"internal"},
+	}
+	credential := &webauthn.Credential{
+		ID:         []byte("credential-id"),
+		PublicKey:  []byte("public-key"),
+		SignCount:  0,
+		Transports: []string{"internal"},
+		AAGUID:     make([]byte, 16),
+	}
+	passkeyID := uuid.New()
+	passkey := &entity.AdminCredential{
+		ID:           uuid.Base58Encode(passkeyID),
+		AdminID:      "admin-id",
+		CredentialID: []byte("credential-id"),
+		Name:         "iPhone",
+		PublicKey:    []byte("public-key"),
+		SignCount:    0,
+		Transports:   []string{"internal"},
+		AAGUID:       make([]byte, 16),
+	}
+	req := &request.RegisterAdminPasskeyRequest{
+		Session: "session",
+		Name:    "iPhone",
+		Credential: &request.AdminPasskeyAttestation{
+			ID:                "Y3JlZGVudGlhbC1pZA",
+			ClientDataJSON:    "client-data",
+			AttestationObject: "attestation-object",
+			Transports:        []string{"internal"},
+		},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.RegisterAdminPasskeyRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.webauthn.EXPECT().FinishRegistration("session", user, attestation).Return(credential, nil)
+				mocks.db.adminCredential.EXPECT().Create(gomock.Any(), passkey).Return(nil)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeyResponse{
+					Passkey: &response.AdminPasskey{
+						ID:         uuid.Base58Encode(passkeyID),
+						Name:       "iPhone",
+						Transports: []string{"internal"},
+					},
+				},
+			},
+		},
+		{
+			name: "bad request",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.RegisterAdminPasskeyRequest{
+				Session: "session",
+				Name:    "iPhone",
+			},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "invalid attestation",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.webauthn.EXPECT().FinishRegistration("session", user, attestation).
+					Return(nil, webauthn.ErrInvalidAttestation)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "already registered",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.webauthn.EXPECT().FinishRegistration("session", user, attestation).Return(credential, nil)
+				mocks.db.adminCredential.EXPECT().Create(gomock.Any(), passkey).Return(database.ErrAlreadyExists)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/passkeys/registration"
+			testPost(t, tt.setup, tt.expect, path, tt.req, withUUID(passkeyID))
+		})
+	}
+}
+
+func TestUpdateAdminPasskey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.UpdateAdminPasskeyRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().UpdateName(gomock.Any(), "admin-id", "passkey-id", "MacBook").Return(nil)
+			},
+			req: &request.UpdateAdminPasskeyRequest{Name: "MacBook"},
+			expect: &testResponse{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "bad request",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.UpdateAdminPasskeyRequest{},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "not found",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().
+					UpdateName(gomock.Any(), "admin-id", "passkey-id", "MacBook").
+					Return(database.ErrNotFound)
+			},
+			req: &request.UpdateAdminPasskeyRequest{Name: "MacBook"},
+			expect: &testResponse{
+				code: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/passkeys/passkey-id"
+			testPatch(t, tt.setup, tt.expect, path, tt.req)
+		})
+	}
+}
+
+func TestDeleteAdminPasskey(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().Delete(gomock.Any(), "admin-id", "passkey-id").Return(nil)
+			},
+			expect: &testResponse{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "failed to delete passkey",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().Delete(gomock.Any(), "admin-id", "passkey-id").Return(assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/passkeys/passkey-id"
+			testDelete(t, tt.setup, tt.expect, path)
+		})
+	}
+}
+
+func TestBeginAdminPasskeySignIn(t *testing.T) {
+	t.Parallel()
+	now := current
+	options := &webauthn.RequestOptions{
+		Challenge:        "challenge",
+		Timeout:          300000,
+		RPID:             "localhost",
+		UserVerification: webauthn.UserVerificationPreferred,
+	}
+	challenge := &entity.PasskeyChallenge{
+		Challenge: "challenge",
+		ExpiresAt: now.Add(5 * time.Minute),
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.webauthn.EXPECT().BeginLogin(nil).Return(options, "session", nil)
+				mocks.db.passkeyChallenge.EXPECT().Create(gomock.Any(), challenge).Return(nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.BeginAdminPasskeySignInResponse{
+					Session: "session",
+					Options: options,
+				},
+			},
+		},
+		{
+			name: "failed to begin login",
+			setup: func(mocks *mocks) {
+				mocks.webauthn.EXPECT().BeginLogin(nil).Return(nil, "", assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "failed to create challenge",
+			setup: func(mocks *mocks) {
+				mocks.webauthn.EXPECT().BeginLogin(nil).Return(options, "session", nil)
+				mocks.db.passkeyChallenge.EXPECT().Create(gomock.Any(), challenge).Return(assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/auth/passkey/options"
+			testPost(t, tt.setup, tt.expect, path, nil, withNow(now))
+		})
+	}
+}
+
+func TestSignInAdminWithPasskey(t *testing.T) {
+	t.Parallel()
+	now := current
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	credential := &entity.AdminCredential{
+		ID:           "passkey-id",
+		AdminID:      "admin-id",
+		CredentialID: []byte("credential-id"),
+		PublicKey:    []byte("public-key"),
+		SignCount:    1,
+	}
+	assertion := &webauthn.AssertionResponse{
+		ID:                "Y3JlZGVudGlhbC1pZA",
+		ClientDataJSON:    "client-data",
+		AuthenticatorData: "authenticator-data",
+		Signature:         "signature",
+	}
+	metadata := map[string]string{
+		entity.AuthMetadataSignInMethod: "passkey",
+	}
+	provideParams := &cognito.RespondCustomAuthChallengeParams{
+		Username: "cognito-id",
+		Session:  "cognito-session",
+		Answer:   "__dummy__",
+		Metadata: metadata,
+	}
+	answerParams := &cognito.RespondCustomAuthChallengeParams{
+		Username: "cognito-id",
+		Session:  "passkey-session",
+		Answer:   entity.NewPasskeyAnswer([]byte("secret"), "cognito-id", now.Add(time.Minute)),
+		Metadata: metadata,
+	}
+	result := &cognito.AuthResult{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		ExpiresIn:    3600,
+	}
+	req := &request.SignInAdminWithPasskeyRequest{
+		Session: "session",
+		Credential: &request.AdminPasskeyAssertion{
+			ID:                "Y3JlZGVudGlhbC1pZA",
+			ClientDataJSON:    "client-data",
+			AuthenticatorData: "authenticator-data",
+			Signature:         "signature",
+		},
+	}
+	verified := func(mocks *mocks) {
+		mocks.db.adminCredential.EXPECT().GetByCredentialID(gomock.Any(), []byte("credential-id")).Return(credential, nil)
+		mocks.webauthn.EXPECT().FinishLogin("session", credential.WebAuthn(), assertion).
+			Return(&webauthn.AssertionResult{SignCount: 2, UserVerified: true, Challenge: "challenge"}, nil)
+		mocks.db.passkeyChallenge.EXPECT().Consume(gomock.Any(), "challenge").Return(nil)
+		mocks.db.adminCredential.EXPECT().UpdateSignCount(gomock.Any(), "passkey-id", uint32(2)).Return(nil)
+		mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+		mocks.adminAuth.EXPECT().
+			StartCustomAuth(gomock.Any(), &cognito.StartCustomAuthParams{Username: "cognito-id"}).
+			Return("cognito-session", nil)
+		mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), provideParams).
+			Return(&cognito.CustomAuthResult{Session: "passkey-session"}, nil)
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.SignInAdminWithPasskeyRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				verified(mocks)
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), answerParams).
+					Return(&cognito.CustomAuthResult{AuthResult: result}, nil)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.SignInAdminWithPasskeyResponse{
+					AdminAuth: &response.AdminAuth{
+						AdminID:      "admin-id",
+						AccessToken:  "access-token",
+						RefreshToken: "refresh-token",
+						ExpiresIn:    3600,
+					},
+				},
+			},
+		},
+		{
+			name:  "bad request",
+			setup: func(mocks *mocks) {},
+			req:   &request.SignInAdminWithPasskeyRequest{Session: "session"},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name:  "invalid credential id",
+			setup: func(mocks *mocks) {},
+			req: &request.SignInAdminWithPasskeyRequest{
+				Session: "session",
+				Credential: &request.AdminPasskeyAssertion{
+					ID:                "!",
+					ClientDataJSON:    "client-data",
+					AuthenticatorData: "authenticator-data",
+					Signature:         "signature",
+				},
+			},
+			expect: &testResponse{
+				code: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "not registered passkey",
+			setup: func(mocks *mocks) {
+				mocks.db.adminCredential.EXPECT().
+					GetByCredentialID(gomock.Any(), []byte("credential-id")).
+					Return(nil, database.ErrNotFound)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "invalid signature",
+			setup: func(mocks *mocks) {
+				mocks.db.adminCredential.EXPECT().GetByCredentialID(gomock.Any(), []byte("credential-id")).Return(credential, nil)
+				mocks.webauthn.EXPECT().FinishLogin("session", credential.WebAuthn(), assertion).
+					Return(nil, webauthn.ErrInvalidSignature)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "replayed assertion",
+			setup: func(mocks *mocks) {
+				mocks.db.adminCredential.EXPECT().GetByCredentialID(gomock.Any(), []byte("credential-id")).Return(credential, nil)
+				mocks.webauthn.EXPECT().FinishLogin("session", credential.WebAuthn(), assertion).
+					Return(&webauthn.AssertionResult{SignCount: 2, Challenge: "challenge"}, nil)
+				mocks.db.passkeyChallenge.EXPECT().Consume(gomock.Any(), "challenge").Return(database.ErrNotFound)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "failed to consume challenge",
+			setup: func(mocks *mocks) {
+				mocks.db.adminCredential.EXPECT().GetByCredentialID(gomock.Any(), []byte("credential-id")).Return(credential, nil)
+				mocks.webauthn.EXPECT().FinishLogin("session", credential.WebAuthn(), assertion).
+					Return(&webauthn.AssertionResult{SignCount: 2, Challenge: "challenge"}, nil)
+				mocks.db.passkeyChallenge.EXPECT().Consume(gomock.Any(), "challenge").Return(assert.AnError)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "failed to issue tokens",
+			setup: func(mocks *mocks) {
+				verified(mocks)
+				mocks.adminAuth.EXPECT().RespondCustomAuthChallenge(gomock.Any(), answerParams).
+					Return(&cognito.CustomAuthResult{Session: "retry-session"}, nil)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "failed to start custom auth",
+			setup: func(mocks *mocks) {
+				mocks.db.adminCredential.EXPECT().GetByCredentialID(gomock.Any(), []byte("credential-id")).Return(credential, nil)
+				mocks.webauthn.EXPECT().FinishLogin("session", credential.WebAuthn(), assertion).
+					Return(&webauthn.AssertionResult{SignCount: 2, Challenge: "challenge"}, nil)
+				mocks.db.passkeyChallenge.EXPECT().Consume(gomock.Any(), "challenge").Return(nil)
+				mocks.db.adminCredential.EXPECT().UpdateSignCount(gomock.Any(), "passkey-id", uint32(2)).Return(nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.adminAuth.EXPECT().StartCustomAuth(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+			},
+			req: req,
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/admin/auth/passkey"
+			testPost(t, tt.setup, tt.expect, path, tt.req, withNow(now))
+		})
+	}
+}
@@ -1,14 +1,16 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/and-period/furumane/internal/auth/database"
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/request"
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cognito"
 	"github.com/and-period/furumane/pkg/uuid"
 	"github.com/stretchr/testify/assert"
@@ -188,6 +190,17 @@ func TestSignUpAdminWithOAuth(t *testing.T) {
 		ProviderType: entity.ProviderTypeOAuth,
 		Email:        "test@example.com",
 	}
+	registered := &entity.Admin{
+		ID:           "admin-id",
+		CognitoID:    "cognito-id",
+		ProviderType: entity.ProviderTypeOAuth,
+		Email:        "test@example.com",
+		Version:      3,
+	}
+	created := func(ctx context.Context, admin *entity.Admin, auth func(context.Context) error) error {
+		admin.Version = 1
+		return auth(ctx)
+	}
 	tests := []struct {
 		name   string
 		setup  func(mocks *mocks)
@@ -197,20 +210,54 @@ func TestSignUpAdminWithOAuth(t *testing.T) {
 			name: "success",
 			setup: func(mocks *mocks) {
 				mocks.adminAuth.EXPECT().GetUser(gomock.Any(), "access-token").Return(auser, nil)
-				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(nil)
-				mocks.db.admin.EXPECT().UpdateVerifiedAt(gomock.Any(), uuid.Base58Encode(adminID)).Return(nil)
+				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).DoAndReturn(created)
+				mocks.db.admin.EXPECT().UpdateVerifiedAt(gomock.Any(), uuid.Base58Encode(adminID), int64(1)).Return(nil)
 			},
 			expect: &testResponse{
 				code: http.StatusOK,
 				body: &response.SignUpAdminWithOAuthResponse{
 					Admin: &response.Admin{
 						ID:           uuid.Base58Encode(adminID),
-						ProviderType: entity.ProviderTypeOAuth,
+						ProviderType: int32(entity.ProviderTypeOAuth),
+						Email:        "test@example.com",
+						CreatedAt:    time.Time{},
+						UpdatedAt:    time.Time{},
+					},
+				},
+				header: map[string]string{"ETag": `"2"`},
+			},
+		},
+		{
+			name: "success already registered",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUser(gomock.Any(), "access-token").Return(auser, nil)
+				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(database.ErrAlreadyExists)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(registered, nil)
+				mocks.db.admin.EXPECT().UpdateVerifiedAt(gomock.Any(), "admin-id", int64(3)).Return(nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.SignUpAdminWithOAuthResponse{
+					Admin: &response.Admin{
+						ID:           "admin-id",
+						ProviderType: int32(entity.ProviderTypeOAuth),
 						Email:        "test@example.com",
 						CreatedAt:    time.Time{},
 						UpdatedAt:    time.Time{},
 					},
 				},
+				header: map[string]string{"ETag": `"4"`},
+			},
+		},
+		{
+			name: "failed to get registered admin",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUser(gomock.Any(), "access-token").Return(auser, nil)
+				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(database.ErrAlreadyExists)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(nil, assert.AnError)
+			},
+			expect: &testResponse{
+				code: http.StatusInternalServerError,
 			},
 		},
 		{
@@ -236,8 +283,8 @@ func TestSignUpAdminWithOAuth(t *testing.T) {
 			name: "failed to update verified at",
 			setup: func(mocks *mocks) {
 				mocks.adminAuth.EXPECT().GetUser(gomock.Any(), "access-token").Return(auser, nil)
-				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(nil)
-				mocks.db.admin.EXPECT().UpdateVerifiedAt(gomock.Any(), uuid.Base58Encode(adminID)).Return(assert.AnError)
+				mocks.db.admin.EXPECT().Create(gomock.Any(), admin, gomock.Any()).DoAndReturn(created)
+				mocks.db.admin.EXPECT().UpdateVerifiedAt(gomock.Any(), uuid.Base58Encode(adminID), int64(1)).Return(assert.AnError)
 			},
 			expect: &testResponse{
 				code: http.StatusInternalServerError,
@@ -261,15 +308,17 @@ func TestGetAdmin(t *testing.T) {
 		CognitoID:    "cognito-id",
 		ProviderType: entity.ProviderTypeOAuth,
 		Email:        "test@example.com",
+		Version:      2,
 		CreatedAt:    current,
 		UpdatedAt:    current,
 		VerifiedAt:   current,
 	}
 	tests := []struct {
-		name    string
-		setup   func(mocks *mocks)
-		adminID string
-		expect  *testResponse
+		name        string
+		setup       func(mocks *mocks)
+		adminID     string
+		ifNoneMatch string
+		expect      *testResponse
 	}{
 		{
 			name: "success",
@@ -278,11 +327,12 @@ func TestGetAdmin(t *testing.T) {
 			},
 			adminID: "admin-id",
 			expect: &testResponse{
-				code: http.StatusOK,
+				code:   http.StatusOK,
+				header: map[string]string{"ETag": `"2"`},
 				body: &response.GetAdminResponse{
 					Admin: &response.Admin{
 						ID:           "admin-id",
-						ProviderType: entity.ProviderTypeOAuth,
+						ProviderType: int32(entity.ProviderTypeOAuth),
 						Email:        "test@example.com",
 						CreatedAt:    current,
 						UpdatedAt:    current,
@@ -290,6 +340,30 @@ func TestGetAdmin(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "not modified",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+			},
+			adminID:     "admin-id",
+			ifNoneMatch: `"1", W/"2"`,
+			expect: &testResponse{
+				code:   http.StatusNotModified,
+				header: map[string]string{"ETag": `"2"`},
+			},
+		},
+		{
+			name: "modified",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+			},
+			adminID:     "admin-id",
+			ifNoneMatch: `"1"`,
+			expect: &testResponse{
+				code:   http.StatusOK,
+				header: map[string]string{"ETag": `"2"`},
+			},
+		},
 		{
 			name: "failed to get admin",
 			setup: func(mocks *mocks) {
@@ -307,7 +381,11 @@ func TestGetAdmin(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			const format = "/admin/%s"
 			path := fmt.Sprintf(format, tt.adminID)
-			testGet(t, tt.setup, tt.expect, path)
+			req := newHTTPRequest(t, http.MethodGet, path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			testHTTP(t, tt.setup, tt.expect, req)
 		})
 	}
 }
@@ -319,6 +397,7 @@ func TestUpdateAdminEmail(t *testing.T) {
 		CognitoID:    "cognito-id",
 		ProviderType: entity.ProviderTypeEmail,
 		Email:        "hoge@example.com",
+		Version:      2,
 		CreatedAt:    current,
 		UpdatedAt:    current,
 		VerifiedAt:   current,
@@ -346,7 +425,8 @@ func TestUpdateAdminEmail(t *testing.T) {
 				Email: "test@example.com",
 			},
 			expect: &testResponse{
-				code: http.StatusNoContent,
+				code:   http.StatusNoContent,
+				header: map[string]string{"ETag": `"2"`},
 			},
 		},
 		{
@@ -428,6 +508,7 @@ func TestVerifyAdminEmail(t *testing.T) {
 		CognitoID:    "cognito-id",
 		ProviderType: entity.ProviderTypeEmail,
 		Email:        "test@example.com",
+		Version:      2,
 		CreatedAt:    current,
 		UpdatedAt:    current,
 		VerifiedAt:   current,
@@ -438,10 +519,11 @@ func TestVerifyAdminEmail(t *testing.T) {
 		VerifyCode:  "verify-code",
 	}
 	tests := []struct {
-		name   string
-		setup  func(mocks *mocks)
-		req    *request.VerifyAdminEmailRequest
-		expect *testResponse
+		name    string
+		setup   func(mocks *mocks)
+		req     *request.VerifyAdminEmailRequest
+		ifMatch string
+		expect  *testResponse
 	}{
 		{
 			name: "success",
@@ -449,13 +531,31 @@ func TestVerifyAdminEmail(t *testing.T) {
 				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
 				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
 				mocks.adminAuth.EXPECT().ConfirmChangeEmail(gomock.Any(), params).Return("test@example.com", nil)
-				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com").Return(nil)
+				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com", int64(2)).Return(nil)
 			},
 			req: &request.VerifyAdminEmailRequest{
 				VerifyCode: "verify-code",
 			},
 			expect: &testResponse{
-				code: http.StatusNoContent,
+				code:   http.StatusNoContent,
+				header: map[string]string{"ETag": `"3"`},
+			},
+		},
+		{
+			name: "success with if-match",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.adminAuth.EXPECT().ConfirmChangeEmail(gomock.Any(), params).Return("test@example.com", nil)
+				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com", int64(2)).Return(nil)
+			},
+			req: &request.VerifyAdminEmailRequest{
+				VerifyCode: "verify-code",
+			},
+			ifMatch: `"2"`,
+			expect: &testResponse{
+				code:   http.StatusNoContent,
+				header: map[string]string{"ETag": `"3"`},
 			},
 		},
 		{
@@ -491,6 +591,20 @@ func TestVerifyAdminEmail(t *testing.T) {
 				code: http.StatusInternalServerError,
 			},
 		},
+		{
+			name: "admin has been modified",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req: &request.VerifyAdminEmailRequest{
+				VerifyCode: "verify-code",
+			},
+			ifMatch: `"1"`,
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
 		{
 			name: "failed to confirm change email",
 			setup: func(mocks *mocks) {
@@ -505,13 +619,29 @@ func TestVerifyAdminEmail(t *testing.T) {
 				code: http.StatusInternalServerError,
 			},
 		},
+		{
+			name: "failed to update email by conflict",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.adminAuth.EXPECT().ConfirmChangeEmail(gomock.Any(), params).Return("test@example.com", nil)
+				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com", int64(2)).Return(database.ErrFailedPrecondition)
+			},
+			req: &request.VerifyAdminEmailRequest{
+				VerifyCode: "verify-code",
+			},
+			ifMatch: `"2"`,
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
 		{
 			name: "failed to update email",
 			setup: func(mocks *mocks) {
 				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
 				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
 				mocks.adminAuth.EXPECT().ConfirmChangeEmail(gomock.Any(), params).Return("test@example.com", nil)
-				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com").Return(assert.AnError)
+				mocks.db.admin.EXPECT().UpdateEmail(gomock.Any(), "admin-id", "test@example.com", int64(2)).Return(assert.AnError)
 			},
 			req: &request.VerifyAdminEmailRequest{
 				VerifyCode: "verify-code",
@@ -526,7 +656,11 @@ func TestVerifyAdminEmail(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			const path = "/admin/email/verified"
-			testPost(t, tt.setup, tt.expect, path, tt.req)
+			req := newHTTPRequest(t, http.MethodPost, path, tt.req)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			testHTTP(t, tt.setup, tt.expect, req)
 		})
 	}
 }
@@ -773,6 +907,7 @@ func TestDeleteAdmin(t *testing.T) {
 		CognitoID:    "cognito-id",
 		ProviderType: entity.ProviderTypeEmail,
 		Email:        "hoge@example.com",
+		Version:      2,
 		CreatedAt:    current,
 		UpdatedAt:    current,
 		VerifiedAt:   current,
@@ -781,19 +916,75 @@ func TestDeleteAdmin(t *testing.T) {
 		name    string
 		setup   func(mocks *mocks)
 		adminID string
+		ifMatch string
 		expect  *testResponse
 	}{
 		{
 			name: "success",
 			setup: func(mocks *mocks) {
 				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
-				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", gomock.Any()).Return(nil)
+				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", int64(2), gomock.Any()).Return(nil)
+			},
+			adminID: "admin-id",
+			expect: &testResponse{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "success with if-match",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", int64(2), gomock.Any()).Return(nil)
+			},
+			adminID: "admin-id",
+			ifMatch: `"1", "2"`,
+			expect: &testResponse{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "already deleted",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(nil, database.ErrNotFound)
 			},
 			adminID: "admin-id",
 			expect: &testResponse{
 				code: http.StatusNoContent,
 			},
 		},
+		{
+			name: "already deleted with if-match",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(nil, database.ErrNotFound)
+			},
+			adminID: "admin-id",
+			ifMatch: `"2"`,
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
+		{
+			name: "admin has been modified",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+			},
+			adminID: "admin-id",
+			ifMatch: `W/"2"`,
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
+		{
+			name: "admin has been modified after get",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", int64(2), gomock.Any()).Return(database.ErrFailedPrecondition)
+			},
+			adminID: "admin-id",
+			expect: &testResponse{
+				code: http.StatusPreconditionFailed,
+			},
+		},
 		{
 			name: "failed to get admin",
 			setup: func(mocks *mocks) {
@@ -808,7 +999,7 @@ func TestDeleteAdmin(t *testing.T) {
 			name: "failed to delete",
 			setup: func(mocks *mocks) {
 				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
-				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", gomock.Any()).Return(assert.AnError)
+				mocks.db.admin.EXPECT().Delete(gomock.Any(), "admin-id", int64(2), gomock.Any()).Return(assert.AnError)
 			},
 			adminID: "admin-id",
 			expect: &testResponse{
@@ -822,7 +1013,11 @@ func TestDeleteAdmin(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			const format = "/admin/%s"
 			path := fmt.Sprintf(format, tt.adminID)
-			testDelete(t, tt.setup, tt.expect, path)
+			req := newHTTPRequest(t, http.MethodDelete, path, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			testHTTP(t, tt.setup, tt.expect, req)
 		})
 	}
 }
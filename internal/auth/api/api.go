@@ -1,15 +1,20 @@
 package api
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/and-period/furumane/internal/auth/database"
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cognito"
+	aphttp "github.com/and-period/furumane/pkg/http"
+	"github.com/and-period/furumane/pkg/i18n"
 	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/log"
 	"github.com/and-period/furumane/pkg/uuid"
 	"github.com/and-period/furumane/pkg/validator"
+	"github.com/and-period/furumane/pkg/webauthn"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"golang.org/x/sync/singleflight"
@@ -26,22 +31,38 @@ type Params struct {
 	Database  *database.Database
 	AdminAuth cognito.Client
 	UserAuth  cognito.Client
+	WebAuthn  webauthn.Client
 }
 
 type controller struct {
-	now         func() time.Time
-	logger      *zap.Logger
-	waitGroup   *sync.WaitGroup
-	sharedGroup *singleflight.Group
-	db          *database.Database
-	validator   validator.Validator
-	adminAuth   cognito.Client
-	userAuth    cognito.Client
-	uuid        func() string
+	now             func() time.Time
+	logger          *zap.Logger
+	waitGroup       *sync.WaitGroup
+	sharedGroup     *singleflight.Group
+	db              *database.Database
+	validator       validator.Validator
+	adminAuth       cognito.Client
+	userAuth        cognito.Client
+	webauthn        webauthn.Client
+	uuid            func() string
+	problem         bool
+	problemType     string
+	challengeSecret []byte
+	challengeTTL    time.Duration
+	clients         map[string][]byte
+	introspection   *introspectionCache
+	cursorSecret    []byte
 }
 
 type options struct {
-	logger *zap.Logger
+	logger          *zap.Logger
+	problem         bool
+	problemType     string
+	challengeSecret []byte
+	clients         map[string]string
+	cacheSize       int
+	cacheTTL        time.Duration
+	cursorSecret    []byte
 }
 
 type Option func(*options)
@@ -52,23 +73,88 @@ func WithLogger(logger *zap.Logger) Option {
 	}
 }
 
+// WithProblemDetails - エラーレスポンスを常にRFC 7807形式で返す
+// 無効の場合も、Acceptヘッダーでapplication/problem+jsonが要求された場合はRFC 7807形式で返す
+func WithProblemDetails(enabled bool) Option {
+	return func(opts *options) {
+		opts.problem = enabled
+	}
+}
+
+// WithProblemTypeBaseURL - RFC 7807形式のエラーレスポンスのtypeに使用するURIの接頭辞
+func WithProblemTypeBaseURL(url string) Option {
+	return func(opts *options) {
+		opts.problemType = url
+	}
+}
+
+// WithAuthChallengeSecret - パスキー認証の回答の署名に使用する共通鍵 (認証チャレンジのトリガーと同じ値を指定)
+func WithAuthChallengeSecret(secret []byte) Option {
+	return func(opts *options) {
+		opts.challengeSecret = secret
+	}
+}
+
+// WithIntrospectionClients - トークンの検証を許可するクライアントの一覧 (クライアントID: クライアントシークレット)
+func WithIntrospectionClients(clients map[string]string) Option {
+	return func(opts *options) {
+		opts.clients = clients
+	}
+}
+
+// WithIntrospectionCacheSize - トークンの検証結果をキャッシュする最大件数
+func WithIntrospectionCacheSize(size int) Option {
+	return func(opts *options) {
+		opts.cacheSize = size
+	}
+}
+
+// WithIntrospectionCacheTTL - トークンの検証結果をキャッシュする最大時間 (0以下の場合はキャッシュしない)
+// キャッシュはプロセスごとに保持するため、他のレプリカで失効したトークンが有効と判定されうる時間の上限となる
+func WithIntrospectionCacheTTL(ttl time.Duration) Option {
+	return func(opts *options) {
+		opts.cacheTTL = ttl
+	}
+}
+
+// WithCursorSecret - 一覧取得時のカーソルの署名に使用する共通鍵
+func WithCursorSecret(secret []byte) Option {
+	return func(opts *options) {
+		opts.cursorSecret = secret
+	}
+}
+
 func NewController(params *Params, opts ...Option) Controller {
 	dopts := &options{
-		logger: zap.NewNop(),
+		logger:    zap.NewNop(),
+		cacheSize: 10000,
+		cacheTTL:  30 * time.Second,
 	}
 	for i := range opts {
 		opts[i](dopts)
 	}
+	clients := make(map[string][]byte, len(dopts.clients))
+	for id, secret := range dopts.clients {
+		clients[id] = hashClientSecret(secret)
+	}
 	return &controller{
-		now:         jst.Now,
-		logger:      dopts.logger,
-		waitGroup:   params.WaitGroup,
-		sharedGroup: &singleflight.Group{},
-		db:          params.Database,
-		validator:   validator.NewValidator(),
-		adminAuth:   params.AdminAuth,
-		userAuth:    params.UserAuth,
-		uuid:        uuid.New,
+		now:             jst.Now,
+		logger:          dopts.logger,
+		waitGroup:       params.WaitGroup,
+		sharedGroup:     &singleflight.Group{},
+		db:              params.Database,
+		validator:       validator.NewValidator(),
+		adminAuth:       params.AdminAuth,
+		userAuth:        params.UserAuth,
+		webauthn:        params.WebAuthn,
+		uuid:            uuid.New,
+		problem:         dopts.problem,
+		problemType:     dopts.problemType,
+		challengeSecret: dopts.challengeSecret,
+		challengeTTL:    time.Minute,
+		clients:         clients,
+		introspection:   newIntrospectionCache(dopts.cacheSize, dopts.cacheTTL),
+		cursorSecret:    dopts.cursorSecret,
 	}
 }
 
@@ -76,30 +162,73 @@ func (c *controller) Routes(rg *gin.RouterGroup) {
 	admin := rg.Group("/admin")
 	{
 		c.adminAuthRoutes(admin)
+		c.adminPasskeyRoutes(admin)
+		c.adminAPIKeyRoutes(admin)
 		c.adminRoutes(admin)
 	}
+	c.organizationRoutes(rg)
+	c.oauthRoutes(rg)
+	c.openAPIRoutes(rg)
 }
 
+// RequestContextKey - 受け付けたリクエストを保持するコンテキストのキー
+// アクセスログでは、リクエストボディの代わりにlogタグに従ってマスキングしたリクエストを出力する
+const RequestContextKey = "request"
+
 func (c *controller) bind(ctx *gin.Context, req interface{}) error {
+	ctx.Set(RequestContextKey, req)
 	if err := ctx.BindJSON(req); err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	return c.validator.Struct(req)
 }
 
-func httpError(ctx *gin.Context, err error) {
-	res, status := response.NewErrorResponse(err)
+// log - リクエスト単位のロガーを取得 (リクエストID、ルーティング、認証済みの管理者IDを含む)
+func (c *controller) log(ctx context.Context) *zap.Logger {
+	if gctx, ok := ctx.(*gin.Context); ok && gctx.Request != nil {
+		ctx = gctx.Request.Context()
+	}
+	return log.FromContext(ctx, c.logger)
+}
+
+// requestID - リクエストIDを取得 (ミドルウェアを経由しない場合はヘッダーの値を使用)
+func (c *controller) requestID(ctx *gin.Context) string {
+	if requestID := aphttp.RequestID(ctx.Request.Context()); requestID != "" {
+		return requestID
+	}
+	return ctx.GetHeader(aphttp.RequestIDHeader)
+}
+
+func (c *controller) httpError(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	locale := i18n.ParseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	requestID := c.requestID(ctx)
+	if !c.problem && !response.AcceptsProblem(ctx.GetHeader("Accept")) {
+		res, status := response.NewErrorResponse(err,
+			response.WithLocale(locale),
+			response.WithRequestID(requestID),
+		)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+	res, status := response.NewProblemDetails(err,
+		response.WithLocale(locale),
+		response.WithInstance(ctx.Request.URL.Path),
+		response.WithRequestID(requestID),
+		response.WithTypeBaseURL(c.problemType),
+	)
+	ctx.Header("Content-Type", response.ProblemContentType)
 	ctx.AbortWithStatusJSON(status, res)
 }
 
-func badRequest(ctx *gin.Context, format string, args ...interface{}) {
-	httpError(ctx, status.Errorf(codes.InvalidArgument, format, args...))
+func (c *controller) unauthorized(ctx *gin.Context, format string, args ...interface{}) {
+	c.httpError(ctx, status.Errorf(codes.Unauthenticated, format, args...))
 }
 
-func unauthorized(ctx *gin.Context, format string, args ...interface{}) {
-	httpError(ctx, status.Errorf(codes.Unauthenticated, format, args...))
+func (c *controller) forbidden(ctx *gin.Context, format string, args ...interface{}) {
+	c.httpError(ctx, status.Errorf(codes.PermissionDenied, format, args...))
 }
 
-func preconditionFailed(ctx *gin.Context, format string, args ...interface{}) {
-	httpError(ctx, status.Errorf(codes.FailedPrecondition, format, args...))
+func (c *controller) preconditionFailed(ctx *gin.Context, format string, args ...interface{}) {
+	c.httpError(ctx, status.Errorf(codes.FailedPrecondition, format, args...))
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,6 +14,8 @@ import (
 	"github.com/and-period/furumane/internal/auth/database"
 	mock_database "github.com/and-period/furumane/mock/auth/database"
 	mock_cognito "github.com/and-period/furumane/mock/pkg/cognito"
+	mock_webauthn "github.com/and-period/furumane/mock/pkg/webauthn"
+	aphttp "github.com/and-period/furumane/pkg/http"
 	"github.com/and-period/furumane/pkg/jst"
 	"github.com/and-period/furumane/pkg/uuid"
 	"github.com/gin-gonic/gin"
@@ -20,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -32,20 +37,29 @@ type mocks struct {
 	db        *dbmocks
 	adminAuth *mock_cognito.MockClient
 	userAuth  *mock_cognito.MockClient
+	webauthn  *mock_webauthn.MockClient
 }
 
 type dbmocks struct {
-	admin *mock_database.MockAdmin
+	transaction        *mock_database.MockTransaction
+	admin              *mock_database.MockAdmin
+	adminAPIKey        *mock_database.MockAdminAPIKey
+	adminCredential    *mock_database.MockAdminCredential
+	passkeyChallenge   *mock_database.MockPasskeyChallenge
+	organization       *mock_database.MockOrganization
+	organizationMember *mock_database.MockOrganizationMember
 }
 
 type testResponse struct {
-	code int
-	body interface{}
+	code   int
+	body   interface{}
+	header map[string]string
 }
 
 type testOptions struct {
-	now  func() time.Time
-	uuid func() string
+	now            func() time.Time
+	uuid           func() string
+	trustedProxies []string
 }
 
 type testOption func(opts *testOptions)
@@ -66,30 +80,58 @@ func withUUID(uuid string) testOption {
 	}
 }
 
+// withTrustedProxies - X-Forwarded-Forを参照するプロキシ (未指定の場合は参照しない)
+func withTrustedProxies(proxies ...string) testOption {
+	return func(opts *testOptions) {
+		opts.trustedProxies = proxies
+	}
+}
+
 func newMocks(ctrl *gomock.Controller) *mocks {
 	return &mocks{
 		db:        newDBMocks(ctrl),
 		adminAuth: mock_cognito.NewMockClient(ctrl),
 		userAuth:  mock_cognito.NewMockClient(ctrl),
+		webauthn:  mock_webauthn.NewMockClient(ctrl),
 	}
 }
 
 func newDBMocks(ctrl *gomock.Controller) *dbmocks {
 	return &dbmocks{
-		admin: mock_database.NewMockAdmin(ctrl),
+		transaction:        mock_database.NewMockTransaction(ctrl),
+		admin:              mock_database.NewMockAdmin(ctrl),
+		adminAPIKey:        mock_database.NewMockAdminAPIKey(ctrl),
+		adminCredential:    mock_database.NewMockAdminCredential(ctrl),
+		passkeyChallenge:   mock_database.NewMockPasskeyChallenge(ctrl),
+		organization:       mock_database.NewMockOrganization(ctrl),
+		organizationMember: mock_database.NewMockOrganizationMember(ctrl),
 	}
 }
 
+// testCursorSecret - 一覧取得時のカーソルの署名に使用する共通鍵
+const testCursorSecret = "cursor-secret"
+
 func newController(mocks *mocks, opts *testOptions) Controller {
 	params := &Params{
 		WaitGroup: &sync.WaitGroup{},
 		Database: &database.Database{
-			Admin: mocks.db.admin,
+			Transaction:        mocks.db.transaction,
+			Admin:              mocks.db.admin,
+			AdminAPIKey:        mocks.db.adminAPIKey,
+			AdminCredential:    mocks.db.adminCredential,
+			PasskeyChallenge:   mocks.db.passkeyChallenge,
+			Organization:       mocks.db.organization,
+			OrganizationMember: mocks.db.organizationMember,
 		},
 		AdminAuth: mocks.adminAuth,
 		UserAuth:  mocks.userAuth,
+		WebAuthn:  mocks.webauthn,
 	}
-	ctrl := NewController(params).(*controller)
+	ctrl := NewController(params,
+		WithAuthChallengeSecret([]byte("secret")),
+		WithIntrospectionClients(map[string]string{"client-id": "client-secret"}),
+		WithCursorSecret([]byte(testCursorSecret)),
+	).(*controller)
 	ctrl.now = func() time.Time {
 		return opts.now()
 	}
@@ -99,6 +141,14 @@ func newController(mocks *mocks, opts *testOptions) Controller {
 	return ctrl
 }
 
+// expectTransaction - トランザクション内の処理をそのまま実行する
+func expectTransaction(mocks *mocks) {
+	mocks.db.transaction.EXPECT().Run(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
 func newRoutes(c Controller, r *gin.Engine) {
 	c.Routes(r.Group(""))
 }
@@ -131,6 +181,10 @@ func testPut(t *testing.T, setup func(*mocks), expect *testResponse, path string
 	testHTTP(t, setup, expect, newHTTPRequest(t, http.MethodPut, path, body), opts...)
 }
 
+func testPatch(t *testing.T, setup func(*mocks), expect *testResponse, path string, body interface{}, opts ...testOption) {
+	testHTTP(t, setup, expect, newHTTPRequest(t, http.MethodPatch, path, body), opts...)
+}
+
 func testDelete(t *testing.T, setup func(*mocks), expect *testResponse, path string, opts ...testOption) {
 	testHTTP(t, setup, expect, newHTTPRequest(t, http.MethodDelete, path, nil), opts...)
 }
@@ -144,14 +198,18 @@ func testHTTP(t *testing.T, setup func(*mocks), expect *testResponse, req *http.
 	// setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	h, _ := testSetup(t, ctrl, setup, opts...)
+	h, dopts := testSetup(t, ctrl, setup, opts...)
 	w := httptest.NewRecorder()
 	_, r := gin.CreateTestContext(w)
+	require.NoError(t, r.SetTrustedProxies(dopts.trustedProxies))
 	newRoutes(h, r)
 
 	// test
 	r.ServeHTTP(w, req)
 	require.Equal(t, expect.code, w.Code)
+	for key, value := range expect.header {
+		require.Equal(t, value, w.Header().Get(key), key)
+	}
 	if isError(w) || expect.body == nil {
 		return
 	}
@@ -193,3 +251,94 @@ func TestController(t *testing.T) {
 	h := NewController(&Params{}, WithLogger(zap.NewNop()))
 	assert.NotNil(t, h)
 }
+
+func TestController_HTTPError(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	err := status.Error(codes.NotFound, "not found")
+	tests := []struct {
+		name        string
+		opts        []Option
+		accept      string
+		requestID   string
+		contentType string
+		expect      map[string]interface{}
+	}{
+		{
+			name:        "default",
+			accept:      "application/json",
+			contentType: "application/json; charset=utf-8",
+			expect: map[string]interface{}{
+				"status":    float64(http.StatusNotFound),
+				"code":      "NOT_FOUND",
+				"message":   "対象が見つかりません",
+				"detail":    err.Error(),
+				"requestId": "request-id",
+			},
+		},
+		{
+			name:        "request id from middleware",
+			accept:      "application/json",
+			requestID:   "generated-request-id",
+			contentType: "application/json; charset=utf-8",
+			expect: map[string]interface{}{
+				"status":    float64(http.StatusNotFound),
+				"code":      "NOT_FOUND",
+				"message":   "対象が見つかりません",
+				"detail":    err.Error(),
+				"requestId": "generated-request-id",
+			},
+		},
+		{
+			name:        "negotiated by accept header",
+			accept:      "application/problem+json",
+			contentType: "application/problem+json",
+			expect: map[string]interface{}{
+				"type":      "about:blank",
+				"title":     "対象が見つかりません",
+				"status":    float64(http.StatusNotFound),
+				"detail":    err.Error(),
+				"instance":  "/admin/me",
+				"code":      "NOT_FOUND",
+				"requestId": "request-id",
+			},
+		},
+		{
+			name:        "enabled by config",
+			opts:        []Option{WithProblemDetails(true), WithProblemTypeBaseURL("https://example.com/problems")},
+			accept:      "application/json",
+			contentType: "application/problem+json",
+			expect: map[string]interface{}{
+				"type":      "https://example.com/problems/not-found",
+				"title":     "対象が見つかりません",
+				"status":    float64(http.StatusNotFound),
+				"detail":    err.Error(),
+				"instance":  "/admin/me",
+				"code":      "NOT_FOUND",
+				"requestId": "request-id",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewController(&Params{}, tt.opts...).(*controller)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/admin/me", nil)
+			ctx.Request.Header.Set("Accept", tt.accept)
+			ctx.Request.Header.Set("X-Request-ID", "request-id")
+			if tt.requestID != "" {
+				ctx.Request = ctx.Request.WithContext(aphttp.NewRequestIDContext(ctx.Request.Context(), tt.requestID))
+			}
+			c.httpError(ctx, err)
+
+			var actual map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
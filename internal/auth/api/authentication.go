@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminContextKey - 認証済みの管理者を保持するコンテキストのキー
+const adminContextKey = "admin"
+
+// authentication - 管理者の認証
+// アクセストークンはすべての操作を許可し、APIキーは指定したスコープをすべて持つ場合のみ許可する
+// スコープを指定しない場合は、APIキーでの認証を許可しない
+func (c *controller) authentication(scopes ...entity.APIKeyScope) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var (
+			admin *entity.Admin
+			err   error
+		)
+		if key, kerr := util.GetAPIKey(ctx); kerr == nil {
+			admin, err = c.authenticateAPIKey(ctx, key, scopes)
+		} else {
+			admin, err = c.authenticateToken(ctx)
+		}
+		if err != nil {
+			c.httpError(ctx, err)
+			return
+		}
+		ctx.Set(adminContextKey, admin)
+		ctx.Request = ctx.Request.WithContext(log.With(ctx.Request.Context(), zap.String("adminId", admin.ID)))
+		ctx.Next()
+	}
+}
+
+func (c *controller) authenticateToken(ctx *gin.Context) (*entity.Admin, error) {
+	token, err := util.GetAuthToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	username, err := c.adminAuth.GetUsername(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return c.db.Admin.GetByCognitoID(ctx, username)
+}
+
+func (c *controller) authenticateAPIKey(
+	ctx *gin.Context, key string, scopes []entity.APIKeyScope,
+) (*entity.Admin, error) {
+	if len(scopes) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "api key is not allowed for this operation")
+	}
+	apiKey, err := c.db.AdminAPIKey.GetByHash(ctx, entity.HashAPIKey(key))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "api key is invalid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := c.now()
+	if !apiKey.Enabled(now) {
+		return nil, status.Error(codes.Unauthenticated, "api key is expired or revoked")
+	}
+	if !apiKey.AllowIP(ctx.ClientIP()) {
+		return nil, status.Error(codes.PermissionDenied, "this ip address is not allowed")
+	}
+	for _, scope := range scopes {
+		if !apiKey.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "api key does not have scope: %s", scope)
+		}
+	}
+	admin, err := c.db.Admin.Get(ctx, apiKey.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	// 書き込みを抑えるため、一定間隔ごとに最終利用日時を更新する
+	if now.Sub(apiKey.LastUsedAt) >= entity.APIKeyLastUsedInterval {
+		if err := c.db.AdminAPIKey.UpdateLastUsedAt(ctx, apiKey.ID); err != nil {
+			c.log(ctx).Warn("Failed to update api key last used at", zap.String("apiKeyId", apiKey.ID), zap.Error(err))
+		}
+	}
+	return admin, nil
+}
+
+// currentAdmin - 認証済みの管理者を取得
+func (c *controller) currentAdmin(ctx *gin.Context) (*entity.Admin, error) {
+	admin, ok := ctx.Get(adminContextKey)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "admin is not authenticated")
+	}
+	return admin.(*entity.Admin), nil
+}
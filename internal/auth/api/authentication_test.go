@@ -0,0 +1,279 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+	const key = "fm_api-key"
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	apiKey := func(modify func(k *entity.AdminAPIKey)) *entity.AdminAPIKey {
+		k := &entity.AdminAPIKey{
+			ID:         "api-key-id",
+			AdminID:    "admin-id",
+			Scopes:     []entity.APIKeyScope{entity.APIKeyScopePasskeyRead},
+			AllowedIPs: []string{"192.0.2.0/24"},
+			LastUsedAt: current.Add(-time.Hour),
+		}
+		if modify != nil {
+			modify(k)
+		}
+		return k
+	}
+	tests := []struct {
+		name       string
+		setup      func(mocks *mocks)
+		method     string
+		path       string
+		headers    map[string]string
+		remoteAddr string
+		proxies    []string
+		expect     *testResponse
+	}{
+		{
+			name: "access token",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(entity.AdminCredentials{}, nil)
+			},
+			method: http.MethodGet,
+			path:   "/admin/passkeys",
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeysResponse{Passkeys: []*response.AdminPasskey{}},
+			},
+		},
+		{
+			name: "invalid access token",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("", cognito.ErrUnauthenticated)
+			},
+			method: http.MethodGet,
+			path:   "/admin/passkeys",
+			expect: &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name: "api key in authorization header",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().UpdateLastUsedAt(gomock.Any(), "api-key-id").Return(nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(entity.AdminCredentials{}, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"Authorization": "ApiKey " + key},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeysResponse{Passkeys: []*response.AdminPasskey{}},
+			},
+		},
+		{
+			name: "api key in x-api-key header",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) { k.LastUsedAt = current })
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(entity.AdminCredentials{}, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeysResponse{Passkeys: []*response.AdminPasskey{}},
+			},
+		},
+		{
+			name: "failed to update last used at",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().UpdateLastUsedAt(gomock.Any(), "api-key-id").Return(assert.AnError)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(entity.AdminCredentials{}, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusOK},
+		},
+		{
+			name: "unknown api key",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(nil, database.ErrNotFound)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name: "revoked api key",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) { k.RevokedAt = current })
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name: "expired api key",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) { k.ExpiresAt = current })
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name: "ip address not allowed",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) { k.AllowedIPs = []string{"198.51.100.1"} })
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/admin/passkeys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "insufficient scope",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+			},
+			method:  http.MethodDelete,
+			path:    "/admin/passkeys/passkey-id",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "api key with organization scope",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) {
+					k.Scopes = []entity.APIKeyScope{entity.APIKeyScopeOrganizationRead}
+					k.LastUsedAt = current
+				})
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().ListByAdminID(gomock.Any(), "admin-id").Return(entity.OrganizationMembers{}, nil)
+			},
+			method:  http.MethodGet,
+			path:    "/organizations",
+			headers: map[string]string{"X-API-Key": key},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationsResponse{Organizations: []*response.Organization{}},
+			},
+		},
+		{
+			name: "api key without organization write scope",
+			setup: func(mocks *mocks) {
+				k := apiKey(func(k *entity.AdminAPIKey) {
+					k.Scopes = []entity.APIKeyScope{entity.APIKeyScopeOrganizationRead}
+				})
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+			},
+			method:  http.MethodDelete,
+			path:    "/organizations/current/members/admin-id",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "spoofed forwarded ip from untrusted peer",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+			},
+			method:     http.MethodGet,
+			path:       "/admin/passkeys",
+			headers:    map[string]string{"X-API-Key": key, "X-Forwarded-For": "192.0.2.1"},
+			remoteAddr: "203.0.113.1:12345",
+			proxies:    []string{"10.0.0.0/8"},
+			expect:     &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "forwarded ip from trusted proxy",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.db.adminAPIKey.EXPECT().UpdateLastUsedAt(gomock.Any(), "api-key-id").Return(nil)
+				mocks.db.adminCredential.EXPECT().List(gomock.Any(), "admin-id").Return(entity.AdminCredentials{}, nil)
+			},
+			method:     http.MethodGet,
+			path:       "/admin/passkeys",
+			headers:    map[string]string{"X-API-Key": key, "X-Forwarded-For": "192.0.2.1"},
+			remoteAddr: "10.0.0.1:12345",
+			proxies:    []string{"10.0.0.0/8"},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.AdminPasskeysResponse{Passkeys: []*response.AdminPasskey{}},
+			},
+		},
+		{
+			name: "forwarded ip without trusted proxies",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey(nil), nil)
+			},
+			method:     http.MethodGet,
+			path:       "/admin/passkeys",
+			headers:    map[string]string{"X-API-Key": key, "X-Forwarded-For": "192.0.2.1"},
+			remoteAddr: "10.0.0.1:12345",
+			expect:     &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name:    "api key not allowed",
+			setup:   func(mocks *mocks) {},
+			method:  http.MethodGet,
+			path:    "/admin/api-keys",
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := newHTTPRequest(t, tt.method, tt.path, nil)
+			req.RemoteAddr = "192.0.2.1:12345"
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			testHTTP(t, tt.setup, tt.expect, req, withNow(current), withTrustedProxies(tt.proxies...))
+		})
+	}
+}
+
+func TestController_CurrentAdmin(t *testing.T) {
+	t.Parallel()
+	c := NewController(&Params{}).(*controller)
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	_, err := c.currentAdmin(ctx)
+	assert.Error(t, err)
+
+	admin := &entity.Admin{ID: "admin-id"}
+	ctx.Set(adminContextKey, admin)
+	actual, err := c.currentAdmin(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, admin, actual)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader        = "ETag"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+)
+
+// newETag - リソースのバージョンからETagを生成
+func newETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatch - If-Matchヘッダーを現在のバージョンと強い比較で検証
+// 更新時に指定するバージョンを返す (ヘッダーが未指定、またはワイルドカードの場合も、
+// 同じリクエスト内で取得したバージョンを返し、取得から更新までの間の更新を検出する)
+func ifMatch(ctx *gin.Context, version int64) (int64, bool) {
+	header := ctx.GetHeader(ifMatchHeader)
+	if header == "" || strings.TrimSpace(header) == "*" {
+		return version, true
+	}
+	if !matchETag(header, newETag(version), false) {
+		return 0, false
+	}
+	return version, true
+}
+
+// ifNoneMatch - If-None-Matchヘッダーが現在のバージョンと弱い比較で一致するか
+func ifNoneMatch(ctx *gin.Context, version int64) bool {
+	header := ctx.GetHeader(ifNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+	return matchETag(header, newETag(version), true)
+}
+
+// matchETag - カンマ区切りのETagのリストに一致するものが含まれているか
+// 強い比較では弱いETag (W/接頭辞) は一致しないものとして扱う
+func matchETag(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewETag(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `"1"`, newETag(1))
+}
+
+func TestMatchETag(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		weak   bool
+		expect bool
+	}{
+		{
+			name:   "match",
+			header: `"1"`,
+			etag:   `"1"`,
+			weak:   false,
+			expect: true,
+		},
+		{
+			name:   "match in list",
+			header: `"1", "2" ,"3"`,
+			etag:   `"2"`,
+			weak:   false,
+			expect: true,
+		},
+		{
+			name:   "wildcard",
+			header: `*`,
+			etag:   `"2"`,
+			weak:   false,
+			expect: true,
+		},
+		{
+			name:   "unmatch",
+			header: `"1"`,
+			etag:   `"2"`,
+			weak:   false,
+			expect: false,
+		},
+		{
+			name:   "weak tag with strong comparison",
+			header: `W/"1"`,
+			etag:   `"1"`,
+			weak:   false,
+			expect: false,
+		},
+		{
+			name:   "weak tag with weak comparison",
+			header: `W/"1"`,
+			etag:   `"1"`,
+			weak:   true,
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, matchETag(tt.header, tt.etag, tt.weak))
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		header  string
+		version int64
+		ok      bool
+	}{
+		{
+			name:    "no header",
+			header:  "",
+			version: 2,
+			ok:      true,
+		},
+		{
+			name:    "wildcard",
+			header:  "*",
+			version: 2,
+			ok:      true,
+		},
+		{
+			name:    "match",
+			header:  `"2"`,
+			version: 2,
+			ok:      true,
+		},
+		{
+			name:    "unmatch",
+			header:  `"1"`,
+			version: 0,
+			ok:      false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/", nil)
+			if tt.header != "" {
+				ctx.Request.Header.Set(ifMatchHeader, tt.header)
+			}
+			// ヘッダーの有無によらず、取得したバージョンで更新時に検証する
+			version, ok := ifMatch(ctx, 2)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
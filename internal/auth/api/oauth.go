@@ -0,0 +1,226 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c *controller) oauthRoutes(rg *gin.RouterGroup) {
+	g := rg.Group("/oauth")
+	g.POST("/introspect", c.clientAuthentication(), c.IntrospectToken)
+}
+
+// IntrospectToken トークンの検証 (RFC 7662)
+func (c *controller) IntrospectToken(ctx *gin.Context) {
+	req := &request.IntrospectTokenRequest{}
+	ctx.Set(RequestContextKey, req)
+	if err := ctx.ShouldBindWith(req, binding.Form); err != nil {
+		c.httpError(ctx, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	if err := c.validator.Struct(req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res, err := c.introspect(ctx, req.Token)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// introspect - アクセストークンの検証
+// 無効なトークンの場合はエラーではなく、active=falseを返す
+func (c *controller) introspect(ctx *gin.Context, token string) (*response.IntrospectTokenResponse, error) {
+	now := c.now()
+	if res, ok := c.introspection.get(token, now); ok {
+		return res, nil
+	}
+	inactive := &response.IntrospectTokenResponse{Active: false}
+	username, err := c.adminAuth.GetUsername(ctx, token)
+	if inactiveTokenError(err) {
+		return inactive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	claims, err := cognito.ParseAccessToken(token)
+	if err != nil {
+		c.log(ctx).Warn("Failed to parse verified access token", zap.String("username", username), zap.Error(err))
+		return inactive, nil
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return inactive, nil
+	}
+	admin, err := c.db.Admin.GetByCognitoID(ctx, username)
+	if errors.Is(err, database.ErrNotFound) {
+		return inactive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &response.IntrospectTokenResponse{
+		Active:    true,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+		Username:  username,
+		TokenType: util.AuthTokenType,
+		Exp:       claims.ExpiresAt.Unix(),
+		Iat:       claims.IssuedAt.Unix(),
+		Sub:       claims.Subject,
+		AdminID:   admin.ID,
+	}
+	c.introspection.set(token, res, claims.ExpiresAt, now)
+	return res, nil
+}
+
+// inactiveTokenError - トークンが無効であることを示すエラーか
+func inactiveTokenError(err error) bool {
+	return errors.Is(err, cognito.ErrUnauthenticated) ||
+		errors.Is(err, cognito.ErrInvalidArgument) ||
+		errors.Is(err, cognito.ErrNotFound)
+}
+
+// clientAuthentication - トークンの検証を行うクライアントの認証
+// クライアントクレデンシャル (Basic認証)、またはトークンの検証スコープを持つAPIキーでの認証を許可する
+func (c *controller) clientAuthentication() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if clientID, secret, ok := ctx.Request.BasicAuth(); ok {
+			if !c.verifyClient(clientID, secret) {
+				ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+				c.unauthorized(ctx, "client credentials are invalid")
+				return
+			}
+			ctx.Next()
+			return
+		}
+		key, err := util.GetAPIKey(ctx)
+		if err != nil {
+			ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			c.unauthorized(ctx, "client authentication is required")
+			return
+		}
+		if _, err := c.authenticateAPIKey(ctx, key, []entity.APIKeyScope{entity.APIKeyScopeTokenIntrospect}); err != nil {
+			c.httpError(ctx, err)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (c *controller) verifyClient(clientID, secret string) bool {
+	expect, ok := c.clients[clientID]
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(expect, hashClientSecret(secret)) == 1
+}
+
+// hashClientSecret - 比較時に長さが漏れないよう、ハッシュ値に変換する
+func hashClientSecret(secret string) []byte {
+	h := sha256.Sum256([]byte(secret))
+	return h[:]
+}
+
+// introspectionCache - 有効なトークンの検証結果のキャッシュ
+// 無効なトークンはキャッシュせず、有効なトークンはトークンの有効期限とTTLのいずれか早い時刻までキャッシュする
+// キャッシュはプロセスごとに保持するため、他のレプリカでのサインアウトやAmazon Cognito側での失効は
+// 最大でTTLの間反映されない (失効の反映は結果整合であり、TTLを失効の反映にかかる時間の上限とする)
+type introspectionCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*introspectionEntry
+}
+
+type introspectionEntry struct {
+	response  *response.IntrospectTokenResponse
+	expiresAt time.Time
+}
+
+func newIntrospectionCache(size int, ttl time.Duration) *introspectionCache {
+	return &introspectionCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*introspectionEntry),
+	}
+}
+
+func (c *introspectionCache) get(token string, now time.Time) (*response.IntrospectTokenResponse, bool) {
+	key := introspectionKey(token)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+func (c *introspectionCache) set(token string, res *response.IntrospectTokenResponse, expiresAt, now time.Time) {
+	if c.size <= 0 || c.ttl <= 0 {
+		return
+	}
+	if limit := now.Add(c.ttl); limit.Before(expiresAt) {
+		expiresAt = limit
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	// 期限切れのキャッシュを削除しても上限を超える場合は、キャッシュしない
+	if len(c.entries) >= c.size {
+		return
+	}
+	c.entries[introspectionKey(token)] = &introspectionEntry{response: res, expiresAt: expiresAt}
+}
+
+// deleteBySubject - 指定したユーザーのキャッシュを削除 (サインアウト時に、失効したトークンを無効にする)
+// 削除されるのはサインアウトを処理したプロセスのキャッシュのみ
+func (c *introspectionCache) deleteBySubject(sub string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.response.Sub == sub {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *introspectionCache) evict(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// introspectionKey - トークンを平文で保持しないよう、ハッシュ値をキーにする
+func introspectionKey(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testAccessToken - Amazon Cognitoのアクセストークンと同じ形式のトークンを生成
+func testAccessToken(sub string, iat, exp time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`))
+	payload := fmt.Sprintf(
+		`{"sub":%q,"username":"cognito-id","client_id":"app-client-id","scope":"aws.cognito.signin.user.admin","token_use":"access","iat":%d,"exp":%d}`,
+		sub, iat.Unix(), exp.Unix(),
+	)
+	return header + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func newIntrospectRequest(t *testing.T, token string) *http.Request {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestIntrospectToken(t *testing.T) {
+	t.Parallel()
+	const key = "fm_api-key"
+	now := current.Truncate(time.Second)
+	token := testAccessToken("sub", now.Add(-time.Minute), now.Add(time.Hour))
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	apiKey := &entity.AdminAPIKey{
+		ID:         "api-key-id",
+		AdminID:    "admin-id",
+		Scopes:     []entity.APIKeyScope{entity.APIKeyScopeTokenIntrospect},
+		LastUsedAt: now,
+	}
+	active := &response.IntrospectTokenResponse{
+		Active:    true,
+		Scope:     "aws.cognito.signin.user.admin",
+		ClientID:  "app-client-id",
+		Username:  "cognito-id",
+		TokenType: "Bearer",
+		Exp:       now.Add(time.Hour).Unix(),
+		Iat:       now.Add(-time.Minute).Unix(),
+		Sub:       "sub",
+		AdminID:   "admin-id",
+	}
+	inactive := &response.IntrospectTokenResponse{Active: false}
+	tests := []struct {
+		name    string
+		setup   func(mocks *mocks)
+		token   string
+		headers map[string]string
+		expect  *testResponse
+	}{
+		{
+			name: "success with client credentials",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), token).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			token:   token,
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusOK, body: active},
+		},
+		{
+			name: "success with api key",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(apiKey, nil)
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(admin, nil)
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), token).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			token:   token,
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusOK, body: active},
+		},
+		{
+			name: "invalid token",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "invalid-token").Return("", cognito.ErrUnauthenticated)
+			},
+			token:   "invalid-token",
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusOK, body: inactive},
+		},
+		{
+			name: "expired token",
+			setup: func(mocks *mocks) {
+				expired := testAccessToken("sub", now.Add(-time.Hour), now)
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), expired).Return("cognito-id", nil)
+			},
+			token:   testAccessToken("sub", now.Add(-time.Hour), now),
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusOK, body: inactive},
+		},
+		{
+			name: "admin not found",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), token).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(nil, database.ErrNotFound)
+			},
+			token:   token,
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusOK, body: inactive},
+		},
+		{
+			name: "failed to get username",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), token).Return("", cognito.ErrInternal)
+			},
+			token:   token,
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusInternalServerError},
+		},
+		{
+			name:    "token is required",
+			setup:   func(mocks *mocks) {},
+			token:   "",
+			headers: map[string]string{"Authorization": basicAuth("client-id", "client-secret")},
+			expect:  &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name:    "invalid client secret",
+			setup:   func(mocks *mocks) {},
+			token:   token,
+			headers: map[string]string{"Authorization": basicAuth("client-id", "invalid")},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name:    "unknown client",
+			setup:   func(mocks *mocks) {},
+			token:   token,
+			headers: map[string]string{"Authorization": basicAuth("unknown", "client-secret")},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name:    "client authentication is required",
+			setup:   func(mocks *mocks) {},
+			token:   token,
+			headers: map[string]string{},
+			expect:  &testResponse{code: http.StatusUnauthorized},
+		},
+		{
+			name: "api key without introspect scope",
+			setup: func(mocks *mocks) {
+				k := &entity.AdminAPIKey{ID: "api-key-id", AdminID: "admin-id", Scopes: []entity.APIKeyScope{entity.APIKeyScopePasskeyRead}}
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey(key)).Return(k, nil)
+			},
+			token:   token,
+			headers: map[string]string{"X-API-Key": key},
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := newIntrospectRequest(t, tt.token)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			testHTTP(t, tt.setup, tt.expect, req, withNow(now))
+		})
+	}
+}
+
+func TestIntrospectToken_Cache(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	now := current.Truncate(time.Second)
+	token := testAccessToken("sub", now, now.Add(time.Hour))
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+
+	ctrl := gomock.NewController(t)
+	c, opts := testSetup(t, ctrl, func(mocks *mocks) {
+		// キャッシュの有効期限内は、Amazon Cognitoへの問い合わせを行わない
+		mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), token).Return("cognito-id", nil).Times(2)
+		mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil).Times(2)
+		mocks.adminAuth.EXPECT().SignOut(gomock.Any(), token).Return(nil)
+	}, withNow(now))
+	r := gin.New()
+	newRoutes(c, r)
+
+	introspect := func() {
+		req := newIntrospectRequest(t, token)
+		req.SetBasicAuth("client-id", "client-secret")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"active":true`)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	}
+	introspect()
+	introspect()
+
+	// サインアウト後は、キャッシュを使用しない
+	req, err := http.NewRequest(http.MethodDelete, "/admin/auth", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	introspect()
+
+	// 有効期限切れ後は、キャッシュを使用しない
+	opts.now = func() time.Time { return now.Add(time.Hour) }
+	_, ok := c.introspection.get(token, opts.now())
+	assert.False(t, ok)
+}
+
+func TestIntrospectionCache(t *testing.T) {
+	t.Parallel()
+	now := current
+	res := &response.IntrospectTokenResponse{Active: true, Sub: "sub"}
+
+	cache := newIntrospectionCache(2, time.Hour)
+	cache.set("token-1", res, now.Add(time.Minute), now)
+	cache.set("token-2", res, now.Add(time.Second), now)
+	cache.set("token-3", res, now.Add(time.Minute), now)
+	_, ok := cache.get("token-3", now)
+	assert.False(t, ok, "exceeded cache size")
+
+	// 期限切れのキャッシュを削除して追加する
+	cache.set("token-3", res, now.Add(time.Minute), now.Add(time.Second))
+	_, ok = cache.get("token-3", now.Add(time.Second))
+	assert.True(t, ok)
+	_, ok = cache.get("token-2", now)
+	assert.False(t, ok)
+
+	actual, ok := cache.get("token-1", now)
+	assert.True(t, ok)
+	assert.Equal(t, res, actual)
+	_, ok = cache.get("token-1", now.Add(time.Minute))
+	assert.False(t, ok)
+
+	cache.deleteBySubject("sub")
+	assert.Empty(t, cache.entries)
+
+	disabled := newIntrospectionCache(0, time.Hour)
+	disabled.set("token", res, now.Add(time.Minute), now)
+	assert.Empty(t, disabled.entries)
+	disabled = newIntrospectionCache(2, 0)
+	disabled.set("token", res, now.Add(time.Minute), now)
+	assert.Empty(t, disabled.entries)
+
+	// トークンの有効期限より前でも、TTLを過ぎたキャッシュは使用しない
+	bounded := newIntrospectionCache(2, 30*time.Second)
+	bounded.set("token", res, now.Add(time.Hour), now)
+	_, ok = bounded.get("token", now.Add(29*time.Second))
+	assert.True(t, ok)
+	_, ok = bounded.get("token", now.Add(30*time.Second))
+	assert.False(t, ok)
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
@@ -0,0 +1,419 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// 認証方式
+const (
+	securityBearer = "bearerAuth" // アクセストークン
+	securityAPIKey = "apiKeyAuth" // APIキー
+	securityClient = "clientAuth" // クライアントクレデンシャル
+)
+
+var (
+	bearerOnly = []openapi.SecurityRequirement{{securityBearer: {}}}
+	// organizationHeader - 操作対象の組織の指定
+	organizationHeader = []*openapi.Parameter{{
+		Name:        util.OrganizationIDHeader,
+		In:          "header",
+		Description: "操作対象の組織ID",
+		Required:    true,
+		Schema:      &openapi.Schema{Type: "string"},
+	}}
+	ifMatchHeaderParam = []*openapi.Parameter{{
+		Name:        ifMatchHeader,
+		In:          "header",
+		Description: "管理者情報の取得・更新時に返したETag (一致しない場合は412を返す)",
+		Schema:      &openapi.Schema{Type: "string"},
+	}}
+	ifNoneMatchHeaderParam = []*openapi.Parameter{{
+		Name:        ifNoneMatchHeader,
+		In:          "header",
+		Description: "前回取得時のETag (一致する場合は304を返す)",
+		Schema:      &openapi.Schema{Type: "string"},
+	}}
+	openAPIOnce sync.Once
+	openAPIDoc  *openapi.Document
+	openAPIErr  error
+)
+
+// paginationQuery - カーソルによる一覧取得のクエリパラメータ
+func paginationQuery(sortKeys map[string]string) []*openapi.Parameter {
+	keys := make([]string, 0, len(sortKeys))
+	for key := range sortKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return []*openapi.Parameter{
+		{
+			Name:        "limit",
+			In:          "query",
+			Description: fmt.Sprintf("取得件数 (デフォルト: %d, 上限: %d)", defaultListLimit, maxListLimit),
+			Schema:      &openapi.Schema{Type: "integer", Format: "int64"},
+		},
+		{
+			Name:        "orders",
+			In:          "query",
+			Description: fmt.Sprintf("ソート順 (カンマ区切り、降順の場合は接頭辞に-を指定) 指定可能なキー: %s", strings.Join(keys, ", ")),
+			Schema:      &openapi.Schema{Type: "string"},
+		},
+		{
+			Name:        util.CursorQuery,
+			In:          "query",
+			Description: "前回のレスポンスのnextCursor (ordersは前回と同じ値を指定)",
+			Schema:      &openapi.Schema{Type: "string"},
+		},
+	}
+}
+
+// bearerOrAPIKey - アクセストークン、または指定したスコープを持つAPIキーでの認証
+func bearerOrAPIKey(scopes ...entity.APIKeyScope) []openapi.SecurityRequirement {
+	ss := make([]string, len(scopes))
+	for i := range scopes {
+		ss[i] = string(scopes[i])
+	}
+	return []openapi.SecurityRequirement{{securityBearer: {}}, {securityAPIKey: ss}}
+}
+
+// endpoints - エンドポイントの定義 (Routesで登録したすべてのエンドポイントを定義すること)
+var endpoints = map[string]*openapi.Endpoint{
+	// 管理者
+	"POST /admin": {
+		Summary:  "管理者登録 (メールアドレス認証)",
+		Tags:     []string{"Admin"},
+		Request:  &request.SignUpAdminRequest{},
+		Response: &response.SignUpAdminResponse{},
+	},
+	"POST /admin/verified": {
+		Summary: "管理者登録後の確認 (メールアドレス認証)",
+		Tags:    []string{"Admin"},
+		Request: &request.VerifyAdminRequest{},
+	},
+	"POST /admin/oauth": {
+		Summary:  "管理者登録 (OAuth認証)",
+		Tags:     []string{"Admin"},
+		Response: &response.SignUpAdminWithOAuthResponse{},
+		Security: bearerOnly,
+	},
+	"PUT /admin/email": {
+		Summary:  "管理者メールアドレス更新",
+		Tags:     []string{"Admin"},
+		Request:  &request.UpdateAdminEmailRequest{},
+		Security: bearerOnly,
+	},
+	"POST /admin/email/verified": {
+		Summary:    "管理者メールアドレス更新後の確認",
+		Tags:       []string{"Admin"},
+		Request:    &request.VerifyAdminEmailRequest{},
+		Parameters: ifMatchHeaderParam,
+		Security:   bearerOnly,
+	},
+	"PUT /admin/password": {
+		Summary:  "管理者パスワード更新",
+		Tags:     []string{"Admin"},
+		Request:  &request.UpdateAdminPasswordRequest{},
+		Security: bearerOnly,
+	},
+	"POST /admin/password/forgot": {
+		Summary: "管理者パスワードリセット (メール/SMS送信)",
+		Tags:    []string{"Admin"},
+		Request: &request.ForgotAdminPasswordRequest{},
+	},
+	"PUT /admin/password/reset": {
+		Summary: "管理者パスワードリセット (パスワード更新)",
+		Tags:    []string{"Admin"},
+		Request: &request.ResetAdminPasswordRequest{},
+	},
+	"GET /admin/:adminId": {
+		Summary:    "管理者情報取得",
+		Tags:       []string{"Admin"},
+		Response:   &response.GetAdminResponse{},
+		Parameters: ifNoneMatchHeaderParam,
+	},
+	"DELETE /admin/:adminId": {
+		Summary:    "管理者退会",
+		Tags:       []string{"Admin"},
+		Parameters: ifMatchHeaderParam,
+	},
+	// 管理者認証
+	"POST /admin/auth": {
+		Summary:  "管理者サインイン (メールアドレス認証)",
+		Tags:     []string{"AdminAuth"},
+		Request:  &request.SignInAdminRequest{},
+		Response: &response.SignInAdminResponse{},
+	},
+	"DELETE /admin/auth": {
+		Summary:  "管理者サインアウト",
+		Tags:     []string{"AdminAuth"},
+		Security: bearerOnly,
+	},
+	"GET /admin/auth": {
+		Summary:  "管理者認証情報取得",
+		Tags:     []string{"AdminAuth"},
+		Response: &response.GetAdminAuthResponse{},
+		Security: bearerOnly,
+	},
+	"POST /admin/auth/refresh": {
+		Summary:  "管理者アクセストークンの更新",
+		Tags:     []string{"AdminAuth"},
+		Request:  &request.RefreshAdminTokenRequest{},
+		Response: &response.RefreshAdminTokenResponse{},
+	},
+	"POST /admin/auth/otp": {
+		Summary:  "管理者サインイン (ワンタイムコード送信)",
+		Tags:     []string{"AdminAuth"},
+		Request:  &request.StartAdminOTPRequest{},
+		Response: &response.StartAdminOTPResponse{},
+	},
+	"POST /admin/auth/otp/verify": {
+		Summary:  "管理者サインイン (ワンタイムコード検証)",
+		Tags:     []string{"AdminAuth"},
+		Request:  &request.VerifyAdminOTPRequest{},
+		Response: &response.VerifyAdminOTPResponse{},
+	},
+	"POST /admin/auth/passkey/options": {
+		Summary:  "管理者サインイン (パスキー認証のオプション取得)",
+		Tags:     []string{"AdminAuth"},
+		Response: &response.BeginAdminPasskeySignInResponse{},
+	},
+	"POST /admin/auth/passkey": {
+		Summary:  "管理者サインイン (パスキー認証)",
+		Tags:     []string{"AdminAuth"},
+		Request:  &request.SignInAdminWithPasskeyRequest{},
+		Response: &response.SignInAdminWithPasskeyResponse{},
+	},
+	// 管理者パスキー
+	"GET /admin/passkeys": {
+		Summary:  "管理者パスキー一覧取得",
+		Tags:     []string{"AdminPasskey"},
+		Response: &response.AdminPasskeysResponse{},
+		Security: bearerOrAPIKey(entity.APIKeyScopePasskeyRead),
+	},
+	"POST /admin/passkeys/registration/options": {
+		Summary:  "管理者パスキー登録 (オプション取得)",
+		Tags:     []string{"AdminPasskey"},
+		Response: &response.BeginAdminPasskeyRegistrationResponse{},
+		Security: bearerOnly,
+	},
+	"POST /admin/passkeys/registration": {
+		Summary:  "管理者パスキー登録",
+		Tags:     []string{"AdminPasskey"},
+		Request:  &request.RegisterAdminPasskeyRequest{},
+		Response: &response.AdminPasskeyResponse{},
+		Security: bearerOnly,
+	},
+	"PATCH /admin/passkeys/:passkeyId": {
+		Summary:  "管理者パスキー名更新",
+		Tags:     []string{"AdminPasskey"},
+		Request:  &request.UpdateAdminPasskeyRequest{},
+		Security: bearerOrAPIKey(entity.APIKeyScopePasskeyWrite),
+	},
+	"DELETE /admin/passkeys/:passkeyId": {
+		Summary:  "管理者パスキー削除",
+		Tags:     []string{"AdminPasskey"},
+		Security: bearerOrAPIKey(entity.APIKeyScopePasskeyWrite),
+	},
+	// 管理者APIキー
+	"GET /admin/api-keys": {
+		Summary:  "管理者APIキー一覧取得",
+		Tags:     []string{"AdminAPIKey"},
+		Response: &response.AdminAPIKeysResponse{},
+		Security: bearerOnly,
+	},
+	"POST /admin/api-keys": {
+		Summary:  "管理者APIキー発行",
+		Tags:     []string{"AdminAPIKey"},
+		Request:  &request.CreateAdminAPIKeyRequest{},
+		Response: &response.CreateAdminAPIKeyResponse{},
+		Security: bearerOnly,
+	},
+	"POST /admin/api-keys/:apiKeyId/rotate": {
+		Summary:  "管理者APIキー再発行",
+		Tags:     []string{"AdminAPIKey"},
+		Response: &response.RotateAdminAPIKeyResponse{},
+		Security: bearerOnly,
+	},
+	"DELETE /admin/api-keys/:apiKeyId": {
+		Summary:  "管理者APIキー失効",
+		Tags:     []string{"AdminAPIKey"},
+		Security: bearerOnly,
+	},
+	// 組織
+	"GET /organizations": {
+		Summary:  "所属する組織一覧取得",
+		Tags:     []string{"Organization"},
+		Response: &response.OrganizationsResponse{},
+		Security: bearerOrAPIKey(entity.APIKeyScopeOrganizationRead),
+	},
+	"POST /organizations": {
+		Summary:  "組織登録",
+		Tags:     []string{"Organization"},
+		Request:  &request.CreateOrganizationRequest{},
+		Response: &response.OrganizationResponse{},
+		Security: bearerOrAPIKey(entity.APIKeyScopeOrganizationWrite),
+	},
+	"GET /organizations/current": {
+		Summary:    "組織取得",
+		Tags:       []string{"Organization"},
+		Response:   &response.OrganizationResponse{},
+		Parameters: organizationHeader,
+		Security:   bearerOrAPIKey(entity.APIKeyScopeOrganizationRead),
+	},
+	"GET /organizations/current/members": {
+		Summary:    "組織のメンバー一覧取得",
+		Tags:       []string{"Organization"},
+		Response:   &response.OrganizationMembersResponse{},
+		Parameters: append(paginationQuery(organizationMemberSortKeys), organizationHeader...),
+		Security:   bearerOrAPIKey(entity.APIKeyScopeOrganizationRead),
+	},
+	"POST /organizations/current/members": {
+		Summary:    "組織のメンバー追加",
+		Tags:       []string{"Organization"},
+		Request:    &request.AddOrganizationMemberRequest{},
+		Response:   &response.OrganizationMemberResponse{},
+		Parameters: organizationHeader,
+		Security:   bearerOrAPIKey(entity.APIKeyScopeOrganizationWrite),
+	},
+	"PATCH /organizations/current/members/:adminId": {
+		Summary:    "組織のメンバーの権限更新",
+		Tags:       []string{"Organization"},
+		Request:    &request.UpdateOrganizationMemberRequest{},
+		Parameters: organizationHeader,
+		Security:   bearerOrAPIKey(entity.APIKeyScopeOrganizationWrite),
+	},
+	"DELETE /organizations/current/members/:adminId": {
+		Summary:    "組織のメンバー削除",
+		Tags:       []string{"Organization"},
+		Parameters: organizationHeader,
+		Security:   bearerOrAPIKey(entity.APIKeyScopeOrganizationWrite),
+	},
+	// OAuth
+	"POST /oauth/introspect": {
+		Summary:     "トークンの検証 (RFC 7662)",
+		Tags:        []string{"OAuth"},
+		Request:     &request.IntrospectTokenRequest{},
+		RequestType: binding.MIMEPOSTForm,
+		Response:    &response.IntrospectTokenResponse{},
+		Security: []openapi.SecurityRequirement{
+			{securityClient: {}},
+			{securityAPIKey: {string(entity.APIKeyScopeTokenIntrospect)}},
+		},
+	},
+	// その他
+	"GET /openapi.json": {
+		Summary:  "OpenAPIドキュメント取得",
+		Tags:     []string{"OpenAPI"},
+		Response: map[string]interface{}{},
+	},
+}
+
+func (c *controller) openAPIRoutes(rg *gin.RouterGroup) {
+	rg.GET("/openapi.json", c.GetOpenAPI)
+}
+
+// GetOpenAPI OpenAPIドキュメント取得
+func (c *controller) GetOpenAPI(ctx *gin.Context) {
+	doc, err := NewOpenAPI()
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, doc)
+}
+
+// NewOpenAPI - Routesで登録したエンドポイントからOpenAPIドキュメントを生成
+func NewOpenAPI() (*openapi.Document, error) {
+	openAPIOnce.Do(func() {
+		rt := gin.New()
+		c := NewController(&Params{})
+		c.Routes(rt.Group(""))
+		openAPIDoc, openAPIErr = newOpenAPI(rt.Routes())
+	})
+	return openAPIDoc, openAPIErr
+}
+
+func newOpenAPI(routes gin.RoutesInfo) (*openapi.Document, error) {
+	info := &openapi.Info{
+		Title:       "furumane auth API",
+		Description: "ふるマネ 認証API",
+		Version:     "1.0.0",
+	}
+	g := openapi.NewGenerator(info,
+		openapi.WithSecurityScheme(securityBearer, &openapi.SecurityScheme{
+			Type:        "http",
+			Description: "Amazon Cognitoのアクセストークン",
+			Scheme:      "bearer",
+		}),
+		openapi.WithSecurityScheme(securityAPIKey, &openapi.SecurityScheme{
+			Type:        "apiKey",
+			Description: "管理者のAPIキー (Authorization: ApiKey <key> も利用可能)",
+			Name:        "X-API-Key",
+			In:          "header",
+		}),
+		openapi.WithSecurityScheme(securityClient, &openapi.SecurityScheme{
+			Type:        "http",
+			Description: "トークンの検証を許可されたクライアントのクライアントID・シークレット",
+			Scheme:      "basic",
+		}),
+		openapi.WithErrorResponses(
+			&openapi.ErrorResponse{ContentType: "application/json", Body: &response.ErrorResponse{}},
+			&openapi.ErrorResponse{ContentType: response.ProblemContentType, Body: &response.ProblemDetails{}},
+		),
+	)
+	documented := make(map[string]bool, len(endpoints))
+	undocumented := make([]string, 0)
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		endpoint, ok := endpoints[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			continue
+		}
+		documented[key] = true
+		ep := *endpoint
+		if ep.OperationID == "" {
+			ep.OperationID = handlerName(r.Handler)
+		}
+		if err := g.Add(r.Method, r.Path, &ep); err != nil {
+			return nil, err
+		}
+	}
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		return nil, fmt.Errorf("api: undocumented endpoints: %s", strings.Join(undocumented, ", "))
+	}
+	unknown := make([]string, 0)
+	for key := range endpoints {
+		if !documented[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("api: endpoints are not registered: %s", strings.Join(unknown, ", "))
+	}
+	return g.Document(), nil
+}
+
+// handlerName - ginのハンドラー名からメソッド名を取得
+// e.g.) github.com/and-period/furumane/internal/auth/api.(*controller).GetAdmin-fm -> GetAdmin
+func handlerName(handler string) string {
+	name := strings.TrimSuffix(handler, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
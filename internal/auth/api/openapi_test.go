@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/and-period/furumane/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOpenAPI_Routes(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, _ := testSetup(t, ctrl, func(mocks *mocks) {})
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	newRoutes(c, r)
+
+	doc, err := NewOpenAPI()
+	require.NoError(t, err)
+	endpoints := doc.Endpoints()
+	// Routesで登録したすべてのエンドポイントがドキュメントに含まれること
+	for _, route := range r.Routes() {
+		endpoint := route.Method + " " + openapi.ConvertPath(route.Path)
+		assert.Contains(t, endpoints, endpoint, "%s is not documented in api/openapi.go", endpoint)
+	}
+	assert.Len(t, endpoints, len(r.Routes()))
+}
+
+func TestOpenAPI_Undocumented(t *testing.T) {
+	t.Parallel()
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/admin/:adminId", Handler: "api.(*controller).GetAdmin-fm"},
+		{Method: http.MethodGet, Path: "/admin/unknown", Handler: "api.(*controller).Unknown-fm"},
+	}
+	_, err := newOpenAPI(routes)
+	assert.ErrorContains(t, err, "undocumented endpoints: GET /admin/unknown")
+}
+
+func TestOpenAPI_Unregistered(t *testing.T) {
+	t.Parallel()
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/admin/:adminId", Handler: "api.(*controller).GetAdmin-fm"},
+	}
+	_, err := newOpenAPI(routes)
+	assert.ErrorContains(t, err, "endpoints are not registered")
+}
+
+func TestOpenAPI_Document(t *testing.T) {
+	t.Parallel()
+	doc, err := NewOpenAPI()
+	require.NoError(t, err)
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	op := doc.Paths["/admin/passkeys/{passkeyId}"]["patch"]
+	require.NotNil(t, op)
+	assert.Equal(t, "UpdateAdminPasskey", op.OperationID)
+	assert.Equal(t, "passkeyId", op.Parameters[0].Name)
+	assert.Equal(t, []openapi.SecurityRequirement{
+		{securityBearer: {}},
+		{securityAPIKey: {"passkey:write"}},
+	}, op.Security)
+	assert.Contains(t, op.Responses, "204")
+	assert.Equal(t, "#/components/schemas/ErrorResponse", op.Responses["default"].Content["application/json"].Schema.Ref)
+
+	schema := doc.Components.Schemas["VerifyAdminOTPRequest"]
+	require.NotNil(t, schema)
+	assert.ElementsMatch(t, []string{"email", "session", "code"}, schema.Required)
+	assert.Equal(t, "email", schema.Properties["email"].Format)
+	assert.Equal(t, int64(6), *schema.Properties["code"].MinLength)
+	assert.Equal(t, int64(6), *schema.Properties["code"].MaxLength)
+}
+
+func TestGetOpenAPI(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, _ := testSetup(t, ctrl, func(mocks *mocks) {})
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	newRoutes(c, r)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	doc := &openapi.Document{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/admin/{adminId}")
+}
+
+func TestHandlerName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		handler string
+		expect  string
+	}{
+		{
+			name:    "method value",
+			handler: "github.com/and-period/furumane/internal/auth/api.(*controller).GetAdmin-fm",
+			expect:  "GetAdmin",
+		},
+		{
+			name:    "function",
+			handler: "main.handler",
+			expect:  "handler",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, handlerName(tt.handler))
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/auth/service"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// organizationContextKey - 操作対象の組織での所属情報を保持するコンテキストのキー
+const organizationContextKey = "organizationMember"
+
+// organizationMemberSortKeys - 組織のメンバー一覧でソート可能なキー (クエリのキー: カラム名)
+var organizationMemberSortKeys = map[string]string{
+	"role":      "role",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// 組織の管理はアクセストークン、または組織のスコープを持つAPIキーでの認証を許可する
+// APIキーの場合も、APIキーを発行した管理者の組織での権限で操作する
+// 操作対象の組織はX-Organization-IDヘッダーで指定する
+func (c *controller) organizationRoutes(rg *gin.RouterGroup) {
+	read := c.authentication(entity.APIKeyScopeOrganizationRead)
+	write := c.authentication(entity.APIKeyScopeOrganizationWrite)
+
+	g := rg.Group("/organizations")
+	g.GET("", read, c.ListOrganizations)
+	g.POST("", write, c.CreateOrganization)
+
+	current := g.Group("/current")
+	current.GET("", read, c.organization(entity.OrganizationRoleMember), c.GetOrganization)
+	current.GET("/members", read, c.organization(entity.OrganizationRoleMember), c.ListOrganizationMembers)
+	current.POST("/members", write, c.organization(entity.OrganizationRoleAdmin), c.AddOrganizationMember)
+	current.PATCH("/members/:adminId", write, c.organization(entity.OrganizationRoleAdmin), c.UpdateOrganizationMember)
+	current.DELETE("/members/:adminId", write, c.organization(entity.OrganizationRoleMember), c.RemoveOrganizationMember)
+}
+
+// organization - 操作対象の組織での権限の検証
+// 所属していない組織の場合は、組織の存在有無に関わらず権限エラーとする
+func (c *controller) organization(role entity.OrganizationRole) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		organizationID, err := util.GetOrganizationID(ctx)
+		if err != nil {
+			c.httpError(ctx, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		admin, err := c.currentAdmin(ctx)
+		if err != nil {
+			c.httpError(ctx, err)
+			return
+		}
+		member, err := c.db.OrganizationMember.Get(ctx, organizationID, admin.ID)
+		if errors.Is(err, database.ErrNotFound) {
+			c.forbidden(ctx, "admin is not a member of this organization")
+			return
+		}
+		if err != nil {
+			c.httpError(ctx, err)
+			return
+		}
+		if !member.HasRole(role) {
+			c.forbidden(ctx, "admin does not have permission for this organization")
+			return
+		}
+		ctx.Set(organizationContextKey, member)
+		ctx.Next()
+	}
+}
+
+// currentMember - 操作対象の組織での所属情報を取得
+func (c *controller) currentMember(ctx *gin.Context) (*entity.OrganizationMember, error) {
+	member, ok := ctx.Get(organizationContextKey)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "organization is not selected")
+	}
+	return member.(*entity.OrganizationMember), nil
+}
+
+// ListOrganizations 所属する組織一覧取得
+func (c *controller) ListOrganizations(ctx *gin.Context) {
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	members, err := c.db.OrganizationMember.ListByAdminID(ctx, admin.ID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	if len(members) == 0 {
+		ctx.JSON(http.StatusOK, &response.OrganizationsResponse{Organizations: []*response.Organization{}})
+		return
+	}
+	organizations, err := c.db.Organization.MultiGet(ctx, members.OrganizationIDs())
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.OrganizationsResponse{
+		Organizations: service.NewOrganizations(organizations, members).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateOrganization 組織登録 (登録した管理者をオーナーとする)
+func (c *controller) CreateOrganization(ctx *gin.Context) {
+	req := &request.CreateOrganizationRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	admin, err := c.currentAdmin(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	organizationParams := &entity.OrganizationParams{
+		ID:   uuid.Base58Encode(c.uuid()),
+		Code: req.Code,
+		Name: req.Name,
+	}
+	organization := entity.NewOrganization(organizationParams)
+	ownerParams := &entity.OrganizationMemberParams{
+		OrganizationID: organization.ID,
+		AdminID:        admin.ID,
+		Role:           entity.OrganizationRoleOwner,
+	}
+	owner := entity.NewOrganizationMember(ownerParams)
+	err = c.db.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := c.db.Organization.Create(ctx, organization); err != nil {
+			return err
+		}
+		return c.db.OrganizationMember.Create(ctx, owner)
+	})
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.OrganizationResponse{
+		Organization: service.NewOrganization(organization, owner).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetOrganization 組織取得
+func (c *controller) GetOrganization(ctx *gin.Context) {
+	member, err := c.currentMember(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	organization, err := c.db.Organization.Get(ctx, member.OrganizationID)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.OrganizationResponse{
+		Organization: service.NewOrganization(organization, member).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListOrganizationMembers 組織のメンバー一覧取得
+func (c *controller) ListOrganizationMembers(ctx *gin.Context) {
+	current, err := c.currentMember(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	p, err := c.newPagination(ctx, organizationMemberSortKeys)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	params := &database.ListOrganizationMembersParams{
+		OrganizationID: current.OrganizationID,
+		Orders:         p.keys,
+		Cursor:         p.cursor,
+		Limit:          p.limit,
+	}
+	members, next, err := c.db.OrganizationMember.List(ctx, params)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	admins, err := c.db.Admin.MultiGet(ctx, members.AdminIDs())
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	cursor, err := c.nextCursor(p, next)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.OrganizationMembersResponse{
+		Members:    service.NewOrganizationMembers(members, admins).Response(),
+		NextCursor: cursor,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AddOrganizationMember 組織のメンバー追加
+func (c *controller) AddOrganizationMember(ctx *gin.Context) {
+	req := &request.AddOrganizationMemberRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	current, err := c.currentMember(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	role := entity.OrganizationRole(req.Role)
+	if !canAssignRole(current, role) {
+		c.forbidden(ctx, "only owners can add owners")
+		return
+	}
+	admin, err := c.db.Admin.GetByEmail(ctx, req.Email)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	params := &entity.OrganizationMemberParams{
+		OrganizationID: current.OrganizationID,
+		AdminID:        admin.ID,
+		Role:           role,
+	}
+	member := entity.NewOrganizationMember(params)
+	if err := c.db.OrganizationMember.Create(ctx, member); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	res := &response.OrganizationMemberResponse{
+		Member: service.NewOrganizationMember(member, admin).Response(),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateOrganizationMember 組織のメンバーの権限更新
+func (c *controller) UpdateOrganizationMember(ctx *gin.Context) {
+	req := &request.UpdateOrganizationMemberRequest{}
+	if err := c.bind(ctx, req); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	current, err := c.currentMember(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	target, err := c.db.OrganizationMember.Get(ctx, current.OrganizationID, util.GetParam(ctx, "adminId"))
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	role := entity.OrganizationRole(req.Role)
+	if !canAssignRole(current, target.Role) || !canAssignRole(current, role) {
+		c.forbidden(ctx, "only owners can change the role of owners")
+		return
+	}
+	if err := c.db.OrganizationMember.UpdateRole(ctx, current.OrganizationID, target.AdminID, role); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveOrganizationMember 組織のメンバー削除 (自身の場合は権限に関わらず脱退できる)
+func (c *controller) RemoveOrganizationMember(ctx *gin.Context) {
+	current, err := c.currentMember(ctx)
+	if err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	adminID := util.GetParam(ctx, "adminId")
+	if adminID != current.AdminID {
+		if !current.HasRole(entity.OrganizationRoleAdmin) {
+			c.forbidden(ctx, "admin does not have permission for this organization")
+			return
+		}
+		target, err := c.db.OrganizationMember.Get(ctx, current.OrganizationID, adminID)
+		if err != nil {
+			c.httpError(ctx, err)
+			return
+		}
+		if !canAssignRole(current, target.Role) {
+			c.forbidden(ctx, "only owners can remove owners")
+			return
+		}
+	}
+	if err := c.db.OrganizationMember.Delete(ctx, current.OrganizationID, adminID); err != nil {
+		c.httpError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// canAssignRole - 指定した権限のメンバーを操作できるか (オーナーの追加・変更・削除はオーナーのみ許可する)
+func canAssignRole(current *entity.OrganizationMember, role entity.OrganizationRole) bool {
+	if role == entity.OrganizationRoleOwner {
+		return current.HasRole(entity.OrganizationRoleOwner)
+	}
+	return current.HasRole(entity.OrganizationRoleAdmin)
+}
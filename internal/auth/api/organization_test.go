@@ -0,0 +1,658 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newOrganizationRequest - 操作対象の組織を指定したHTTP Requestを生成
+func newOrganizationRequest(t *testing.T, method, path string, body interface{}) *http.Request {
+	req := newHTTPRequest(t, method, path, body)
+	req.Header.Set(util.OrganizationIDHeader, "organization-id")
+	return req
+}
+
+// expectOrganizationMember - 認証と操作対象の組織での所属情報の取得
+func expectOrganizationMember(mocks *mocks, role entity.OrganizationRole) {
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	member := &entity.OrganizationMember{OrganizationID: "organization-id", AdminID: "admin-id", Role: role}
+	mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+	mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+	mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "admin-id").Return(member, nil)
+}
+
+func TestOrganization(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	organization := &entity.Organization{ID: "organization-id", Code: "402214", Name: "宗像市", CreatedAt: current, UpdatedAt: current}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		header bool
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				mocks.db.organization.EXPECT().Get(gomock.Any(), "organization-id").Return(organization, nil)
+			},
+			header: true,
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationResponse{
+					Organization: &response.Organization{
+						ID:        "organization-id",
+						Code:      "402214",
+						Name:      "宗像市",
+						Role:      int32(entity.OrganizationRoleMember),
+						CreatedAt: current,
+						UpdatedAt: current,
+					},
+				},
+			},
+		},
+		{
+			name: "organization id is required",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			header: false,
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name: "not a member",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "admin-id").Return(nil, database.ErrNotFound)
+			},
+			header: true,
+			expect: &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "failed to get member",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "admin-id").Return(nil, assert.AnError)
+			},
+			header: true,
+			expect: &testResponse{code: http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations/current"
+			req := newHTTPRequest(t, http.MethodGet, path, nil)
+			if tt.header {
+				req.Header.Set(util.OrganizationIDHeader, "organization-id")
+			}
+			testHTTP(t, tt.setup, tt.expect, req)
+		})
+	}
+}
+
+func TestListOrganizations(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	members := entity.OrganizationMembers{
+		{OrganizationID: "organization-id01", AdminID: "admin-id", Role: entity.OrganizationRoleOwner},
+		{OrganizationID: "organization-id02", AdminID: "admin-id", Role: entity.OrganizationRoleMember},
+	}
+	organizations := entity.Organizations{
+		{ID: "organization-id01", Code: "402214", Name: "宗像市", CreatedAt: current, UpdatedAt: current},
+		{ID: "organization-id02", Code: "402231", Name: "古賀市", CreatedAt: current, UpdatedAt: current},
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().ListByAdminID(gomock.Any(), "admin-id").Return(members, nil)
+				mocks.db.organization.EXPECT().
+					MultiGet(gomock.Any(), []string{"organization-id01", "organization-id02"}).
+					Return(organizations, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationsResponse{
+					Organizations: []*response.Organization{
+						{
+							ID:        "organization-id01",
+							Code:      "402214",
+							Name:      "宗像市",
+							Role:      int32(entity.OrganizationRoleOwner),
+							CreatedAt: current,
+							UpdatedAt: current,
+						},
+						{
+							ID:        "organization-id02",
+							Code:      "402231",
+							Name:      "古賀市",
+							Role:      int32(entity.OrganizationRoleMember),
+							CreatedAt: current,
+							UpdatedAt: current,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "empty",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().ListByAdminID(gomock.Any(), "admin-id").Return(entity.OrganizationMembers{}, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationsResponse{Organizations: []*response.Organization{}},
+			},
+		},
+		{
+			name: "failed to list organizations",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				mocks.db.organizationMember.EXPECT().ListByAdminID(gomock.Any(), "admin-id").Return(members, nil)
+				mocks.db.organization.EXPECT().MultiGet(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			expect: &testResponse{code: http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations"
+			testGet(t, tt.setup, tt.expect, path)
+		})
+	}
+}
+
+func TestCreateOrganization(t *testing.T) {
+	t.Parallel()
+	admin := &entity.Admin{ID: "admin-id", CognitoID: "cognito-id"}
+	organizationID := uuid.Base58Encode(idmock)
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.CreateOrganizationRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				expectTransaction(mocks)
+				mocks.db.organization.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, organization *entity.Organization) error {
+						assert.Equal(t, &entity.Organization{ID: organizationID, Code: "402214", Name: "宗像市"}, organization)
+						return nil
+					})
+				mocks.db.organizationMember.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, owner *entity.OrganizationMember) error {
+						expect := &entity.OrganizationMember{
+							OrganizationID: organizationID,
+							AdminID:        "admin-id",
+							Role:           entity.OrganizationRoleOwner,
+						}
+						assert.Equal(t, expect, owner)
+						return nil
+					})
+			},
+			req: &request.CreateOrganizationRequest{Code: "402214", Name: "宗像市"},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationResponse{
+					Organization: &response.Organization{
+						ID:   organizationID,
+						Code: "402214",
+						Name: "宗像市",
+						Role: int32(entity.OrganizationRoleOwner),
+					},
+				},
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				expectTransaction(mocks)
+				mocks.db.organization.EXPECT().Create(gomock.Any(), gomock.Any()).Return(database.ErrAlreadyExists)
+			},
+			req:    &request.CreateOrganizationRequest{Code: "402214", Name: "宗像市"},
+			expect: &testResponse{code: http.StatusConflict},
+		},
+		{
+			name: "failed to create owner",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+				expectTransaction(mocks)
+				mocks.db.organization.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mocks.db.organizationMember.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			req:    &request.CreateOrganizationRequest{Code: "402214", Name: "宗像市"},
+			expect: &testResponse{code: http.StatusInternalServerError},
+		},
+		{
+			name: "invalid code",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), tokenmock).Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(admin, nil)
+			},
+			req:    &request.CreateOrganizationRequest{Code: "4022", Name: "宗像市"},
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations"
+			testPost(t, tt.setup, tt.expect, path, tt.req, withUUID(idmock))
+		})
+	}
+}
+
+func TestListOrganizationMembers(t *testing.T) {
+	t.Parallel()
+	members := entity.OrganizationMembers{
+		{OrganizationID: "organization-id", AdminID: "admin-id", Role: entity.OrganizationRoleOwner, CreatedAt: current, UpdatedAt: current},
+		{OrganizationID: "organization-id", AdminID: "deleted-id", Role: entity.OrganizationRoleMember, CreatedAt: current, UpdatedAt: current},
+	}
+	admins := entity.Admins{{ID: "admin-id", Email: "test@example.com"}}
+	orders := []*util.Order{{Key: "role", Direction: util.OrderByDesc}}
+	cursor, err := util.EncodeCursor([]byte(testCursorSecret), &util.Cursor{
+		Orders: orders,
+		Values: []interface{}{int64(entity.OrganizationRoleMember), "deleted-id"},
+	})
+	require.NoError(t, err)
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		query  string
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				params := &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id",
+					Orders:         []*mysql.Order{},
+					Limit:          20,
+				}
+				mocks.db.organizationMember.EXPECT().List(gomock.Any(), params).Return(members, nil, nil)
+				mocks.db.admin.EXPECT().MultiGet(gomock.Any(), []string{"admin-id", "deleted-id"}).Return(admins, nil)
+			},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationMembersResponse{
+					Members: []*response.OrganizationMember{
+						{
+							AdminID:   "admin-id",
+							Email:     "test@example.com",
+							Role:      int32(entity.OrganizationRoleOwner),
+							CreatedAt: current,
+							UpdatedAt: current,
+						},
+					},
+					NextCursor: "",
+				},
+			},
+		},
+		{
+			name: "success with next cursor",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				params := &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id",
+					Orders:         []*mysql.Order{{Column: "role", Desc: true}},
+					Limit:          2,
+				}
+				next := []interface{}{entity.OrganizationRoleMember, "deleted-id"}
+				mocks.db.organizationMember.EXPECT().List(gomock.Any(), params).Return(members, next, nil)
+				mocks.db.admin.EXPECT().MultiGet(gomock.Any(), []string{"admin-id", "deleted-id"}).Return(admins, nil)
+			},
+			query: "?limit=2&orders=-role",
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationMembersResponse{
+					Members: []*response.OrganizationMember{
+						{
+							AdminID:   "admin-id",
+							Email:     "test@example.com",
+							Role:      int32(entity.OrganizationRoleOwner),
+							CreatedAt: current,
+							UpdatedAt: current,
+						},
+					},
+					NextCursor: cursor,
+				},
+			},
+		},
+		{
+			name: "success with cursor",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				params := &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id",
+					Orders:         []*mysql.Order{{Column: "role", Desc: true}},
+					Cursor:         []interface{}{int64(entity.OrganizationRoleMember), "deleted-id"},
+					Limit:          2,
+				}
+				mocks.db.organizationMember.EXPECT().List(gomock.Any(), params).Return(entity.OrganizationMembers{}, nil, nil)
+				mocks.db.admin.EXPECT().MultiGet(gomock.Any(), []string{}).Return(entity.Admins{}, nil)
+			},
+			query: "?limit=2&orders=-role&cursor=" + cursor,
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationMembersResponse{
+					Members:    []*response.OrganizationMember{},
+					NextCursor: "",
+				},
+			},
+		},
+		{
+			name: "invalid limit",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			query:  "?limit=0",
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name: "unsupported order key",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			query:  "?orders=email",
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name: "orders do not match the cursor",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			query:  "?orders=role&cursor=" + cursor,
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name: "tampered cursor",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			query:  "?orders=-role&cursor=" + cursor + "x",
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+		{
+			name: "failed to list members",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				mocks.db.organizationMember.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, assert.AnError)
+			},
+			expect: &testResponse{code: http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations/current/members"
+			testHTTP(t, tt.setup, tt.expect, newOrganizationRequest(t, http.MethodGet, path+tt.query, nil))
+		})
+	}
+}
+
+func TestAddOrganizationMember(t *testing.T) {
+	t.Parallel()
+	target := &entity.Admin{ID: "target-id", Email: "target@example.com"}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.AddOrganizationMemberRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+				mocks.db.admin.EXPECT().GetByEmail(gomock.Any(), "target@example.com").Return(target, nil)
+				mocks.db.organizationMember.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, member *entity.OrganizationMember) error {
+						expect := &entity.OrganizationMember{
+							OrganizationID: "organization-id",
+							AdminID:        "target-id",
+							Role:           entity.OrganizationRoleMember,
+						}
+						assert.Equal(t, expect, member)
+						return nil
+					})
+			},
+			req: &request.AddOrganizationMemberRequest{Email: "target@example.com", Role: int32(entity.OrganizationRoleMember)},
+			expect: &testResponse{
+				code: http.StatusOK,
+				body: &response.OrganizationMemberResponse{
+					Member: &response.OrganizationMember{
+						AdminID: "target-id",
+						Email:   "target@example.com",
+						Role:    int32(entity.OrganizationRoleMember),
+					},
+				},
+			},
+		},
+		{
+			name: "admin cannot add owner",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+			},
+			req:    &request.AddOrganizationMemberRequest{Email: "target@example.com", Role: int32(entity.OrganizationRoleOwner)},
+			expect: &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "member cannot add member",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			req:    &request.AddOrganizationMemberRequest{Email: "target@example.com", Role: int32(entity.OrganizationRoleMember)},
+			expect: &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "admin not found",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+				mocks.db.admin.EXPECT().GetByEmail(gomock.Any(), "target@example.com").Return(nil, database.ErrNotFound)
+			},
+			req:    &request.AddOrganizationMemberRequest{Email: "target@example.com", Role: int32(entity.OrganizationRoleOwner)},
+			expect: &testResponse{code: http.StatusNotFound},
+		},
+		{
+			name: "already a member",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+				mocks.db.admin.EXPECT().GetByEmail(gomock.Any(), "target@example.com").Return(target, nil)
+				mocks.db.organizationMember.EXPECT().Create(gomock.Any(), gomock.Any()).Return(database.ErrAlreadyExists)
+			},
+			req:    &request.AddOrganizationMemberRequest{Email: "target@example.com", Role: int32(entity.OrganizationRoleAdmin)},
+			expect: &testResponse{code: http.StatusConflict},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations/current/members"
+			testHTTP(t, tt.setup, tt.expect, newOrganizationRequest(t, http.MethodPost, path, tt.req))
+		})
+	}
+}
+
+func TestUpdateOrganizationMember(t *testing.T) {
+	t.Parallel()
+	member := func(role entity.OrganizationRole) *entity.OrganizationMember {
+		return &entity.OrganizationMember{OrganizationID: "organization-id", AdminID: "target-id", Role: role}
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *request.UpdateOrganizationMemberRequest
+		expect *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(member(entity.OrganizationRoleMember), nil)
+				mocks.db.organizationMember.EXPECT().
+					UpdateRole(gomock.Any(), "organization-id", "target-id", entity.OrganizationRoleAdmin).
+					Return(nil)
+			},
+			req:    &request.UpdateOrganizationMemberRequest{Role: int32(entity.OrganizationRoleAdmin)},
+			expect: &testResponse{code: http.StatusNoContent},
+		},
+		{
+			name: "admin cannot demote owner",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(member(entity.OrganizationRoleOwner), nil)
+			},
+			req:    &request.UpdateOrganizationMemberRequest{Role: int32(entity.OrganizationRoleMember)},
+			expect: &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "last owner",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(member(entity.OrganizationRoleOwner), nil)
+				mocks.db.organizationMember.EXPECT().
+					UpdateRole(gomock.Any(), "organization-id", "target-id", entity.OrganizationRoleMember).
+					Return(database.ErrFailedPrecondition)
+			},
+			req:    &request.UpdateOrganizationMemberRequest{Role: int32(entity.OrganizationRoleMember)},
+			expect: &testResponse{code: http.StatusPreconditionFailed},
+		},
+		{
+			name: "member of other organization",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(nil, database.ErrNotFound)
+			},
+			req:    &request.UpdateOrganizationMemberRequest{Role: int32(entity.OrganizationRoleMember)},
+			expect: &testResponse{code: http.StatusNotFound},
+		},
+		{
+			name: "invalid role",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+			},
+			req:    &request.UpdateOrganizationMemberRequest{Role: 4},
+			expect: &testResponse{code: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			const path = "/organizations/current/members/target-id"
+			testHTTP(t, tt.setup, tt.expect, newOrganizationRequest(t, http.MethodPatch, path, tt.req))
+		})
+	}
+}
+
+func TestRemoveOrganizationMember(t *testing.T) {
+	t.Parallel()
+	member := func(role entity.OrganizationRole) *entity.OrganizationMember {
+		return &entity.OrganizationMember{OrganizationID: "organization-id", AdminID: "target-id", Role: role}
+	}
+	tests := []struct {
+		name    string
+		setup   func(mocks *mocks)
+		adminID string
+		expect  *testResponse
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(member(entity.OrganizationRoleMember), nil)
+				mocks.db.organizationMember.EXPECT().Delete(gomock.Any(), "organization-id", "target-id").Return(nil)
+			},
+			adminID: "target-id",
+			expect:  &testResponse{code: http.StatusNoContent},
+		},
+		{
+			name: "leave organization",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+				mocks.db.organizationMember.EXPECT().Delete(gomock.Any(), "organization-id", "admin-id").Return(nil)
+			},
+			adminID: "admin-id",
+			expect:  &testResponse{code: http.StatusNoContent},
+		},
+		{
+			name: "member cannot remove others",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleMember)
+			},
+			adminID: "target-id",
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "admin cannot remove owner",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleAdmin)
+				mocks.db.organizationMember.EXPECT().Get(gomock.Any(), "organization-id", "target-id").
+					Return(member(entity.OrganizationRoleOwner), nil)
+			},
+			adminID: "target-id",
+			expect:  &testResponse{code: http.StatusForbidden},
+		},
+		{
+			name: "last owner cannot leave",
+			setup: func(mocks *mocks) {
+				expectOrganizationMember(mocks, entity.OrganizationRoleOwner)
+				mocks.db.organizationMember.EXPECT().Delete(gomock.Any(), "organization-id", "admin-id").
+					Return(database.ErrFailedPrecondition)
+			},
+			adminID: "admin-id",
+			expect:  &testResponse{code: http.StatusPreconditionFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/organizations/current/members/" + tt.adminID
+			testHTTP(t, tt.setup, tt.expect, newOrganizationRequest(t, http.MethodDelete, path, nil))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/and-period/furumane/internal/util"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultListLimit = 20  // 一覧取得時の取得件数のデフォルト値
+	maxListLimit     = 200 // 一覧取得時の取得件数の上限
+)
+
+// pagination - カーソルによる一覧取得の条件
+type pagination struct {
+	limit  int
+	orders []*util.Order  // リクエストで指定されたソート順
+	keys   []*mysql.Order // ソート順に対応するソートキー
+	cursor []interface{}  // 前ページの最終行のソートキーの値
+}
+
+// newPagination - クエリパラメータ (limit, orders, cursor) から一覧取得の条件を生成
+// ソート可能なキーはsortKeys (クエリのキー: カラム名) で指定したもののみとする
+func (c *controller) newPagination(ctx *gin.Context, sortKeys map[string]string) (*pagination, error) {
+	limit, err := util.GetQueryInt64(ctx, "limit", defaultListLimit)
+	if err != nil || limit <= 0 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxListLimit)
+	}
+	orders := util.GetOrders(ctx)
+	keys := make([]*mysql.Order, len(orders))
+	for i, o := range orders {
+		column, ok := sortKeys[o.Key]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported order key: %s", o.Key)
+		}
+		keys[i] = &mysql.Order{Column: column, Desc: o.Direction == util.OrderByDesc}
+	}
+	cursor, err := util.GetCursor(ctx, c.cursorSecret)
+	if errors.Is(err, util.ErrCursorSecretRequired) {
+		return nil, fmt.Errorf("api: failed to decode cursor: %w", err)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res := &pagination{
+		limit:  int(limit),
+		orders: orders,
+		keys:   keys,
+	}
+	if cursor != nil {
+		res.cursor = cursor.Values
+	}
+	return res, nil
+}
+
+// nextCursor - 次ページの取得に使用するカーソルを生成 (次ページが存在しない場合は空文字)
+func (c *controller) nextCursor(p *pagination, values []interface{}) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	cursor, err := util.EncodeCursor(c.cursorSecret, &util.Cursor{Orders: p.orders, Values: values})
+	if err != nil {
+		return "", fmt.Errorf("api: failed to encode cursor: %w", err)
+	}
+	return cursor, nil
+}
@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"github.com/and-period/furumane/internal/auth/cmd/migrate"
+	"github.com/and-period/furumane/internal/auth/cmd/openapi"
 	"github.com/and-period/furumane/internal/auth/cmd/server"
+	"github.com/and-period/furumane/internal/auth/cmd/trigger"
 	"github.com/spf13/cobra"
 )
 
 func RegisterCommand(registry *cobra.Command) {
+	registry.AddCommand(migrate.NewApp().Command)
+	registry.AddCommand(openapi.NewApp().Command)
 	registry.AddCommand(server.NewApp().Command)
+	registry.AddCommand(server.NewConfigApp().Command)
+	registry.AddCommand(trigger.NewApp().Command)
 }
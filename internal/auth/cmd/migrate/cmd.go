@@ -0,0 +1,95 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+type app struct {
+	*cobra.Command
+	upSteps    int
+	downSteps  int
+	baseline   string
+	batchSize  int
+	rolledBack bool
+}
+
+//nolint:revive
+func NewApp() *app {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "auth database schema migration",
+	}
+	app := &app{Command: cmd}
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "apply pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, app.up)
+		},
+	}
+	up.Flags().IntVarP(&app.upSteps, "steps", "n", 0, "number of migrations to apply (default: all)")
+	up.Flags().StringVar(&app.baseline, "baseline", "",
+		"record migrations up to this version as applied without running them (for databases created before migration history)")
+
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "roll back applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, app.down)
+		},
+	}
+	down.Flags().IntVarP(&app.downSteps, "steps", "n", 1, "number of migrations to roll back")
+
+	resolve := &cobra.Command{
+		Use:   "resolve <version>",
+		Short: "resolve an interrupted migration after fixing the schema manually",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, func(ctx context.Context, c *cobra.Command, env *environment) error {
+				return app.resolve(ctx, c, env, args[0])
+			})
+		},
+	}
+	resolve.Flags().BoolVar(&app.rolledBack, "rolled-back", false,
+		"record the migration as not applied (default: record it as applied)")
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "print migration status",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, app.status)
+		},
+	}
+
+	verify := &cobra.Command{
+		Use:   "verify",
+		Short: "compare the database schema with the entities",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, app.verify)
+		},
+	}
+
+	encrypt := &cobra.Command{
+		Use:   "encrypt",
+		Short: "encrypt personal data with the primary key (also used for key rotation)",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.run(c, app.encrypt)
+		},
+	}
+	encrypt.Flags().IntVar(&app.batchSize, "batch-size", 100, "number of rows to process at once")
+
+	app.AddCommand(up, down, resolve, status, verify, encrypt)
+	// 実行時のエラーでは使い方を出力しない
+	for _, c := range app.Commands() {
+		c.SilenceUsage = true
+	}
+	return app
+}
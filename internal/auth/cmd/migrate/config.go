@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type config struct {
+	LogLevel             string `envconfig:"LOG_LEVEL" default:"info"`
+	AWSRegion            string `envconfig:"AWS_REGION" default:"ap-northeast-1"`
+	DBSocket             string `envconfig:"DB_SOCKET" default:"tcp"`
+	DBHost               string `envconfig:"DB_HOST" default:"127.0.0.1"`
+	DBPort               string `envconfig:"DB_PORT" default:"3306"`
+	DBDatabase           string `envconfig:"DB_DATABASE" default:"furumane"`
+	DBUsername           string `envconfig:"DB_USERNAME" default:"root"`
+	DBPassword           string `envconfig:"DB_PASSWORD" default:""`
+	DBTimeZone           string `envconfig:"DB_TIMEZONE" default:"Asia/Tokyo"`
+	DBEnabledTLS         bool   `envconfig:"DB_ENABLED_TLS" default:"false"`
+	DBSecretName         string `envconfig:"DB_SECRET_NAME" default:""`
+	MigrationLockTimeout int64  `envconfig:"MIGRATION_LOCK_TIMEOUT_SEC" default:"60"`
+	EncryptionKeyFile    string `envconfig:"ENCRYPTION_KEY_FILE" default:""`
+	EncryptionSecretName string `envconfig:"ENCRYPTION_SECRET_NAME" default:""`
+}
+
+func newConfig() (*config, error) {
+	conf := &config{}
+	if err := envconfig.Process("", conf); err != nil {
+		return conf, fmt.Errorf("config: failed to new config: %w", err)
+	}
+	return conf, nil
+}
@@ -0,0 +1,246 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/and-period/furumane/config/mysql/schema"
+	"github.com/and-period/furumane/internal/auth/database/mysql"
+	"github.com/and-period/furumane/pkg/encryption"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/log"
+	apmysql "github.com/and-period/furumane/pkg/mysql"
+	"github.com/and-period/furumane/pkg/secret"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	errSchemaMismatch     = errors.New("migrate: database schema does not match the entities")
+	errEmptyEncryptionKey = errors.New("migrate: ENCRYPTION_KEY_FILE or ENCRYPTION_SECRET_NAME is required")
+)
+
+type runFunc func(ctx context.Context, c *cobra.Command, env *environment) error
+
+// environment - サブコマンドの実行に必要な設定・クライアント
+type environment struct {
+	config   *config
+	db       *apmysql.Client
+	migrator *apmysql.Migrator
+}
+
+func (a *app) run(c *cobra.Command, fn runFunc) error {
+	ctx := c.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 環境変数の読み込み
+	conf, err := newConfig()
+	if err != nil {
+		return err
+	}
+
+	// Loggerの設定
+	logger, err := log.NewLogger(log.WithLogLevel(conf.LogLevel))
+	if err != nil {
+		return err
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	// Databaseの設定
+	db, err := newDatabase(ctx, conf, logger)
+	if err != nil {
+		logger.Error("Failed to connect database", zap.Error(err))
+		return err
+	}
+	migrations, err := apmysql.LoadMigrations(schema.FS)
+	if err != nil {
+		return err
+	}
+	migrator := apmysql.NewMigrator(db, migrations,
+		apmysql.WithMigrationLogger(logger),
+		apmysql.WithMigrationNow(jst.Now),
+		apmysql.WithMigrationLockTimeout(time.Duration(conf.MigrationLockTimeout)*time.Second),
+	)
+	env := &environment{
+		config:   conf,
+		db:       db,
+		migrator: migrator,
+	}
+	return fn(ctx, c, env)
+}
+
+func (a *app) up(ctx context.Context, c *cobra.Command, env *environment) error {
+	if a.baseline != "" {
+		recorded, err := env.migrator.Baseline(ctx, a.baseline)
+		if err != nil {
+			return err
+		}
+		for _, m := range recorded {
+			fmt.Fprintf(c.OutOrStdout(), "recorded %s\n", m)
+		}
+	}
+	applied, err := env.migrator.Up(ctx, a.upSteps)
+	for _, m := range applied {
+		fmt.Fprintf(c.OutOrStdout(), "applied %s\n", m)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(c.OutOrStdout(), "no pending migrations")
+	}
+	return nil
+}
+
+func (a *app) down(ctx context.Context, c *cobra.Command, env *environment) error {
+	rolledBack, err := env.migrator.Down(ctx, a.downSteps)
+	for _, m := range rolledBack {
+		fmt.Fprintf(c.OutOrStdout(), "rolled back %s\n", m)
+	}
+	if err != nil {
+		return err
+	}
+	if len(rolledBack) == 0 {
+		fmt.Fprintln(c.OutOrStdout(), "no applied migrations")
+	}
+	return nil
+}
+
+// resolve - 中断されたマイグレーションの状態を確定
+// スキーマを手動で適用後・適用前のいずれかの状態に揃えてから実行する
+func (a *app) resolve(ctx context.Context, c *cobra.Command, env *environment, version string) error {
+	migration, err := env.migrator.Resolve(ctx, version, !a.rolledBack)
+	if err != nil {
+		return err
+	}
+	state := "applied"
+	if a.rolledBack {
+		state = "pending"
+	}
+	fmt.Fprintf(c.OutOrStdout(), "resolved %s as %s\n", migration, state)
+	return nil
+}
+
+func (a *app) status(ctx context.Context, c *cobra.Command, env *environment) error {
+	statuses, err := env.migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		var appliedAt string
+		if !s.AppliedAt.IsZero() {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Version, s.Name, s.State, appliedAt)
+	}
+	return w.Flush()
+}
+
+// verify - エンティティに対応するテーブル・カラムが存在しない場合はエラーとする
+// エンティティに対応しないカラムは、アプリケーションの動作に影響しないため警告のみとする
+func (a *app) verify(ctx context.Context, c *cobra.Command, env *environment) error {
+	diffs, err := env.migrator.Verify(ctx, mysql.Models())
+	if err != nil {
+		return err
+	}
+	var mismatched bool
+	for _, d := range diffs {
+		level := "error"
+		if d.Kind == apmysql.SchemaDiffUnmappedColumn {
+			level = "warning"
+		} else {
+			mismatched = true
+		}
+		fmt.Fprintf(c.OutOrStdout(), "%s: %s: table=%s column=%s\n", level, d.Kind, d.Table, d.Column)
+	}
+	if mismatched {
+		return errSchemaMismatch
+	}
+	fmt.Fprintln(c.OutOrStdout(), "database schema matches the entities")
+	return nil
+}
+
+// encrypt - 個人情報の暗号化・再暗号化
+// 暗号化の導入前のデータの移行と、マスターキーのローテーション後の再暗号化に使用する (何度実行しても結果は変わらない)
+func (a *app) encrypt(ctx context.Context, c *cobra.Command, env *environment) error {
+	cipher, err := newCipher(ctx, env.config)
+	if err != nil {
+		return err
+	}
+	total, err := mysql.EncryptAdmins(ctx, env.db, cipher, a.batchSize)
+	fmt.Fprintf(c.OutOrStdout(), "encrypted %d admins\n", total)
+	return err
+}
+
+func newCipher(ctx context.Context, conf *config) (*encryption.Cipher, error) {
+	if conf.EncryptionSecretName == "" {
+		if conf.EncryptionKeyFile == "" {
+			return nil, errEmptyEncryptionKey
+		}
+		file, err := encryption.ReadKeyFile(conf.EncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return file.NewCipher()
+	}
+	awscfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(conf.AWSRegion))
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := secret.NewClient(awscfg).Get(ctx, conf.EncryptionSecretName)
+	if err != nil {
+		return nil, err
+	}
+	file := &encryption.KeyFile{
+		PrimaryKeyID: secrets["primaryKeyId"],
+		Keys:         encryption.ParseKeys(strings.Split(secrets["keys"], ",")),
+		IndexKey:     secrets["indexKey"],
+	}
+	return file.NewCipher()
+}
+
+func newDatabase(ctx context.Context, conf *config, logger *zap.Logger) (*apmysql.Client, error) {
+	params := &apmysql.Params{
+		Socket:   conf.DBSocket,
+		Host:     conf.DBHost,
+		Port:     conf.DBPort,
+		Database: conf.DBDatabase,
+		Username: conf.DBUsername,
+		Password: conf.DBPassword,
+	}
+	// データベース認証情報の取得
+	if conf.DBSecretName != "" {
+		awscfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(conf.AWSRegion))
+		if err != nil {
+			return nil, err
+		}
+		secrets, err := secret.NewClient(awscfg).Get(ctx, conf.DBSecretName)
+		if err != nil {
+			return nil, err
+		}
+		params.Host = secrets["host"]
+		params.Port = secrets["port"]
+		params.Username = secrets["username"]
+		params.Password = secrets["password"]
+	}
+	location, err := time.LoadLocation(conf.DBTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return apmysql.NewClient(
+		params,
+		apmysql.WithLogger(logger),
+		apmysql.WithNow(jst.Now),
+		apmysql.WithTLS(conf.DBEnabledTLS),
+		apmysql.WithLocation(location),
+	)
+}
@@ -0,0 +1,24 @@
+package openapi
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type app struct {
+	*cobra.Command
+	output string
+}
+
+//nolint:revive
+func NewApp() *app {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "print auth api openapi document",
+	}
+	app := &app{Command: cmd}
+	app.Flags().StringVarP(&app.output, "output", "o", "", "output file path (default: stdout)")
+	app.RunE = func(c *cobra.Command, args []string) error {
+		return app.run()
+	}
+	return app
+}
@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/and-period/furumane/internal/auth/api"
+	"github.com/gin-gonic/gin"
+)
+
+func (a *app) run() error {
+	// ルーティングのデバッグログを出力しない
+	gin.SetMode(gin.ReleaseMode)
+
+	doc, err := api.NewOpenAPI()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = a.OutOrStdout()
+	if a.output != "" {
+		f, err := os.Create(a.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
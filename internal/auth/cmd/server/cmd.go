@@ -1,11 +1,15 @@
 package server
 
 import (
+	"fmt"
+
+	apconfig "github.com/and-period/furumane/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 type app struct {
 	*cobra.Command
+	configFile string
 }
 
 //nolint:revive
@@ -13,10 +17,55 @@ func NewApp() *app {
 	cmd := &cobra.Command{
 		Use:   "server",
 		Short: "auth server",
+		// 実行時のエラーでは使い方を出力しない
+		SilenceUsage: true,
 	}
 	app := &app{Command: cmd}
 	app.RunE = func(c *cobra.Command, args []string) error {
 		return app.run()
 	}
+	app.registerFlags(cmd)
+	return app
+}
+
+// NewConfigApp - サーバーの設定値を扱うコマンドの生成
+//
+//nolint:revive
+func NewConfigApp() *app {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "auth server configuration",
+	}
+	app := &app{Command: cmd}
+
+	print := &cobra.Command{
+		Use:          "print",
+		Short:        "print the effective server configuration with secrets redacted",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return app.print(c)
+		},
+	}
+	app.registerFlags(print)
+
+	cmd.AddCommand(print)
 	return app
 }
+
+// registerFlags - 設定ファイルと設定値を上書きするフラグの登録
+func (a *app) registerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&a.configFile, "config", "", "path to the YAML config file (env: CONFIG_FILE)")
+	apconfig.RegisterFlags(cmd.Flags(), &config{})
+}
+
+// print - 読み込み元を含めた設定値の出力 (秘匿情報はマスクする)
+// 検証エラーがある場合も、設定値を出力した上でエラーを返す
+func (a *app) print(cmd *cobra.Command) error {
+	_, fields, err := newConfig(cmd.Flags(), a.configFile)
+	w := cmd.OutOrStdout()
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s=%s # %s\n", f.Name, f, f.Source)
+	}
+	return err
+}
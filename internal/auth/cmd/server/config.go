@@ -1,44 +1,164 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"sort"
+	"time"
 
-	"github.com/kelseyhightower/envconfig"
+	apconfig "github.com/and-period/furumane/pkg/config"
+	"github.com/and-period/furumane/pkg/redact"
+	"github.com/and-period/furumane/pkg/tracing"
+	"github.com/spf13/pflag"
 )
 
 type config struct {
-	AppName              string `envconfig:"APP_NAME" default:"auth"`
-	Environment          string `envconfig:"ENV" default:"none"`
-	Port                 int64  `envconfig:"PORT" default:"8080"`
-	MetricsPort          int64  `envconfig:"METRICS_PORT" default:"9090"`
-	ShutdownDelaySec     int64  `envconfig:"SHUTDOWN_DELAY_SEC" default:"20"`
-	LogPath              string `envconfig:"LOG_PATH" default:""`
-	LogLevel             string `envconfig:"LOG_LEVEL" default:"info"`
-	DBSocket             string `envconfig:"DB_SOCKET" default:"tcp"`
-	DBHost               string `envconfig:"DB_HOST" default:"127.0.0.1"`
-	DBPort               string `envconfig:"DB_PORT" default:"3306"`
-	DBDatabase           string `envconfig:"DB_DATABASE" default:"furumane"`
-	DBUsername           string `envconfig:"DB_USERNAME" default:"root"`
-	DBPassword           string `envconfig:"DB_PASSWORD" default:""`
-	DBTimeZone           string `envconfig:"DB_TIMEZONE" default:"Asia/Tokyo"`
-	DBEnabledTLS         bool   `envconfig:"DB_ENABLED_TLS" default:"false"`
-	DBSecretName         string `envconfig:"DB_SECRET_NAME" default:""`
-	NewRelicLicense      string `envconfig:"NEW_RELIC_LICENSE" default:""`
-	NewRelicSecretName   string `envconfig:"NEW_RELIC_SECRET_NAME" default:""`
-	SlackAPIToken        string `envconfig:"SLACK_API_TOKEN" default:""`
-	SlackChannelID       string `envconfig:"SLACK_CHANNEL_ID" default:""`
-	SlackSecretName      string `envconfig:"SLACK_SECRET_NAME" default:""`
-	AWSRegion            string `envconfig:"AWS_REGION" default:"ap-northeast-1"`
-	CognitoAdminPoolID   string `envconfig:"COGNITO_ADMIN_POOL_ID" default:""`
-	CognitoAdminClientID string `envconfig:"COGNITO_ADMIN_CLIENT_ID" default:""`
-	CognitoUserPoolID    string `envconfig:"COGNITO_USER_POOL_ID" default:""`
-	CognitoUserClientID  string `envconfig:"COGNITO_USER_CLIENT_ID" default:""`
+	AppName               string   `envconfig:"APP_NAME" default:"auth"`
+	Environment           string   `envconfig:"ENV" default:"none"`
+	Port                  int64    `envconfig:"PORT" default:"8080"`
+	MetricsPort           int64    `envconfig:"METRICS_PORT" default:"9090"`
+	TrustedProxies        []string `envconfig:"TRUSTED_PROXIES" default:""`
+	TrustedPlatform       string   `envconfig:"TRUSTED_PLATFORM" default:""`
+	GRPCPort              int64    `envconfig:"GRPC_PORT" default:"50051"`
+	GRPCServiceTokens     []string `envconfig:"GRPC_SERVICE_TOKENS" default:"" log:"secret"`
+	GRPCSecretName        string   `envconfig:"GRPC_SECRET_NAME" default:""`
+	GRPCReflectionEnabled bool     `envconfig:"GRPC_REFLECTION_ENABLED" default:"true"`
+	ShutdownDelaySec      int64    `envconfig:"SHUTDOWN_DELAY_SEC" default:"20"`
+	StartupTimeoutSec     int64    `envconfig:"STARTUP_TIMEOUT_SEC" default:"60"`
+	ShutdownTimeoutSec    int64    `envconfig:"SHUTDOWN_TIMEOUT_SEC" default:"30"`
+	HealthCheckTimeoutSec int64    `envconfig:"HEALTH_CHECK_TIMEOUT_SEC" default:"2"`
+	HealthCheckCacheSec   int64    `envconfig:"HEALTH_CHECK_CACHE_SEC" default:"5"`
+	HealthSecretCacheSec  int64    `envconfig:"HEALTH_SECRET_CACHE_SEC" default:"300"`
+	LogPath               string   `envconfig:"LOG_PATH" default:""`
+	LogLevel              string   `envconfig:"LOG_LEVEL" default:"info"`
+	LogRedactPaths        []string `envconfig:"LOG_REDACT_PATHS" default:""`
+	DBSocket              string   `envconfig:"DB_SOCKET" default:"tcp"`
+	DBHost                string   `envconfig:"DB_HOST" default:"127.0.0.1"`
+	DBPort                string   `envconfig:"DB_PORT" default:"3306"`
+	DBDatabase            string   `envconfig:"DB_DATABASE" default:"furumane"`
+	DBUsername            string   `envconfig:"DB_USERNAME" default:"root"`
+	DBPassword            string   `envconfig:"DB_PASSWORD" default:"" log:"secret"`
+	DBTimeZone            string   `envconfig:"DB_TIMEZONE" default:"Asia/Tokyo"`
+	DBEnabledTLS          bool     `envconfig:"DB_ENABLED_TLS" default:"false"`
+	DBSecretName          string   `envconfig:"DB_SECRET_NAME" default:""`
+	DBReplicaHosts        []string `envconfig:"DB_REPLICA_HOSTS" default:""`
+	DBMaxOpenConns        int      `envconfig:"DB_MAX_OPEN_CONNS" default:"25"`
+	DBMaxIdleConns        int      `envconfig:"DB_MAX_IDLE_CONNS" default:"10"`
+	DBConnMaxLifetimeSec  int64    `envconfig:"DB_CONN_MAX_LIFETIME_SEC" default:"300"`
+	DBConnMaxIdleTimeSec  int64    `envconfig:"DB_CONN_MAX_IDLE_TIME_SEC" default:"60"`
+	DBMaxRetries          int      `envconfig:"DB_MAX_RETRIES" default:"3"`
+	TracingBackend        string   `envconfig:"TRACING_BACKEND" default:"newrelic"`
+	TracingSampleRatio    float64  `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	NewRelicLicense       string   `envconfig:"NEW_RELIC_LICENSE" default:"" log:"secret"`
+	NewRelicSecretName    string   `envconfig:"NEW_RELIC_SECRET_NAME" default:""`
+	SlackAPIToken         string   `envconfig:"SLACK_API_TOKEN" default:"" log:"secret"`
+	SlackChannelID        string   `envconfig:"SLACK_CHANNEL_ID" default:""`
+	SlackSecretName       string   `envconfig:"SLACK_SECRET_NAME" default:""`
+	AWSRegion             string   `envconfig:"AWS_REGION" default:"ap-northeast-1"`
+	CognitoAdminPoolID    string   `envconfig:"COGNITO_ADMIN_POOL_ID" required:"true"`
+	CognitoAdminClientID  string   `envconfig:"COGNITO_ADMIN_CLIENT_ID" required:"true"`
+	CognitoUserPoolID     string   `envconfig:"COGNITO_USER_POOL_ID" required:"true"`
+	CognitoUserClientID   string   `envconfig:"COGNITO_USER_CLIENT_ID" required:"true"`
+	ProblemJSONEnabled    bool     `envconfig:"PROBLEM_JSON_ENABLED" default:"false"`
+	ProblemTypeBaseURL    string   `envconfig:"PROBLEM_TYPE_BASE_URL" default:""`
+	WebAuthnRPID          string   `envconfig:"WEBAUTHN_RP_ID" default:"localhost"`
+	WebAuthnRPName        string   `envconfig:"WEBAUTHN_RP_NAME" default:"furumane"`
+	WebAuthnOrigins       []string `envconfig:"WEBAUTHN_ORIGINS" default:"http://localhost:3000"`
+	WebAuthnSessionSecret string   `envconfig:"WEBAUTHN_SESSION_SECRET" required:"true" log:"secret"`
+	AuthChallengeSecret   string   `envconfig:"AUTH_CHALLENGE_SECRET" required:"true" log:"secret"`
+	IntrospectionClients  []string `envconfig:"INTROSPECTION_CLIENTS" default:"" log:"secret"`
+	IntrospectionSecret   string   `envconfig:"INTROSPECTION_SECRET_NAME" default:""`
+	IntrospectionCache    int      `envconfig:"INTROSPECTION_CACHE_SIZE" default:"10000"`
+	IntrospectionCacheSec int64    `envconfig:"INTROSPECTION_CACHE_TTL_SEC" default:"30"`
+	CursorSecret          string   `envconfig:"PAGINATION_CURSOR_SECRET" required:"true" log:"secret"`
+	EncryptionKeyFile     string   `envconfig:"ENCRYPTION_KEY_FILE" default:""`
+	EncryptionSecretName  string   `envconfig:"ENCRYPTION_SECRET_NAME" default:""`
 }
 
-func newConfig() (*config, error) {
+// validProxy - IPアドレスまたはCIDRの形式か
+func validProxy(proxy string) bool {
+	if _, _, err := net.ParseCIDR(proxy); err == nil {
+		return true
+	}
+	return net.ParseIP(proxy) != nil
+}
+
+// maxIntrospectionCacheSec - トークンの失効が反映されるまでの時間の上限 (秒)
+const maxIntrospectionCacheSec = 300
+
+// newConfig - 設定値の読み込み (デフォルト値 < 設定ファイル < 環境変数 < コマンドライン引数)
+// 設定ファイルは--configまたはCONFIG_FILEで指定し、読み込み・検証のエラーはすべてまとめて返す
+func newConfig(flags *pflag.FlagSet, file string) (*config, apconfig.Fields, error) {
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
 	conf := &config{}
-	if err := envconfig.Process("", conf); err != nil {
-		return conf, fmt.Errorf("config: failed to new config: %w", err)
+	fields, err := apconfig.Load(conf, apconfig.WithFile(file), apconfig.WithFlags(flags))
+	if fields == nil {
+		return nil, nil, err
+	}
+	if err := errors.Join(err, conf.validate()); err != nil {
+		return conf, fields, err
+	}
+	return conf, fields, nil
+}
+
+// validate - 設定値の組み合わせ・範囲の検証
+func (c *config) validate() error {
+	errs := make([]error, 0)
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: %s", apconfig.ErrInvalidConfig, fmt.Sprintf(format, args...)))
+	}
+	for name, port := range map[string]int64{"PORT": c.Port, "METRICS_PORT": c.MetricsPort, "GRPC_PORT": c.GRPCPort} {
+		if port < 1 || port > 65535 {
+			invalid("%s must be between 1 and 65535: %d", name, port)
+		}
+	}
+	for name, sec := range map[string]int64{
+		"STARTUP_TIMEOUT_SEC":      c.StartupTimeoutSec,
+		"SHUTDOWN_TIMEOUT_SEC":     c.ShutdownTimeoutSec,
+		"HEALTH_CHECK_TIMEOUT_SEC": c.HealthCheckTimeoutSec,
+	} {
+		if sec <= 0 {
+			invalid("%s must be greater than 0: %d", name, sec)
+		}
+	}
+	// 接続元のIPアドレスの判定に使用するため、プロキシはIPアドレスまたはCIDRで指定する
+	for _, proxy := range c.TrustedProxies {
+		if !validProxy(proxy) {
+			invalid("TRUSTED_PROXIES must be ip addresses or cidrs: %s", proxy)
+		}
+	}
+	if c.ShutdownDelaySec < 0 {
+		invalid("SHUTDOWN_DELAY_SEC must not be negative: %d", c.ShutdownDelaySec)
+	}
+	// 検証結果のキャッシュはレプリカ間で共有しないため、トークンの失効が反映されるまでの時間の上限とする
+	if c.IntrospectionCacheSec < 0 || c.IntrospectionCacheSec > maxIntrospectionCacheSec {
+		invalid("INTROSPECTION_CACHE_TTL_SEC must be between 0 and %d: %d", maxIntrospectionCacheSec, c.IntrospectionCacheSec)
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		invalid("LOG_LEVEL must be one of debug, info, warn, error: %s", c.LogLevel)
+	}
+	if _, err := redact.ParseRules(c.LogRedactPaths); err != nil {
+		invalid("LOG_REDACT_PATHS: %s", err)
+	}
+	if _, err := time.LoadLocation(c.DBTimeZone); err != nil {
+		invalid("DB_TIMEZONE: %s", err)
+	}
+	if _, err := tracing.ParseBackend(c.TracingBackend); err != nil {
+		invalid("TRACING_BACKEND: %s", err)
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		invalid("TRACING_SAMPLE_RATIO must be between 0 and 1: %g", c.TracingSampleRatio)
+	}
+	if c.EncryptionKeyFile == "" && c.EncryptionSecretName == "" {
+		invalid("ENCRYPTION_KEY_FILE or ENCRYPTION_SECRET_NAME is required")
 	}
-	return conf, nil
+	// 複数の検証エラーの出力順を固定する
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
 }
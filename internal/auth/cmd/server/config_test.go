@@ -0,0 +1,91 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	apconfig "github.com/and-period/furumane/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		file  string
+		isErr []string
+	}{
+		{
+			name: "local",
+			file: "../../../../config/auth/local.yaml",
+		},
+		{
+			name: "empty secrets",
+			file: `
+cognito_admin_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_admin_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+cognito_user_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_user_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+encryption_key_file: ./config/encryption/dev-keyring.json
+webauthn_session_secret: ""
+`,
+			isErr: []string{
+				"AUTH_CHALLENGE_SECRET is required",
+				"PAGINATION_CURSOR_SECRET is required",
+				"WEBAUTHN_SESSION_SECRET is required",
+			},
+		},
+		{
+			name: "introspection cache ttl exceeds revocation limit",
+			file: `
+cognito_admin_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_admin_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+cognito_user_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_user_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+encryption_key_file: ./config/encryption/dev-keyring.json
+webauthn_session_secret: session-secret
+auth_challenge_secret: challenge-secret
+pagination_cursor_secret: cursor-secret
+introspection_cache_ttl_sec: 3600
+`,
+			isErr: []string{"INTROSPECTION_CACHE_TTL_SEC must be between 0 and 300: 3600"},
+		},
+		{
+			name: "invalid trusted proxies",
+			file: `
+cognito_admin_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_admin_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+cognito_user_pool_id: ap-northeast-1_xxxxxxxxx
+cognito_user_client_id: xxxxxxxxxxxxxxxxxxxxxxxxxx
+encryption_key_file: ./config/encryption/dev-keyring.json
+webauthn_session_secret: session-secret
+auth_challenge_secret: challenge-secret
+pagination_cursor_secret: cursor-secret
+trusted_proxies: 10.0.0.0/16,alb.internal
+`,
+			isErr: []string{"TRUSTED_PROXIES must be ip addresses or cidrs: alb.internal"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := tt.file
+			if filepath.Ext(path) != ".yaml" {
+				path = filepath.Join(t.TempDir(), "config.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.file), 0o600))
+			}
+			_, _, err := newConfig(nil, path)
+			if len(tt.isErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, apconfig.ErrInvalidConfig)
+			for _, msg := range tt.isErr {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
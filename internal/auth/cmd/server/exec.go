@@ -2,87 +2,134 @@ package server
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/and-period/furumane/pkg/grpc"
 	"github.com/and-period/furumane/pkg/http"
+	"github.com/and-period/furumane/pkg/lifecycle"
 	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/redact"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
+	ggrpc "google.golang.org/grpc"
 )
 
 func (a *app) run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 環境変数の読み込み
-	conf, err := newConfig()
+	// 設定値の読み込み
+	conf, _, err := newConfig(a.Flags(), a.configFile)
+	if err != nil {
+		return err
+	}
+
+	// ログのマスキング設定
+	rules, err := redact.ParseRules(conf.LogRedactPaths)
+	if err != nil {
+		return err
+	}
+	redactor, err := redact.New(append(redact.DefaultRules(), rules...)...)
 	if err != nil {
 		return err
 	}
 
 	// Loggerの設定
-	logger, err := log.NewLogger(log.WithLogLevel(conf.LogLevel), log.WithOutput(conf.LogPath))
+	logger, err := log.NewLogger(
+		log.WithLogLevel(conf.LogLevel),
+		log.WithOutput(conf.LogPath),
+		log.WithRedactor(redactor),
+	)
 	if err != nil {
 		return err
 	}
 	defer logger.Sync() //nolint:errcheck
 
+	// コンポーネントの起動・停止順序の設定
+	lc := lifecycle.NewManager(
+		lifecycle.WithLogger(logger),
+		lifecycle.WithStartTimeout(time.Duration(conf.StartupTimeoutSec)*time.Second),
+		lifecycle.WithStopTimeout(time.Duration(conf.ShutdownTimeoutSec)*time.Second),
+	)
+
 	// 依存関係の解決
-	reg, err := newRegistry(ctx, conf, logger)
+	reg, err := newRegistry(ctx, conf, logger, lc)
 	if err != nil {
 		logger.Error("Failed to new registry", zap.Error(err))
 		return err
 	}
 
-	// HTTP Serverの設定
-	rt := newRouter(reg, logger)
-	hs := http.NewHTTPServer(rt, conf.Port)
-
 	// Metrics Serverの設定
 	ms := http.NewMetricsServer(conf.MetricsPort)
+	lc.Register("metricsServer", lifecycle.Hooks{
+		Run:  func(context.Context) error { return ms.Serve() },
+		Stop: ms.Stop,
+	})
+
+	// HTTP Serverの設定
+	var hs http.Server
+	lc.Register("httpServer", lifecycle.Hooks{
+		Start: func(context.Context) error {
+			rt, err := newRouter(reg, logger, redactor)
+			if err != nil {
+				return err
+			}
+			hs = http.NewHTTPServer(rt, conf.Port)
+			return nil
+		},
+		Run:  func(context.Context) error { return hs.Serve() },
+		Stop: func(ctx context.Context) error { return hs.Stop(ctx) },
+	}, lifecycle.WithDependsOn("service"))
+
+	// gRPC Serverの設定
+	var gs grpc.Server
+	lc.Register("grpcServer", lifecycle.Hooks{
+		Start: func(context.Context) error {
+			register := func(s ggrpc.ServiceRegistrar) {
+				authv1.RegisterAuthServiceServer(s, reg.rpc)
+			}
+			gs = grpc.NewGRPCServer(register, conf.GRPCPort,
+				grpc.WithLogger(logger),
+				grpc.WithServiceTokens(reg.grpcTokens...),
+				grpc.WithReflection(conf.GRPCReflectionEnabled),
+			)
+			return nil
+		},
+		Run:  func(context.Context) error { return gs.Serve() },
+		Stop: func(ctx context.Context) error { return gs.Stop(ctx) },
+	}, lifecycle.WithDependsOn("service"))
 
 	// Serverの起動
-	eg, ectx := errgroup.WithContext(ctx)
-	eg.Go(func() (err error) {
-		if err = ms.Serve(); err != nil {
-			logger.Error("Failed to run metrics server", zap.Error(err))
-		}
-		return
-	})
-	eg.Go(func() (err error) {
-		if err = hs.Serve(); err != nil {
-			logger.Error("Failed to run http server", zap.Error(err))
-		}
-		return
-	})
-	logger.Info("Started server", zap.Int64("port", conf.Port))
+	if err := lc.Start(ctx); err != nil {
+		logger.Error("Failed to start server", zap.Error(err))
+		return err
+	}
+	logger.Info("Started server", zap.Int64("port", conf.Port), zap.Int64("grpcPort", conf.GRPCPort))
 
 	// シグナル検知設定
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT)
 	select {
-	case <-ectx.Done():
-		logger.Error("Done context", zap.Error(ectx.Err()))
+	case err = <-lc.Err():
+		logger.Error("Stopped server unexpectedly", zap.Error(err))
 	case signal := <-signalCh:
 		logger.Info("Received signal", zap.Any("signal", signal))
+		// ロードバランサーから切り離されるよう、待機前に受付不可と判定させる
+		reg.health.Shutdown()
 		delay := time.Duration(conf.ShutdownDelaySec) * time.Second
 		logger.Info("Pre-shutdown", zap.Duration("delay", delay))
 		time.Sleep(delay)
 	}
 
-	// Serverの停止
+	// Serverの停止 (起動とは逆順に停止し、処理中のリクエストと非同期処理の完了を待つ)
 	logger.Info("Shutdown...")
-	if err = hs.Stop(ectx); err != nil {
-		logger.Error("Failed to stopeed http server", zap.Error(err))
-		return err
-	}
-	if err = ms.Stop(ectx); err != nil {
-		logger.Error("Failed to stopeed metrics server", zap.Error(err))
-		return err
+	if serr := lc.Stop(ctx); serr != nil {
+		logger.Error("Failed to shutdown server", zap.Error(serr))
+		return errors.Join(err, serr)
 	}
-	reg.waitGroup.Wait()
-	return eg.Wait()
+	return err
 }
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/and-period/furumane/pkg/health"
+	"github.com/and-period/furumane/pkg/secret"
+	"golang.org/x/sync/errgroup"
+)
+
+var errSecretRotated = errors.New("health: secret has been rotated since startup")
+
+// secretFingerprints - 起動時に読み込んだシークレットのハッシュ値 (シークレット名ごと)
+type secretFingerprints struct {
+	mu     sync.Mutex
+	values map[string][32]byte
+}
+
+// get - シークレットを取得し、起動時の値としてハッシュ値を記録する
+func (f *secretFingerprints) get(ctx context.Context, cli secret.Client, name string) (map[string]string, error) {
+	secrets, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.values == nil {
+		f.values = make(map[string][32]byte)
+	}
+	f.values[name] = fingerprint(secrets)
+	return secrets, nil
+}
+
+// check - 起動時に読み込んだシークレットが最新の値と一致するかを確認
+// ローテーション後は再起動するまで古い認証情報を使い続けるため、異常として検知する
+func (f *secretFingerprints) check(cli secret.Client) health.CheckFunc {
+	return func(ctx context.Context) error {
+		f.mu.Lock()
+		values := make(map[string][32]byte, len(f.values))
+		for name, value := range f.values {
+			values[name] = value
+		}
+		f.mu.Unlock()
+
+		eg, ectx := errgroup.WithContext(ctx)
+		for name, value := range values {
+			name, value := name, value
+			eg.Go(func() error {
+				secrets, err := cli.Get(ectx, name)
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				if fingerprint(secrets) != value {
+					return fmt.Errorf("%w: %s", errSecretRotated, name)
+				}
+				return nil
+			})
+		}
+		return eg.Wait()
+	}
+}
+
+func (f *secretFingerprints) empty() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.values) == 0
+}
+
+func fingerprint(secrets map[string]string) [32]byte {
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "%q=%q;", key, secrets[key])
+	}
+	return sha256.Sum256(buf.Bytes())
+}
+
+// newHealth - リクエストの受付可否の判定に使用する依存先の登録
+func newHealth(p *params) *health.Health {
+	h := health.NewHealth(
+		health.WithNow(p.now),
+		health.WithTimeout(time.Duration(p.config.HealthCheckTimeoutSec)*time.Second),
+		health.WithCacheTTL(time.Duration(p.config.HealthCheckCacheSec)*time.Second),
+	)
+	h.Register("mysql", p.db.Ping)
+	h.Register("cognitoAdminPool", p.adminAuth.Ping)
+	h.Register("cognitoUserPool", p.userAuth.Ping)
+	if !p.secrets.empty() {
+		// シークレットのローテーションだけではリクエストの処理に影響しないため、任意の依存先とする
+		h.Register("secrets", p.secrets.check(p.secret),
+			health.WithOptional(),
+			health.WithCheckCacheTTL(time.Duration(p.config.HealthSecretCacheSec)*time.Second),
+		)
+	}
+	return h
+}
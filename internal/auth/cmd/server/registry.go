@@ -2,32 +2,55 @@ package server
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/and-period/furumane/internal/auth/api"
 	"github.com/and-period/furumane/internal/auth/database/mysql"
+	"github.com/and-period/furumane/internal/auth/rpc"
 	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/encryption"
+	"github.com/and-period/furumane/pkg/health"
 	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/lifecycle"
 	apmysql "github.com/and-period/furumane/pkg/mysql"
 	"github.com/and-period/furumane/pkg/secret"
 	"github.com/and-period/furumane/pkg/slack"
+	"github.com/and-period/furumane/pkg/tracing"
+	"github.com/and-period/furumane/pkg/webauthn"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rafaelhl/gorm-newrelic-telemetry-plugin/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
+var errEmptyEncryptionKey = errors.New("registry: ENCRYPTION_KEY_FILE or ENCRYPTION_SECRET_NAME is required")
+
 type registry struct {
 	appName   string
 	env       string
 	debugMode bool
-	waitGroup *sync.WaitGroup
-	service   api.Controller
-	newRelic  *newrelic.Application
-	slack     slack.Client
+	// 接続元のIPアドレスの判定に使用するプロキシ (未指定の場合はX-Forwarded-Forなどのヘッダーを参照しない)
+	trustedProxies  []string
+	trustedPlatform string
+	waitGroup       *sync.WaitGroup
+	service         api.Controller
+	rpc             authv1.AuthServiceServer
+	grpcTokens      []string
+	tracing         tracing.Backend
+	tracerProvider  trace.TracerProvider
+	propagator      propagation.TextMapPropagator
+	newRelic        *newrelic.Application
+	slack           slack.Client
+	health          *health.Health
 }
 
 type params struct {
@@ -36,9 +59,15 @@ type params struct {
 	waitGroup       *sync.WaitGroup
 	aws             aws.Config
 	secret          secret.Client
+	secrets         *secretFingerprints
 	db              *apmysql.Client
+	cipher          *encryption.Cipher
 	adminAuth       cognito.Client
 	userAuth        cognito.Client
+	webauthn        webauthn.Client
+	tracing         tracing.Backend
+	tracerProvider  trace.TracerProvider        // OTLPの場合のみ設定
+	shutdownTracer  func(context.Context) error // OTLPの場合のみ設定
 	newRelic        *newrelic.Application
 	slack           slack.Client
 	now             func() time.Time
@@ -46,18 +75,31 @@ type params struct {
 	dbPort          string
 	dbUsername      string
 	dbPassword      string
+	dbReplicaHosts  []string
 	newRelicLicense string
 	slackToken      string
 	slackChannelID  string
+	grpcTokens      []string
+	clients         map[string]string
+	encryptionKeys  *encryption.KeyFile
 }
 
+// newRegistry - 依存関係の解決
+// データベースに依存するコンポーネントはlcに登録し、lcの起動時に生成する
+//
 //nolint:funlen
-func newRegistry(ctx context.Context, conf *config, logger *zap.Logger) (*registry, error) {
+func newRegistry(ctx context.Context, conf *config, logger *zap.Logger, lc *lifecycle.Manager) (*registry, error) {
 	params := &params{
 		config:    conf,
 		logger:    logger,
 		now:       jst.Now,
 		waitGroup: &sync.WaitGroup{},
+		secrets:   &secretFingerprints{},
+	}
+
+	// トレーシングの設定
+	if err := newTracerProvider(ctx, params); err != nil {
+		return nil, err
 	}
 
 	// AWS SDKの設定
@@ -65,6 +107,9 @@ func newRegistry(ctx context.Context, conf *config, logger *zap.Logger) (*regist
 	if err != nil {
 		return nil, err
 	}
+	if params.tracing == tracing.BackendOTLP {
+		awscfg.APIOptions = append(awscfg.APIOptions, tracing.AWSMiddleware(params.tracerProvider))
+	}
 	params.aws = awscfg
 
 	// AWS Secrets Managerの設定
@@ -85,14 +130,23 @@ func newRegistry(ctx context.Context, conf *config, logger *zap.Logger) (*regist
 	}
 	params.userAuth = cognito.NewClient(awscfg, userAuthParams, cognito.WithLogger(params.logger))
 
-	// Databaseの設定
-	params.db, err = newDatabase(params)
+	// WebAuthnの設定
+	webauthnParams := &webauthn.Params{
+		RPID:          conf.WebAuthnRPID,
+		RPName:        conf.WebAuthnRPName,
+		Origins:       conf.WebAuthnOrigins,
+		SessionSecret: []byte(conf.WebAuthnSessionSecret),
+	}
+	params.webauthn = webauthn.NewClient(webauthnParams)
+
+	// 暗号化の設定
+	params.cipher, err = params.encryptionKeys.NewCipher()
 	if err != nil {
 		return nil, err
 	}
 
 	// New Relicの設定
-	if params.newRelicLicense != "" {
+	if params.tracing == tracing.BackendNewRelic && params.newRelicLicense != "" {
 		newrelicApp, err := newrelic.NewApplication(
 			newrelic.ConfigEnabled(true),
 			newrelic.ConfigAppName(conf.AppName),
@@ -111,25 +165,124 @@ func newRegistry(ctx context.Context, conf *config, logger *zap.Logger) (*regist
 			Token:     params.slackToken,
 			ChannelID: params.slackChannelID,
 		}
-		params.slack = slack.NewClient(slackParams, slack.WithLogger(logger))
+		params.slack = slack.NewClient(slackParams,
+			slack.WithLogger(logger),
+			slack.WithTracerProvider(params.tracerProvider),
+		)
+	}
+
+	reg := &registry{
+		appName:         conf.AppName,
+		env:             conf.Environment,
+		debugMode:       conf.LogLevel == "debug",
+		trustedProxies:  conf.TrustedProxies,
+		trustedPlatform: conf.TrustedPlatform,
+		waitGroup:       params.waitGroup,
+		grpcTokens:      params.grpcTokens,
+		tracing:         params.tracing,
+		tracerProvider:  params.tracerProvider,
+		propagator:      tracing.NewPropagator(),
+		newRelic:        params.newRelic,
+		slack:           params.slack,
 	}
 
-	// Serviceの設定
+	// コンポーネントの登録 (依存先から順に起動し、逆順に停止する)
+	if params.shutdownTracer != nil {
+		lc.Register("tracer", lifecycle.Hooks{
+			// 送信待ちのトレースを出力してから終了する
+			Stop: params.shutdownTracer,
+		})
+	}
+	if params.newRelic != nil {
+		lc.Register("newrelic", lifecycle.Hooks{
+			Stop: func(ctx context.Context) error {
+				timeout := time.Duration(conf.ShutdownTimeoutSec) * time.Second
+				if deadline, ok := ctx.Deadline(); ok {
+					timeout = time.Until(deadline)
+				}
+				params.newRelic.Shutdown(timeout)
+				return nil
+			},
+		})
+	}
+	lc.Register("mysql", lifecycle.Hooks{
+		Start: func(ctx context.Context) (err error) {
+			params.db, err = newDatabase(params)
+			return
+		},
+		Ready: func(ctx context.Context) error {
+			return params.db.Ping(ctx)
+		},
+		Stop: func(ctx context.Context) error {
+			return params.db.Disconnect()
+		},
+	}, lifecycle.WithRetry())
+	lc.Register("service", lifecycle.Hooks{
+		Start: func(ctx context.Context) error {
+			reg.newService(params)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			// 処理中の非同期処理 (Slackへのアラート送信など) の完了を待つ
+			params.waitGroup.Wait()
+			return nil
+		},
+	}, lifecycle.WithDependsOn("mysql"))
+	return reg, nil
+}
+
+// newService - APIとgRPCのサービスの生成 (データベースの接続後に呼び出す)
+func (r *registry) newService(p *params) {
 	apiParams := &api.Params{
-		WaitGroup: params.waitGroup,
-		Database:  mysql.NewDatabase(params.db),
-		AdminAuth: params.adminAuth,
-		UserAuth:  params.userAuth,
-	}
-	return &registry{
-		appName:   conf.AppName,
-		env:       conf.Environment,
-		debugMode: conf.LogLevel == "debug",
-		waitGroup: params.waitGroup,
-		service:   api.NewController(apiParams, api.WithLogger(logger)),
-		newRelic:  params.newRelic,
-		slack:     params.slack,
-	}, nil
+		WaitGroup: p.waitGroup,
+		Database:  mysql.NewDatabase(p.db, p.cipher),
+		AdminAuth: p.adminAuth,
+		UserAuth:  p.userAuth,
+		WebAuthn:  p.webauthn,
+	}
+	apiOpts := []api.Option{
+		api.WithLogger(p.logger),
+		api.WithProblemDetails(p.config.ProblemJSONEnabled),
+		api.WithProblemTypeBaseURL(p.config.ProblemTypeBaseURL),
+		api.WithAuthChallengeSecret([]byte(p.config.AuthChallengeSecret)),
+		api.WithCursorSecret([]byte(p.config.CursorSecret)),
+		api.WithIntrospectionClients(p.clients),
+		api.WithIntrospectionCacheSize(p.config.IntrospectionCache),
+		api.WithIntrospectionCacheTTL(time.Duration(p.config.IntrospectionCacheSec) * time.Second),
+	}
+	rpcParams := &rpc.Params{
+		Database:  apiParams.Database,
+		AdminAuth: p.adminAuth,
+	}
+	r.service = api.NewController(apiParams, apiOpts...)
+	r.rpc = rpc.NewAuthService(rpcParams, rpc.WithLogger(p.logger))
+	r.health = newHealth(p)
+}
+
+// newTracerProvider - トレースの送信先に応じたTracerProviderの生成
+// New Relicの場合は、APMエージェント (nrgin、GORMのプラグイン) でトレースを送信する
+func newTracerProvider(ctx context.Context, p *params) error {
+	backend, err := tracing.ParseBackend(p.config.TracingBackend)
+	if err != nil {
+		return err
+	}
+	p.tracing = backend
+	otel.SetTextMapPropagator(tracing.NewPropagator())
+	if backend != tracing.BackendOTLP {
+		return nil
+	}
+	tp, err := tracing.NewTracerProvider(ctx,
+		tracing.WithServiceName(p.config.AppName),
+		tracing.WithEnvironment(p.config.Environment),
+		tracing.WithSampleRatio(p.config.TracingSampleRatio),
+	)
+	if err != nil {
+		return err
+	}
+	otel.SetTracerProvider(tp)
+	p.tracerProvider = tp
+	p.shutdownTracer = tp.Shutdown
+	return nil
 }
 
 func getSecret(ctx context.Context, p *params) error {
@@ -141,9 +294,10 @@ func getSecret(ctx context.Context, p *params) error {
 			p.dbPort = p.config.DBPort
 			p.dbUsername = p.config.DBUsername
 			p.dbPassword = p.config.DBPassword
+			p.dbReplicaHosts = p.config.DBReplicaHosts
 			return nil
 		}
-		secrets, err := p.secret.Get(ectx, p.config.DBSecretName)
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.DBSecretName)
 		if err != nil {
 			return err
 		}
@@ -151,6 +305,9 @@ func getSecret(ctx context.Context, p *params) error {
 		p.dbPort = secrets["port"]
 		p.dbUsername = secrets["username"]
 		p.dbPassword = secrets["password"]
+		if hosts := secrets["replicaHosts"]; hosts != "" {
+			p.dbReplicaHosts = strings.Split(hosts, ",")
+		}
 		return nil
 	})
 	eg.Go(func() error {
@@ -159,7 +316,7 @@ func getSecret(ctx context.Context, p *params) error {
 			p.newRelicLicense = p.config.NewRelicLicense
 			return nil
 		}
-		secrets, err := p.secret.Get(ectx, p.config.NewRelicSecretName)
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.NewRelicSecretName)
 		if err != nil {
 			return err
 		}
@@ -173,7 +330,7 @@ func getSecret(ctx context.Context, p *params) error {
 			p.slackChannelID = p.config.SlackChannelID
 			return nil
 		}
-		secrets, err := p.secret.Get(ectx, p.config.SlackSecretName)
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.SlackSecretName)
 		if err != nil {
 			return err
 		}
@@ -181,9 +338,93 @@ func getSecret(ctx context.Context, p *params) error {
 		p.slackChannelID = secrets["channelId"]
 		return nil
 	})
+	eg.Go(func() error {
+		// gRPCのサービストークンの取得
+		if p.config.GRPCSecretName == "" {
+			p.grpcTokens = p.config.GRPCServiceTokens
+			return nil
+		}
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.GRPCSecretName)
+		if err != nil {
+			return err
+		}
+		p.grpcTokens = strings.Split(secrets["serviceTokens"], ",")
+		return nil
+	})
+	eg.Go(func() error {
+		// トークンの検証を許可するクライアントの取得
+		if p.config.IntrospectionSecret == "" {
+			p.clients = parseClients(p.config.IntrospectionClients)
+			return nil
+		}
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.IntrospectionSecret)
+		if err != nil {
+			return err
+		}
+		p.clients = parseClients(strings.Split(secrets["clients"], ","))
+		return nil
+	})
+	eg.Go(func() error {
+		// 個人情報の暗号化に使用する鍵の取得
+		if p.config.EncryptionSecretName == "" {
+			if p.config.EncryptionKeyFile == "" {
+				return errEmptyEncryptionKey
+			}
+			file, err := encryption.ReadKeyFile(p.config.EncryptionKeyFile)
+			p.encryptionKeys = file
+			return err
+		}
+		secrets, err := p.secrets.get(ectx, p.secret, p.config.EncryptionSecretName)
+		if err != nil {
+			return err
+		}
+		p.encryptionKeys = &encryption.KeyFile{
+			PrimaryKeyID: secrets["primaryKeyId"],
+			Keys:         encryption.ParseKeys(strings.Split(secrets["keys"], ",")),
+			IndexKey:     secrets["indexKey"],
+		}
+		return nil
+	})
 	return eg.Wait()
 }
 
+// parseClients - クライアントの一覧を変換 (e.g. client-id:client-secret)
+func parseClients(clients []string) map[string]string {
+	res := make(map[string]string, len(clients))
+	for _, client := range clients {
+		id, secret, ok := strings.Cut(client, ":")
+		if !ok || id == "" || secret == "" {
+			continue
+		}
+		res[id] = secret
+	}
+	return res
+}
+
+// newReplicas - リードレプリカの接続情報を生成 (e.g. host, host:port)
+// 認証情報とポート番号の指定がない場合は、プライマリレプリカと同じものを使用する
+func newReplicas(p *params) []*apmysql.Params {
+	replicas := make([]*apmysql.Params, 0, len(p.dbReplicaHosts))
+	for _, host := range p.dbReplicaHosts {
+		if host == "" {
+			continue
+		}
+		port := p.dbPort
+		if h, pt, ok := strings.Cut(host, ":"); ok {
+			host, port = h, pt
+		}
+		replicas = append(replicas, &apmysql.Params{
+			Socket:   p.config.DBSocket,
+			Host:     host,
+			Port:     port,
+			Database: p.config.DBDatabase,
+			Username: p.dbUsername,
+			Password: p.dbPassword,
+		})
+	}
+	return replicas
+}
+
 func newDatabase(p *params) (*apmysql.Client, error) {
 	params := &apmysql.Params{
 		Socket:   p.config.DBSocket,
@@ -203,10 +444,21 @@ func newDatabase(p *params) (*apmysql.Client, error) {
 		apmysql.WithNow(p.now),
 		apmysql.WithTLS(p.config.DBEnabledTLS),
 		apmysql.WithLocation(location),
+		apmysql.WithReplicas(newReplicas(p)...),
+		apmysql.WithMaxOpenConns(p.config.DBMaxOpenConns),
+		apmysql.WithMaxIdleConns(p.config.DBMaxIdleConns),
+		apmysql.WithConnMaxLifetime(time.Duration(p.config.DBConnMaxLifetimeSec)*time.Second),
+		apmysql.WithConnMaxIdleTime(time.Duration(p.config.DBConnMaxIdleTimeSec)*time.Second),
+		apmysql.WithMaxRetries(p.config.DBMaxRetries),
+		apmysql.WithMetrics(true),
+		apmysql.WithTracerProvider(p.tracerProvider),
 	)
 	if err != nil {
 		return nil, err
 	}
+	if p.tracing != tracing.BackendNewRelic {
+		return cli, nil
+	}
 	tracer := telemetry.NewNrTracer(p.config.DBDatabase, p.dbHost, string(newrelic.DatastoreMySQL))
 	if err := cli.DB.Use(tracer); err != nil {
 		return nil, err
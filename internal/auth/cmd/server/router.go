@@ -3,28 +3,44 @@ package server
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/internal/auth/api"
+	"github.com/and-period/furumane/pkg/authclient/response"
 	"github.com/and-period/furumane/pkg/cors"
+	aphttp "github.com/and-period/furumane/pkg/http"
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/redact"
+	"github.com/and-period/furumane/pkg/tracing"
 	ginzip "github.com/gin-contrib/gzip"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
 	"github.com/newrelic/go-agent/v3/integrations/nrgin"
 	"github.com/slack-go/slack"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func newRouter(reg *registry, logger *zap.Logger) *gin.Engine {
+func newRouter(reg *registry, logger *zap.Logger, redactor *redact.Redactor) (*gin.Engine, error) {
 	opts := make([]gin.HandlerFunc, 0)
-	opts = append(opts, nrgin.Middleware(reg.newRelic))
-	opts = append(opts, accessLogger(logger, reg))
+	switch reg.tracing {
+	case tracing.BackendNewRelic:
+		opts = append(opts, nrgin.Middleware(reg.newRelic))
+	case tracing.BackendOTLP:
+		opts = append(opts, otelgin.Middleware(reg.appName,
+			otelgin.WithTracerProvider(reg.tracerProvider),
+			otelgin.WithPropagators(reg.propagator),
+		))
+	}
+	opts = append(opts, aphttp.NewGinMetricsMiddleware())
+	opts = append(opts, aphttp.NewGinRequestMiddleware(logger))
+	opts = append(opts, accessLogger(logger, reg, redactor))
 	opts = append(opts, cors.NewGinMiddleware())
 	opts = append(opts, ginzip.Gzip(ginzip.DefaultCompression))
 	opts = append(opts, ginzap.RecoveryWithZap(logger, true))
@@ -36,6 +52,13 @@ func newRouter(reg *registry, logger *zap.Logger) *gin.Engine {
 	}
 
 	rt := gin.New()
+	rt.ContextWithFallback = true // ハンドラーからリクエストのコンテキスト (リクエストID、ロガー) を参照できるようにする
+	// APIキーの接続元制限に使用するため、信頼するプロキシ経由の場合のみX-Forwarded-Forを参照する
+	// (未指定の場合は、ヘッダーを参照せずに接続元のIPアドレスを使用する)
+	rt.TrustedPlatform = reg.trustedPlatform
+	if err := rt.SetTrustedProxies(reg.trustedProxies); err != nil {
+		return nil, err
+	}
 	rt.Use(opts...)
 
 	reg.service.Routes(rt.Group(""))
@@ -44,11 +67,13 @@ func newRouter(reg *registry, logger *zap.Logger) *gin.Engine {
 	rt.GET("/health", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, "ok")
 	})
+	rt.GET("/livez", reg.health.LivenessHandler())
+	rt.GET("/readyz", reg.health.ReadinessHandler())
 	rt.NoRoute(func(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, "not found")
 	})
 
-	return rt
+	return rt, nil
 }
 
 type wrapResponseWriter struct {
@@ -75,9 +100,11 @@ func (w *wrapResponseWriter) errorResponse() (*response.ErrorResponse, error) {
 	return res, json.NewDecoder(r).Decode(&res)
 }
 
-func accessLogger(logger *zap.Logger, reg *registry) gin.HandlerFunc {
+func accessLogger(logger *zap.Logger, reg *registry, redactor *redact.Redactor) gin.HandlerFunc {
 	skipPaths := map[string]bool{
 		"/health": true,
+		"/livez":  true,
+		"/readyz": true,
 	}
 
 	return func(ctx *gin.Context) {
@@ -104,6 +131,8 @@ func accessLogger(logger *zap.Logger, reg *registry) gin.HandlerFunc {
 
 		end := time.Now()
 		status := ctx.Writer.Status()
+		// リクエストID、ルーティング、認証済みの管理者IDを含むリクエスト単位のロガー
+		logger := log.FromContext(ctx.Request.Context(), logger)
 
 		fields := []zapcore.Field{
 			zap.Int("status", status),
@@ -123,15 +152,20 @@ func accessLogger(logger *zap.Logger, reg *registry) gin.HandlerFunc {
 			return
 		}
 
+		// リクエスト・レスポンスの秘匿情報と個人情報はマスキングして出力する
+		// リクエストを受け付けた場合はlogタグとルール、受け付ける前に失敗した場合はルールに従ってマスキングする
 		if reg.debugMode {
-			str := strings.ReplaceAll(bytes.NewBuffer(req).String(), "\n", "")
-			fields = append(fields, zap.String("request", str))
+			if v, ok := ctx.Get(api.RequestContextKey); ok {
+				fields = append(fields, zap.Any("request", redactor.Value(v)))
+			} else {
+				fields = append(fields, zap.String("request", redactor.Body(ctx.ContentType(), req)))
+			}
 		}
 		res, err := w.errorResponse()
 		if err != nil {
 			logger.Error("Failed to parse http response", zap.Error(err))
 		}
-		fields = append(fields, zap.Any("response", res))
+		fields = append(fields, zap.Any("response", redactor.Value(res)))
 
 		// 400 ~ 499
 		if status < 500 {
@@ -147,22 +181,25 @@ func accessLogger(logger *zap.Logger, reg *registry) gin.HandlerFunc {
 			return
 		}
 
-		details, _ := json.Marshal(res)
+		details, _ := json.Marshal(redactor.Value(res))
 		params := &alertMessageParams{
-			title:   "ふるマネ APIアラート",
-			appName: reg.appName,
-			env:     reg.env,
-			status:  int64(status),
-			method:  method,
-			path:    path,
-			details: string(details),
+			title:     "ふるマネ APIアラート",
+			appName:   reg.appName,
+			env:       reg.env,
+			status:    int64(status),
+			method:    method,
+			path:      path,
+			requestID: aphttp.RequestID(ctx.Request.Context()),
+			details:   string(details),
 		}
 		msg := newAlertMessage(params)
 
+		// レスポンス後に送信するため、リクエストのキャンセルを引き継がない
+		actx := context.WithoutCancel(ctx.Request.Context())
 		reg.waitGroup.Add(1)
 		go func(msg slack.MsgOption) {
 			defer reg.waitGroup.Done()
-			err := reg.slack.SendMessage(ctx, msg)
+			err := reg.slack.SendMessage(actx, msg)
 			if err != nil {
 				logger.Error("Failed to alert message", zap.Error(err))
 			}
@@ -171,13 +208,14 @@ func accessLogger(logger *zap.Logger, reg *registry) gin.HandlerFunc {
 }
 
 type alertMessageParams struct {
-	title   string
-	appName string
-	env     string
-	status  int64
-	method  string
-	path    string
-	details string
+	title     string
+	appName   string
+	env       string
+	status    int64
+	method    string
+	path      string
+	requestID string
+	details   string
 }
 
 func newAlertMessage(params *alertMessageParams) slack.MsgOption {
@@ -189,7 +227,8 @@ func newAlertMessage(params *alertMessageParams) slack.MsgOption {
 			{Title: "environment", Value: params.env, Short: true},
 			{Title: "method", Value: params.method, Short: true},
 			{Title: "path", Value: params.path, Short: true},
-			{Title: "status", Value: strconv.FormatInt(params.status, 10), Short: false},
+			{Title: "status", Value: strconv.FormatInt(params.status, 10), Short: true},
+			{Title: "requestId", Value: params.requestID, Short: true},
 			{Title: "details", Value: params.details, Short: false},
 		},
 	}
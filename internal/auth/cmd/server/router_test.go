@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/api"
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/redact"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLogger_Request(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	// コントローラーと同様に、受け付けたリクエストをコンテキストに保持して400を返す
+	bind := func(newRequest func() interface{}, b binding.Binding) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+			req := newRequest()
+			ctx.Set(api.RequestContextKey, req)
+			_ = ctx.ShouldBindWith(req, b)
+			ctx.AbortWithStatus(http.StatusBadRequest)
+		}
+	}
+	tests := []struct {
+		name        string
+		path        string
+		handler     gin.HandlerFunc
+		contentType string
+		body        string
+		expect      []string
+		plaintext   []string
+	}{
+		{
+			name: "sign in",
+			path: "/v1/admin/auth",
+			handler: bind(func() interface{} {
+				return &request.SignInAdminRequest{}
+			}, binding.JSON),
+			contentType: "application/json",
+			body:        `{"key":"taro@example.com","password":"Passw0rd!"}`,
+			expect:      []string{"t***@example.com", redact.Redacted},
+			plaintext:   []string{"taro@", "Passw0rd!"},
+		},
+		{
+			name: "verify otp",
+			path: "/v1/admin/auth/otp/verify",
+			handler: bind(func() interface{} {
+				return &request.VerifyAdminOTPRequest{}
+			}, binding.JSON),
+			contentType: "application/json",
+			body:        `{"email":"taro@example.com","session":"session-id","code":"123456"}`,
+			expect:      []string{"t***@example.com", redact.Redacted},
+			plaintext:   []string{"taro@", "session-id", "123456"},
+		},
+		{
+			name: "introspect token",
+			path: "/v1/oauth/introspect",
+			handler: bind(func() interface{} {
+				return &request.IntrospectTokenRequest{}
+			}, binding.Form),
+			contentType: "application/x-www-form-urlencoded",
+			body:        "token=access-token&token_type_hint=access_token",
+			expect:      []string{redact.Redacted, "access_token"},
+			plaintext:   []string{"access-token"},
+		},
+		{
+			name: "introspect token before binding",
+			path: "/v1/oauth/introspect",
+			handler: func(ctx *gin.Context) {
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+			},
+			contentType: "application/x-www-form-urlencoded",
+			body:        "token=access-token&client_id=client-id&client_secret=client-secret",
+			expect:      []string{"client-id"},
+			plaintext:   []string{"access-token", "client-secret"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			core, logs := observer.New(zap.DebugLevel)
+			reg := &registry{debugMode: true, waitGroup: &sync.WaitGroup{}}
+			rt := gin.New()
+			rt.Use(accessLogger(zap.New(core), reg, redact.Default()))
+			rt.POST(tt.path, tt.handler)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rt.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.FilterMessage(tt.path).All()
+			require.Len(t, entries, 1)
+			fields := entries[0].ContextMap()
+			require.Contains(t, fields, "request")
+			buf, err := json.Marshal(fields["request"])
+			require.NoError(t, err)
+			logged := string(buf)
+			for _, str := range tt.expect {
+				assert.Contains(t, logged, str)
+			}
+			for _, str := range tt.plaintext {
+				assert.NotContains(t, logged, str)
+			}
+		})
+	}
+}
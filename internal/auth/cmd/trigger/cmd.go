@@ -0,0 +1,22 @@
+package trigger
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type app struct {
+	*cobra.Command
+}
+
+//nolint:revive
+func NewApp() *app {
+	cmd := &cobra.Command{
+		Use:   "trigger",
+		Short: "auth cognito trigger (aws lambda)",
+	}
+	app := &app{Command: cmd}
+	app.RunE = func(c *cobra.Command, args []string) error {
+		return app.run()
+	}
+	return app
+}
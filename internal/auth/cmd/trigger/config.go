@@ -0,0 +1,25 @@
+package trigger
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type config struct {
+	LogLevel        string `envconfig:"LOG_LEVEL" default:"info"`
+	AWSRegion       string `envconfig:"AWS_REGION" default:"ap-northeast-1"`
+	MailFromName    string `envconfig:"MAIL_FROM_NAME" default:""`
+	MailFromEmail   string `envconfig:"MAIL_FROM_EMAIL" default:""`
+	OTPCodeTTLSec   int64  `envconfig:"OTP_CODE_TTL_SEC" default:"300"`
+	OTPMaxAttempts  int64  `envconfig:"OTP_MAX_ATTEMPTS" default:"3"`
+	ChallengeSecret string `envconfig:"AUTH_CHALLENGE_SECRET" default:""`
+}
+
+func newConfig() (*config, error) {
+	conf := &config{}
+	if err := envconfig.Process("", conf); err != nil {
+		return conf, fmt.Errorf("config: failed to new config: %w", err)
+	}
+	return conf, nil
+}
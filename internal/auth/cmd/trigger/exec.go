@@ -0,0 +1,54 @@
+package trigger
+
+import (
+	"context"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/trigger"
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/mailer"
+	"github.com/aws/aws-lambda-go/lambda"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+func (a *app) run() error {
+	ctx := context.Background()
+
+	// 環境変数の読み込み
+	conf, err := newConfig()
+	if err != nil {
+		return err
+	}
+
+	// Loggerの設定
+	logger, err := log.NewLogger(log.WithLogLevel(conf.LogLevel))
+	if err != nil {
+		return err
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	// AWS SDKの設定
+	awscfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(conf.AWSRegion))
+	if err != nil {
+		return err
+	}
+
+	// Amazon SESの設定
+	mailerParams := &mailer.Params{
+		FromName:    conf.MailFromName,
+		FromAddress: conf.MailFromEmail,
+	}
+
+	// Lambdaハンドラの起動
+	params := &trigger.Params{
+		Mailer: mailer.NewClient(awscfg, mailerParams),
+	}
+	h := trigger.NewHandler(params,
+		trigger.WithLogger(logger),
+		trigger.WithCodeTTL(time.Duration(conf.OTPCodeTTLSec)*time.Second),
+		trigger.WithMaxAttempts(int(conf.OTPMaxAttempts)),
+		trigger.WithChallengeSecret([]byte(conf.ChallengeSecret)),
+	)
+	lambda.StartWithOptions(h.Handle, lambda.WithContext(ctx))
+	return nil
+}
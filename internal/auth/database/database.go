@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
 )
 
 var (
@@ -16,20 +17,92 @@ var (
 	ErrFailedPrecondition = errors.New("database: failed precondition")
 	ErrCanceled           = errors.New("database: canceled")
 	ErrDeadlineExceeded   = errors.New("database: deadline exceeded")
+	ErrUnavailable        = errors.New("database: unavailable")
 	ErrInternal           = errors.New("database: internal error")
 	ErrUnknown            = errors.New("database: unknown")
 )
 
+// ReadFromPrimary - 参照クエリをリードレプリカではなくプライマリレプリカで実行する
+// 登録・更新直後の参照 (read-after-write) で使用する
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return mysql.ReadFromPrimary(ctx)
+}
+
 type Database struct {
-	Admin Admin
+	Transaction        Transaction
+	Admin              Admin
+	AdminAPIKey        AdminAPIKey
+	AdminCredential    AdminCredential
+	PasskeyChallenge   PasskeyChallenge
+	Organization       Organization
+	OrganizationMember OrganizationMember
+}
+
+// Transaction - 複数のリポジトリの操作を1つのトランザクションで実行する (Unit of Work)
+// fnに渡されたコンテキストを引き継いだ操作は、すべて同じトランザクション内で実行される
+// 入れ子で呼び出した場合はセーブポイントを作成し、内側の処理の失敗時はセーブポイントまでロールバックする
+type Transaction interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Admin interface {
+	MultiGet(ctx context.Context, adminIDs []string, fields ...string) (entity.Admins, error)
 	Get(ctx context.Context, adminID string, fields ...string) (*entity.Admin, error)
 	GetByCognitoID(ctx context.Context, cognitoID string, fields ...string) (*entity.Admin, error)
 	GetByEmail(ctx context.Context, email string, fields ...string) (*entity.Admin, error)
 	Create(ctx context.Context, admin *entity.Admin, auth func(context.Context) error) error
-	UpdateEmail(ctx context.Context, adminID, email string) error
-	UpdateVerifiedAt(ctx context.Context, adminID string) error
-	Delete(ctx context.Context, adminID string, auth func(context.Context) error) error
+	UpdateEmail(ctx context.Context, adminID, email string, version int64) error
+	UpdateVerifiedAt(ctx context.Context, adminID string, version int64) error
+	Delete(ctx context.Context, adminID string, version int64, auth func(context.Context) error) error
+}
+
+type AdminCredential interface {
+	List(ctx context.Context, adminID string, fields ...string) (entity.AdminCredentials, error)
+	Get(ctx context.Context, adminID, credentialID string, fields ...string) (*entity.AdminCredential, error)
+	GetByCredentialID(ctx context.Context, credentialID []byte, fields ...string) (*entity.AdminCredential, error)
+	Create(ctx context.Context, credential *entity.AdminCredential) error
+	UpdateName(ctx context.Context, adminID, credentialID, name string) error
+	UpdateSignCount(ctx context.Context, credentialID string, signCount uint32) error
+	Delete(ctx context.Context, adminID, credentialID string) error
+}
+
+// PasskeyChallenge - パスキー認証のチャレンジ (検証に成功したチャレンジは再利用させない)
+type PasskeyChallenge interface {
+	Create(ctx context.Context, challenge *entity.PasskeyChallenge) error
+	// Consume - 有効期限内のチャレンジを削除 (未発行、利用済み、有効期限切れの場合はErrNotFound)
+	Consume(ctx context.Context, challenge string) error
+}
+
+type AdminAPIKey interface {
+	List(ctx context.Context, adminID string, fields ...string) (entity.AdminAPIKeys, error)
+	Get(ctx context.Context, adminID, apiKeyID string, fields ...string) (*entity.AdminAPIKey, error)
+	GetByHash(ctx context.Context, hash []byte, fields ...string) (*entity.AdminAPIKey, error)
+	Create(ctx context.Context, apiKey *entity.AdminAPIKey) error
+	Rotate(ctx context.Context, adminID, apiKeyID, prefix string, hash []byte) error
+	Revoke(ctx context.Context, adminID, apiKeyID string) error
+	UpdateLastUsedAt(ctx context.Context, apiKeyID string) error
+}
+
+type Organization interface {
+	MultiGet(ctx context.Context, organizationIDs []string, fields ...string) (entity.Organizations, error)
+	Get(ctx context.Context, organizationID string, fields ...string) (*entity.Organization, error)
+	Create(ctx context.Context, organization *entity.Organization) error
+}
+
+// ListOrganizationMembersParams - 組織のメンバー一覧の取得条件
+type ListOrganizationMembersParams struct {
+	OrganizationID string
+	Orders         []*mysql.Order // ソートキー (未指定の場合は登録日時の昇順)
+	Cursor         []interface{}  // 前ページの最終行のソートキーの値 (未指定の場合は先頭ページ)
+	Limit          int            // 取得上限 (0以下の場合は上限なし)
+}
+
+// OrganizationMember - 組織のメンバー (ListByAdminID以外は組織IDの指定を必須とし、他の組織のデータを参照させない)
+type OrganizationMember interface {
+	List(ctx context.Context, params *ListOrganizationMembersParams, fields ...string) (entity.OrganizationMembers, []interface{}, error)
+	ListByAdminID(ctx context.Context, adminID string, fields ...string) (entity.OrganizationMembers, error)
+	Get(ctx context.Context, organizationID, adminID string, fields ...string) (*entity.OrganizationMember, error)
+	Create(ctx context.Context, member *entity.OrganizationMember) error
+	UpdateRole(ctx context.Context, organizationID, adminID string, role entity.OrganizationRole) error
+	Delete(ctx context.Context, organizationID, adminID string) error
 }
@@ -2,29 +2,54 @@ package mysql
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/and-period/furumane/internal/auth/database"
 	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/encryption"
 	"github.com/and-period/furumane/pkg/jst"
 	"github.com/and-period/furumane/pkg/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const adminTable = "admins"
 
+// admin - 管理者情報
+// 個人情報 (メールアドレス・電話番号) は暗号化して保存し、メールアドレスはブラインドインデックスで検索する
 type admin struct {
-	db  *mysql.Client
-	now func() time.Time
+	db     *mysql.Client
+	cipher *encryption.Cipher
+	now    func() time.Time
 }
 
-func newAdmin(db *mysql.Client) database.Admin {
+func newAdmin(db *mysql.Client, cipher *encryption.Cipher) database.Admin {
 	return &admin{
-		db:  db,
-		now: jst.Now,
+		db:     db,
+		cipher: cipher,
+		now:    jst.Now,
 	}
 }
 
+func (a *admin) MultiGet(ctx context.Context, adminIDs []string, fields ...string) (entity.Admins, error) {
+	var admins entity.Admins
+
+	stmt := a.db.
+		Statement(ctx, a.db.DB, adminTable, fields...).
+		Where("id IN (?)", adminIDs)
+
+	if err := stmt.Find(&admins).Error; err != nil {
+		return nil, dbError(err)
+	}
+	if err := a.decrypt(ctx, admins...); err != nil {
+		return nil, dbError(err)
+	}
+	return admins, nil
+}
+
 func (a *admin) Get(ctx context.Context, adminID string, fields ...string) (*entity.Admin, error) {
 	var admin *entity.Admin
 
@@ -35,6 +60,9 @@ func (a *admin) Get(ctx context.Context, adminID string, fields ...string) (*ent
 	if err := stmt.First(&admin).Error; err != nil {
 		return nil, dbError(err)
 	}
+	if err := a.decrypt(ctx, admin); err != nil {
+		return nil, dbError(err)
+	}
 	return admin, nil
 }
 
@@ -48,6 +76,9 @@ func (a *admin) GetByCognitoID(ctx context.Context, cognitoID string, fields ...
 	if err := stmt.First(&admin).Error; err != nil {
 		return nil, dbError(err)
 	}
+	if err := a.decrypt(ctx, admin); err != nil {
+		return nil, dbError(err)
+	}
 	return admin, nil
 }
 
@@ -56,70 +87,223 @@ func (a *admin) GetByEmail(ctx context.Context, email string, fields ...string)
 
 	stmt := a.db.
 		Statement(ctx, a.db.DB, adminTable, fields...).
-		Where("email = ?", email)
+		Where("email_index = ?", a.emailIndex(email))
 
 	if err := stmt.First(&admin).Error; err != nil {
 		return nil, dbError(err)
 	}
+	if err := a.decrypt(ctx, admin); err != nil {
+		return nil, dbError(err)
+	}
 	return admin, nil
 }
 
 func (a *admin) Create(ctx context.Context, admin *entity.Admin, auth func(context.Context) error) error {
-	err := a.db.Transaction(ctx, func(tx *gorm.DB) error {
+	err := a.db.RunInTransaction(ctx, func(ctx context.Context) error {
 		now := a.now()
 		admin.CreatedAt, admin.UpdatedAt = now, now
+		admin.Version = 1
 
-		if err := tx.WithContext(ctx).Create(&admin).Error; err != nil {
+		row, err := a.encrypt(ctx, admin)
+		if err != nil {
 			return err
 		}
+		if err := a.db.WithContext(ctx).Create(&row).Error; err != nil {
+			return err
+		}
+		// 外部サービスへの登録は取り消せないため、以降はトランザクションを再実行しない
+		mysql.MarkNonRetryable(ctx)
 		return auth(ctx)
 	})
 	return dbError(err)
 }
 
-func (a *admin) UpdateEmail(ctx context.Context, adminID, email string) error {
+func (a *admin) UpdateEmail(ctx context.Context, adminID, email string, version int64) error {
+	encrypted, err := a.cipher.Encrypt(ctx, email)
+	if err != nil {
+		return dbError(err)
+	}
 	updates := map[string]interface{}{
-		"email":      email,
-		"updated_at": a.now(),
+		"email":       encrypted,
+		"email_index": a.emailIndex(email),
+		"updated_at":  a.now(),
+	}
+	err = a.db.Transaction(ctx, func(tx *gorm.DB) error {
+		return a.update(ctx, tx, adminID, version, updates)
+	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
 	}
-	stmt := a.db.DB.WithContext(ctx).
-		Table(adminTable).
-		Where("id = ?", adminID)
-
-	err := stmt.Updates(updates).Error
 	return dbError(err)
 }
 
-func (a *admin) UpdateVerifiedAt(ctx context.Context, adminID string) error {
+func (a *admin) UpdateVerifiedAt(ctx context.Context, adminID string, version int64) error {
 	now := a.now()
 	updates := map[string]interface{}{
 		"verified_at": now,
 		"updated_at":  now,
 	}
-	stmt := a.db.DB.WithContext(ctx).
-		Table(adminTable).
-		Where("id = ?", adminID)
-
-	err := stmt.Updates(updates).Error
+	err := a.db.Transaction(ctx, func(tx *gorm.DB) error {
+		return a.update(ctx, tx, adminID, version, updates)
+	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
+	}
 	return dbError(err)
 }
 
-func (a *admin) Delete(ctx context.Context, adminID string, auth func(context.Context) error) error {
-	err := a.db.Transaction(ctx, func(tx *gorm.DB) error {
+func (a *admin) Delete(ctx context.Context, adminID string, version int64, auth func(context.Context) error) error {
+	err := a.db.RunInTransaction(ctx, func(ctx context.Context) error {
 		now := a.now()
 		updates := map[string]interface{}{
 			"exists":     nil,
 			"updated_at": now,
 			"deleted_at": now,
 		}
-		stmt := tx.WithContext(ctx).
-			Table(adminTable).
-			Where("id = ?", adminID)
-
-		if err := stmt.Updates(updates).Error; err != nil {
+		if err := a.update(ctx, a.db.WithContext(ctx), adminID, version, updates); err != nil {
 			return err
 		}
+		// 外部サービスからの削除は取り消せないため、以降はトランザクションを再実行しない
+		mysql.MarkNonRetryable(ctx)
 		return auth(ctx)
 	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
+	}
 	return dbError(err)
 }
+
+// update - バージョンを検証した上で管理者情報を更新し、バージョンを進める
+// versionが0の場合はバージョンを検証せずに更新する
+func (a *admin) update(
+	ctx context.Context, tx *gorm.DB, adminID string, version int64, updates map[string]interface{},
+) error {
+	var current *entity.Admin
+
+	stmt := a.db.
+		Statement(ctx, tx, adminTable, "version").
+		Where("id = ?", adminID).
+		Clauses(clause.Locking{Strength: "UPDATE"})
+
+	if err := stmt.First(&current).Error; err != nil {
+		return err
+	}
+	if version > 0 && current.Version != version {
+		return fmt.Errorf("%w: admin has been modified: version=%d", database.ErrFailedPrecondition, current.Version)
+	}
+	updates["version"] = gorm.Expr("version + 1")
+
+	stmt = tx.WithContext(ctx).
+		Table(adminTable).
+		Where("id = ?", adminID)
+
+	return stmt.Updates(updates).Error
+}
+
+// encrypt - 保存用に個人情報を暗号化した管理者情報を生成 (引数の管理者情報は変更しない)
+func (a *admin) encrypt(ctx context.Context, admin *entity.Admin) (*entity.Admin, error) {
+	row := *admin
+	email, err := a.cipher.Encrypt(ctx, admin.Email)
+	if err != nil {
+		return nil, err
+	}
+	phoneNumber, err := a.cipher.Encrypt(ctx, admin.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	row.Email, row.PhoneNumber = email, phoneNumber
+	row.EmailIndex = a.emailIndex(admin.Email)
+	return &row, nil
+}
+
+// decrypt - 取得した管理者情報の個人情報を復号
+func (a *admin) decrypt(ctx context.Context, admins ...*entity.Admin) error {
+	for _, admin := range admins {
+		email, err := a.cipher.Decrypt(ctx, admin.Email)
+		if err != nil {
+			return err
+		}
+		phoneNumber, err := a.cipher.Decrypt(ctx, admin.PhoneNumber)
+		if err != nil {
+			return err
+		}
+		admin.Email, admin.PhoneNumber = email, phoneNumber
+	}
+	return nil
+}
+
+// emailIndex - メールアドレスのブラインドインデックス
+// 暗号化の導入前と同様に大文字・小文字を区別せずに検索・一意制約を適用するため、小文字に揃えてから生成する
+func (a *admin) emailIndex(email string) string {
+	return a.cipher.BlindIndex(strings.ToLower(email))
+}
+
+// EncryptAdmins - 管理者の個人情報の暗号化・再暗号化 (暗号化の導入前のデータの移行、マスターキーのローテーション用)
+// 未暗号化の値やプライマリ以外のマスターキーで暗号化された値を、プライマリのマスターキーで暗号化し直す
+// 論理的な値は変わらないため、バージョンと更新日時は更新しない
+func EncryptAdmins(ctx context.Context, db *mysql.Client, cipher *encryption.Cipher, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("%w: batch size must be positive", database.ErrInvalidArgument)
+	}
+	a := &admin{db: db, cipher: cipher, now: jst.Now}
+	var (
+		total  int64
+		lastID string
+	)
+	for {
+		var admins entity.Admins
+		stmt := a.db.
+			Statement(ctx, a.db.DB, adminTable, "id", "email", "email_index", "phone_number").
+			Unscoped().
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize)
+
+		if err := stmt.Find(&admins).Error; err != nil {
+			return total, dbError(err)
+		}
+		for _, admin := range admins {
+			updated, err := a.reencrypt(ctx, admin)
+			if err != nil {
+				return total, fmt.Errorf("mysql: failed to encrypt admin: id=%s: %w", admin.ID, dbError(err))
+			}
+			if updated {
+				total++
+			}
+		}
+		if len(admins) < batchSize {
+			return total, nil
+		}
+		lastID = admins[len(admins)-1].ID
+	}
+}
+
+func (a *admin) reencrypt(ctx context.Context, current *entity.Admin) (bool, error) {
+	decrypted := *current
+	if err := a.decrypt(ctx, &decrypted); err != nil {
+		return false, err
+	}
+	if !a.cipher.NeedsReencryption(current.Email) &&
+		!a.cipher.NeedsReencryption(current.PhoneNumber) &&
+		current.EmailIndex == a.emailIndex(decrypted.Email) {
+		return false, nil
+	}
+	row, err := a.encrypt(ctx, &decrypted)
+	if err != nil {
+		return false, err
+	}
+	updates := map[string]interface{}{
+		"email":        gorm.Expr("NULLIF(?, '')", row.Email),
+		"email_index":  gorm.Expr("NULLIF(?, '')", row.EmailIndex),
+		"phone_number": gorm.Expr("NULLIF(?, '')", row.PhoneNumber),
+	}
+	// 移行中に更新された値を上書きしないよう、取得時の値から変わっていない場合のみ更新する
+	stmt := a.db.DB.WithContext(ctx).
+		Table(adminTable).
+		Where("id = ?", current.ID).
+		Where("email <=> NULLIF(?, '')", current.Email).
+		Where("phone_number <=> NULLIF(?, '')", current.PhoneNumber)
+
+	res := stmt.Updates(updates)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,141 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mysql"
+	"gorm.io/gorm"
+)
+
+const adminAPIKeyTable = "admin_api_keys"
+
+type adminAPIKey struct {
+	db  *mysql.Client
+	now func() time.Time
+}
+
+func newAdminAPIKey(db *mysql.Client) database.AdminAPIKey {
+	return &adminAPIKey{
+		db:  db,
+		now: jst.Now,
+	}
+}
+
+func (k *adminAPIKey) List(ctx context.Context, adminID string, fields ...string) (entity.AdminAPIKeys, error) {
+	var apiKeys entity.AdminAPIKeys
+
+	stmt := k.db.
+		Statement(ctx, k.db.DB, adminAPIKeyTable, fields...).
+		Where("admin_id = ?", adminID).
+		Order("created_at ASC")
+
+	err := stmt.Find(&apiKeys).Error
+	return apiKeys, dbError(err)
+}
+
+func (k *adminAPIKey) Get(ctx context.Context, adminID, apiKeyID string, fields ...string) (*entity.AdminAPIKey, error) {
+	apiKey, err := k.get(ctx, k.db.DB, adminID, apiKeyID, fields...)
+	return apiKey, dbError(err)
+}
+
+func (k *adminAPIKey) GetByHash(ctx context.Context, hash []byte, fields ...string) (*entity.AdminAPIKey, error) {
+	var apiKey *entity.AdminAPIKey
+
+	stmt := k.db.
+		Statement(ctx, k.db.DB, adminAPIKeyTable, fields...).
+		Where("key_hash = ?", hash)
+
+	if err := stmt.First(&apiKey).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return apiKey, nil
+}
+
+func (k *adminAPIKey) Create(ctx context.Context, apiKey *entity.AdminAPIKey) error {
+	now := k.now()
+	apiKey.CreatedAt, apiKey.UpdatedAt = now, now
+
+	err := k.db.WithContext(ctx).Table(adminAPIKeyTable).Create(&apiKey).Error
+	return dbError(err)
+}
+
+func (k *adminAPIKey) Rotate(ctx context.Context, adminID, apiKeyID, prefix string, hash []byte) error {
+	err := k.db.Transaction(ctx, func(tx *gorm.DB) error {
+		current, err := k.get(ctx, tx, adminID, apiKeyID, "revoked_at")
+		if err != nil {
+			return err
+		}
+		if !current.RevokedAt.IsZero() {
+			return fmt.Errorf("%w: this api key is already revoked", database.ErrFailedPrecondition)
+		}
+		updates := map[string]interface{}{
+			"prefix":       prefix,
+			"key_hash":     hash,
+			"last_used_at": nil,
+			"updated_at":   k.now(),
+		}
+		stmt := tx.WithContext(ctx).
+			Table(adminAPIKeyTable).
+			Where("id = ?", apiKeyID)
+
+		return stmt.Updates(updates).Error
+	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
+	}
+	return dbError(err)
+}
+
+func (k *adminAPIKey) Revoke(ctx context.Context, adminID, apiKeyID string) error {
+	err := k.db.Transaction(ctx, func(tx *gorm.DB) error {
+		current, err := k.get(ctx, tx, adminID, apiKeyID, "revoked_at")
+		if err != nil {
+			return err
+		}
+		if !current.RevokedAt.IsZero() {
+			return nil // 失効済み
+		}
+		now := k.now()
+		updates := map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}
+		stmt := tx.WithContext(ctx).
+			Table(adminAPIKeyTable).
+			Where("id = ?", apiKeyID)
+
+		return stmt.Updates(updates).Error
+	})
+	return dbError(err)
+}
+
+func (k *adminAPIKey) UpdateLastUsedAt(ctx context.Context, apiKeyID string) error {
+	stmt := k.db.WithContext(ctx).
+		Table(adminAPIKeyTable).
+		Where("id = ?", apiKeyID)
+
+	err := stmt.Update("last_used_at", k.now()).Error
+	return dbError(err)
+}
+
+func (k *adminAPIKey) get(
+	ctx context.Context, tx *gorm.DB, adminID, apiKeyID string, fields ...string,
+) (*entity.AdminAPIKey, error) {
+	var apiKey *entity.AdminAPIKey
+
+	stmt := k.db.
+		Statement(ctx, tx, adminAPIKeyTable, fields...).
+		Where("id = ?", apiKeyID).
+		Where("admin_id = ?", adminID)
+
+	if err := stmt.First(&apiKey).Error; err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
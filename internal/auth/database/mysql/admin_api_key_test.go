@@ -0,0 +1,544 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPIKey(t *testing.T) {
+	t.Parallel()
+	assert.NotNil(t, newAdminAPIKey(nil))
+}
+
+func TestAdminAPIKey_List(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	apiKeys := make(entity.AdminAPIKeys, 2)
+	apiKeys[0] = fakeAdminAPIKey("api-key-id01", "admin-id", "fm_key01", now())
+	apiKeys[1] = fakeAdminAPIKey("api-key-id02", "admin-id", "fm_key02", now().Add(time.Hour))
+	err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&apiKeys).Error
+	require.NoError(t, err)
+
+	type args struct {
+		adminID string
+	}
+	type want struct {
+		apiKeys entity.AdminAPIKeys
+		err     error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "admin-id",
+			},
+			want: want{
+				apiKeys: apiKeys,
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			actual, err := db.List(ctx, tt.args.adminID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.apiKeys, actual)
+		})
+	}
+}
+
+func TestAdminAPIKey_Get(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+	err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+	require.NoError(t, err)
+
+	type args struct {
+		adminID  string
+		apiKeyID string
+	}
+	type want struct {
+		apiKey *entity.AdminAPIKey
+		err    error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				apiKey: k,
+				err:    nil,
+			},
+		},
+		{
+			name:  "other admin",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:  "other-id",
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				apiKey: nil,
+				err:    database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			actual, err := db.Get(ctx, tt.args.adminID, tt.args.apiKeyID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.apiKey, actual)
+		})
+	}
+}
+
+func TestAdminAPIKey_GetByHash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+	err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+	require.NoError(t, err)
+
+	type args struct {
+		hash []byte
+	}
+	type want struct {
+		apiKey *entity.AdminAPIKey
+		err    error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				hash: entity.HashAPIKey("fm_key"),
+			},
+			want: want{
+				apiKey: k,
+				err:    nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				hash: entity.HashAPIKey("fm_unknown"),
+			},
+			want: want{
+				apiKey: nil,
+				err:    database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			actual, err := db.GetByHash(ctx, tt.args.hash)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.apiKey, actual)
+		})
+	}
+}
+
+func TestAdminAPIKey_Create(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		apiKey *entity.AdminAPIKey
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				apiKey: fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				apiKey: fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now()),
+			},
+			want: want{
+				err: database.ErrAlreadyExists,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			err = db.Create(ctx, tt.args.apiKey)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminAPIKey_Rotate(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		adminID  string
+		apiKeyID string
+		prefix   string
+		hash     []byte
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+				prefix:   "fm_rotated",
+				hash:     entity.HashAPIKey("fm_rotated"),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already revoked",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				k.RevokedAt = now()
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+				prefix:   "fm_rotated",
+				hash:     entity.HashAPIKey("fm_rotated"),
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+				prefix:   "fm_rotated",
+				hash:     entity.HashAPIKey("fm_rotated"),
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			err = db.Rotate(ctx, tt.args.adminID, tt.args.apiKeyID, tt.args.prefix, tt.args.hash)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminAPIKey_Revoke(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		adminID  string
+		apiKeyID string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already revoked",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				k.RevokedAt = now()
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:  "admin-id",
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			err = db.Revoke(ctx, tt.args.adminID, tt.args.apiKeyID)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminAPIKey_UpdateLastUsedAt(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		apiKeyID string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				k := fakeAdminAPIKey("api-key-id", "admin-id", "fm_key", now())
+				err = db.DB.WithContext(ctx).Table(adminAPIKeyTable).Create(&k).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				apiKeyID: "api-key-id",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminAPIKey{db: db, now: now}
+			err = db.UpdateLastUsedAt(ctx, tt.args.apiKeyID)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func fakeAdminAPIKey(apiKeyID, adminID, key string, now time.Time) *entity.AdminAPIKey {
+	return &entity.AdminAPIKey{
+		ID:         apiKeyID,
+		AdminID:    adminID,
+		Name:       "batch",
+		Prefix:     key,
+		KeyHash:    entity.HashAPIKey(key),
+		Scopes:     []entity.APIKeyScope{entity.APIKeyScopePasskeyRead},
+		AllowedIPs: []string{"192.0.2.0/24"},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
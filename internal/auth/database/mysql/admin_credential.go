@@ -0,0 +1,141 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mysql"
+	"gorm.io/gorm"
+)
+
+const adminCredentialTable = "admin_credentials"
+
+type adminCredential struct {
+	db  *mysql.Client
+	now func() time.Time
+}
+
+func newAdminCredential(db *mysql.Client) database.AdminCredential {
+	return &adminCredential{
+		db:  db,
+		now: jst.Now,
+	}
+}
+
+func (c *adminCredential) List(ctx context.Context, adminID string, fields ...string) (entity.AdminCredentials, error) {
+	var credentials entity.AdminCredentials
+
+	stmt := c.db.
+		Statement(ctx, c.db.DB, adminCredentialTable, fields...).
+		Where("admin_id = ?", adminID).
+		Order("created_at ASC")
+
+	err := stmt.Find(&credentials).Error
+	return credentials, dbError(err)
+}
+
+func (c *adminCredential) Get(
+	ctx context.Context, adminID, credentialID string, fields ...string,
+) (*entity.AdminCredential, error) {
+	var credential *entity.AdminCredential
+
+	stmt := c.db.
+		Statement(ctx, c.db.DB, adminCredentialTable, fields...).
+		Where("id = ?", credentialID).
+		Where("admin_id = ?", adminID)
+
+	if err := stmt.First(&credential).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return credential, nil
+}
+
+func (c *adminCredential) GetByCredentialID(
+	ctx context.Context, credentialID []byte, fields ...string,
+) (*entity.AdminCredential, error) {
+	var credential *entity.AdminCredential
+
+	stmt := c.db.
+		Statement(ctx, c.db.DB, adminCredentialTable, fields...).
+		Where("credential_id = ?", credentialID)
+
+	if err := stmt.First(&credential).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return credential, nil
+}
+
+func (c *adminCredential) Create(ctx context.Context, credential *entity.AdminCredential) error {
+	now := c.now()
+	credential.CreatedAt, credential.UpdatedAt = now, now
+
+	err := c.db.WithContext(ctx).Table(adminCredentialTable).Create(&credential).Error
+	return dbError(err)
+}
+
+func (c *adminCredential) UpdateName(ctx context.Context, adminID, credentialID, name string) error {
+	updates := map[string]interface{}{
+		"name":       name,
+		"updated_at": c.now(),
+	}
+	return c.update(ctx, adminID, credentialID, updates)
+}
+
+func (c *adminCredential) UpdateSignCount(ctx context.Context, credentialID string, signCount uint32) error {
+	now := c.now()
+	updates := map[string]interface{}{
+		"sign_count":   signCount,
+		"last_used_at": now,
+		"updated_at":   now,
+	}
+	stmt := c.db.WithContext(ctx).
+		Table(adminCredentialTable).
+		Where("id = ?", credentialID)
+
+	err := stmt.Updates(updates).Error
+	return dbError(err)
+}
+
+func (c *adminCredential) Delete(ctx context.Context, adminID, credentialID string) error {
+	stmt := c.db.WithContext(ctx).
+		Table(adminCredentialTable).
+		Where("id = ?", credentialID).
+		Where("admin_id = ?", adminID)
+
+	err := stmt.Delete(&entity.AdminCredential{}).Error
+	return dbError(err)
+}
+
+// update - 管理者に紐づくパスキーを更新 (対象が存在しない場合はエラー)
+func (c *adminCredential) update(ctx context.Context, adminID, credentialID string, updates map[string]interface{}) error {
+	err := c.db.Transaction(ctx, func(tx *gorm.DB) error {
+		if _, err := c.get(ctx, tx, adminID, credentialID, "id"); err != nil {
+			return err
+		}
+		stmt := tx.WithContext(ctx).
+			Table(adminCredentialTable).
+			Where("id = ?", credentialID)
+
+		return stmt.Updates(updates).Error
+	})
+	return dbError(err)
+}
+
+func (c *adminCredential) get(
+	ctx context.Context, tx *gorm.DB, adminID, credentialID string, fields ...string,
+) (*entity.AdminCredential, error) {
+	var credential *entity.AdminCredential
+
+	stmt := c.db.
+		Statement(ctx, tx, adminCredentialTable, fields...).
+		Where("id = ?", credentialID).
+		Where("admin_id = ?", adminID)
+
+	if err := stmt.First(&credential).Error; err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
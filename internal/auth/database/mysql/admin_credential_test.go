@@ -0,0 +1,504 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminCredential(t *testing.T) {
+	t.Parallel()
+	assert.NotNil(t, newAdminCredential(nil))
+}
+
+func TestAdminCredential_List(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	credentials := make(entity.AdminCredentials, 2)
+	credentials[0] = fakeAdminCredential("credential-id01", "admin-id", []byte("credential01"), now())
+	credentials[1] = fakeAdminCredential("credential-id02", "admin-id", []byte("credential02"), now().Add(time.Hour))
+	err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&credentials).Error
+	require.NoError(t, err)
+
+	type args struct {
+		adminID string
+	}
+	type want struct {
+		credentials entity.AdminCredentials
+		err         error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "admin-id",
+			},
+			want: want{
+				credentials: credentials,
+				err:         nil,
+			},
+		},
+		{
+			name:  "empty",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "other-id",
+			},
+			want: want{
+				credentials: entity.AdminCredentials{},
+				err:         nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			actual, err := db.List(ctx, tt.args.adminID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.credentials, actual)
+		})
+	}
+}
+
+func TestAdminCredential_Get(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	c := fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now())
+	err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+	require.NoError(t, err)
+
+	type args struct {
+		adminID      string
+		credentialID string
+	}
+	type want struct {
+		credential *entity.AdminCredential
+		err        error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:      "admin-id",
+				credentialID: "credential-id",
+			},
+			want: want{
+				credential: c,
+				err:        nil,
+			},
+		},
+		{
+			name:  "other admin",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:      "other-id",
+				credentialID: "credential-id",
+			},
+			want: want{
+				credential: nil,
+				err:        database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			actual, err := db.Get(ctx, tt.args.adminID, tt.args.credentialID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.credential, actual)
+		})
+	}
+}
+
+func TestAdminCredential_GetByCredentialID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	a := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&a).Error
+	require.NoError(t, err)
+	c := fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now())
+	err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+	require.NoError(t, err)
+
+	type args struct {
+		credentialID []byte
+	}
+	type want struct {
+		credential *entity.AdminCredential
+		err        error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				credentialID: []byte("credential"),
+			},
+			want: want{
+				credential: c,
+				err:        nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				credentialID: []byte("unknown"),
+			},
+			want: want{
+				credential: nil,
+				err:        database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			actual, err := db.GetByCredentialID(ctx, tt.args.credentialID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.credential, actual)
+		})
+	}
+}
+
+func TestAdminCredential_Create(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		credential *entity.AdminCredential
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				credential: fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				c := fakeAdminCredential("other-id", "admin-id", []byte("credential"), now())
+				err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				credential: fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now()),
+			},
+			want: want{
+				err: database.ErrAlreadyExists,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			err = db.Create(ctx, tt.args.credential)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminCredential_UpdateName(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		adminID      string
+		credentialID string
+		name         string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				c := fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now())
+				err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:      "admin-id",
+				credentialID: "credential-id",
+				name:         "MacBook",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID:      "admin-id",
+				credentialID: "credential-id",
+				name:         "MacBook",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			err = db.UpdateName(ctx, tt.args.adminID, tt.args.credentialID, tt.args.name)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminCredential_UpdateSignCount(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		credentialID string
+		signCount    uint32
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				c := fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now())
+				err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				credentialID: "credential-id",
+				signCount:    2,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			err = db.UpdateSignCount(ctx, tt.args.credentialID, tt.args.signCount)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestAdminCredential_Delete(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		adminID      string
+		credentialID string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				c := fakeAdminCredential("credential-id", "admin-id", []byte("credential"), now())
+				err = db.DB.WithContext(ctx).Table(adminCredentialTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID:      "admin-id",
+				credentialID: "credential-id",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &adminCredential{db: db, now: now}
+			err = db.Delete(ctx, tt.args.adminID, tt.args.credentialID)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func fakeAdminCredential(credentialID, adminID string, id []byte, now time.Time) *entity.AdminCredential {
+	return &entity.AdminCredential{
+		ID:           credentialID,
+		AdminID:      adminID,
+		CredentialID: id,
+		Name:         "iPhone",
+		PublicKey:    []byte("public-key"),
+		SignCount:    0,
+		Transports:   []string{"internal", "hybrid"},
+		AAGUID:       make([]byte, 16),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
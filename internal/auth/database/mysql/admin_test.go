@@ -2,19 +2,93 @@ package mysql
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/and-period/furumane/internal/auth/database"
 	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/encryption"
 	"github.com/and-period/furumane/pkg/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestAdmin(t *testing.T) {
 	t.Parallel()
-	assert.NotNil(t, newAdmin(nil))
+	assert.NotNil(t, newAdmin(nil, nil))
+}
+
+func TestAdmin_MultiGet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	admins := make(entity.Admins, 2)
+	admins[0] = fakeAdmin("admin-id01", "cognito-id01", "test01@example.com", now())
+	admins[1] = fakeAdmin("admin-id02", "cognito-id02", "test02@example.com", now())
+	err = db.DB.WithContext(ctx).Create(&admins).Error
+	require.NoError(t, err)
+
+	type args struct {
+		adminIDs []string
+	}
+	type want struct {
+		admins entity.Admins
+		err    error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminIDs: []string{"admin-id01", "admin-id02", "admin-id03"},
+			},
+			want: want{
+				admins: admins,
+				err:    nil,
+			},
+		},
+		{
+			name:  "empty",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminIDs: []string{"admin-id03"},
+			},
+			want: want{
+				admins: entity.Admins{},
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &admin{db: db, cipher: testCipher, now: now}
+			actual, err := db.MultiGet(ctx, tt.args.adminIDs)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.ElementsMatch(t, tt.want.admins, actual)
+		})
+	}
 }
 
 func TestAdmin_Get(t *testing.T) {
@@ -78,7 +152,7 @@ func TestAdmin_Get(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
+			db := &admin{db: db, cipher: testCipher, now: now}
 			actual, err := db.Get(ctx, tt.args.adminID)
 			assert.ErrorIs(t, err, tt.want.err)
 			assert.Equal(t, tt.want.admin, actual)
@@ -147,7 +221,7 @@ func TestAdmin_GetByCognitoID(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
+			db := &admin{db: db, cipher: testCipher, now: now}
 			actual, err := db.GetByCognitoID(ctx, tt.args.cognitoID)
 			assert.ErrorIs(t, err, tt.want.err)
 			assert.Equal(t, tt.want.admin, actual)
@@ -195,6 +269,17 @@ func TestAdmin_GetByEmail(t *testing.T) {
 				err:   nil,
 			},
 		},
+		{
+			name:  "success ignoring case",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				email: "TEST@example.com",
+			},
+			want: want{
+				admin: a,
+				err:   nil,
+			},
+		},
 		{
 			name:  "not found",
 			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
@@ -216,7 +301,7 @@ func TestAdmin_GetByEmail(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
+			db := &admin{db: db, cipher: testCipher, now: now}
 			actual, err := db.GetByEmail(ctx, tt.args.email)
 			assert.ErrorIs(t, err, tt.want.err)
 			assert.Equal(t, tt.want.admin, actual)
@@ -298,9 +383,23 @@ func TestAdmin_Create(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
+			db := &admin{db: db, cipher: testCipher, now: now}
 			err = db.Create(ctx, tt.args.admin, tt.args.fn)
 			assert.ErrorIs(t, err, tt.want.err)
+			if err != nil {
+				return
+			}
+			// 個人情報は暗号化して保存する
+			var row *entity.Admin
+			err = dbClient.DB.WithContext(ctx).Table(adminTable).Where("id = ?", tt.args.admin.ID).First(&row).Error
+			require.NoError(t, err)
+			assert.True(t, encryption.IsEncrypted(row.Email))
+			assert.True(t, encryption.IsEncrypted(row.PhoneNumber))
+			assert.Equal(t, testCipher.BlindIndex("test@example.com"), row.EmailIndex)
+			actual, err := db.GetByEmail(ctx, "test@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, tt.args.admin.Email, actual.Email)
+			assert.Equal(t, tt.args.admin.PhoneNumber, actual.PhoneNumber)
 		})
 	}
 }
@@ -314,6 +413,7 @@ func TestAdmin_UpdateEmail(t *testing.T) {
 	type args struct {
 		adminID string
 		email   string
+		version int64
 	}
 	type want struct {
 		err error
@@ -339,6 +439,50 @@ func TestAdmin_UpdateEmail(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "success with version",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				email:   "test@example.com",
+				version: 1,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "version mismatch",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				admin.Version = 2
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				email:   "test@example.com",
+				version: 1,
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "admin-id",
+				email:   "test@example.com",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -351,9 +495,15 @@ func TestAdmin_UpdateEmail(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
-			err = db.UpdateEmail(ctx, tt.args.adminID, tt.args.email)
+			db := &admin{db: db, cipher: testCipher, now: now}
+			err = db.UpdateEmail(ctx, tt.args.adminID, tt.args.email, tt.args.version)
 			assert.ErrorIs(t, err, tt.want.err)
+			if err != nil {
+				return
+			}
+			current, err := db.Get(ctx, tt.args.adminID, "version")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), current.Version)
 		})
 	}
 }
@@ -366,6 +516,7 @@ func TestAdmin_UpdateVerifiedAt(t *testing.T) {
 
 	type args struct {
 		adminID string
+		version int64
 	}
 	type want struct {
 		err error
@@ -390,6 +541,47 @@ func TestAdmin_UpdateVerifiedAt(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "success with version",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				version: 1,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "version mismatch",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				admin.Version = 2
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				version: 1,
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "admin-id",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -402,9 +594,15 @@ func TestAdmin_UpdateVerifiedAt(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
-			err = db.UpdateVerifiedAt(ctx, tt.args.adminID)
+			db := &admin{db: db, cipher: testCipher, now: now}
+			err = db.UpdateVerifiedAt(ctx, tt.args.adminID, tt.args.version)
 			assert.ErrorIs(t, err, tt.want.err)
+			if err != nil {
+				return
+			}
+			current, err := db.Get(ctx, tt.args.adminID, "version")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), current.Version)
 		})
 	}
 }
@@ -417,6 +615,7 @@ func TestAdmin_Delete(t *testing.T) {
 
 	type args struct {
 		adminID string
+		version int64
 		fn      func(ctx context.Context) error
 	}
 	type want struct {
@@ -462,6 +661,56 @@ func TestAdmin_Delete(t *testing.T) {
 				err: database.ErrUnknown,
 			},
 		},
+		{
+			name: "success with version",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				fn: func(ctx context.Context) error {
+					return nil
+				},
+				version: 1,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "version mismatch",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				admin.Version = 2
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				adminID: "admin-id",
+				fn: func(ctx context.Context) error {
+					return nil
+				},
+				version: 1,
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				adminID: "admin-id",
+				fn: func(ctx context.Context) error {
+					return nil
+				},
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -474,20 +723,84 @@ func TestAdmin_Delete(t *testing.T) {
 
 			tt.setup(ctx, t, db)
 
-			db := &admin{db: db, now: now}
-			err = db.Delete(ctx, tt.args.adminID, tt.args.fn)
+			db := &admin{db: db, cipher: testCipher, now: now}
+			err = db.Delete(ctx, tt.args.adminID, tt.args.version, tt.args.fn)
 			assert.ErrorIs(t, err, tt.want.err)
 		})
 	}
 }
 
+func TestEncryptAdmins(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	// 暗号化の導入前に保存された管理者
+	plain := fakeAdmin("admin-id01", "cognito-id01", "test01@example.com", now())
+	plain.EmailIndex = ""
+	deleted := fakeAdmin("admin-id02", "cognito-id02", "test02@example.com", now())
+	deleted.EmailIndex = ""
+	deleted.DeletedAt = gorm.DeletedAt{Time: now(), Valid: true}
+	err = dbClient.DB.WithContext(ctx).Create(entity.Admins{plain, deleted}).Error
+	require.NoError(t, err)
+	// ローテーション前のマスターキーで暗号化された管理者
+	err = (&admin{db: dbClient, cipher: testCipher, now: now}).
+		Create(ctx, fakeAdmin("admin-id03", "cognito-id03", "test03@example.com", now()), func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	rotated, err := newTestCipher("rotated-key", "test-key")
+	require.NoError(t, err)
+	// ローテーション後のマスターキーで暗号化された管理者
+	db := &admin{db: dbClient, cipher: rotated, now: now}
+	err = db.Create(ctx, fakeAdmin("admin-id04", "cognito-id04", "test04@example.com", now()), func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	total, err := EncryptAdmins(ctx, dbClient, rotated, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	var rows entity.Admins
+	err = dbClient.DB.WithContext(ctx).Table(adminTable).Unscoped().Order("id").Find(&rows).Error
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	for i, row := range rows {
+		email := fmt.Sprintf("test%02d@example.com", i+1)
+		assert.False(t, rotated.NeedsReencryption(row.Email), row.ID)
+		assert.False(t, rotated.NeedsReencryption(row.PhoneNumber), row.ID)
+		assert.Equal(t, rotated.BlindIndex(email), row.EmailIndex, row.ID)
+		if row.ID == deleted.ID {
+			continue
+		}
+		actual, err := db.GetByEmail(ctx, email)
+		require.NoError(t, err, row.ID)
+		assert.Equal(t, email, actual.Email)
+		assert.Equal(t, "09012341234", actual.PhoneNumber)
+	}
+
+	// 再実行しても変更しない
+	total, err = EncryptAdmins(ctx, dbClient, rotated, 100)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	_, err = EncryptAdmins(ctx, dbClient, rotated, 0)
+	assert.ErrorIs(t, err, database.ErrInvalidArgument)
+}
+
 func fakeAdmin(adminID, cognitoID, email string, now time.Time) *entity.Admin {
 	return &entity.Admin{
 		ID:           adminID,
 		CognitoID:    cognitoID,
 		ProviderType: entity.ProviderTypeEmail,
 		Email:        email,
+		EmailIndex:   testCipher.BlindIndex(email),
 		PhoneNumber:  "09012341234",
+		Version:      1,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		VerifiedAt:   now,
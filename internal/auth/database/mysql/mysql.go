@@ -2,18 +2,57 @@ package mysql
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/encryption"
 	"github.com/and-period/furumane/pkg/mysql"
 	gmysql "github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
-func NewDatabase(db *mysql.Client) *database.Database {
+// NewDatabase - MySQLによるデータベースの生成
+// cipherは個人情報の暗号化に使用する
+func NewDatabase(db *mysql.Client, cipher *encryption.Cipher) *database.Database {
 	return &database.Database{
-		Admin: newAdmin(db),
+		Transaction:        newTransaction(db),
+		Admin:              newAdmin(db, cipher),
+		AdminAPIKey:        newAdminAPIKey(db),
+		AdminCredential:    newAdminCredential(db),
+		PasskeyChallenge:   newPasskeyChallenge(db),
+		Organization:       newOrganization(db),
+		OrganizationMember: newOrganizationMember(db),
+	}
+}
+
+// Models - テーブル名とエンティティの対応 (スキーマの検証で使用)
+func Models() map[string]interface{} {
+	return map[string]interface{}{
+		adminTable:              &entity.Admin{},
+		adminAPIKeyTable:        &entity.AdminAPIKey{},
+		adminCredentialTable:    &entity.AdminCredential{},
+		passkeyChallengeTable:   &entity.PasskeyChallenge{},
+		organizationTable:       &entity.Organization{},
+		organizationMemberTable: &entity.OrganizationMember{},
+	}
+}
+
+// errEmptyOrganization - 組織IDを指定せずに組織のデータを参照・更新しようとした
+var errEmptyOrganization = fmt.Errorf("%w: organization id is required", gorm.ErrMissingWhereClause)
+
+// withOrganization - 組織によるテナント分離
+// 組織IDが未指定の場合は条件なしで実行せずにエラーとし、他の組織のデータを参照・更新させない
+func withOrganization(organizationID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if organizationID == "" {
+			_ = db.AddError(errEmptyOrganization)
+			return db
+		}
+		return db.Where("organization_id = ?", organizationID)
 	}
 }
 
@@ -29,13 +68,16 @@ func dbError(err error) error {
 		return fmt.Errorf("%w: %s", database.ErrDeadlineExceeded, err.Error())
 	}
 
-	//nolint:gocritic
-	switch err := err.(type) {
-	case *gmysql.MySQLError:
-		if err.Number == 1062 {
-			return fmt.Errorf("%w: %s", database.ErrAlreadyExists, err)
-		}
-		return fmt.Errorf("%w: %s", database.ErrInternal, err)
+	var merr *gmysql.MySQLError
+	if errors.As(err, &merr) {
+		return mysqlError(err, merr)
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, gmysql.ErrInvalidConn) {
+		return fmt.Errorf("%w: %s", database.ErrUnavailable, err)
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) {
+		return fmt.Errorf("%w: %s", database.ErrUnavailable, err)
 	}
 
 	switch {
@@ -47,7 +89,8 @@ func dbError(err error) error {
 		errors.Is(err, gorm.ErrInvalidValueOfLength),
 		errors.Is(err, gorm.ErrMissingWhereClause),
 		errors.Is(err, gorm.ErrModelValueRequired),
-		errors.Is(err, gorm.ErrPrimaryKeyRequired):
+		errors.Is(err, gorm.ErrPrimaryKeyRequired),
+		errors.Is(err, mysql.ErrInvalidKeyset):
 		return fmt.Errorf("%w: %s", database.ErrInvalidArgument, err)
 	case errors.Is(err, gorm.ErrRecordNotFound):
 		return fmt.Errorf("%w: %s", database.ErrNotFound, err)
@@ -55,9 +98,39 @@ func dbError(err error) error {
 		errors.Is(err, gorm.ErrInvalidDB),
 		errors.Is(err, gorm.ErrRegistered),
 		errors.Is(err, gorm.ErrUnsupportedDriver),
-		errors.Is(err, gorm.ErrUnsupportedRelation):
+		errors.Is(err, gorm.ErrUnsupportedRelation),
+		errors.Is(err, encryption.ErrInvalidCiphertext),
+		errors.Is(err, encryption.ErrInvalidKey),
+		errors.Is(err, encryption.ErrKeyNotFound):
 		return fmt.Errorf("%w: %s", database.ErrInternal, err)
 	default:
 		return fmt.Errorf("%w: %s", database.ErrUnknown, err)
 	}
 }
+
+// mysqlError - MySQLのエラー番号によるエラーの変換
+// @see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+func mysqlError(err error, merr *gmysql.MySQLError) error {
+	switch merr.Number {
+	case 1062: // ER_DUP_ENTRY
+		return fmt.Errorf("%w: %s", database.ErrAlreadyExists, err)
+	case 1451, // ER_ROW_IS_REFERENCED_2
+		1452: // ER_NO_REFERENCED_ROW_2
+		return fmt.Errorf("%w: %s", database.ErrFailedPrecondition, err)
+	case 1264, // ER_WARN_DATA_OUT_OF_RANGE
+		1406, // ER_DATA_TOO_LONG
+		1690: // ER_DATA_OUT_OF_RANGE
+		return fmt.Errorf("%w: %s", database.ErrInvalidArgument, err)
+	case mysql.ErrNumberDeadlock, mysql.ErrNumberLockWaitTimeout:
+		// 外側のトランザクションで再試行を判定できるよう、元のエラーも保持する
+		return fmt.Errorf("%w: %w", database.ErrUnavailable, err)
+	case 1040, // ER_CON_COUNT_ERROR
+		1053, // ER_SERVER_SHUTDOWN
+		1290, // ER_OPTION_PREVENTS_STATEMENT (--read-only)
+		1792, // ER_CANT_EXECUTE_IN_READ_ONLY_TRANSACTION
+		1836: // ER_READ_ONLY_MODE
+		return fmt.Errorf("%w: %s", database.ErrUnavailable, err)
+	default:
+		return fmt.Errorf("%w: %s", database.ErrInternal, err)
+	}
+}
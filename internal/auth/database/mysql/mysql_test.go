@@ -1,13 +1,18 @@
 package mysql
 
 import (
+	"bytes"
 	"context"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/encryption"
 	"github.com/and-period/furumane/pkg/mysql"
 	gmysql "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
@@ -16,8 +21,9 @@ import (
 )
 
 var (
-	dbClient *mysql.Client
-	current  = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	dbClient   *mysql.Client
+	testCipher *encryption.Cipher
+	current    = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
 func TestMain(m *testing.M) {
@@ -29,6 +35,12 @@ func TestMain(m *testing.M) {
 	}
 	dbClient = client
 
+	cipher, err := newTestCipher("test-key")
+	if err != nil {
+		panic(err)
+	}
+	testCipher = cipher
+
 	os.Exit(m.Run())
 }
 
@@ -46,10 +58,28 @@ func newTestDBClient() (*mysql.Client, error) {
 	return mysql.NewClient(params)
 }
 
+// newTestCipher - テスト用の暗号化クライアントの生成 (ブラインドインデックスの鍵はマスターキーによらず共通)
+func newTestCipher(primary string, keyIDs ...string) (*encryption.Cipher, error) {
+	keys := map[string][]byte{primary: bytes.Repeat([]byte(primary[len(primary)-1:]), 32)}
+	for _, keyID := range keyIDs {
+		keys[keyID] = bytes.Repeat([]byte(keyID[len(keyID)-1:]), 32)
+	}
+	keyring, err := encryption.NewKeyring(primary, keys)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.NewCipher(keyring, bytes.Repeat([]byte("i"), 32))
+}
+
 func deleteAll(ctx context.Context) error {
 	tables := []string{
 		// テストに対応したテーブルから追記(削除順)
+		organizationMemberTable,
+		organizationTable,
+		adminAPIKeyTable,
+		adminCredentialTable,
 		adminTable,
+		passkeyChallengeTable,
 	}
 	if err := dbClient.DB.Exec("SET foreign_key_checks = 0").Error; err != nil {
 		return err
@@ -74,7 +104,18 @@ func delete(ctx context.Context, tables ...string) error {
 
 func TestDatabase(t *testing.T) {
 	t.Parallel()
-	require.NotNil(t, NewDatabase(nil))
+	require.NotNil(t, NewDatabase(nil, nil))
+}
+
+func TestModels(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	diffs, err := mysql.NewMigrator(dbClient, nil).Verify(ctx, Models())
+	require.NoError(t, err)
+	for _, diff := range diffs {
+		assert.Equal(t, mysql.SchemaDiffUnmappedColumn, diff.Kind, "table=%s, column=%s", diff.Table, diff.Column)
+	}
 }
 
 func TestDBError(t *testing.T) {
@@ -104,6 +145,56 @@ func TestDBError(t *testing.T) {
 			err:    &gmysql.MySQLError{Number: 1062},
 			expect: database.ErrAlreadyExists,
 		},
+		{
+			name:   "mysql foreign key violation",
+			err:    &gmysql.MySQLError{Number: 1452},
+			expect: database.ErrFailedPrecondition,
+		},
+		{
+			name:   "mysql row is referenced",
+			err:    &gmysql.MySQLError{Number: 1451},
+			expect: database.ErrFailedPrecondition,
+		},
+		{
+			name:   "mysql data too long",
+			err:    &gmysql.MySQLError{Number: 1406},
+			expect: database.ErrInvalidArgument,
+		},
+		{
+			name:   "mysql out of range",
+			err:    &gmysql.MySQLError{Number: 1264},
+			expect: database.ErrInvalidArgument,
+		},
+		{
+			name:   "mysql deadlock",
+			err:    &gmysql.MySQLError{Number: 1213},
+			expect: database.ErrUnavailable,
+		},
+		{
+			name:   "mysql read only",
+			err:    &gmysql.MySQLError{Number: 1290},
+			expect: database.ErrUnavailable,
+		},
+		{
+			name:   "wrapped mysql error",
+			err:    fmt.Errorf("wrapped: %w", &gmysql.MySQLError{Number: 1062}),
+			expect: database.ErrAlreadyExists,
+		},
+		{
+			name:   "bad connection",
+			err:    driver.ErrBadConn,
+			expect: database.ErrUnavailable,
+		},
+		{
+			name:   "invalid connection",
+			err:    gmysql.ErrInvalidConn,
+			expect: database.ErrUnavailable,
+		},
+		{
+			name:   "network error",
+			err:    &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expect: database.ErrUnavailable,
+		},
 		{
 			name:   "other mysql error",
 			err:    &gmysql.MySQLError{},
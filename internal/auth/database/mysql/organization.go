@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mysql"
+)
+
+const organizationTable = "organizations"
+
+type organization struct {
+	db  *mysql.Client
+	now func() time.Time
+}
+
+func newOrganization(db *mysql.Client) database.Organization {
+	return &organization{
+		db:  db,
+		now: jst.Now,
+	}
+}
+
+func (o *organization) MultiGet(
+	ctx context.Context, organizationIDs []string, fields ...string,
+) (entity.Organizations, error) {
+	var organizations entity.Organizations
+
+	stmt := o.db.
+		Statement(ctx, o.db.DB, organizationTable, fields...).
+		Where("id IN (?)", organizationIDs)
+
+	if err := stmt.Find(&organizations).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return organizations, nil
+}
+
+func (o *organization) Get(ctx context.Context, organizationID string, fields ...string) (*entity.Organization, error) {
+	var organization *entity.Organization
+
+	stmt := o.db.
+		Statement(ctx, o.db.DB, organizationTable, fields...).
+		Where("id = ?", organizationID)
+
+	if err := stmt.First(&organization).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return organization, nil
+}
+
+func (o *organization) Create(ctx context.Context, organization *entity.Organization) error {
+	now := o.now()
+	organization.CreatedAt, organization.UpdatedAt = now, now
+
+	err := o.db.WithContext(ctx).Table(organizationTable).Create(&organization).Error
+	return dbError(err)
+}
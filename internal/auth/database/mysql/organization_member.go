@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const organizationMemberTable = "organization_members"
+
+type organizationMember struct {
+	db  *mysql.Client
+	now func() time.Time
+}
+
+func newOrganizationMember(db *mysql.Client) database.OrganizationMember {
+	return &organizationMember{
+		db:  db,
+		now: jst.Now,
+	}
+}
+
+// List - 組織のメンバー一覧の取得
+// 次ページが存在する場合は、次ページの取得に使用するソートキーの値を返す
+func (m *organizationMember) List(
+	ctx context.Context, params *database.ListOrganizationMembersParams, fields ...string,
+) (entity.OrganizationMembers, []interface{}, error) {
+	var members entity.OrganizationMembers
+
+	orders := params.Orders
+	if len(orders) == 0 {
+		orders = []*mysql.Order{{Column: "created_at"}}
+	}
+	keyset := &mysql.Keyset{
+		Orders:     orders,
+		PrimaryKey: "admin_id",
+		After:      params.Cursor,
+		Limit:      params.Limit,
+	}
+	stmt := m.db.
+		Statement(ctx, m.db.DB, organizationMemberTable, fields...).
+		Scopes(withOrganization(params.OrganizationID), m.db.Paginate(keyset))
+
+	if err := stmt.Find(&members).Error; err != nil {
+		return nil, nil, dbError(err)
+	}
+	next, err := m.db.NextKeyset(ctx, keyset, &members)
+	if err != nil {
+		return nil, nil, dbError(err)
+	}
+	return members, next, nil
+}
+
+func (m *organizationMember) ListByAdminID(
+	ctx context.Context, adminID string, fields ...string,
+) (entity.OrganizationMembers, error) {
+	var members entity.OrganizationMembers
+
+	stmt := m.db.
+		Statement(ctx, m.db.DB, organizationMemberTable, fields...).
+		Where("admin_id = ?", adminID).
+		Order("created_at ASC")
+
+	err := stmt.Find(&members).Error
+	return members, dbError(err)
+}
+
+func (m *organizationMember) Get(
+	ctx context.Context, organizationID, adminID string, fields ...string,
+) (*entity.OrganizationMember, error) {
+	member, err := m.get(ctx, m.db.DB, organizationID, adminID, fields...)
+	return member, dbError(err)
+}
+
+func (m *organizationMember) Create(ctx context.Context, member *entity.OrganizationMember) error {
+	if member.OrganizationID == "" {
+		return dbError(errEmptyOrganization)
+	}
+	now := m.now()
+	member.CreatedAt, member.UpdatedAt = now, now
+
+	err := m.db.WithContext(ctx).Table(organizationMemberTable).Create(&member).Error
+	return dbError(err)
+}
+
+func (m *organizationMember) UpdateRole(
+	ctx context.Context, organizationID, adminID string, role entity.OrganizationRole,
+) error {
+	err := m.db.Transaction(ctx, func(tx *gorm.DB) error {
+		current, err := m.get(ctx, tx, organizationID, adminID, "role")
+		if err != nil {
+			return err
+		}
+		if current.Role == entity.OrganizationRoleOwner && role != entity.OrganizationRoleOwner {
+			if err := m.ensureOtherOwner(ctx, tx, organizationID); err != nil {
+				return err
+			}
+		}
+		updates := map[string]interface{}{
+			"role":       role,
+			"updated_at": m.now(),
+		}
+		stmt := tx.WithContext(ctx).
+			Table(organizationMemberTable).
+			Scopes(withOrganization(organizationID)).
+			Where("admin_id = ?", adminID)
+
+		return stmt.Updates(updates).Error
+	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
+	}
+	return dbError(err)
+}
+
+func (m *organizationMember) Delete(ctx context.Context, organizationID, adminID string) error {
+	err := m.db.Transaction(ctx, func(tx *gorm.DB) error {
+		current, err := m.get(ctx, tx, organizationID, adminID, "role")
+		if err != nil {
+			return err
+		}
+		if current.Role == entity.OrganizationRoleOwner {
+			if err := m.ensureOtherOwner(ctx, tx, organizationID); err != nil {
+				return err
+			}
+		}
+		stmt := tx.WithContext(ctx).
+			Table(organizationMemberTable).
+			Scopes(withOrganization(organizationID)).
+			Where("admin_id = ?", adminID)
+
+		return stmt.Delete(&entity.OrganizationMember{}).Error
+	})
+	if errors.Is(err, database.ErrFailedPrecondition) {
+		return err
+	}
+	return dbError(err)
+}
+
+// ensureOtherOwner - オーナーが不在にならないよう、他にオーナーが存在することを確認
+// 同時に複数のオーナーが降格・削除されることを防ぐため、オーナーの行をロックする
+func (m *organizationMember) ensureOtherOwner(ctx context.Context, tx *gorm.DB, organizationID string) error {
+	var owners entity.OrganizationMembers
+
+	stmt := m.db.
+		Statement(ctx, tx, organizationMemberTable, "admin_id").
+		Scopes(withOrganization(organizationID)).
+		Where("role = ?", entity.OrganizationRoleOwner).
+		Clauses(clause.Locking{Strength: "UPDATE"})
+
+	if err := stmt.Find(&owners).Error; err != nil {
+		return err
+	}
+	if len(owners) <= 1 {
+		return fmt.Errorf("%w: organization must have at least one owner", database.ErrFailedPrecondition)
+	}
+	return nil
+}
+
+func (m *organizationMember) get(
+	ctx context.Context, tx *gorm.DB, organizationID, adminID string, fields ...string,
+) (*entity.OrganizationMember, error) {
+	var member *entity.OrganizationMember
+
+	stmt := m.db.
+		Statement(ctx, tx, organizationMemberTable, fields...).
+		Scopes(withOrganization(organizationID)).
+		Where("admin_id = ?", adminID)
+
+	if err := stmt.First(&member).Error; err != nil {
+		return nil, err
+	}
+	return member, nil
+}
@@ -0,0 +1,561 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationMember(t *testing.T) {
+	t.Parallel()
+	assert.NotNil(t, newOrganizationMember(nil))
+}
+
+// setupOrganizationMembers - 2つの組織に所属する管理者を登録
+// organization-id01: admin-id01 (オーナー), admin-id02 (メンバー)
+// organization-id02: admin-id01 (オーナー), admin-id03 (オーナー)
+func setupOrganizationMembers(ctx context.Context, t *testing.T, db *mysql.Client, now time.Time) entity.OrganizationMembers {
+	admins := entity.Admins{
+		fakeAdmin("admin-id01", "cognito-id01", "test01@example.com", now),
+		fakeAdmin("admin-id02", "cognito-id02", "test02@example.com", now),
+		fakeAdmin("admin-id03", "cognito-id03", "test03@example.com", now),
+	}
+	err := db.DB.WithContext(ctx).Create(&admins).Error
+	require.NoError(t, err)
+	organizations := entity.Organizations{
+		fakeOrganization("organization-id01", "402214", now),
+		fakeOrganization("organization-id02", "402231", now),
+	}
+	err = db.DB.WithContext(ctx).Table(organizationTable).Create(&organizations).Error
+	require.NoError(t, err)
+	members := entity.OrganizationMembers{
+		fakeOrganizationMember("organization-id01", "admin-id01", entity.OrganizationRoleOwner, now),
+		fakeOrganizationMember("organization-id01", "admin-id02", entity.OrganizationRoleMember, now.Add(time.Hour)),
+		fakeOrganizationMember("organization-id02", "admin-id01", entity.OrganizationRoleOwner, now),
+		fakeOrganizationMember("organization-id02", "admin-id03", entity.OrganizationRoleOwner, now.Add(time.Hour)),
+	}
+	err = db.DB.WithContext(ctx).Table(organizationMemberTable).Create(&members).Error
+	require.NoError(t, err)
+	return members
+}
+
+func TestOrganizationMember_List(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+	members := setupOrganizationMembers(ctx, t, db, now())
+
+	type args struct {
+		params *database.ListOrganizationMembersParams
+	}
+	type want struct {
+		members entity.OrganizationMembers
+		next    bool
+		err     error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "success",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id01",
+				},
+			},
+			want: want{
+				members: members[:2],
+				next:    false,
+				err:     nil,
+			},
+		},
+		{
+			name: "success with limit",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id01",
+					Limit:          1,
+				},
+			},
+			want: want{
+				members: members[:1],
+				next:    true,
+				err:     nil,
+			},
+		},
+		{
+			name: "success with cursor",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id01",
+					Cursor:         []interface{}{current, "admin-id01"},
+					Limit:          1,
+				},
+			},
+			want: want{
+				members: members[1:2],
+				next:    false,
+				err:     nil,
+			},
+		},
+		{
+			name: "success with descending order",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id01",
+					Orders:         []*mysql.Order{{Column: "created_at", Desc: true}},
+					Limit:          1,
+				},
+			},
+			want: want{
+				members: members[1:2],
+				next:    true,
+				err:     nil,
+			},
+		},
+		{
+			name: "invalid cursor",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "organization-id01",
+					Cursor:         []interface{}{current},
+				},
+			},
+			want: want{
+				members: nil,
+				err:     database.ErrInvalidArgument,
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "other-id",
+				},
+			},
+			want: want{
+				members: entity.OrganizationMembers{},
+				err:     nil,
+			},
+		},
+		{
+			name: "organization id is required",
+			args: args{
+				params: &database.ListOrganizationMembersParams{
+					OrganizationID: "",
+				},
+			},
+			want: want{
+				members: nil,
+				err:     database.ErrInvalidArgument,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db := &organizationMember{db: db, now: now}
+			actual, next, err := db.List(ctx, tt.args.params)
+			assert.ErrorIs(t, err, tt.want.err)
+			if tt.want.err != nil {
+				assert.Empty(t, actual)
+				return
+			}
+			assert.Equal(t, tt.want.members, actual)
+			assert.Equal(t, tt.want.next, next != nil)
+		})
+	}
+}
+
+func TestOrganizationMember_ListByAdminID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+	members := setupOrganizationMembers(ctx, t, db, now())
+
+	db2 := &organizationMember{db: db, now: now}
+	actual, err := db2.ListByAdminID(ctx, "admin-id01")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, entity.OrganizationMembers{members[0], members[2]}, actual)
+
+	actual, err = db2.ListByAdminID(ctx, "other-id")
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestOrganizationMember_Get(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+	members := setupOrganizationMembers(ctx, t, db, now())
+
+	type args struct {
+		organizationID string
+		adminID        string
+	}
+	type want struct {
+		member *entity.OrganizationMember
+		err    error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "success",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id02",
+			},
+			want: want{
+				member: members[1],
+				err:    nil,
+			},
+		},
+		{
+			name: "member of other organization",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id03",
+			},
+			want: want{
+				member: nil,
+				err:    database.ErrNotFound,
+			},
+		},
+		{
+			name: "organization id is required",
+			args: args{
+				organizationID: "",
+				adminID:        "admin-id02",
+			},
+			want: want{
+				member: nil,
+				err:    database.ErrInvalidArgument,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db := &organizationMember{db: db, now: now}
+			actual, err := db.Get(ctx, tt.args.organizationID, tt.args.adminID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.member, actual)
+		})
+	}
+}
+
+func TestOrganizationMember_Create(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		member *entity.OrganizationMember
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				setupOrganizationMembers(ctx, t, db, now())
+			},
+			args: args{
+				member: &entity.OrganizationMember{
+					OrganizationID: "organization-id01",
+					AdminID:        "admin-id03",
+					Role:           entity.OrganizationRoleAdmin,
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				setupOrganizationMembers(ctx, t, db, now())
+			},
+			args: args{
+				member: &entity.OrganizationMember{
+					OrganizationID: "organization-id01",
+					AdminID:        "admin-id02",
+					Role:           entity.OrganizationRoleAdmin,
+				},
+			},
+			want: want{
+				err: database.ErrAlreadyExists,
+			},
+		},
+		{
+			name:  "organization id is required",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				member: &entity.OrganizationMember{AdminID: "admin-id03", Role: entity.OrganizationRoleAdmin},
+			},
+			want: want{
+				err: database.ErrInvalidArgument,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &organizationMember{db: db, now: now}
+			err = db.Create(ctx, tt.args.member)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestOrganizationMember_UpdateRole(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		organizationID string
+		adminID        string
+		role           entity.OrganizationRole
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "success",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id02",
+				role:           entity.OrganizationRoleAdmin,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "demote owner",
+			args: args{
+				organizationID: "organization-id02",
+				adminID:        "admin-id03",
+				role:           entity.OrganizationRoleMember,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "demote last owner",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id01",
+				role:           entity.OrganizationRoleMember,
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name: "member of other organization",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id03",
+				role:           entity.OrganizationRoleMember,
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+		{
+			name: "organization id is required",
+			args: args{
+				organizationID: "",
+				adminID:        "admin-id02",
+				role:           entity.OrganizationRoleAdmin,
+			},
+			want: want{
+				err: database.ErrInvalidArgument,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+			setupOrganizationMembers(ctx, t, db, now())
+
+			db := &organizationMember{db: db, now: now}
+			err = db.UpdateRole(ctx, tt.args.organizationID, tt.args.adminID, tt.args.role)
+			assert.ErrorIs(t, err, tt.want.err)
+			if err != nil {
+				return
+			}
+			member, err := db.Get(ctx, tt.args.organizationID, tt.args.adminID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.args.role, member.Role)
+		})
+	}
+}
+
+func TestOrganizationMember_Delete(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		organizationID string
+		adminID        string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "success",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id02",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "delete owner",
+			args: args{
+				organizationID: "organization-id02",
+				adminID:        "admin-id01",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "delete last owner",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id01",
+			},
+			want: want{
+				err: database.ErrFailedPrecondition,
+			},
+		},
+		{
+			name: "member of other organization",
+			args: args{
+				organizationID: "organization-id01",
+				adminID:        "admin-id03",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+			setupOrganizationMembers(ctx, t, db, now())
+
+			db := &organizationMember{db: db, now: now}
+			err = db.Delete(ctx, tt.args.organizationID, tt.args.adminID)
+			assert.ErrorIs(t, err, tt.want.err)
+			if err != nil {
+				return
+			}
+			_, err = db.Get(ctx, tt.args.organizationID, tt.args.adminID)
+			assert.ErrorIs(t, err, database.ErrNotFound)
+			// 他の組織の所属には影響しない
+			members, err := db.ListByAdminID(ctx, tt.args.adminID)
+			require.NoError(t, err)
+			for _, m := range members {
+				assert.NotEqual(t, tt.args.organizationID, m.OrganizationID)
+			}
+		})
+	}
+}
+
+func fakeOrganizationMember(
+	organizationID, adminID string, role entity.OrganizationRole, now time.Time,
+) *entity.OrganizationMember {
+	return &entity.OrganizationMember{
+		OrganizationID: organizationID,
+		AdminID:        adminID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
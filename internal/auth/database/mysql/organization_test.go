@@ -0,0 +1,236 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganization(t *testing.T) {
+	t.Parallel()
+	assert.NotNil(t, newOrganization(nil))
+}
+
+func TestOrganization_MultiGet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	organizations := make(entity.Organizations, 2)
+	organizations[0] = fakeOrganization("organization-id01", "402214", now())
+	organizations[1] = fakeOrganization("organization-id02", "402231", now())
+	err = db.DB.WithContext(ctx).Table(organizationTable).Create(&organizations).Error
+	require.NoError(t, err)
+
+	type args struct {
+		organizationIDs []string
+	}
+	type want struct {
+		organizations entity.Organizations
+		err           error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				organizationIDs: []string{"organization-id01", "organization-id02", "organization-id03"},
+			},
+			want: want{
+				organizations: organizations,
+				err:           nil,
+			},
+		},
+		{
+			name:  "empty",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				organizationIDs: []string{"organization-id03"},
+			},
+			want: want{
+				organizations: entity.Organizations{},
+				err:           nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &organization{db: db, now: now}
+			actual, err := db.MultiGet(ctx, tt.args.organizationIDs)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.ElementsMatch(t, tt.want.organizations, actual)
+		})
+	}
+}
+
+func TestOrganization_Get(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	err := deleteAll(ctx)
+	require.NoError(t, err)
+
+	o := fakeOrganization("organization-id", "402214", now())
+	err = db.DB.WithContext(ctx).Table(organizationTable).Create(&o).Error
+	require.NoError(t, err)
+
+	type args struct {
+		organizationID string
+	}
+	type want struct {
+		organization *entity.Organization
+		err          error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name:  "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				organizationID: "organization-id",
+			},
+			want: want{
+				organization: o,
+				err:          nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				organizationID: "other-id",
+			},
+			want: want{
+				organization: nil,
+				err:          database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			tt.setup(ctx, t, db)
+
+			db := &organization{db: db, now: now}
+			actual, err := db.Get(ctx, tt.args.organizationID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.organization, actual)
+		})
+	}
+}
+
+func TestOrganization_Create(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		organization *entity.Organization
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				organization: fakeOrganization("organization-id", "402214", now()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+				err := db.DB.WithContext(ctx).Create(&admin).Error
+				require.NoError(t, err)
+				o := fakeOrganization("other-id", "402214", now())
+				err = db.DB.WithContext(ctx).Table(organizationTable).Create(&o).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				organization: fakeOrganization("organization-id", "402214", now()),
+			},
+			want: want{
+				err: database.ErrAlreadyExists,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &organization{db: db, now: now}
+			err = db.Create(ctx, tt.args.organization)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func fakeOrganization(organizationID, code string, now time.Time) *entity.Organization {
+	return &entity.Organization{
+		ID:        organizationID,
+		Code:      code,
+		Name:      "宗像市",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
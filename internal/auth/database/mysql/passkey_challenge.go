@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mysql"
+)
+
+const passkeyChallengeTable = "passkey_challenges"
+
+type passkeyChallenge struct {
+	db  *mysql.Client
+	now func() time.Time
+}
+
+func newPasskeyChallenge(db *mysql.Client) database.PasskeyChallenge {
+	return &passkeyChallenge{
+		db:  db,
+		now: jst.Now,
+	}
+}
+
+func (c *passkeyChallenge) Create(ctx context.Context, challenge *entity.PasskeyChallenge) error {
+	now := c.now()
+	challenge.CreatedAt = now
+
+	if err := c.db.WithContext(ctx).Table(passkeyChallengeTable).Create(&challenge).Error; err != nil {
+		return dbError(err)
+	}
+	// 検証されずに有効期限が切れたチャレンジは、発行時にあわせて削除する
+	stmt := c.db.WithContext(ctx).
+		Table(passkeyChallengeTable).
+		Where("expires_at <= ?", now)
+
+	err := stmt.Delete(&entity.PasskeyChallenge{}).Error
+	return dbError(err)
+}
+
+func (c *passkeyChallenge) Consume(ctx context.Context, challenge string) error {
+	// 削除できた場合のみ成功とし、同じチャレンジの同時検証でも1度しか成功させない
+	stmt := c.db.WithContext(ctx).
+		Table(passkeyChallengeTable).
+		Where("challenge = ?", challenge).
+		Where("expires_at > ?", c.now())
+
+	res := stmt.Delete(&entity.PasskeyChallenge{})
+	if err := res.Error; err != nil {
+		return dbError(err)
+	}
+	if res.RowsAffected == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskeyChallenge(t *testing.T) {
+	t.Parallel()
+	assert.NotNil(t, newPasskeyChallenge(nil))
+}
+
+func TestPasskeyChallenge_Create(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		challenge *entity.PasskeyChallenge
+	}
+	type want struct {
+		challenges []string
+		err        error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				challenges := []*entity.PasskeyChallenge{
+					fakePasskeyChallenge("expired", now().Add(-time.Minute), now()),
+					fakePasskeyChallenge("active", now().Add(time.Minute), now()),
+				}
+				err := db.DB.WithContext(ctx).Table(passkeyChallengeTable).Create(&challenges).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				challenge: entity.NewPasskeyChallenge("challenge", now().Add(time.Minute)),
+			},
+			want: want{
+				challenges: []string{"active", "challenge"},
+				err:        nil,
+			},
+		},
+		{
+			name: "already exists",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				c := fakePasskeyChallenge("challenge", now().Add(time.Minute), now())
+				err := db.DB.WithContext(ctx).Table(passkeyChallengeTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				challenge: entity.NewPasskeyChallenge("challenge", now().Add(time.Minute)),
+			},
+			want: want{
+				challenges: []string{"challenge"},
+				err:        database.ErrAlreadyExists,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := delete(ctx, passkeyChallengeTable)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &passkeyChallenge{db: db, now: now}
+			err = db.Create(ctx, tt.args.challenge)
+			assert.ErrorIs(t, err, tt.want.err)
+
+			var challenges []string
+			err = db.db.DB.WithContext(ctx).Table(passkeyChallengeTable).Order("challenge ASC").Pluck("challenge", &challenges).Error
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.challenges, challenges)
+		})
+	}
+}
+
+func TestPasskeyChallenge_Consume(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type args struct {
+		challenge string
+	}
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context, t *testing.T, db *mysql.Client)
+		args  args
+		want  want
+	}{
+		{
+			name: "success",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				c := fakePasskeyChallenge("challenge", now().Add(time.Minute), now())
+				err := db.DB.WithContext(ctx).Table(passkeyChallengeTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				challenge: "challenge",
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name:  "not found",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {},
+			args: args{
+				challenge: "challenge",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+		{
+			name: "expired",
+			setup: func(ctx context.Context, t *testing.T, db *mysql.Client) {
+				c := fakePasskeyChallenge("challenge", now(), now().Add(-time.Minute))
+				err := db.DB.WithContext(ctx).Table(passkeyChallengeTable).Create(&c).Error
+				require.NoError(t, err)
+			},
+			args: args{
+				challenge: "challenge",
+			},
+			want: want{
+				err: database.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := delete(ctx, passkeyChallengeTable)
+			require.NoError(t, err)
+
+			tt.setup(ctx, t, db)
+
+			db := &passkeyChallenge{db: db, now: now}
+			err = db.Consume(ctx, tt.args.challenge)
+			assert.ErrorIs(t, err, tt.want.err)
+		})
+	}
+}
+
+func TestPasskeyChallenge_Replay(t *testing.T) {
+	ctx := context.Background()
+	now := func() time.Time {
+		return current
+	}
+
+	err := delete(ctx, passkeyChallengeTable)
+	require.NoError(t, err)
+
+	db := &passkeyChallenge{db: dbClient, now: now}
+	err = db.Create(ctx, entity.NewPasskeyChallenge("challenge", now().Add(time.Minute)))
+	require.NoError(t, err)
+
+	// 同じチャレンジに対する認証器の応答は、1度目の検証のみ受け付ける
+	require.NoError(t, db.Consume(ctx, "challenge"))
+	assert.ErrorIs(t, db.Consume(ctx, "challenge"), database.ErrNotFound)
+}
+
+func fakePasskeyChallenge(challenge string, expiresAt, now time.Time) *entity.PasskeyChallenge {
+	return &entity.PasskeyChallenge{
+		Challenge: challenge,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/mysql"
+)
+
+type transaction struct {
+	db *mysql.Client
+}
+
+func newTransaction(db *mysql.Client) database.Transaction {
+	return &transaction{
+		db: db,
+	}
+}
+
+// Run - fnが返したエラーはそのまま返し、トランザクションの開始・確定に失敗した場合のみデータベースのエラーに変換する
+func (t *transaction) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := t.db.RunInTransaction(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return dbError(err)
+}
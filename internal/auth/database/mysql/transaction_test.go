@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_Run(t *testing.T) {
+	db := dbClient
+	now := func() time.Time {
+		return current
+	}
+
+	type want struct {
+		exists bool
+		err    error
+	}
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context, t *testing.T) error
+		want want
+	}{
+		{
+			name: "success",
+			fn: func(ctx context.Context, t *testing.T) error {
+				organization := &organization{db: db, now: now}
+				if err := organization.Create(ctx, fakeOrganization("organization-id", "402214", now())); err != nil {
+					return err
+				}
+				member := &organizationMember{db: db, now: now}
+				owner := fakeOrganizationMember("organization-id", "admin-id", entity.OrganizationRoleOwner, now())
+				return member.Create(ctx, owner)
+			},
+			want: want{
+				exists: true,
+				err:    nil,
+			},
+		},
+		{
+			name: "rollback by callback error",
+			fn: func(ctx context.Context, t *testing.T) error {
+				organization := &organization{db: db, now: now}
+				if err := organization.Create(ctx, fakeOrganization("organization-id", "402214", now())); err != nil {
+					return err
+				}
+				return assert.AnError
+			},
+			want: want{
+				exists: false,
+				err:    assert.AnError,
+			},
+		},
+		{
+			name: "rollback by database error",
+			fn: func(ctx context.Context, t *testing.T) error {
+				organization := &organization{db: db, now: now}
+				if err := organization.Create(ctx, fakeOrganization("organization-id", "402214", now())); err != nil {
+					return err
+				}
+				// 同じ組織コードの組織は登録できない
+				return organization.Create(ctx, fakeOrganization("other-id", "402214", now()))
+			},
+			want: want{
+				exists: false,
+				err:    database.ErrAlreadyExists,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := deleteAll(ctx)
+			require.NoError(t, err)
+			admin := fakeAdmin("admin-id", "cognito-id", "test@example.com", now())
+			err = db.DB.WithContext(ctx).Create(&admin).Error
+			require.NoError(t, err)
+
+			tx := &transaction{db: db}
+			err = tx.Run(ctx, func(ctx context.Context) error {
+				assert.True(t, mysql.InTransaction(ctx))
+				return tt.fn(ctx, t)
+			})
+			assert.ErrorIs(t, err, tt.want.err)
+
+			organization := &organization{db: db, now: now}
+			_, err = organization.Get(ctx, "organization-id")
+			assert.Equal(t, tt.want.exists, err == nil, err)
+		})
+	}
+}
@@ -11,14 +11,18 @@ type Admin struct {
 	ID           string         `gorm:"primaryKey;<-:create"` // 管理者ID
 	CognitoID    string         `gorm:""`                     // 管理者ID（Cognito用）
 	ProviderType ProviderType   `gorm:""`                     // 認証種別
-	Email        string         `gorm:"default:null"`         // メールアドレス
-	PhoneNumber  string         `gorm:"default:null"`         // 電話番号
+	Email        string         `gorm:"default:null"`         // メールアドレス（DBには暗号化して保存）
+	EmailIndex   string         `gorm:"default:null"`         // メールアドレスのブラインドインデックス（検索・一意制約用）
+	PhoneNumber  string         `gorm:"default:null"`         // 電話番号（DBには暗号化して保存）
+	Version      int64          `gorm:""`                     // バージョン（楽観的排他制御用）
 	CreatedAt    time.Time      `gorm:"<-:create"`            // 登録日時
 	UpdatedAt    time.Time      `gorm:""`                     // 更新日時
 	VerifiedAt   time.Time      `gorm:"default:null"`         // 確認日時
 	DeletedAt    gorm.DeletedAt `gorm:"default:null"`         // 削除日時
 }
 
+type Admins []*Admin
+
 type AdminParams struct {
 	AdminID      string
 	CognitID     string
@@ -43,3 +47,11 @@ func (a *Admin) InternationalPhoneNumber() string {
 	}
 	return strings.Replace(a.PhoneNumber, "0", "+81", 1)
 }
+
+func (as Admins) Map() map[string]*Admin {
+	res := make(map[string]*Admin, len(as))
+	for _, a := range as {
+		res[a.ID] = a
+	}
+	return res
+}
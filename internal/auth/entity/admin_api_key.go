@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// APIKeyPrefix - APIキーの接頭辞 (シークレットスキャン等での識別用)
+	APIKeyPrefix = "fm_"
+	// apiKeyVisibleLength - 一覧等で表示するAPIキーの先頭の文字数
+	apiKeyVisibleLength = len(APIKeyPrefix) + 8
+	// apiKeySecretSize - APIキーのランダム部分のバイト数
+	apiKeySecretSize = 32
+	// APIKeyLastUsedInterval - APIキーの最終利用日時を更新する間隔
+	APIKeyLastUsedInterval = time.Minute
+)
+
+// AdminAPIKey - 管理者のAPIキー (マシンクライアント用)
+// APIキーはハッシュ値のみを保持し、発行時以外に平文を参照することはできない
+type AdminAPIKey struct {
+	ID         string        `gorm:"primaryKey;<-:create"`               // APIキーID
+	AdminID    string        `gorm:"<-:create"`                          // 管理者ID
+	Name       string        `gorm:""`                                   // APIキー名
+	Prefix     string        `gorm:""`                                   // APIキーの接頭辞 (識別表示用)
+	KeyHash    []byte        `gorm:""`                                   // APIキーのハッシュ値
+	Scopes     []APIKeyScope `gorm:"serializer:json"`                    // 許可するスコープ一覧
+	AllowedIPs []string      `gorm:"column:allowed_ips;serializer:json"` // 接続を許可するIPアドレス一覧 (CIDR表記可)
+	ExpiresAt  time.Time     `gorm:"default:null"`                       // 有効期限
+	LastUsedAt time.Time     `gorm:"default:null"`                       // 最終利用日時
+	RevokedAt  time.Time     `gorm:"default:null"`                       // 失効日時
+	CreatedAt  time.Time     `gorm:"<-:create"`                          // 登録日時
+	UpdatedAt  time.Time     `gorm:""`                                   // 更新日時
+}
+
+type AdminAPIKeys []*AdminAPIKey
+
+type AdminAPIKeyParams struct {
+	ID         string
+	AdminID    string
+	Name       string
+	Scopes     []APIKeyScope
+	AllowedIPs []string
+	ExpiresAt  time.Time
+}
+
+// NewAdminAPIKey - APIキーを発行 (平文のAPIキーは戻り値でのみ返す)
+func NewAdminAPIKey(params *AdminAPIKeyParams) (*AdminAPIKey, string, error) {
+	key := &AdminAPIKey{
+		ID:         params.ID,
+		AdminID:    params.AdminID,
+		Name:       params.Name,
+		Scopes:     params.Scopes,
+		AllowedIPs: params.AllowedIPs,
+		ExpiresAt:  params.ExpiresAt,
+	}
+	secret, err := key.Rotate()
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// Rotate - APIキーを再発行 (平文のAPIキーは戻り値でのみ返す)
+func (k *AdminAPIKey) Rotate() (string, error) {
+	buf := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	k.Prefix = secret[:apiKeyVisibleLength]
+	k.KeyHash = HashAPIKey(secret)
+	return secret, nil
+}
+
+// Enabled - 失効・有効期限切れではないか
+func (k *AdminAPIKey) Enabled(now time.Time) bool {
+	if !k.RevokedAt.IsZero() {
+		return false
+	}
+	return k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt)
+}
+
+// HasScope - 指定したスコープを許可しているか
+func (k *AdminAPIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowIP - 接続元のIPアドレスを許可しているか (未指定の場合はすべて許可)
+func (k *AdminAPIKey) AllowIP(addr string) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, allowed := range k.AllowedIPs {
+		if !strings.Contains(allowed, "/") {
+			if ip.Equal(net.ParseIP(allowed)) {
+				return true
+			}
+			continue
+		}
+		if _, ipnet, err := net.ParseCIDR(allowed); err == nil && ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey - APIキーのハッシュ値を生成
+// APIキーは十分な長さのランダム値のため、ソルトやストレッチングは行わない
+func HashAPIKey(key string) []byte {
+	h := sha256.Sum256([]byte(key))
+	return h[:]
+}
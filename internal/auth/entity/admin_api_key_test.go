@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPIKey(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 10, 3, 18, 30, 0, 0, time.UTC)
+	params := &AdminAPIKeyParams{
+		ID:         "api-key-id",
+		AdminID:    "admin-id",
+		Name:       "batch",
+		Scopes:     []APIKeyScope{APIKeyScopePasskeyRead},
+		AllowedIPs: []string{"192.0.2.1", "198.51.100.0/24"},
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	key, secret, err := NewAdminAPIKey(params)
+	require.NoError(t, err)
+
+	t.Run("constructor", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(secret, APIKeyPrefix))
+		assert.Len(t, secret, len(APIKeyPrefix)+43)
+		assert.Equal(t, secret[:11], key.Prefix)
+		assert.Equal(t, HashAPIKey(secret), key.KeyHash)
+		assert.Equal(t, "admin-id", key.AdminID)
+	})
+	t.Run("rotate", func(t *testing.T) {
+		key := *key
+		rotated, err := key.Rotate()
+		require.NoError(t, err)
+		assert.NotEqual(t, secret, rotated)
+		assert.Equal(t, HashAPIKey(rotated), key.KeyHash)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		assert.True(t, key.Enabled(now))
+		assert.False(t, key.Enabled(now.Add(time.Hour)))
+		assert.True(t, (&AdminAPIKey{}).Enabled(now))
+		assert.False(t, (&AdminAPIKey{RevokedAt: now}).Enabled(now))
+	})
+	t.Run("has scope", func(t *testing.T) {
+		assert.True(t, key.HasScope(APIKeyScopePasskeyRead))
+		assert.False(t, key.HasScope(APIKeyScopePasskeyWrite))
+	})
+	t.Run("allow ip", func(t *testing.T) {
+		assert.True(t, key.AllowIP("192.0.2.1"))
+		assert.True(t, key.AllowIP("198.51.100.10"))
+		assert.False(t, key.AllowIP("192.0.2.2"))
+		assert.False(t, key.AllowIP("invalid"))
+		assert.True(t, (&AdminAPIKey{}).AllowIP("192.0.2.2"))
+	})
+}
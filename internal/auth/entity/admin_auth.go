@@ -1,6 +1,15 @@
 package entity
 
-import "github.com/and-period/furumane/pkg/cognito"
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/and-period/furumane/pkg/cognito"
+)
 
 // AdminAuth - 管理者認証情報
 type AdminAuth struct {
@@ -18,3 +27,33 @@ func NewAdminAuth(admin *Admin, rs *cognito.AuthResult) *AdminAuth {
 		ExpiresIn:    rs.ExpiresIn,
 	}
 }
+
+// NewPasskeyAnswer - パスキー認証に成功したことを示すカスタム認証の回答を生成
+// {有効期限(UNIX時間)}.{署名} の形式で、認証チャレンジのトリガーで署名を検証する
+func NewPasskeyAnswer(secret []byte, username string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expires + "." + base64.RawURLEncoding.EncodeToString(passkeyAnswerMAC(secret, username, expires))
+}
+
+// VerifyPasskeyAnswer - パスキー認証の回答を検証
+func VerifyPasskeyAnswer(secret []byte, username, answer string, now time.Time) bool {
+	expires, signature, ok := strings.Cut(answer, ".")
+	if !ok || len(secret) == 0 {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(expiresAt, 0)) {
+		return false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sig, passkeyAnswerMAC(secret, username, expires))
+}
+
+func passkeyAnswerMAC(secret []byte, username, expires string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(string(SignInMethodPasskey) + ":" + username + ":" + expires))
+	return h.Sum(nil)
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/and-period/furumane/pkg/cognito"
 	"github.com/stretchr/testify/assert"
@@ -32,3 +33,34 @@ func TestAdminAuth(t *testing.T) {
 		assert.Equal(t, expect, actual)
 	})
 }
+
+func TestPasskeyAnswer(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 10, 1, 18, 30, 0, 0, time.UTC)
+	secret := []byte("secret")
+	answer := NewPasskeyAnswer(secret, "cognito-id", now.Add(time.Minute))
+	tests := []struct {
+		name     string
+		secret   []byte
+		username string
+		answer   string
+		now      time.Time
+		expect   bool
+	}{
+		{name: "success", secret: secret, username: "cognito-id", answer: answer, now: now, expect: true},
+		{name: "other user", secret: secret, username: "other-id", answer: answer, now: now, expect: false},
+		{name: "other secret", secret: []byte("other"), username: "cognito-id", answer: answer, now: now, expect: false},
+		{name: "empty secret", secret: nil, username: "cognito-id", answer: answer, now: now, expect: false},
+		{name: "expired", secret: secret, username: "cognito-id", answer: answer, now: now.Add(time.Minute), expect: false},
+		{name: "invalid format", secret: secret, username: "cognito-id", answer: "answer", now: now, expect: false},
+		{name: "invalid expires", secret: secret, username: "cognito-id", answer: "expires.signature", now: now, expect: false},
+		{name: "invalid signature", secret: secret, username: "cognito-id", answer: answer + "!", now: now, expect: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, VerifyPasskeyAnswer(tt.secret, tt.username, tt.answer, tt.now))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/and-period/furumane/pkg/webauthn"
+)
+
+// AdminCredential - 管理者のパスキー (WebAuthn認証情報)
+type AdminCredential struct {
+	ID           string    `gorm:"primaryKey;<-:create"`    // パスキーID
+	AdminID      string    `gorm:"<-:create"`               // 管理者ID
+	CredentialID []byte    `gorm:"<-:create"`               // 認証情報ID
+	Name         string    `gorm:""`                        // パスキー名
+	PublicKey    []byte    `gorm:"<-:create"`               // 公開鍵 (COSE_Key形式)
+	SignCount    uint32    `gorm:""`                        // 署名回数
+	Transports   []string  `gorm:"serializer:json"`         // 認証器との通信方法
+	AAGUID       []byte    `gorm:"column:aaguid;<-:create"` // 認証器の種別
+	CreatedAt    time.Time `gorm:"<-:create"`               // 登録日時
+	UpdatedAt    time.Time `gorm:""`                        // 更新日時
+	LastUsedAt   time.Time `gorm:"default:null"`            // 最終利用日時
+}
+
+type AdminCredentials []*AdminCredential
+
+type AdminCredentialParams struct {
+	ID         string
+	AdminID    string
+	Name       string
+	Credential *webauthn.Credential
+}
+
+func NewAdminCredential(params *AdminCredentialParams) *AdminCredential {
+	return &AdminCredential{
+		ID:           params.ID,
+		AdminID:      params.AdminID,
+		CredentialID: params.Credential.ID,
+		Name:         params.Name,
+		PublicKey:    params.Credential.PublicKey,
+		SignCount:    params.Credential.SignCount,
+		Transports:   params.Credential.Transports,
+		AAGUID:       params.Credential.AAGUID,
+	}
+}
+
+func (c *AdminCredential) WebAuthn() *webauthn.Credential {
+	return &webauthn.Credential{
+		ID:         c.CredentialID,
+		PublicKey:  c.PublicKey,
+		SignCount:  c.SignCount,
+		Transports: c.Transports,
+		AAGUID:     c.AAGUID,
+	}
+}
+
+func (cs AdminCredentials) WebAuthn() []*webauthn.Credential {
+	res := make([]*webauthn.Credential, len(cs))
+	for i := range cs {
+		res[i] = cs[i].WebAuthn()
+	}
+	return res
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/and-period/furumane/pkg/webauthn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminCredential(t *testing.T) {
+	t.Parallel()
+	credential := &webauthn.Credential{
+		ID:         []byte("credential-id"),
+		PublicKey:  []byte("public-key"),
+		SignCount:  1,
+		Transports: []string{"internal"},
+		AAGUID:     make([]byte, 16),
+	}
+	params := &AdminCredentialParams{
+		ID:         "passkey-id",
+		AdminID:    "admin-id",
+		Name:       "iPhone",
+		Credential: credential,
+	}
+	actual := NewAdminCredential(params)
+
+	t.Run("constructor", func(t *testing.T) {
+		expect := &AdminCredential{
+			ID:           "passkey-id",
+			AdminID:      "admin-id",
+			CredentialID: []byte("credential-id"),
+			Name:         "iPhone",
+			PublicKey:    []byte("public-key"),
+			SignCount:    1,
+			Transports:   []string{"internal"},
+			AAGUID:       make([]byte, 16),
+		}
+		assert.Equal(t, expect, actual)
+	})
+	t.Run("webauthn", func(t *testing.T) {
+		assert.Equal(t, credential, actual.WebAuthn())
+		assert.Equal(t, []*webauthn.Credential{credential}, AdminCredentials{actual}.WebAuthn())
+	})
+}
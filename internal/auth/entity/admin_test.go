@@ -33,3 +33,13 @@ func TestAdmin(t *testing.T) {
 		assert.Empty(t, actual.InternationalPhoneNumber())
 	})
 }
+
+func TestAdmins_Map(t *testing.T) {
+	t.Parallel()
+	admins := Admins{{ID: "admin-id01"}, {ID: "admin-id02"}}
+	expect := map[string]*Admin{
+		"admin-id01": {ID: "admin-id01"},
+		"admin-id02": {ID: "admin-id02"},
+	}
+	assert.Equal(t, expect, admins.Map())
+}
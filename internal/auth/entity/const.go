@@ -7,3 +7,45 @@ const (
 	ProviderTypeEmail   ProviderType = 1 // メールアドレス認証
 	ProviderTypeOAuth   ProviderType = 2 // OAuth認証
 )
+
+type OrganizationRole int32 // 組織内の権限
+
+const (
+	OrganizationRoleUnknown OrganizationRole = 0
+	OrganizationRoleOwner   OrganizationRole = 1 // オーナー (組織の全操作)
+	OrganizationRoleAdmin   OrganizationRole = 2 // 管理者 (メンバーの管理)
+	OrganizationRoleMember  OrganizationRole = 3 // メンバー (参照のみ)
+)
+
+type SignInMethod string // サインイン方法 (カスタム認証)
+
+const (
+	SignInMethodEmailOTP SignInMethod = "email_otp" // メールアドレス宛のワンタイムコード
+	SignInMethodPasskey  SignInMethod = "passkey"   // パスキー (WebAuthn)
+)
+
+// カスタム認証のトリガーに渡す値のキー
+const (
+	AuthMetadataSignInMethod = "signInMethod" // サインイン方法
+	AuthMetadataLocale       = "locale"       // 通知メッセージの言語
+)
+
+// カスタム認証の初回チャレンジ
+// InitiateAuthではチャレンジのトリガーにClientMetadataが渡されないため、
+// 初回はダミーのチャレンジに回答してサインイン方法などのパラメータを渡す
+const (
+	AuthChallengeProvideParameters       = "PROVIDE_AUTH_PARAMETERS" // パラメータ受け渡し用のチャレンジ
+	AuthChallengeProvideParametersAnswer = "__dummy__"               // パラメータ受け渡し用のチャレンジへの回答
+)
+
+type APIKeyScope string // APIキーのスコープ
+
+// APIキーのスコープ一覧
+// 管理者情報の更新、パスキーの登録、APIキーの管理は本人の操作であることを確認するため、APIキーでは操作できない
+const (
+	APIKeyScopePasskeyRead       APIKeyScope = "passkey:read"       // パスキーの参照
+	APIKeyScopePasskeyWrite      APIKeyScope = "passkey:write"      // パスキーの更新・削除
+	APIKeyScopeTokenIntrospect   APIKeyScope = "token:introspect"   // トークンの検証
+	APIKeyScopeOrganizationRead  APIKeyScope = "organization:read"  // 所属組織・メンバーの参照
+	APIKeyScopeOrganizationWrite APIKeyScope = "organization:write" // 組織の作成、メンバーの追加・更新・削除
+)
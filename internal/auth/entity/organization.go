@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Organization - 組織 (自治体)
+type Organization struct {
+	ID        string    `gorm:"primaryKey;<-:create"` // 組織ID
+	Code      string    `gorm:"<-:create"`            // 地方公共団体コード
+	Name      string    `gorm:""`                     // 組織名
+	CreatedAt time.Time `gorm:"<-:create"`            // 登録日時
+	UpdatedAt time.Time `gorm:""`                     // 更新日時
+}
+
+type Organizations []*Organization
+
+type OrganizationParams struct {
+	ID   string
+	Code string
+	Name string
+}
+
+func NewOrganization(params *OrganizationParams) *Organization {
+	return &Organization{
+		ID:   params.ID,
+		Code: params.Code,
+		Name: params.Name,
+	}
+}
+
+func (os Organizations) Map() map[string]*Organization {
+	res := make(map[string]*Organization, len(os))
+	for _, o := range os {
+		res[o.ID] = o
+	}
+	return res
+}
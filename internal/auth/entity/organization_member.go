@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// OrganizationMember - 組織に所属する管理者
+type OrganizationMember struct {
+	OrganizationID string           `gorm:"primaryKey;<-:create"` // 組織ID
+	AdminID        string           `gorm:"primaryKey;<-:create"` // 管理者ID
+	Role           OrganizationRole `gorm:""`                     // 組織内の権限
+	CreatedAt      time.Time        `gorm:"<-:create"`            // 登録日時
+	UpdatedAt      time.Time        `gorm:""`                     // 更新日時
+}
+
+type OrganizationMembers []*OrganizationMember
+
+type OrganizationMemberParams struct {
+	OrganizationID string
+	AdminID        string
+	Role           OrganizationRole
+}
+
+func NewOrganizationMember(params *OrganizationMemberParams) *OrganizationMember {
+	return &OrganizationMember{
+		OrganizationID: params.OrganizationID,
+		AdminID:        params.AdminID,
+		Role:           params.Role,
+	}
+}
+
+// HasRole - 指定した権限以上の権限を持つか (オーナー > 管理者 > メンバー)
+func (m *OrganizationMember) HasRole(role OrganizationRole) bool {
+	if m.Role == OrganizationRoleUnknown || role == OrganizationRoleUnknown {
+		return false
+	}
+	return m.Role <= role
+}
+
+func (ms OrganizationMembers) AdminIDs() []string {
+	res := make([]string, len(ms))
+	for i := range ms {
+		res[i] = ms[i].AdminID
+	}
+	return res
+}
+
+func (ms OrganizationMembers) OrganizationIDs() []string {
+	res := make([]string, len(ms))
+	for i := range ms {
+		res[i] = ms[i].OrganizationID
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationMember(t *testing.T) {
+	t.Parallel()
+	params := &OrganizationMemberParams{
+		OrganizationID: "organization-id",
+		AdminID:        "admin-id",
+		Role:           OrganizationRoleOwner,
+	}
+	expect := &OrganizationMember{
+		OrganizationID: "organization-id",
+		AdminID:        "admin-id",
+		Role:           OrganizationRoleOwner,
+	}
+	assert.Equal(t, expect, NewOrganizationMember(params))
+}
+
+func TestOrganizationMember_HasRole(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		member *OrganizationMember
+		role   OrganizationRole
+		expect bool
+	}{
+		{name: "owner requires owner", member: &OrganizationMember{Role: OrganizationRoleOwner}, role: OrganizationRoleOwner, expect: true},
+		{name: "owner requires member", member: &OrganizationMember{Role: OrganizationRoleOwner}, role: OrganizationRoleMember, expect: true},
+		{name: "admin requires owner", member: &OrganizationMember{Role: OrganizationRoleAdmin}, role: OrganizationRoleOwner, expect: false},
+		{name: "admin requires admin", member: &OrganizationMember{Role: OrganizationRoleAdmin}, role: OrganizationRoleAdmin, expect: true},
+		{name: "member requires admin", member: &OrganizationMember{Role: OrganizationRoleMember}, role: OrganizationRoleAdmin, expect: false},
+		{name: "unknown role", member: &OrganizationMember{Role: OrganizationRoleUnknown}, role: OrganizationRoleMember, expect: false},
+		{name: "requires unknown role", member: &OrganizationMember{Role: OrganizationRoleOwner}, role: OrganizationRoleUnknown, expect: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, tt.member.HasRole(tt.role))
+		})
+	}
+}
+
+func TestOrganizationMembers(t *testing.T) {
+	t.Parallel()
+	members := OrganizationMembers{
+		{OrganizationID: "organization-id01", AdminID: "admin-id01"},
+		{OrganizationID: "organization-id02", AdminID: "admin-id02"},
+	}
+	assert.Equal(t, []string{"admin-id01", "admin-id02"}, members.AdminIDs())
+	assert.Equal(t, []string{"organization-id01", "organization-id02"}, members.OrganizationIDs())
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganization(t *testing.T) {
+	t.Parallel()
+	params := &OrganizationParams{
+		ID:   "organization-id",
+		Code: "402214",
+		Name: "宗像市",
+	}
+	expect := &Organization{
+		ID:   "organization-id",
+		Code: "402214",
+		Name: "宗像市",
+	}
+	assert.Equal(t, expect, NewOrganization(params))
+}
+
+func TestOrganizations_Map(t *testing.T) {
+	t.Parallel()
+	organizations := Organizations{{ID: "organization-id01"}, {ID: "organization-id02"}}
+	expect := map[string]*Organization{
+		"organization-id01": {ID: "organization-id01"},
+		"organization-id02": {ID: "organization-id02"},
+	}
+	assert.Equal(t, expect, organizations.Map())
+}
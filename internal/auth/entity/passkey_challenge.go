@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// PasskeyChallenge - パスキー認証で発行したチャレンジ
+// 認証器の応答の再送 (リプレイ攻撃) を防ぐため、検証に成功した時点で削除し、1度のみ利用できるようにする
+type PasskeyChallenge struct {
+	Challenge string    `gorm:"primaryKey;<-:create"` // チャレンジ (base64url形式)
+	ExpiresAt time.Time `gorm:"<-:create"`            // 有効期限
+	CreatedAt time.Time `gorm:"<-:create"`            // 発行日時
+}
+
+func NewPasskeyChallenge(challenge string, expiresAt time.Time) *PasskeyChallenge {
+	return &PasskeyChallenge{
+		Challenge: challenge,
+		ExpiresAt: expiresAt,
+	}
+}
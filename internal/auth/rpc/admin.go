@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/entity"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// batchGetAdminsLimit - 管理者の一括取得の上限
+const batchGetAdminsLimit = 100
+
+func (s *authService) VerifyToken(
+	ctx context.Context, req *authv1.VerifyTokenRequest,
+) (*authv1.VerifyTokenResponse, error) {
+	if req.GetAccessToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+	username, err := s.adminAuth.GetUsername(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	admin, err := s.db.Admin.GetByCognitoID(ctx, username)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &authv1.VerifyTokenResponse{Admin: newAdmin(admin)}, nil
+}
+
+func (s *authService) GetAdmin(ctx context.Context, req *authv1.GetAdminRequest) (*authv1.GetAdminResponse, error) {
+	if req.GetAdminId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "admin id is required")
+	}
+	admin, err := s.db.Admin.Get(ctx, req.GetAdminId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &authv1.GetAdminResponse{Admin: newAdmin(admin)}, nil
+}
+
+func (s *authService) BatchGetAdmins(
+	ctx context.Context, req *authv1.BatchGetAdminsRequest,
+) (*authv1.BatchGetAdminsResponse, error) {
+	if len(req.GetAdminIds()) > batchGetAdminsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "admin ids must be %d or less", batchGetAdminsLimit)
+	}
+	if len(req.GetAdminIds()) == 0 {
+		return &authv1.BatchGetAdminsResponse{Admins: []*authv1.Admin{}}, nil
+	}
+	admins, err := s.db.Admin.MultiGet(ctx, req.GetAdminIds())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	res := make([]*authv1.Admin, len(admins))
+	for i := range admins {
+		res[i] = newAdmin(admins[i])
+	}
+	return &authv1.BatchGetAdminsResponse{Admins: res}, nil
+}
+
+func newAdmin(admin *entity.Admin) *authv1.Admin {
+	return &authv1.Admin{
+		Id:           admin.ID,
+		ProviderType: authv1.ProviderType(admin.ProviderType),
+		Email:        admin.Email,
+		PhoneNumber:  admin.PhoneNumber,
+		CreatedAt:    newTimestamp(admin.CreatedAt),
+		UpdatedAt:    newTimestamp(admin.UpdatedAt),
+		VerifiedAt:   newTimestamp(admin.VerifiedAt),
+	}
+}
+
+func newTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/cognito"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testAdmin(adminID string) *entity.Admin {
+	return &entity.Admin{
+		ID:           adminID,
+		CognitoID:    "cognito-id",
+		ProviderType: entity.ProviderTypeEmail,
+		Email:        "test@example.com",
+		PhoneNumber:  "09012341234",
+		CreatedAt:    current,
+		UpdatedAt:    current,
+	}
+}
+
+func testAdminProto(adminID string) *authv1.Admin {
+	return &authv1.Admin{
+		Id:           adminID,
+		ProviderType: authv1.ProviderType_PROVIDER_TYPE_EMAIL,
+		Email:        "test@example.com",
+		PhoneNumber:  "09012341234",
+		CreatedAt:    timestamppb.New(current),
+		UpdatedAt:    timestamppb.New(current),
+		VerifiedAt:   nil,
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *authv1.VerifyTokenRequest
+		expect *authv1.VerifyTokenResponse
+		code   codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(testAdmin("admin-id"), nil)
+			},
+			req:    &authv1.VerifyTokenRequest{AccessToken: "access-token"},
+			expect: &authv1.VerifyTokenResponse{Admin: testAdminProto("admin-id")},
+			code:   codes.OK,
+		},
+		{
+			name:  "empty access token",
+			setup: func(mocks *mocks) {},
+			req:   &authv1.VerifyTokenRequest{},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name: "invalid access token",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("", cognito.ErrUnauthenticated)
+			},
+			req:  &authv1.VerifyTokenRequest{AccessToken: "access-token"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "admin not found",
+			setup: func(mocks *mocks) {
+				mocks.adminAuth.EXPECT().GetUsername(gomock.Any(), "access-token").Return("cognito-id", nil)
+				mocks.db.admin.EXPECT().GetByCognitoID(gomock.Any(), "cognito-id").Return(nil, database.ErrNotFound)
+			},
+			req:  &authv1.VerifyTokenRequest{AccessToken: "access-token"},
+			code: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := testClient(t, tt.setup)
+			res, err := client.VerifyToken(testContext(), tt.req)
+			assert.Equal(t, tt.code, status.Code(err), err)
+			assert.True(t, proto.Equal(tt.expect, res), res)
+		})
+	}
+}
+
+func TestGetAdmin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *authv1.GetAdminRequest
+		expect *authv1.GetAdminResponse
+		code   codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(testAdmin("admin-id"), nil)
+			},
+			req:    &authv1.GetAdminRequest{AdminId: "admin-id"},
+			expect: &authv1.GetAdminResponse{Admin: testAdminProto("admin-id")},
+			code:   codes.OK,
+		},
+		{
+			name:  "empty admin id",
+			setup: func(mocks *mocks) {},
+			req:   &authv1.GetAdminRequest{},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(nil, database.ErrNotFound)
+			},
+			req:  &authv1.GetAdminRequest{AdminId: "admin-id"},
+			code: codes.NotFound,
+		},
+		{
+			name: "failed to get admin",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().Get(gomock.Any(), "admin-id").Return(nil, assert.AnError)
+			},
+			req:  &authv1.GetAdminRequest{AdminId: "admin-id"},
+			code: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := testClient(t, tt.setup)
+			res, err := client.GetAdmin(testContext(), tt.req)
+			assert.Equal(t, tt.code, status.Code(err), err)
+			assert.True(t, proto.Equal(tt.expect, res), res)
+		})
+	}
+}
+
+func TestBatchGetAdmins(t *testing.T) {
+	t.Parallel()
+	adminIDs := make([]string, batchGetAdminsLimit+1)
+	for i := range adminIDs {
+		adminIDs[i] = "admin-id"
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *authv1.BatchGetAdminsRequest
+		expect *authv1.BatchGetAdminsResponse
+		code   codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(mocks *mocks) {
+				admins := entity.Admins{testAdmin("admin-id01"), testAdmin("admin-id02")}
+				mocks.db.admin.EXPECT().
+					MultiGet(gomock.Any(), []string{"admin-id01", "admin-id02", "admin-id03"}).
+					Return(admins, nil)
+			},
+			req: &authv1.BatchGetAdminsRequest{AdminIds: []string{"admin-id01", "admin-id02", "admin-id03"}},
+			expect: &authv1.BatchGetAdminsResponse{
+				Admins: []*authv1.Admin{testAdminProto("admin-id01"), testAdminProto("admin-id02")},
+			},
+			code: codes.OK,
+		},
+		{
+			name:   "empty admin ids",
+			setup:  func(mocks *mocks) {},
+			req:    &authv1.BatchGetAdminsRequest{},
+			expect: &authv1.BatchGetAdminsResponse{},
+			code:   codes.OK,
+		},
+		{
+			name:  "too many admin ids",
+			setup: func(mocks *mocks) {},
+			req:   &authv1.BatchGetAdminsRequest{AdminIds: adminIDs},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name: "failed to multi get admins",
+			setup: func(mocks *mocks) {
+				mocks.db.admin.EXPECT().MultiGet(gomock.Any(), []string{"admin-id01"}).Return(nil, database.ErrInternal)
+			},
+			req:  &authv1.BatchGetAdminsRequest{AdminIds: []string{"admin-id01"}},
+			code: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := testClient(t, tt.setup)
+			res, err := client.BatchGetAdmins(testContext(), tt.req)
+			assert.Equal(t, tt.code, status.Code(err), err)
+			assert.True(t, proto.Equal(tt.expect, res), res)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckPermission - APIキーの権限の確認
+// APIキーが無効な場合や権限がない場合はエラーではなく、拒否した理由を返す
+func (s *authService) CheckPermission(
+	ctx context.Context, req *authv1.CheckPermissionRequest,
+) (*authv1.CheckPermissionResponse, error) {
+	if req.GetApiKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "api key is required")
+	}
+	if req.GetScope() == "" {
+		return nil, status.Error(codes.InvalidArgument, "scope is required")
+	}
+	apiKey, err := s.db.AdminAPIKey.GetByHash(ctx, entity.HashAPIKey(req.GetApiKey()))
+	if errors.Is(err, database.ErrNotFound) {
+		return deny("api key is invalid"), nil
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	now := s.now()
+	if !apiKey.Enabled(now) {
+		return deny("api key is expired or revoked"), nil
+	}
+	if !apiKey.AllowIP(req.GetIpAddress()) {
+		return deny("this ip address is not allowed"), nil
+	}
+	if !apiKey.HasScope(entity.APIKeyScope(req.GetScope())) {
+		return deny("api key does not have scope: " + req.GetScope()), nil
+	}
+	// 書き込みを抑えるため、一定間隔ごとに最終利用日時を更新する
+	if now.Sub(apiKey.LastUsedAt) >= entity.APIKeyLastUsedInterval {
+		if err := s.db.AdminAPIKey.UpdateLastUsedAt(ctx, apiKey.ID); err != nil {
+			s.logger.Warn("Failed to update api key last used at", zap.String("apiKeyId", apiKey.ID), zap.Error(err))
+		}
+	}
+	res := &authv1.CheckPermissionResponse{
+		Allowed: true,
+		AdminId: apiKey.AdminID,
+	}
+	return res, nil
+}
+
+func deny(reason string) *authv1.CheckPermissionResponse {
+	return &authv1.CheckPermissionResponse{
+		Allowed: false,
+		Reason:  reason,
+	}
+}
@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/internal/auth/entity"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCheckPermission(t *testing.T) {
+	t.Parallel()
+	const key = "fm_secret"
+	hash := entity.HashAPIKey(key)
+	apiKey := func() *entity.AdminAPIKey {
+		return &entity.AdminAPIKey{
+			ID:         "api-key-id",
+			AdminID:    "admin-id",
+			Scopes:     []entity.APIKeyScope{entity.APIKeyScopePasskeyRead},
+			AllowedIPs: []string{"192.0.2.0/24"},
+			LastUsedAt: current,
+		}
+	}
+	req := &authv1.CheckPermissionRequest{
+		ApiKey:    key,
+		Scope:     "passkey:read",
+		IpAddress: "192.0.2.1",
+	}
+	tests := []struct {
+		name   string
+		setup  func(mocks *mocks)
+		req    *authv1.CheckPermissionRequest
+		expect *authv1.CheckPermissionResponse
+		code   codes.Code
+	}{
+		{
+			name: "allowed",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(apiKey(), nil)
+			},
+			req:    req,
+			expect: &authv1.CheckPermissionResponse{Allowed: true, AdminId: "admin-id"},
+			code:   codes.OK,
+		},
+		{
+			name: "allowed and update last used at",
+			setup: func(mocks *mocks) {
+				k := apiKey()
+				k.LastUsedAt = current.Add(-entity.APIKeyLastUsedInterval)
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(k, nil)
+				mocks.db.adminAPIKey.EXPECT().UpdateLastUsedAt(gomock.Any(), "api-key-id").Return(assert.AnError)
+			},
+			req:    req,
+			expect: &authv1.CheckPermissionResponse{Allowed: true, AdminId: "admin-id"},
+			code:   codes.OK,
+		},
+		{
+			name:  "empty api key",
+			setup: func(mocks *mocks) {},
+			req:   &authv1.CheckPermissionRequest{Scope: "passkey:read"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "empty scope",
+			setup: func(mocks *mocks) {},
+			req:   &authv1.CheckPermissionRequest{ApiKey: key},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name: "unknown api key",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(nil, database.ErrNotFound)
+			},
+			req:    req,
+			expect: &authv1.CheckPermissionResponse{Allowed: false, Reason: "api key is invalid"},
+			code:   codes.OK,
+		},
+		{
+			name: "revoked",
+			setup: func(mocks *mocks) {
+				k := apiKey()
+				k.RevokedAt = current
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(k, nil)
+			},
+			req:    req,
+			expect: &authv1.CheckPermissionResponse{Allowed: false, Reason: "api key is expired or revoked"},
+			code:   codes.OK,
+		},
+		{
+			name: "ip address is not allowed",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(apiKey(), nil)
+			},
+			req: &authv1.CheckPermissionRequest{
+				ApiKey: key,
+				Scope:  "passkey:read",
+			},
+			expect: &authv1.CheckPermissionResponse{Allowed: false, Reason: "this ip address is not allowed"},
+			code:   codes.OK,
+		},
+		{
+			name: "insufficient scope",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(apiKey(), nil)
+			},
+			req: &authv1.CheckPermissionRequest{
+				ApiKey:    key,
+				Scope:     "passkey:write",
+				IpAddress: "192.0.2.1",
+			},
+			expect: &authv1.CheckPermissionResponse{Allowed: false, Reason: "api key does not have scope: passkey:write"},
+			code:   codes.OK,
+		},
+		{
+			name: "failed to get api key",
+			setup: func(mocks *mocks) {
+				mocks.db.adminAPIKey.EXPECT().GetByHash(gomock.Any(), hash).Return(nil, database.ErrInternal)
+			},
+			req:  req,
+			code: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := testClient(t, tt.setup)
+			res, err := client.CheckPermission(testContext(), tt.req)
+			assert.Equal(t, tt.code, status.Code(err), err)
+			assert.True(t, proto.Equal(tt.expect, res), res)
+		})
+	}
+}
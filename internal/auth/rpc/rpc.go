@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/jst"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Params struct {
+	Database  *database.Database
+	AdminAuth cognito.Client
+}
+
+type authService struct {
+	authv1.UnimplementedAuthServiceServer
+	now       func() time.Time
+	logger    *zap.Logger
+	db        *database.Database
+	adminAuth cognito.Client
+}
+
+type options struct {
+	logger *zap.Logger
+}
+
+type Option func(*options)
+
+func WithLogger(logger *zap.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// NewAuthService - 他のマイクロサービス向けの認証・認可サービスの生成
+func NewAuthService(params *Params, opts ...Option) authv1.AuthServiceServer {
+	dopts := &options{
+		logger: zap.NewNop(),
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &authService{
+		now:       jst.Now,
+		logger:    dopts.logger,
+		db:        params.Database,
+		adminAuth: params.AdminAuth,
+	}
+}
+
+// grpcError - 内部のエラーをgRPCのステータスに変換
+//
+//nolint:gocyclo
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var c codes.Code
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, database.ErrCanceled),
+		errors.Is(err, cognito.ErrCanceled):
+		c = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, database.ErrDeadlineExceeded),
+		errors.Is(err, cognito.ErrTimeout):
+		c = codes.DeadlineExceeded
+	case errors.Is(err, database.ErrInvalidArgument),
+		errors.Is(err, cognito.ErrInvalidArgument):
+		c = codes.InvalidArgument
+	case errors.Is(err, database.ErrNotFound):
+		c = codes.NotFound
+	case errors.Is(err, database.ErrAlreadyExists),
+		errors.Is(err, cognito.ErrAlreadyExists):
+		c = codes.AlreadyExists
+	case errors.Is(err, database.ErrFailedPrecondition):
+		c = codes.FailedPrecondition
+	case errors.Is(err, cognito.ErrUnauthenticated),
+		errors.Is(err, cognito.ErrNotFound):
+		c = codes.Unauthenticated
+	case errors.Is(err, cognito.ErrResourceExhausted):
+		c = codes.ResourceExhausted
+	case errors.Is(err, database.ErrUnavailable):
+		c = codes.Unavailable
+	case errors.Is(err, database.ErrInternal),
+		errors.Is(err, cognito.ErrInternal):
+		c = codes.Internal
+	default:
+		c = codes.Unknown
+	}
+	return status.Error(c, err.Error())
+}
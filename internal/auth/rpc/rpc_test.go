@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	mock_database "github.com/and-period/furumane/mock/auth/database"
+	mock_cognito "github.com/and-period/furumane/mock/pkg/cognito"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/grpc"
+	"github.com/and-period/furumane/pkg/jst"
+	authv1 "github.com/and-period/furumane/proto/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var current = jst.Date(2023, 10, 1, 18, 30, 0, 0)
+
+const serviceToken = "service-token"
+
+type mocks struct {
+	db        *dbmocks
+	adminAuth *mock_cognito.MockClient
+}
+
+type dbmocks struct {
+	admin       *mock_database.MockAdmin
+	adminAPIKey *mock_database.MockAdminAPIKey
+}
+
+func newMocks(ctrl *gomock.Controller) *mocks {
+	return &mocks{
+		db: &dbmocks{
+			admin:       mock_database.NewMockAdmin(ctrl),
+			adminAPIKey: mock_database.NewMockAdminAPIKey(ctrl),
+		},
+		adminAuth: mock_cognito.NewMockClient(ctrl),
+	}
+}
+
+func newAuthService(mocks *mocks) authv1.AuthServiceServer {
+	params := &Params{
+		Database: &database.Database{
+			Admin:       mocks.db.admin,
+			AdminAPIKey: mocks.db.adminAPIKey,
+		},
+		AdminAuth: mocks.adminAuth,
+	}
+	srv := NewAuthService(params, WithLogger(zap.NewNop())).(*authService)
+	srv.now = func() time.Time {
+		return current
+	}
+	return srv
+}
+
+// testClient - インメモリで起動したgRPCサーバーに接続するクライアントの生成
+func testClient(t *testing.T, setup func(mocks *mocks)) authv1.AuthServiceClient {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mocks := newMocks(ctrl)
+	setup(mocks)
+
+	lis := bufconn.Listen(1024 * 1024)
+	register := func(s ggrpc.ServiceRegistrar) {
+		authv1.RegisterAuthServiceServer(s, newAuthService(mocks))
+	}
+	server := grpc.NewGRPCServer(register, 0, grpc.WithListener(lis), grpc.WithServiceTokens(serviceToken))
+	go server.Serve() //nolint:errcheck
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+	conn, err := ggrpc.DialContext(context.Background(), "bufnet",
+		ggrpc.WithContextDialer(dialer),
+		ggrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop(context.Background()) //nolint:errcheck
+	})
+	return authv1.NewAuthServiceClient(conn)
+}
+
+func testContext() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+serviceToken)
+}
+
+func TestAuthService_Unauthenticated(t *testing.T) {
+	t.Parallel()
+	client := testClient(t, func(mocks *mocks) {})
+	_, err := client.GetAdmin(context.Background(), &authv1.GetAdminRequest{AdminId: "admin-id"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		err    error
+		expect codes.Code
+	}{
+		{name: "nil", err: nil, expect: codes.OK},
+		{name: "status", err: status.Error(codes.PermissionDenied, "denied"), expect: codes.PermissionDenied},
+		{name: "context canceled", err: context.Canceled, expect: codes.Canceled},
+		{name: "context deadline exceeded", err: context.DeadlineExceeded, expect: codes.DeadlineExceeded},
+		{name: "database invalid argument", err: database.ErrInvalidArgument, expect: codes.InvalidArgument},
+		{name: "database not found", err: database.ErrNotFound, expect: codes.NotFound},
+		{name: "database already exists", err: database.ErrAlreadyExists, expect: codes.AlreadyExists},
+		{name: "database failed precondition", err: database.ErrFailedPrecondition, expect: codes.FailedPrecondition},
+		{name: "database unavailable", err: database.ErrUnavailable, expect: codes.Unavailable},
+		{name: "database internal", err: database.ErrInternal, expect: codes.Internal},
+		{name: "cognito unauthenticated", err: cognito.ErrUnauthenticated, expect: codes.Unauthenticated},
+		{name: "cognito not found", err: cognito.ErrNotFound, expect: codes.Unauthenticated},
+		{name: "cognito resource exhausted", err: cognito.ErrResourceExhausted, expect: codes.ResourceExhausted},
+		{name: "cognito timeout", err: cognito.ErrTimeout, expect: codes.DeadlineExceeded},
+		{name: "unknown", err: assert.AnError, expect: codes.Unknown},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, status.Code(grpcError(tt.err)))
+		})
+	}
+}
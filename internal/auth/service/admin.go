@@ -2,7 +2,7 @@ package service
 
 import (
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/pkg/authclient/response"
 )
 
 type Admin struct {
@@ -13,7 +13,7 @@ func NewAdmin(admin *entity.Admin) *Admin {
 	return &Admin{
 		Admin: response.Admin{
 			ID:           admin.ID,
-			ProviderType: admin.ProviderType,
+			ProviderType: int32(admin.ProviderType),
 			Email:        admin.Email,
 			PhoneNumber:  admin.PhoneNumber,
 			CreatedAt:    admin.CreatedAt,
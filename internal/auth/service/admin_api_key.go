@@ -0,0 +1,53 @@
+package service
+
+import (
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+type AdminAPIKey struct {
+	response.AdminAPIKey
+}
+
+type AdminAPIKeys []*AdminAPIKey
+
+func NewAdminAPIKey(apiKey *entity.AdminAPIKey) *AdminAPIKey {
+	scopes := make([]string, len(apiKey.Scopes))
+	for i := range apiKey.Scopes {
+		scopes[i] = string(apiKey.Scopes[i])
+	}
+	return &AdminAPIKey{
+		AdminAPIKey: response.AdminAPIKey{
+			ID:         apiKey.ID,
+			Name:       apiKey.Name,
+			Prefix:     apiKey.Prefix,
+			Scopes:     scopes,
+			AllowedIPs: apiKey.AllowedIPs,
+			ExpiresAt:  apiKey.ExpiresAt,
+			LastUsedAt: apiKey.LastUsedAt,
+			RevokedAt:  apiKey.RevokedAt,
+			CreatedAt:  apiKey.CreatedAt,
+			UpdatedAt:  apiKey.UpdatedAt,
+		},
+	}
+}
+
+func NewAdminAPIKeys(apiKeys entity.AdminAPIKeys) AdminAPIKeys {
+	res := make(AdminAPIKeys, len(apiKeys))
+	for i := range apiKeys {
+		res[i] = NewAdminAPIKey(apiKeys[i])
+	}
+	return res
+}
+
+func (k *AdminAPIKey) Response() *response.AdminAPIKey {
+	return &k.AdminAPIKey
+}
+
+func (ks AdminAPIKeys) Response() []*response.AdminAPIKey {
+	res := make([]*response.AdminAPIKey, len(ks))
+	for i := range ks {
+		res[i] = ks[i].Response()
+	}
+	return res
+}
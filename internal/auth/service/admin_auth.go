@@ -2,7 +2,7 @@ package service
 
 import (
 	"github.com/and-period/furumane/internal/auth/entity"
-	"github.com/and-period/furumane/internal/auth/response"
+	"github.com/and-period/furumane/pkg/authclient/response"
 )
 
 type AdminAuth struct {
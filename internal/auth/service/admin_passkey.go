@@ -0,0 +1,45 @@
+package service
+
+import (
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+type AdminPasskey struct {
+	response.AdminPasskey
+}
+
+type AdminPasskeys []*AdminPasskey
+
+func NewAdminPasskey(credential *entity.AdminCredential) *AdminPasskey {
+	return &AdminPasskey{
+		AdminPasskey: response.AdminPasskey{
+			ID:         credential.ID,
+			Name:       credential.Name,
+			Transports: credential.Transports,
+			CreatedAt:  credential.CreatedAt,
+			UpdatedAt:  credential.UpdatedAt,
+			LastUsedAt: credential.LastUsedAt,
+		},
+	}
+}
+
+func NewAdminPasskeys(credentials entity.AdminCredentials) AdminPasskeys {
+	res := make(AdminPasskeys, len(credentials))
+	for i := range credentials {
+		res[i] = NewAdminPasskey(credentials[i])
+	}
+	return res
+}
+
+func (p *AdminPasskey) Response() *response.AdminPasskey {
+	return &p.AdminPasskey
+}
+
+func (ps AdminPasskeys) Response() []*response.AdminPasskey {
+	res := make([]*response.AdminPasskey, len(ps))
+	for i := range ps {
+		res[i] = ps[i].Response()
+	}
+	return res
+}
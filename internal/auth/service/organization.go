@@ -0,0 +1,96 @@
+package service
+
+import (
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+type Organization struct {
+	response.Organization
+}
+
+type Organizations []*Organization
+
+type OrganizationMember struct {
+	response.OrganizationMember
+}
+
+type OrganizationMembers []*OrganizationMember
+
+// NewOrganization - 組織の生成 (権限はリクエストした管理者の権限)
+func NewOrganization(organization *entity.Organization, member *entity.OrganizationMember) *Organization {
+	return &Organization{
+		Organization: response.Organization{
+			ID:        organization.ID,
+			Code:      organization.Code,
+			Name:      organization.Name,
+			Role:      int32(member.Role),
+			CreatedAt: organization.CreatedAt,
+			UpdatedAt: organization.UpdatedAt,
+		},
+	}
+}
+
+// NewOrganizations - 所属する組織一覧の生成 (組織が存在しない所属は除外する)
+func NewOrganizations(organizations entity.Organizations, members entity.OrganizationMembers) Organizations {
+	organizationMap := organizations.Map()
+	res := make(Organizations, 0, len(members))
+	for _, member := range members {
+		organization, ok := organizationMap[member.OrganizationID]
+		if !ok {
+			continue
+		}
+		res = append(res, NewOrganization(organization, member))
+	}
+	return res
+}
+
+func (o *Organization) Response() *response.Organization {
+	return &o.Organization
+}
+
+func (os Organizations) Response() []*response.Organization {
+	res := make([]*response.Organization, len(os))
+	for i := range os {
+		res[i] = os[i].Response()
+	}
+	return res
+}
+
+func NewOrganizationMember(member *entity.OrganizationMember, admin *entity.Admin) *OrganizationMember {
+	return &OrganizationMember{
+		OrganizationMember: response.OrganizationMember{
+			AdminID:   member.AdminID,
+			Email:     admin.Email,
+			Role:      int32(member.Role),
+			CreatedAt: member.CreatedAt,
+			UpdatedAt: member.UpdatedAt,
+		},
+	}
+}
+
+// NewOrganizationMembers - メンバー一覧の生成 (退会済みの管理者は除外する)
+func NewOrganizationMembers(members entity.OrganizationMembers, admins entity.Admins) OrganizationMembers {
+	adminMap := admins.Map()
+	res := make(OrganizationMembers, 0, len(members))
+	for _, member := range members {
+		admin, ok := adminMap[member.AdminID]
+		if !ok {
+			continue
+		}
+		res = append(res, NewOrganizationMember(member, admin))
+	}
+	return res
+}
+
+func (m *OrganizationMember) Response() *response.OrganizationMember {
+	return &m.OrganizationMember
+}
+
+func (ms OrganizationMembers) Response() []*response.OrganizationMember {
+	res := make([]*response.OrganizationMember, len(ms))
+	for i := range ms {
+		res[i] = ms[i].Response()
+	}
+	return res
+}
@@ -0,0 +1,200 @@
+package trigger
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/i18n"
+	"github.com/and-period/furumane/pkg/mailer"
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+)
+
+const (
+	customChallenge = "CUSTOM_CHALLENGE"
+	// ワンタイムコードのチャレンジのメタデータ (CODE-{コード}-{有効期限(UNIX時間)})
+	codeMetadataPrefix = "CODE-"
+	// パスキーのチャレンジのメタデータ
+	passkeyMetadata = "PASSKEY"
+	// チャレンジのパラメータ
+	challengeParamKey   = "challenge"
+	codeParamKey        = "code"
+	expiresAtParamKey   = "expiresAt"
+	deliveryParamKey    = "deliveryMedium"
+	deliveryMediumEmail = "EMAIL"
+)
+
+var (
+	errUnsupportedSignInMethod = errors.New("trigger: unsupported sign-in method")
+	errNotFoundEmail           = errors.New("trigger: not found email")
+	errInvalidCodeMetadata     = errors.New("trigger: invalid code metadata")
+)
+
+// defineAuthChallenge - 次に提示するチャレンジの判定
+// パラメータ受け渡し用のチャレンジの後にサインイン方法に応じたチャレンジを提示し、
+// 検証に成功した場合はトークンを発行、失敗回数が上限に達した場合は認証失敗とする
+func (h *handler) defineAuthChallenge(
+	_ context.Context, event *events.CognitoEventUserPoolsDefineAuthChallenge,
+) (*events.CognitoEventUserPoolsDefineAuthChallenge, error) {
+	sessions := event.Request.Session
+	if event.Request.UserNotFound {
+		event.Response.FailAuthentication = true
+		return event, nil
+	}
+	var failures int
+	for _, s := range sessions {
+		if s.ChallengeName != customChallenge {
+			// カスタム認証以外のチャレンジは許可しない
+			event.Response.FailAuthentication = true
+			return event, nil
+		}
+		if !s.ChallengeResult {
+			failures++
+		}
+	}
+	switch {
+	case len(sessions) == 0:
+		event.Response.ChallengeName = customChallenge
+	case sessions[len(sessions)-1].ChallengeResult &&
+		sessions[len(sessions)-1].ChallengeMetadata != entity.AuthChallengeProvideParameters:
+		event.Response.IssueTokens = true
+	case failures >= h.maxAttempts:
+		event.Response.FailAuthentication = true
+	default:
+		event.Response.ChallengeName = customChallenge
+	}
+	return event, nil
+}
+
+// createAuthChallenge - チャレンジの生成
+// 再試行時は直前のチャレンジと同じワンタイムコードを使用し、メールの再送は行わない
+func (h *handler) createAuthChallenge(
+	ctx context.Context, event *events.CognitoEventUserPoolsCreateAuthChallenge,
+) (*events.CognitoEventUserPoolsCreateAuthChallenge, error) {
+	if len(event.Request.Session) == 0 {
+		event.Response.PublicChallengeParameters = map[string]string{
+			challengeParamKey: entity.AuthChallengeProvideParameters,
+		}
+		event.Response.PrivateChallengeParameters = map[string]string{
+			challengeParamKey: entity.AuthChallengeProvideParameters,
+		}
+		event.Response.ChallengeMetadata = entity.AuthChallengeProvideParameters
+		return event, nil
+	}
+	method := entity.SignInMethod(event.Request.ClientMetadata[entity.AuthMetadataSignInMethod])
+	switch method {
+	case entity.SignInMethodEmailOTP:
+		return h.createCodeChallenge(ctx, event)
+	case entity.SignInMethodPasskey:
+		// パスキーの署名はAPIサーバー側で検証済みのため、検証結果の回答を求める
+		event.Response.PublicChallengeParameters = map[string]string{
+			challengeParamKey: string(entity.SignInMethodPasskey),
+		}
+		event.Response.PrivateChallengeParameters = map[string]string{
+			challengeParamKey: string(entity.SignInMethodPasskey),
+		}
+		event.Response.ChallengeMetadata = passkeyMetadata
+		return event, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedSignInMethod, method)
+	}
+}
+
+func (h *handler) createCodeChallenge(
+	ctx context.Context, event *events.CognitoEventUserPoolsCreateAuthChallenge,
+) (*events.CognitoEventUserPoolsCreateAuthChallenge, error) {
+	code, expiresAt, err := h.newCode(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	event.Response.PublicChallengeParameters = map[string]string{
+		challengeParamKey: string(entity.SignInMethodEmailOTP),
+		deliveryParamKey:  deliveryMediumEmail,
+	}
+	event.Response.PrivateChallengeParameters = map[string]string{
+		challengeParamKey: string(entity.SignInMethodEmailOTP),
+		codeParamKey:      code,
+		expiresAtParamKey: strconv.FormatInt(expiresAt.Unix(), 10),
+	}
+	event.Response.ChallengeMetadata = codeMetadata(code, expiresAt)
+	return event, nil
+}
+
+func (h *handler) newCode(
+	ctx context.Context, event *events.CognitoEventUserPoolsCreateAuthChallenge,
+) (string, time.Time, error) {
+	last := event.Request.Session[len(event.Request.Session)-1]
+	if strings.HasPrefix(last.ChallengeMetadata, codeMetadataPrefix) {
+		return parseCodeMetadata(last.ChallengeMetadata)
+	}
+	email := event.Request.UserAttributes["email"]
+	if email == "" {
+		return "", time.Time{}, errNotFoundEmail
+	}
+	code, err := h.generateCode()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := h.now().Add(h.codeTTL)
+	locale := i18n.Locale(event.Request.ClientMetadata[entity.AuthMetadataLocale])
+	params := &mailer.SendParams{
+		To:      []string{email},
+		Subject: messages.Message(locale, codeSubjectKey),
+		Body:    messages.Message(locale, codeBodyKey, code, int64(h.codeTTL.Minutes())),
+	}
+	if err := h.mailer.Send(ctx, params); err != nil {
+		h.logger.Error("Failed to send one-time code", zap.String("userName", event.UserName), zap.Error(err))
+		return "", time.Time{}, err
+	}
+	return code, expiresAt, nil
+}
+
+// verifyAuthChallenge - チャレンジへの回答の検証
+func (h *handler) verifyAuthChallenge(
+	_ context.Context, event *events.CognitoEventUserPoolsVerifyAuthChallenge,
+) (*events.CognitoEventUserPoolsVerifyAuthChallenge, error) {
+	params := event.Request.PrivateChallengeParameters
+	answer, _ := event.Request.ChallengeAnswer.(string)
+	switch params[challengeParamKey] {
+	case entity.AuthChallengeProvideParameters:
+		event.Response.AnswerCorrect = answer == entity.AuthChallengeProvideParametersAnswer
+	case string(entity.SignInMethodEmailOTP):
+		event.Response.AnswerCorrect = h.verifyCode(params, answer)
+	case string(entity.SignInMethodPasskey):
+		event.Response.AnswerCorrect = entity.VerifyPasskeyAnswer(h.challengeSecret, event.UserName, answer, h.now())
+	default:
+		event.Response.AnswerCorrect = false
+	}
+	return event, nil
+}
+
+func (h *handler) verifyCode(params map[string]string, answer string) bool {
+	expiresAt, err := strconv.ParseInt(params[expiresAtParamKey], 10, 64)
+	if err != nil || !h.now().Before(time.Unix(expiresAt, 0)) {
+		return false
+	}
+	code := params[codeParamKey]
+	return code != "" && subtle.ConstantTimeCompare([]byte(code), []byte(answer)) == 1
+}
+
+func codeMetadata(code string, expiresAt time.Time) string {
+	return fmt.Sprintf("%s%s-%d", codeMetadataPrefix, code, expiresAt.Unix())
+}
+
+func parseCodeMetadata(metadata string) (string, time.Time, error) {
+	strs := strings.Split(strings.TrimPrefix(metadata, codeMetadataPrefix), "-")
+	if len(strs) != 2 {
+		return "", time.Time{}, errInvalidCodeMetadata
+	}
+	expiresAt, err := strconv.ParseInt(strs[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %s", errInvalidCodeMetadata, err.Error())
+	}
+	return strs[0], time.Unix(expiresAt, 0), nil
+}
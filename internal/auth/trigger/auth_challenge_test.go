@@ -0,0 +1,344 @@
+package trigger
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/internal/auth/entity"
+	"github.com/and-period/furumane/pkg/mailer"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDefineAuthChallenge(t *testing.T) {
+	t.Parallel()
+	provide := &events.CognitoEventUserPoolsChallengeResult{
+		ChallengeName:     customChallenge,
+		ChallengeResult:   true,
+		ChallengeMetadata: entity.AuthChallengeProvideParameters,
+	}
+	failed := &events.CognitoEventUserPoolsChallengeResult{
+		ChallengeName:     customChallenge,
+		ChallengeResult:   false,
+		ChallengeMetadata: "CODE-123456-1696152900",
+	}
+	succeeded := &events.CognitoEventUserPoolsChallengeResult{
+		ChallengeName:     customChallenge,
+		ChallengeResult:   true,
+		ChallengeMetadata: "CODE-123456-1696152900",
+	}
+	tests := []struct {
+		name    string
+		request events.CognitoEventUserPoolsDefineAuthChallengeRequest
+		expect  events.CognitoEventUserPoolsDefineAuthChallengeResponse
+	}{
+		{
+			name:    "first challenge",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{},
+			expect:  events.CognitoEventUserPoolsDefineAuthChallengeResponse{ChallengeName: customChallenge},
+		},
+		{
+			name: "provided parameters",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{provide},
+			},
+			expect: events.CognitoEventUserPoolsDefineAuthChallengeResponse{ChallengeName: customChallenge},
+		},
+		{
+			name: "retry",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{provide, failed, failed},
+			},
+			expect: events.CognitoEventUserPoolsDefineAuthChallengeResponse{ChallengeName: customChallenge},
+		},
+		{
+			name: "issue tokens",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{provide, failed, succeeded},
+			},
+			expect: events.CognitoEventUserPoolsDefineAuthChallengeResponse{IssueTokens: true},
+		},
+		{
+			name: "too many failed attempts",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{provide, failed, failed, failed},
+			},
+			expect: events.CognitoEventUserPoolsDefineAuthChallengeResponse{FailAuthentication: true},
+		},
+		{
+			name: "not custom challenge",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{{ChallengeName: "SRP_A", ChallengeResult: true}},
+			},
+			expect: events.CognitoEventUserPoolsDefineAuthChallengeResponse{FailAuthentication: true},
+		},
+		{
+			name:    "user not found",
+			request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{UserNotFound: true},
+			expect:  events.CognitoEventUserPoolsDefineAuthChallengeResponse{FailAuthentication: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHandler(t, func(mocks *mocks) {}, func(ctx context.Context, t *testing.T, h *handler) {
+				event := &events.CognitoEventUserPoolsDefineAuthChallenge{Request: tt.request}
+				actual, err := h.defineAuthChallenge(ctx, event)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expect, actual.Response)
+			})
+		})
+	}
+}
+
+func TestCreateAuthChallenge(t *testing.T) {
+	t.Parallel()
+	expiresAt := current.Add(5 * time.Minute).Unix()
+	metadata := "CODE-123456-" + strconv.FormatInt(expiresAt, 10)
+	provide := &events.CognitoEventUserPoolsChallengeResult{
+		ChallengeName:     customChallenge,
+		ChallengeResult:   true,
+		ChallengeMetadata: entity.AuthChallengeProvideParameters,
+	}
+	failed := &events.CognitoEventUserPoolsChallengeResult{
+		ChallengeName:     customChallenge,
+		ChallengeResult:   false,
+		ChallengeMetadata: metadata,
+	}
+	clientMetadata := map[string]string{
+		entity.AuthMetadataSignInMethod: string(entity.SignInMethodEmailOTP),
+		entity.AuthMetadataLocale:       "en",
+	}
+	otpResponse := events.CognitoEventUserPoolsCreateAuthChallengeResponse{
+		PublicChallengeParameters: map[string]string{
+			challengeParamKey: "email_otp",
+			deliveryParamKey:  "EMAIL",
+		},
+		PrivateChallengeParameters: map[string]string{
+			challengeParamKey: "email_otp",
+			codeParamKey:      "123456",
+			expiresAtParamKey: strconv.FormatInt(expiresAt, 10),
+		},
+		ChallengeMetadata: metadata,
+	}
+	tests := []struct {
+		name      string
+		setup     func(mocks *mocks)
+		request   events.CognitoEventUserPoolsCreateAuthChallengeRequest
+		expect    events.CognitoEventUserPoolsCreateAuthChallengeResponse
+		expectErr bool
+	}{
+		{
+			name:    "provide parameters",
+			setup:   func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{},
+			expect: events.CognitoEventUserPoolsCreateAuthChallengeResponse{
+				PublicChallengeParameters:  map[string]string{challengeParamKey: "PROVIDE_AUTH_PARAMETERS"},
+				PrivateChallengeParameters: map[string]string{challengeParamKey: "PROVIDE_AUTH_PARAMETERS"},
+				ChallengeMetadata:          "PROVIDE_AUTH_PARAMETERS",
+			},
+		},
+		{
+			name: "send code",
+			setup: func(mocks *mocks) {
+				params := &mailer.SendParams{
+					To:      []string{"test@example.com"},
+					Subject: "[furumane] Your sign-in verification code",
+					Body:    "Your sign-in verification code is 123456.\nThis code expires in 5 minutes.\n\nIf you did not request this code, please ignore this email.",
+				}
+				mocks.mailer.EXPECT().Send(gomock.Any(), params).Return(nil)
+			},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				UserAttributes: map[string]string{"email": "test@example.com"},
+				Session:        []*events.CognitoEventUserPoolsChallengeResult{provide},
+				ClientMetadata: clientMetadata,
+			},
+			expect: otpResponse,
+		},
+		{
+			name:  "reuse code",
+			setup: func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				UserAttributes: map[string]string{"email": "test@example.com"},
+				Session:        []*events.CognitoEventUserPoolsChallengeResult{provide, failed},
+				ClientMetadata: clientMetadata,
+			},
+			expect: otpResponse,
+		},
+		{
+			name:  "passkey",
+			setup: func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{provide},
+				ClientMetadata: map[string]string{
+					entity.AuthMetadataSignInMethod: string(entity.SignInMethodPasskey),
+				},
+			},
+			expect: events.CognitoEventUserPoolsCreateAuthChallengeResponse{
+				PublicChallengeParameters:  map[string]string{challengeParamKey: "passkey"},
+				PrivateChallengeParameters: map[string]string{challengeParamKey: "passkey"},
+				ChallengeMetadata:          "PASSKEY",
+			},
+		},
+		{
+			name:  "unsupported sign-in method",
+			setup: func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				UserAttributes: map[string]string{"email": "test@example.com"},
+				Session:        []*events.CognitoEventUserPoolsChallengeResult{provide},
+			},
+			expectErr: true,
+		},
+		{
+			name:  "not found email",
+			setup: func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				Session:        []*events.CognitoEventUserPoolsChallengeResult{provide},
+				ClientMetadata: clientMetadata,
+			},
+			expectErr: true,
+		},
+		{
+			name: "failed to send code",
+			setup: func(mocks *mocks) {
+				mocks.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				UserAttributes: map[string]string{"email": "test@example.com"},
+				Session:        []*events.CognitoEventUserPoolsChallengeResult{provide},
+				ClientMetadata: clientMetadata,
+			},
+			expectErr: true,
+		},
+		{
+			name:  "invalid code metadata",
+			setup: func(mocks *mocks) {},
+			request: events.CognitoEventUserPoolsCreateAuthChallengeRequest{
+				Session: []*events.CognitoEventUserPoolsChallengeResult{
+					provide,
+					{ChallengeName: customChallenge, ChallengeMetadata: "CODE-123456"},
+				},
+				ClientMetadata: clientMetadata,
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHandler(t, tt.setup, func(ctx context.Context, t *testing.T, h *handler) {
+				event := &events.CognitoEventUserPoolsCreateAuthChallenge{Request: tt.request}
+				actual, err := h.createAuthChallenge(ctx, event)
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expect, actual.Response)
+			})
+		})
+	}
+}
+
+func TestVerifyAuthChallenge(t *testing.T) {
+	t.Parallel()
+	otpParams := func(expiresAt time.Time) map[string]string {
+		return map[string]string{
+			challengeParamKey: "email_otp",
+			codeParamKey:      "123456",
+			expiresAtParamKey: strconv.FormatInt(expiresAt.Unix(), 10),
+		}
+	}
+	tests := []struct {
+		name   string
+		params map[string]string
+		answer interface{}
+		expect bool
+	}{
+		{
+			name:   "provide parameters",
+			params: map[string]string{challengeParamKey: "PROVIDE_AUTH_PARAMETERS"},
+			answer: "__dummy__",
+			expect: true,
+		},
+		{
+			name:   "invalid provide parameters answer",
+			params: map[string]string{challengeParamKey: "PROVIDE_AUTH_PARAMETERS"},
+			answer: "123456",
+			expect: false,
+		},
+		{
+			name:   "correct code",
+			params: otpParams(current.Add(time.Minute)),
+			answer: "123456",
+			expect: true,
+		},
+		{
+			name:   "code mismatch",
+			params: otpParams(current.Add(time.Minute)),
+			answer: "654321",
+			expect: false,
+		},
+		{
+			name:   "code expired",
+			params: otpParams(current),
+			answer: "123456",
+			expect: false,
+		},
+		{
+			name:   "invalid answer type",
+			params: otpParams(current.Add(time.Minute)),
+			answer: 123456,
+			expect: false,
+		},
+		{
+			name: "invalid expires at",
+			params: map[string]string{
+				challengeParamKey: "email_otp",
+				codeParamKey:      "123456",
+				expiresAtParamKey: "invalid",
+			},
+			answer: "123456",
+			expect: false,
+		},
+		{
+			name:   "passkey",
+			params: map[string]string{challengeParamKey: "passkey"},
+			answer: entity.NewPasskeyAnswer([]byte("secret"), "username", current.Add(time.Minute)),
+			expect: true,
+		},
+		{
+			name:   "invalid passkey answer",
+			params: map[string]string{challengeParamKey: "passkey"},
+			answer: entity.NewPasskeyAnswer([]byte("other"), "username", current.Add(time.Minute)),
+			expect: false,
+		},
+		{
+			name:   "unknown challenge",
+			params: map[string]string{},
+			answer: "123456",
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHandler(t, func(mocks *mocks) {}, func(ctx context.Context, t *testing.T, h *handler) {
+				event := &events.CognitoEventUserPoolsVerifyAuthChallenge{
+					CognitoEventUserPoolsHeader: events.CognitoEventUserPoolsHeader{UserName: "username"},
+					Request: events.CognitoEventUserPoolsVerifyAuthChallengeRequest{
+						PrivateChallengeParameters: tt.params,
+						ChallengeAnswer:            tt.answer,
+					},
+				}
+				actual, err := h.verifyAuthChallenge(ctx, event)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expect, actual.Response.AnswerCorrect)
+			})
+		})
+	}
+}
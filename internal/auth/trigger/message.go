@@ -0,0 +1,20 @@
+package trigger
+
+import "github.com/and-period/furumane/pkg/i18n"
+
+const (
+	codeSubjectKey = "code.subject"
+	codeBodyKey    = "code.body"
+)
+
+// messages - 通知メッセージの一覧
+var messages = i18n.Catalog{
+	codeSubjectKey: {
+		i18n.LocaleJA: "【furumane】サインイン用の確認コード",
+		i18n.LocaleEN: "[furumane] Your sign-in verification code",
+	},
+	codeBodyKey: {
+		i18n.LocaleJA: "サインイン用の確認コードは %[1]s です。\n有効期限は%[2]d分です。\n\nお心当たりのない場合は、このメールを破棄してください。",
+		i18n.LocaleEN: "Your sign-in verification code is %[1]s.\nThis code expires in %[2]d minutes.\n\nIf you did not request this code, please ignore this email.",
+	},
+}
@@ -0,0 +1,141 @@
+package trigger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/mailer"
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+)
+
+// Amazon Cognitoのトリガー種別
+const (
+	sourceDefineAuthChallenge = "DefineAuthChallenge_Authentication"
+	sourceCreateAuthChallenge = "CreateAuthChallenge_Authentication"
+	sourceVerifyAuthChallenge = "VerifyAuthChallengeResponse_Authentication"
+)
+
+var errUnsupportedTrigger = errors.New("trigger: unsupported trigger source")
+
+// Handler - Amazon Cognitoのトリガーを処理するLambdaハンドラ
+type Handler interface {
+	Handle(ctx context.Context, event json.RawMessage) (interface{}, error)
+}
+
+type Params struct {
+	Mailer mailer.Client
+}
+
+type handler struct {
+	now             func() time.Time
+	logger          *zap.Logger
+	mailer          mailer.Client
+	codeTTL         time.Duration
+	maxAttempts     int
+	challengeSecret []byte
+	generateCode    func() (string, error)
+}
+
+type options struct {
+	logger          *zap.Logger
+	codeTTL         time.Duration
+	maxAttempts     int
+	challengeSecret []byte
+}
+
+type Option func(*options)
+
+func WithLogger(logger *zap.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithCodeTTL - ワンタイムコードの有効期間
+func WithCodeTTL(ttl time.Duration) Option {
+	return func(opts *options) {
+		opts.codeTTL = ttl
+	}
+}
+
+// WithMaxAttempts - ワンタイムコードの検証を試行できる回数
+func WithMaxAttempts(attempts int) Option {
+	return func(opts *options) {
+		opts.maxAttempts = attempts
+	}
+}
+
+// WithChallengeSecret - パスキー認証の回答の署名に使用する共通鍵
+func WithChallengeSecret(secret []byte) Option {
+	return func(opts *options) {
+		opts.challengeSecret = secret
+	}
+}
+
+func NewHandler(params *Params, opts ...Option) Handler {
+	dopts := &options{
+		logger:      zap.NewNop(),
+		codeTTL:     5 * time.Minute,
+		maxAttempts: 3,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &handler{
+		now:             jst.Now,
+		logger:          dopts.logger,
+		mailer:          params.Mailer,
+		codeTTL:         dopts.codeTTL,
+		maxAttempts:     dopts.maxAttempts,
+		challengeSecret: dopts.challengeSecret,
+		generateCode:    generateCode,
+	}
+}
+
+func (h *handler) Handle(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	header := &events.CognitoEventUserPoolsHeader{}
+	if err := json.Unmarshal(event, header); err != nil {
+		return nil, err
+	}
+	h.logger.Debug("Received cognito trigger",
+		zap.String("triggerSource", header.TriggerSource), zap.String("userName", header.UserName))
+
+	switch header.TriggerSource {
+	case sourceDefineAuthChallenge:
+		in := &events.CognitoEventUserPoolsDefineAuthChallenge{}
+		if err := json.Unmarshal(event, in); err != nil {
+			return nil, err
+		}
+		return h.defineAuthChallenge(ctx, in)
+	case sourceCreateAuthChallenge:
+		in := &events.CognitoEventUserPoolsCreateAuthChallenge{}
+		if err := json.Unmarshal(event, in); err != nil {
+			return nil, err
+		}
+		return h.createAuthChallenge(ctx, in)
+	case sourceVerifyAuthChallenge:
+		in := &events.CognitoEventUserPoolsVerifyAuthChallenge{}
+		if err := json.Unmarshal(event, in); err != nil {
+			return nil, err
+		}
+		return h.verifyAuthChallenge(ctx, in)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedTrigger, header.TriggerSource)
+	}
+}
+
+// generateCode - 6桁のワンタイムコードを生成
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,141 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mock_mailer "github.com/and-period/furumane/mock/pkg/mailer"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+var current = jst.Date(2023, 10, 1, 18, 30, 0, 0)
+
+type mocks struct {
+	mailer *mock_mailer.MockClient
+}
+
+func newMocks(ctrl *gomock.Controller) *mocks {
+	return &mocks{
+		mailer: mock_mailer.NewMockClient(ctrl),
+	}
+}
+
+func newHandler(mocks *mocks) *handler {
+	params := &Params{
+		Mailer: mocks.mailer,
+	}
+	h := NewHandler(params, WithChallengeSecret([]byte("secret"))).(*handler)
+	h.now = func() time.Time {
+		return current
+	}
+	h.generateCode = func() (string, error) {
+		return "123456", nil
+	}
+	return h
+}
+
+func testHandler(t *testing.T, setup func(*mocks), testFunc func(context.Context, *testing.T, *handler)) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMocks(ctrl)
+	setup(mocks)
+	testFunc(ctx, t, newHandler(mocks))
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(&Params{}, WithLogger(zap.NewNop()), WithCodeTTL(time.Minute), WithMaxAttempts(5))
+	assert.NotNil(t, h)
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		setup     func(mocks *mocks)
+		event     string
+		expect    interface{}
+		expectErr bool
+	}{
+		{
+			name:  "define auth challenge",
+			setup: func(mocks *mocks) {},
+			event: `{"triggerSource":"DefineAuthChallenge_Authentication","userName":"username","request":{"session":[]}}`,
+			expect: &events.CognitoEventUserPoolsDefineAuthChallenge{
+				CognitoEventUserPoolsHeader: events.CognitoEventUserPoolsHeader{
+					TriggerSource: sourceDefineAuthChallenge,
+					UserName:      "username",
+				},
+				Request: events.CognitoEventUserPoolsDefineAuthChallengeRequest{
+					Session: []*events.CognitoEventUserPoolsChallengeResult{},
+				},
+				Response: events.CognitoEventUserPoolsDefineAuthChallengeResponse{
+					ChallengeName: customChallenge,
+				},
+			},
+		},
+		{
+			name:  "verify auth challenge",
+			setup: func(mocks *mocks) {},
+			event: `{"triggerSource":"VerifyAuthChallengeResponse_Authentication","userName":"username","request":{"privateChallengeParameters":{"challenge":"PROVIDE_AUTH_PARAMETERS"},"challengeAnswer":"__dummy__"}}`,
+			expect: &events.CognitoEventUserPoolsVerifyAuthChallenge{
+				CognitoEventUserPoolsHeader: events.CognitoEventUserPoolsHeader{
+					TriggerSource: sourceVerifyAuthChallenge,
+					UserName:      "username",
+				},
+				Request: events.CognitoEventUserPoolsVerifyAuthChallengeRequest{
+					PrivateChallengeParameters: map[string]string{challengeParamKey: "PROVIDE_AUTH_PARAMETERS"},
+					ChallengeAnswer:            "__dummy__",
+				},
+				Response: events.CognitoEventUserPoolsVerifyAuthChallengeResponse{
+					AnswerCorrect: true,
+				},
+			},
+		},
+		{
+			name:      "unsupported trigger source",
+			setup:     func(mocks *mocks) {},
+			event:     `{"triggerSource":"PreSignUp_SignUp"}`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid event",
+			setup:     func(mocks *mocks) {},
+			event:     `{`,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHandler(t, tt.setup, func(ctx context.Context, t *testing.T, h *handler) {
+				actual, err := h.Handle(ctx, json.RawMessage(tt.event))
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expect, actual)
+			})
+		})
+	}
+}
+
+func TestGenerateCode(t *testing.T) {
+	t.Parallel()
+	code, err := generateCode()
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9]{6}$`, code)
+}
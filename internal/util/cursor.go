@@ -0,0 +1,188 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CursorQuery - 次ページの取得に使用するカーソルのクエリパラメータ
+const CursorQuery = "cursor"
+
+var (
+	ErrInvalidCursor        = errors.New("util: invalid cursor")
+	ErrCursorSecretRequired = errors.New("util: cursor secret is required")
+)
+
+// Cursor - カーソルページングの開始位置
+// クライアントには署名付きの不透明な文字列として返し、改ざんされたカーソルは受け付けない
+type Cursor struct {
+	Orders []*Order      // 発行時のソート順
+	Values []interface{} // 前ページの最終行のソートキーと主キーの値
+}
+
+type cursorPayload struct {
+	Orders string         `json:"o"`
+	Values []*cursorValue `json:"v"`
+}
+
+// cursorValue - 型を保持したまま復元するための値 (いずれか1つのみ指定)
+type cursorValue struct {
+	String *string    `json:"s,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	Uint   *uint64    `json:"u,omitempty"`
+	Float  *float64   `json:"f,omitempty"`
+	Bool   *bool      `json:"b,omitempty"`
+	Time   *time.Time `json:"t,omitempty"`
+}
+
+// GetCursor - カーソルの取得 (未指定の場合はnilを返す)
+// 発行時とソート順 (ordersクエリ) が異なる場合は、取得位置が不定となるためエラーとする
+func GetCursor(ctx *gin.Context, secret []byte) (*Cursor, error) {
+	str := GetQuery(ctx, CursorQuery, "")
+	if str == "" {
+		return nil, nil
+	}
+	cursor, err := DecodeCursor(secret, str)
+	if err != nil {
+		return nil, err
+	}
+	if formatOrders(cursor.Orders) != formatOrders(GetOrders(ctx)) {
+		return nil, fmt.Errorf("%w: orders do not match the cursor", ErrInvalidCursor)
+	}
+	return cursor, nil
+}
+
+// EncodeCursor - カーソルを署名付きの文字列に変換 (共通鍵が未設定の場合はエラー)
+func EncodeCursor(secret []byte, cursor *Cursor) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrCursorSecretRequired
+	}
+	payload := &cursorPayload{
+		Orders: formatOrders(cursor.Orders),
+		Values: make([]*cursorValue, len(cursor.Values)),
+	}
+	for i := range cursor.Values {
+		value, err := newCursorValue(cursor.Values[i])
+		if err != nil {
+			return "", err
+		}
+		payload.Values[i] = value
+	}
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(buf)
+	signature := base64.RawURLEncoding.EncodeToString(signCursor(secret, body))
+	return body + "." + signature, nil
+}
+
+// DecodeCursor - 署名を検証した上でカーソルを復元 (共通鍵が未設定の場合は、偽造を防ぐためにエラー)
+func DecodeCursor(secret []byte, str string) (*Cursor, error) {
+	if len(secret) == 0 {
+		return nil, ErrCursorSecretRequired
+	}
+	body, signature, ok := strings.Cut(str, ".")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidCursor)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, signCursor(secret, body)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err.Error())
+	}
+	payload := &cursorPayload{}
+	if err := json.Unmarshal(buf, payload); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err.Error())
+	}
+	cursor := &Cursor{
+		Orders: parseOrders(payload.Orders),
+		Values: make([]interface{}, len(payload.Values)),
+	}
+	for i := range payload.Values {
+		value, err := payload.Values[i].value()
+		if err != nil {
+			return nil, err
+		}
+		cursor.Values[i] = value
+	}
+	return cursor, nil
+}
+
+func signCursor(secret []byte, body string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func newCursorValue(v interface{}) (*cursorValue, error) {
+	if t, ok := v.(time.Time); ok {
+		return &cursorValue{Time: &t}, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		s := rv.String()
+		return &cursorValue{String: &s}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		return &cursorValue{Int: &i}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		return &cursorValue{Uint: &u}, nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return &cursorValue{Float: &f}, nil
+	case reflect.Bool:
+		b := rv.Bool()
+		return &cursorValue{Bool: &b}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported value type: %T", ErrInvalidCursor, v)
+	}
+}
+
+func (v *cursorValue) value() (interface{}, error) {
+	switch {
+	case v == nil:
+		return nil, fmt.Errorf("%w: empty value", ErrInvalidCursor)
+	case v.String != nil:
+		return *v.String, nil
+	case v.Int != nil:
+		return *v.Int, nil
+	case v.Uint != nil:
+		return *v.Uint, nil
+	case v.Float != nil:
+		return *v.Float, nil
+	case v.Bool != nil:
+		return *v.Bool, nil
+	case v.Time != nil:
+		return *v.Time, nil
+	default:
+		return nil, fmt.Errorf("%w: empty value", ErrInvalidCursor)
+	}
+}
+
+// formatOrders - ソート順をordersクエリの形式に変換 (e.g. foo,-bar)
+func formatOrders(orders []*Order) string {
+	strs := make([]string, len(orders))
+	for i, o := range orders {
+		if o.Direction == OrderByDesc {
+			strs[i] = "-" + o.Key
+		} else {
+			strs[i] = o.Key
+		}
+	}
+	return strings.Join(strs, ",")
+}
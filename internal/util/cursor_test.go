@@ -0,0 +1,131 @@
+package util
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+	secret := []byte("secret")
+	now := time.Date(2023, 10, 5, 18, 30, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	type role int32
+	cursor := &Cursor{
+		Orders: []*Order{
+			{Key: "role", Direction: OrderByDesc},
+			{Key: "createdAt", Direction: OrderByASC},
+		},
+		Values: []interface{}{role(2), now, "admin-id", uint32(1), 1.5, true},
+	}
+	str, err := EncodeCursor(secret, cursor)
+	require.NoError(t, err)
+
+	t.Run("decode", func(t *testing.T) {
+		t.Parallel()
+		actual, err := DecodeCursor(secret, str)
+		require.NoError(t, err)
+		assert.Equal(t, cursor.Orders, actual.Orders)
+		require.Len(t, actual.Values, 6)
+		assert.Equal(t, int64(2), actual.Values[0])
+		assert.True(t, now.Equal(actual.Values[1].(time.Time)))
+		assert.Equal(t, "admin-id", actual.Values[2])
+		assert.Equal(t, uint64(1), actual.Values[3])
+		assert.Equal(t, 1.5, actual.Values[4])
+		assert.Equal(t, true, actual.Values[5])
+	})
+	t.Run("tampered", func(t *testing.T) {
+		t.Parallel()
+		other, err := EncodeCursor(secret, &Cursor{Orders: cursor.Orders, Values: []interface{}{"other-id"}})
+		require.NoError(t, err)
+		// 別のカーソルの署名を流用する
+		body, _, _ := strings.Cut(other, ".")
+		_, signature, _ := strings.Cut(str, ".")
+		_, err = DecodeCursor(secret, body+"."+signature)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+	t.Run("different secret", func(t *testing.T) {
+		t.Parallel()
+		_, err := DecodeCursor([]byte("other"), str)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+	t.Run("empty secret", func(t *testing.T) {
+		t.Parallel()
+		_, err := EncodeCursor(nil, cursor)
+		assert.ErrorIs(t, err, ErrCursorSecretRequired)
+		forged, err := EncodeCursor([]byte("secret"), cursor)
+		require.NoError(t, err)
+		body, _, _ := strings.Cut(forged, ".")
+		_, err = DecodeCursor(nil, body+"."+base64.RawURLEncoding.EncodeToString(signCursor(nil, body)))
+		assert.ErrorIs(t, err, ErrCursorSecretRequired)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		_, err := DecodeCursor(secret, "cursor")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+	t.Run("unsupported value", func(t *testing.T) {
+		t.Parallel()
+		_, err := EncodeCursor(secret, &Cursor{Values: []interface{}{[]string{"a"}}})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestGetCursor(t *testing.T) {
+	t.Parallel()
+	secret := []byte("secret")
+	cursor := &Cursor{
+		Orders: []*Order{{Key: "createdAt", Direction: OrderByDesc}},
+		Values: []interface{}{"admin-id"},
+	}
+	str, err := EncodeCursor(secret, cursor)
+	require.NoError(t, err)
+	tests := []struct {
+		name   string
+		query  url.Values
+		expect *Cursor
+		hasErr bool
+	}{
+		{
+			name:   "success",
+			query:  url.Values{"orders": {"-createdAt"}, "cursor": {str}},
+			expect: cursor,
+		},
+		{
+			name:   "empty",
+			query:  url.Values{"orders": {"-createdAt"}},
+			expect: nil,
+		},
+		{
+			name:   "orders mismatch",
+			query:  url.Values{"orders": {"createdAt"}, "cursor": {str}},
+			hasErr: true,
+		},
+		{
+			name:   "invalid cursor",
+			query:  url.Values{"orders": {"-createdAt"}, "cursor": {"invalid"}},
+			hasErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = &http.Request{URL: &url.URL{RawQuery: tt.query.Encode()}}
+			actual, err := GetCursor(ctx, secret)
+			assert.Equal(t, tt.hasErr, err != nil, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
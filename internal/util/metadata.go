@@ -7,16 +7,44 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-var AuthTokenType = "Bearer"
+var (
+	AuthTokenType        = "Bearer"
+	APIKeyType           = "ApiKey"
+	APIKeyHeader         = "X-API-Key"
+	OrganizationIDHeader = "X-Organization-ID"
+)
 
-var errNotExistsAuthorizationHeader = errors.New("util: authorization header is not contain")
+var (
+	errNotExistsAuthorizationHeader = errors.New("util: authorization header is not contain")
+	errNotExistsAPIKey              = errors.New("util: api key is not contain")
+	errNotExistsOrganizationID      = errors.New("util: organization id is not contain")
+)
 
 func GetAuthToken(c *gin.Context) (string, error) {
 	authorization := c.GetHeader("Authorization")
-	if authorization == "" {
+	if authorization == "" || strings.HasPrefix(authorization, APIKeyType+" ") {
 		return "", errNotExistsAuthorizationHeader
 	}
 
 	token := strings.TrimPrefix(authorization, AuthTokenType+" ")
 	return token, nil
 }
+
+// GetAPIKey - APIキーを取得 (Authorization: ApiKey {key} または X-API-Key: {key})
+func GetAPIKey(c *gin.Context) (string, error) {
+	if key, ok := strings.CutPrefix(c.GetHeader("Authorization"), APIKeyType+" "); ok && key != "" {
+		return key, nil
+	}
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		return key, nil
+	}
+	return "", errNotExistsAPIKey
+}
+
+// GetOrganizationID - 操作対象の組織IDを取得 (X-Organization-ID: {organizationId})
+func GetOrganizationID(c *gin.Context) (string, error) {
+	if organizationID := c.GetHeader(OrganizationIDHeader); organizationID != "" {
+		return organizationID, nil
+	}
+	return "", errNotExistsOrganizationID
+}
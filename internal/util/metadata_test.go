@@ -43,6 +43,19 @@ func TestGetAuthToken(t *testing.T) {
 			expect: "",
 			isErr:  true,
 		},
+		{
+			name: "api key",
+			ctx: func() *gin.Context {
+				gin.SetMode(gin.TestMode)
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Request = &http.Request{Header: http.Header{}}
+				c.Request.Header.Set("Authorization", "ApiKey fm_xxxxxx")
+				return c
+			}(),
+			expect: "",
+			isErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -54,3 +67,89 @@ func TestGetAuthToken(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAPIKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		expect string
+		isErr  bool
+	}{
+		{
+			name:   "authorization header",
+			header: http.Header{"Authorization": []string{"ApiKey fm_xxxxxx"}},
+			expect: "fm_xxxxxx",
+			isErr:  false,
+		},
+		{
+			name:   "x-api-key header",
+			header: http.Header{"X-Api-Key": []string{"fm_xxxxxx"}},
+			expect: "fm_xxxxxx",
+			isErr:  false,
+		},
+		{
+			name:   "bearer token",
+			header: http.Header{"Authorization": []string{"Bearer xxxxxx"}},
+			expect: "",
+			isErr:  true,
+		},
+		{
+			name:   "not exists api key",
+			header: http.Header{},
+			expect: "",
+			isErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = &http.Request{Header: tt.header}
+			actual, err := GetAPIKey(c)
+			assert.Equal(t, tt.isErr, err != nil, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestGetOrganizationID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		expect string
+		isErr  bool
+	}{
+		{
+			name:   "success",
+			header: http.Header{"X-Organization-Id": []string{"organization-id"}},
+			expect: "organization-id",
+			isErr:  false,
+		},
+		{
+			name:   "not exists organization id",
+			header: http.Header{},
+			expect: "",
+			isErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = &http.Request{Header: tt.header}
+			actual, err := GetOrganizationID(c)
+			assert.Equal(t, tt.isErr, err != nil, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
@@ -64,7 +64,15 @@ func GetQueryInt32s(ctx *gin.Context, query string) ([]int32, error) {
 }
 
 func GetOrders(ctx *gin.Context) []*Order {
-	strs := GetQueryStrings(ctx, "orders")
+	return parseOrders(GetQuery(ctx, "orders", ""))
+}
+
+// parseOrders - ordersクエリの形式 (e.g. foo,-bar) からソート順を復元
+func parseOrders(str string) []*Order {
+	if str == "" {
+		return []*Order{}
+	}
+	strs := strings.Split(str, ",")
 	orders := make([]*Order, len(strs))
 	for i := range strs {
 		order := &Order{}
@@ -8,10 +8,48 @@ import (
 	context "context"
 	reflect "reflect"
 
+	database "github.com/and-period/furumane/internal/auth/database"
 	entity "github.com/and-period/furumane/internal/auth/entity"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockTransaction is a mock of Transaction interface.
+type MockTransaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionMockRecorder
+}
+
+// MockTransactionMockRecorder is the mock recorder for MockTransaction.
+type MockTransactionMockRecorder struct {
+	mock *MockTransaction
+}
+
+// NewMockTransaction creates a new mock instance.
+func NewMockTransaction(ctrl *gomock.Controller) *MockTransaction {
+	mock := &MockTransaction{ctrl: ctrl}
+	mock.recorder = &MockTransactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockTransaction) Run(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockTransactionMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTransaction)(nil).Run), ctx, fn)
+}
+
 // MockAdmin is a mock of Admin interface.
 type MockAdmin struct {
 	ctrl     *gomock.Controller
@@ -50,17 +88,17 @@ func (mr *MockAdminMockRecorder) Create(ctx, admin, auth interface{}) *gomock.Ca
 }
 
 // Delete mocks base method.
-func (m *MockAdmin) Delete(ctx context.Context, adminID string, auth func(context.Context) error) error {
+func (m *MockAdmin) Delete(ctx context.Context, adminID string, version int64, auth func(context.Context) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, adminID, auth)
+	ret := m.ctrl.Call(m, "Delete", ctx, adminID, version, auth)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockAdminMockRecorder) Delete(ctx, adminID, auth interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Delete(ctx, adminID, version, auth interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), ctx, adminID, auth)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), ctx, adminID, version, auth)
 }
 
 // Get mocks base method.
@@ -123,30 +161,582 @@ func (mr *MockAdminMockRecorder) GetByEmail(ctx, email interface{}, fields ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockAdmin)(nil).GetByEmail), varargs...)
 }
 
+// MultiGet mocks base method.
+func (m *MockAdmin) MultiGet(ctx context.Context, adminIDs []string, fields ...string) (entity.Admins, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminIDs}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MultiGet", varargs...)
+	ret0, _ := ret[0].(entity.Admins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MultiGet indicates an expected call of MultiGet.
+func (mr *MockAdminMockRecorder) MultiGet(ctx, adminIDs interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminIDs}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiGet", reflect.TypeOf((*MockAdmin)(nil).MultiGet), varargs...)
+}
+
 // UpdateEmail mocks base method.
-func (m *MockAdmin) UpdateEmail(ctx context.Context, adminID, email string) error {
+func (m *MockAdmin) UpdateEmail(ctx context.Context, adminID, email string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEmail", ctx, adminID, email)
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, adminID, email, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateEmail indicates an expected call of UpdateEmail.
-func (mr *MockAdminMockRecorder) UpdateEmail(ctx, adminID, email interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) UpdateEmail(ctx, adminID, email, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockAdmin)(nil).UpdateEmail), ctx, adminID, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockAdmin)(nil).UpdateEmail), ctx, adminID, email, version)
 }
 
 // UpdateVerifiedAt mocks base method.
-func (m *MockAdmin) UpdateVerifiedAt(ctx context.Context, adminID string) error {
+func (m *MockAdmin) UpdateVerifiedAt(ctx context.Context, adminID string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateVerifiedAt", ctx, adminID)
+	ret := m.ctrl.Call(m, "UpdateVerifiedAt", ctx, adminID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateVerifiedAt indicates an expected call of UpdateVerifiedAt.
-func (mr *MockAdminMockRecorder) UpdateVerifiedAt(ctx, adminID interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) UpdateVerifiedAt(ctx, adminID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifiedAt", reflect.TypeOf((*MockAdmin)(nil).UpdateVerifiedAt), ctx, adminID, version)
+}
+
+// MockAdminCredential is a mock of AdminCredential interface.
+type MockAdminCredential struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminCredentialMockRecorder
+}
+
+// MockAdminCredentialMockRecorder is the mock recorder for MockAdminCredential.
+type MockAdminCredentialMockRecorder struct {
+	mock *MockAdminCredential
+}
+
+// NewMockAdminCredential creates a new mock instance.
+func NewMockAdminCredential(ctrl *gomock.Controller) *MockAdminCredential {
+	mock := &MockAdminCredential{ctrl: ctrl}
+	mock.recorder = &MockAdminCredentialMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminCredential) EXPECT() *MockAdminCredentialMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAdminCredential) Create(ctx context.Context, credential *entity.AdminCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAdminCredentialMockRecorder) Create(ctx, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdminCredential)(nil).Create), ctx, credential)
+}
+
+// Delete mocks base method.
+func (m *MockAdminCredential) Delete(ctx context.Context, adminID, credentialID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, adminID, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAdminCredentialMockRecorder) Delete(ctx, adminID, credentialID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdminCredential)(nil).Delete), ctx, adminID, credentialID)
+}
+
+// Get mocks base method.
+func (m *MockAdminCredential) Get(ctx context.Context, adminID, credentialID string, fields ...string) (*entity.AdminCredential, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminID, credentialID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*entity.AdminCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAdminCredentialMockRecorder) Get(ctx, adminID, credentialID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminID, credentialID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdminCredential)(nil).Get), varargs...)
+}
+
+// GetByCredentialID mocks base method.
+func (m *MockAdminCredential) GetByCredentialID(ctx context.Context, credentialID []byte, fields ...string) (*entity.AdminCredential, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, credentialID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByCredentialID", varargs...)
+	ret0, _ := ret[0].(*entity.AdminCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCredentialID indicates an expected call of GetByCredentialID.
+func (mr *MockAdminCredentialMockRecorder) GetByCredentialID(ctx, credentialID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, credentialID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentialID", reflect.TypeOf((*MockAdminCredential)(nil).GetByCredentialID), varargs...)
+}
+
+// List mocks base method.
+func (m *MockAdminCredential) List(ctx context.Context, adminID string, fields ...string) (entity.AdminCredentials, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(entity.AdminCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAdminCredentialMockRecorder) List(ctx, adminID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAdminCredential)(nil).List), varargs...)
+}
+
+// UpdateName mocks base method.
+func (m *MockAdminCredential) UpdateName(ctx context.Context, adminID, credentialID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateName", ctx, adminID, credentialID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateName indicates an expected call of UpdateName.
+func (mr *MockAdminCredentialMockRecorder) UpdateName(ctx, adminID, credentialID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateName", reflect.TypeOf((*MockAdminCredential)(nil).UpdateName), ctx, adminID, credentialID, name)
+}
+
+// UpdateSignCount mocks base method.
+func (m *MockAdminCredential) UpdateSignCount(ctx context.Context, credentialID string, signCount uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSignCount", ctx, credentialID, signCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSignCount indicates an expected call of UpdateSignCount.
+func (mr *MockAdminCredentialMockRecorder) UpdateSignCount(ctx, credentialID, signCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSignCount", reflect.TypeOf((*MockAdminCredential)(nil).UpdateSignCount), ctx, credentialID, signCount)
+}
+
+// MockPasskeyChallenge is a mock of PasskeyChallenge interface.
+type MockPasskeyChallenge struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeyChallengeMockRecorder
+}
+
+// MockPasskeyChallengeMockRecorder is the mock recorder for MockPasskeyChallenge.
+type MockPasskeyChallengeMockRecorder struct {
+	mock *MockPasskeyChallenge
+}
+
+// NewMockPasskeyChallenge creates a new mock instance.
+func NewMockPasskeyChallenge(ctrl *gomock.Controller) *MockPasskeyChallenge {
+	mock := &MockPasskeyChallenge{ctrl: ctrl}
+	mock.recorder = &MockPasskeyChallengeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeyChallenge) EXPECT() *MockPasskeyChallengeMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockPasskeyChallenge) Consume(ctx context.Context, challenge string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockPasskeyChallengeMockRecorder) Consume(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockPasskeyChallenge)(nil).Consume), ctx, challenge)
+}
+
+// Create mocks base method.
+func (m *MockPasskeyChallenge) Create(ctx context.Context, challenge *entity.PasskeyChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasskeyChallengeMockRecorder) Create(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasskeyChallenge)(nil).Create), ctx, challenge)
+}
+
+// MockAdminAPIKey is a mock of AdminAPIKey interface.
+type MockAdminAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminAPIKeyMockRecorder
+}
+
+// MockAdminAPIKeyMockRecorder is the mock recorder for MockAdminAPIKey.
+type MockAdminAPIKeyMockRecorder struct {
+	mock *MockAdminAPIKey
+}
+
+// NewMockAdminAPIKey creates a new mock instance.
+func NewMockAdminAPIKey(ctrl *gomock.Controller) *MockAdminAPIKey {
+	mock := &MockAdminAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAdminAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminAPIKey) EXPECT() *MockAdminAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAdminAPIKey) Create(ctx context.Context, apiKey *entity.AdminAPIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAdminAPIKeyMockRecorder) Create(ctx, apiKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdminAPIKey)(nil).Create), ctx, apiKey)
+}
+
+// Get mocks base method.
+func (m *MockAdminAPIKey) Get(ctx context.Context, adminID, apiKeyID string, fields ...string) (*entity.AdminAPIKey, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminID, apiKeyID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*entity.AdminAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAdminAPIKeyMockRecorder) Get(ctx, adminID, apiKeyID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminID, apiKeyID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdminAPIKey)(nil).Get), varargs...)
+}
+
+// GetByHash mocks base method.
+func (m *MockAdminAPIKey) GetByHash(ctx context.Context, hash []byte, fields ...string) (*entity.AdminAPIKey, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, hash}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByHash", varargs...)
+	ret0, _ := ret[0].(*entity.AdminAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAdminAPIKeyMockRecorder) GetByHash(ctx, hash interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, hash}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAdminAPIKey)(nil).GetByHash), varargs...)
+}
+
+// List mocks base method.
+func (m *MockAdminAPIKey) List(ctx context.Context, adminID string, fields ...string) (entity.AdminAPIKeys, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(entity.AdminAPIKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAdminAPIKeyMockRecorder) List(ctx, adminID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAdminAPIKey)(nil).List), varargs...)
+}
+
+// Revoke mocks base method.
+func (m *MockAdminAPIKey) Revoke(ctx context.Context, adminID, apiKeyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, adminID, apiKeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAdminAPIKeyMockRecorder) Revoke(ctx, adminID, apiKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAdminAPIKey)(nil).Revoke), ctx, adminID, apiKeyID)
+}
+
+// Rotate mocks base method.
+func (m *MockAdminAPIKey) Rotate(ctx context.Context, adminID, apiKeyID, prefix string, hash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, adminID, apiKeyID, prefix, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockAdminAPIKeyMockRecorder) Rotate(ctx, adminID, apiKeyID, prefix, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockAdminAPIKey)(nil).Rotate), ctx, adminID, apiKeyID, prefix, hash)
+}
+
+// UpdateLastUsedAt mocks base method.
+func (m *MockAdminAPIKey) UpdateLastUsedAt(ctx context.Context, apiKeyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedAt", ctx, apiKeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedAt indicates an expected call of UpdateLastUsedAt.
+func (mr *MockAdminAPIKeyMockRecorder) UpdateLastUsedAt(ctx, apiKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedAt", reflect.TypeOf((*MockAdminAPIKey)(nil).UpdateLastUsedAt), ctx, apiKeyID)
+}
+
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganization) Create(ctx context.Context, organization *entity.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationMockRecorder) Create(ctx, organization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganization)(nil).Create), ctx, organization)
+}
+
+// Get mocks base method.
+func (m *MockOrganization) Get(ctx context.Context, organizationID string, fields ...string) (*entity.Organization, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, organizationID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*entity.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrganizationMockRecorder) Get(ctx, organizationID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, organizationID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrganization)(nil).Get), varargs...)
+}
+
+// MultiGet mocks base method.
+func (m *MockOrganization) MultiGet(ctx context.Context, organizationIDs []string, fields ...string) (entity.Organizations, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, organizationIDs}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MultiGet", varargs...)
+	ret0, _ := ret[0].(entity.Organizations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MultiGet indicates an expected call of MultiGet.
+func (mr *MockOrganizationMockRecorder) MultiGet(ctx, organizationIDs interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, organizationIDs}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiGet", reflect.TypeOf((*MockOrganization)(nil).MultiGet), varargs...)
+}
+
+// MockOrganizationMember is a mock of OrganizationMember interface.
+type MockOrganizationMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMemberMockRecorder
+}
+
+// MockOrganizationMemberMockRecorder is the mock recorder for MockOrganizationMember.
+type MockOrganizationMemberMockRecorder struct {
+	mock *MockOrganizationMember
+}
+
+// NewMockOrganizationMember creates a new mock instance.
+func NewMockOrganizationMember(ctrl *gomock.Controller) *MockOrganizationMember {
+	mock := &MockOrganizationMember{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationMember) EXPECT() *MockOrganizationMemberMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizationMember) Create(ctx context.Context, member *entity.OrganizationMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationMemberMockRecorder) Create(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationMember)(nil).Create), ctx, member)
+}
+
+// Delete mocks base method.
+func (m *MockOrganizationMember) Delete(ctx context.Context, organizationID, adminID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, organizationID, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrganizationMemberMockRecorder) Delete(ctx, organizationID, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrganizationMember)(nil).Delete), ctx, organizationID, adminID)
+}
+
+// Get mocks base method.
+func (m *MockOrganizationMember) Get(ctx context.Context, organizationID, adminID string, fields ...string) (*entity.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, organizationID, adminID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*entity.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrganizationMemberMockRecorder) Get(ctx, organizationID, adminID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, organizationID, adminID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrganizationMember)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockOrganizationMember) List(ctx context.Context, params *database.ListOrganizationMembersParams, fields ...string) (entity.OrganizationMembers, []interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(entity.OrganizationMembers)
+	ret1, _ := ret[1].([]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrganizationMemberMockRecorder) List(ctx, params interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrganizationMember)(nil).List), varargs...)
+}
+
+// ListByAdminID mocks base method.
+func (m *MockOrganizationMember) ListByAdminID(ctx context.Context, adminID string, fields ...string) (entity.OrganizationMembers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, adminID}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListByAdminID", varargs...)
+	ret0, _ := ret[0].(entity.OrganizationMembers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAdminID indicates an expected call of ListByAdminID.
+func (mr *MockOrganizationMemberMockRecorder) ListByAdminID(ctx, adminID interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, adminID}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAdminID", reflect.TypeOf((*MockOrganizationMember)(nil).ListByAdminID), varargs...)
+}
+
+// UpdateRole mocks base method.
+func (m *MockOrganizationMember) UpdateRole(ctx context.Context, organizationID, adminID string, role entity.OrganizationRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, organizationID, adminID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockOrganizationMemberMockRecorder) UpdateRole(ctx, organizationID, adminID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifiedAt", reflect.TypeOf((*MockAdmin)(nil).UpdateVerifiedAt), ctx, adminID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockOrganizationMember)(nil).UpdateRole), ctx, organizationID, adminID, role)
 }
@@ -0,0 +1,458 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+
+// Package mock_authclient is a generated GoMock package.
+package mock_authclient
+
+import (
+	context "context"
+	reflect "reflect"
+
+	authclient "github.com/and-period/furumane/pkg/authclient"
+	request "github.com/and-period/furumane/pkg/authclient/request"
+	response "github.com/and-period/furumane/pkg/authclient/response"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// BeginAdminPasskeyRegistration mocks base method.
+func (m *MockClient) BeginAdminPasskeyRegistration(ctx context.Context) (*response.BeginAdminPasskeyRegistrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginAdminPasskeyRegistration", ctx)
+	ret0, _ := ret[0].(*response.BeginAdminPasskeyRegistrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginAdminPasskeyRegistration indicates an expected call of BeginAdminPasskeyRegistration.
+func (mr *MockClientMockRecorder) BeginAdminPasskeyRegistration(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginAdminPasskeyRegistration", reflect.TypeOf((*MockClient)(nil).BeginAdminPasskeyRegistration), ctx)
+}
+
+// BeginAdminPasskeySignIn mocks base method.
+func (m *MockClient) BeginAdminPasskeySignIn(ctx context.Context) (*response.BeginAdminPasskeySignInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginAdminPasskeySignIn", ctx)
+	ret0, _ := ret[0].(*response.BeginAdminPasskeySignInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginAdminPasskeySignIn indicates an expected call of BeginAdminPasskeySignIn.
+func (mr *MockClientMockRecorder) BeginAdminPasskeySignIn(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginAdminPasskeySignIn", reflect.TypeOf((*MockClient)(nil).BeginAdminPasskeySignIn), ctx)
+}
+
+// CreateAdminAPIKey mocks base method.
+func (m *MockClient) CreateAdminAPIKey(ctx context.Context, req *request.CreateAdminAPIKeyRequest) (*response.CreateAdminAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminAPIKey", ctx, req)
+	ret0, _ := ret[0].(*response.CreateAdminAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminAPIKey indicates an expected call of CreateAdminAPIKey.
+func (mr *MockClientMockRecorder) CreateAdminAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminAPIKey", reflect.TypeOf((*MockClient)(nil).CreateAdminAPIKey), ctx, req)
+}
+
+// DeleteAdmin mocks base method.
+func (m *MockClient) DeleteAdmin(ctx context.Context, adminID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdmin", ctx, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdmin indicates an expected call of DeleteAdmin.
+func (mr *MockClientMockRecorder) DeleteAdmin(ctx, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdmin", reflect.TypeOf((*MockClient)(nil).DeleteAdmin), ctx, adminID)
+}
+
+// DeleteAdminPasskey mocks base method.
+func (m *MockClient) DeleteAdminPasskey(ctx context.Context, passkeyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminPasskey", ctx, passkeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminPasskey indicates an expected call of DeleteAdminPasskey.
+func (mr *MockClientMockRecorder) DeleteAdminPasskey(ctx, passkeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminPasskey", reflect.TypeOf((*MockClient)(nil).DeleteAdminPasskey), ctx, passkeyID)
+}
+
+// ForgotAdminPassword mocks base method.
+func (m *MockClient) ForgotAdminPassword(ctx context.Context, req *request.ForgotAdminPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotAdminPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotAdminPassword indicates an expected call of ForgotAdminPassword.
+func (mr *MockClientMockRecorder) ForgotAdminPassword(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotAdminPassword", reflect.TypeOf((*MockClient)(nil).ForgotAdminPassword), ctx, req)
+}
+
+// GetAdmin mocks base method.
+func (m *MockClient) GetAdmin(ctx context.Context, adminID string) (*response.GetAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdmin", ctx, adminID)
+	ret0, _ := ret[0].(*response.GetAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdmin indicates an expected call of GetAdmin.
+func (mr *MockClientMockRecorder) GetAdmin(ctx, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmin", reflect.TypeOf((*MockClient)(nil).GetAdmin), ctx, adminID)
+}
+
+// GetAdminAuth mocks base method.
+func (m *MockClient) GetAdminAuth(ctx context.Context) (*response.GetAdminAuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAuth", ctx)
+	ret0, _ := ret[0].(*response.GetAdminAuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAuth indicates an expected call of GetAdminAuth.
+func (mr *MockClientMockRecorder) GetAdminAuth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAuth", reflect.TypeOf((*MockClient)(nil).GetAdminAuth), ctx)
+}
+
+// ListAdminAPIKeys mocks base method.
+func (m *MockClient) ListAdminAPIKeys(ctx context.Context) (*response.AdminAPIKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdminAPIKeys", ctx)
+	ret0, _ := ret[0].(*response.AdminAPIKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdminAPIKeys indicates an expected call of ListAdminAPIKeys.
+func (mr *MockClientMockRecorder) ListAdminAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminAPIKeys", reflect.TypeOf((*MockClient)(nil).ListAdminAPIKeys), ctx)
+}
+
+// ListAdminPasskeys mocks base method.
+func (m *MockClient) ListAdminPasskeys(ctx context.Context) (*response.AdminPasskeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdminPasskeys", ctx)
+	ret0, _ := ret[0].(*response.AdminPasskeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdminPasskeys indicates an expected call of ListAdminPasskeys.
+func (mr *MockClientMockRecorder) ListAdminPasskeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminPasskeys", reflect.TypeOf((*MockClient)(nil).ListAdminPasskeys), ctx)
+}
+
+// RefreshAdminToken mocks base method.
+func (m *MockClient) RefreshAdminToken(ctx context.Context, req *request.RefreshAdminTokenRequest) (*response.RefreshAdminTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAdminToken", ctx, req)
+	ret0, _ := ret[0].(*response.RefreshAdminTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshAdminToken indicates an expected call of RefreshAdminToken.
+func (mr *MockClientMockRecorder) RefreshAdminToken(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAdminToken", reflect.TypeOf((*MockClient)(nil).RefreshAdminToken), ctx, req)
+}
+
+// RegisterAdminPasskey mocks base method.
+func (m *MockClient) RegisterAdminPasskey(ctx context.Context, req *request.RegisterAdminPasskeyRequest) (*response.AdminPasskeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterAdminPasskey", ctx, req)
+	ret0, _ := ret[0].(*response.AdminPasskeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterAdminPasskey indicates an expected call of RegisterAdminPasskey.
+func (mr *MockClientMockRecorder) RegisterAdminPasskey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAdminPasskey", reflect.TypeOf((*MockClient)(nil).RegisterAdminPasskey), ctx, req)
+}
+
+// ResetAdminPassword mocks base method.
+func (m *MockClient) ResetAdminPassword(ctx context.Context, req *request.ResetAdminPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAdminPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAdminPassword indicates an expected call of ResetAdminPassword.
+func (mr *MockClientMockRecorder) ResetAdminPassword(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAdminPassword", reflect.TypeOf((*MockClient)(nil).ResetAdminPassword), ctx, req)
+}
+
+// RevokeAdminAPIKey mocks base method.
+func (m *MockClient) RevokeAdminAPIKey(ctx context.Context, apiKeyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAdminAPIKey", ctx, apiKeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAdminAPIKey indicates an expected call of RevokeAdminAPIKey.
+func (mr *MockClientMockRecorder) RevokeAdminAPIKey(ctx, apiKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAdminAPIKey", reflect.TypeOf((*MockClient)(nil).RevokeAdminAPIKey), ctx, apiKeyID)
+}
+
+// RotateAdminAPIKey mocks base method.
+func (m *MockClient) RotateAdminAPIKey(ctx context.Context, apiKeyID string) (*response.RotateAdminAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAdminAPIKey", ctx, apiKeyID)
+	ret0, _ := ret[0].(*response.RotateAdminAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateAdminAPIKey indicates an expected call of RotateAdminAPIKey.
+func (mr *MockClientMockRecorder) RotateAdminAPIKey(ctx, apiKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAdminAPIKey", reflect.TypeOf((*MockClient)(nil).RotateAdminAPIKey), ctx, apiKeyID)
+}
+
+// SetToken mocks base method.
+func (m *MockClient) SetToken(token *authclient.Token) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetToken", token)
+}
+
+// SetToken indicates an expected call of SetToken.
+func (mr *MockClientMockRecorder) SetToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetToken", reflect.TypeOf((*MockClient)(nil).SetToken), token)
+}
+
+// SignInAdmin mocks base method.
+func (m *MockClient) SignInAdmin(ctx context.Context, req *request.SignInAdminRequest) (*response.SignInAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInAdmin", ctx, req)
+	ret0, _ := ret[0].(*response.SignInAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInAdmin indicates an expected call of SignInAdmin.
+func (mr *MockClientMockRecorder) SignInAdmin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInAdmin", reflect.TypeOf((*MockClient)(nil).SignInAdmin), ctx, req)
+}
+
+// SignInAdminWithPasskey mocks base method.
+func (m *MockClient) SignInAdminWithPasskey(ctx context.Context, req *request.SignInAdminWithPasskeyRequest) (*response.SignInAdminWithPasskeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInAdminWithPasskey", ctx, req)
+	ret0, _ := ret[0].(*response.SignInAdminWithPasskeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInAdminWithPasskey indicates an expected call of SignInAdminWithPasskey.
+func (mr *MockClientMockRecorder) SignInAdminWithPasskey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInAdminWithPasskey", reflect.TypeOf((*MockClient)(nil).SignInAdminWithPasskey), ctx, req)
+}
+
+// SignOutAdmin mocks base method.
+func (m *MockClient) SignOutAdmin(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOutAdmin", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOutAdmin indicates an expected call of SignOutAdmin.
+func (mr *MockClientMockRecorder) SignOutAdmin(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOutAdmin", reflect.TypeOf((*MockClient)(nil).SignOutAdmin), ctx)
+}
+
+// SignUpAdmin mocks base method.
+func (m *MockClient) SignUpAdmin(ctx context.Context, req *request.SignUpAdminRequest) (*response.SignUpAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUpAdmin", ctx, req)
+	ret0, _ := ret[0].(*response.SignUpAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignUpAdmin indicates an expected call of SignUpAdmin.
+func (mr *MockClientMockRecorder) SignUpAdmin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUpAdmin", reflect.TypeOf((*MockClient)(nil).SignUpAdmin), ctx, req)
+}
+
+// SignUpAdminWithOAuth mocks base method.
+func (m *MockClient) SignUpAdminWithOAuth(ctx context.Context) (*response.SignUpAdminWithOAuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUpAdminWithOAuth", ctx)
+	ret0, _ := ret[0].(*response.SignUpAdminWithOAuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignUpAdminWithOAuth indicates an expected call of SignUpAdminWithOAuth.
+func (mr *MockClientMockRecorder) SignUpAdminWithOAuth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUpAdminWithOAuth", reflect.TypeOf((*MockClient)(nil).SignUpAdminWithOAuth), ctx)
+}
+
+// StartAdminOTP mocks base method.
+func (m *MockClient) StartAdminOTP(ctx context.Context, req *request.StartAdminOTPRequest) (*response.StartAdminOTPResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAdminOTP", ctx, req)
+	ret0, _ := ret[0].(*response.StartAdminOTPResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAdminOTP indicates an expected call of StartAdminOTP.
+func (mr *MockClientMockRecorder) StartAdminOTP(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAdminOTP", reflect.TypeOf((*MockClient)(nil).StartAdminOTP), ctx, req)
+}
+
+// Token mocks base method.
+func (m *MockClient) Token() *authclient.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(*authclient.Token)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockClientMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockClient)(nil).Token))
+}
+
+// UpdateAdminEmail mocks base method.
+func (m *MockClient) UpdateAdminEmail(ctx context.Context, req *request.UpdateAdminEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdminEmail", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdminEmail indicates an expected call of UpdateAdminEmail.
+func (mr *MockClientMockRecorder) UpdateAdminEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminEmail", reflect.TypeOf((*MockClient)(nil).UpdateAdminEmail), ctx, req)
+}
+
+// UpdateAdminPasskey mocks base method.
+func (m *MockClient) UpdateAdminPasskey(ctx context.Context, passkeyID string, req *request.UpdateAdminPasskeyRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdminPasskey", ctx, passkeyID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdminPasskey indicates an expected call of UpdateAdminPasskey.
+func (mr *MockClientMockRecorder) UpdateAdminPasskey(ctx, passkeyID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminPasskey", reflect.TypeOf((*MockClient)(nil).UpdateAdminPasskey), ctx, passkeyID, req)
+}
+
+// UpdateAdminPassword mocks base method.
+func (m *MockClient) UpdateAdminPassword(ctx context.Context, req *request.UpdateAdminPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdminPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdminPassword indicates an expected call of UpdateAdminPassword.
+func (mr *MockClientMockRecorder) UpdateAdminPassword(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminPassword", reflect.TypeOf((*MockClient)(nil).UpdateAdminPassword), ctx, req)
+}
+
+// VerifyAdmin mocks base method.
+func (m *MockClient) VerifyAdmin(ctx context.Context, req *request.VerifyAdminRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAdmin", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyAdmin indicates an expected call of VerifyAdmin.
+func (mr *MockClientMockRecorder) VerifyAdmin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAdmin", reflect.TypeOf((*MockClient)(nil).VerifyAdmin), ctx, req)
+}
+
+// VerifyAdminEmail mocks base method.
+func (m *MockClient) VerifyAdminEmail(ctx context.Context, req *request.VerifyAdminEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAdminEmail", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyAdminEmail indicates an expected call of VerifyAdminEmail.
+func (mr *MockClientMockRecorder) VerifyAdminEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAdminEmail", reflect.TypeOf((*MockClient)(nil).VerifyAdminEmail), ctx, req)
+}
+
+// VerifyAdminOTP mocks base method.
+func (m *MockClient) VerifyAdminOTP(ctx context.Context, req *request.VerifyAdminOTPRequest) (*response.VerifyAdminOTPResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAdminOTP", ctx, req)
+	ret0, _ := ret[0].(*response.VerifyAdminOTPResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAdminOTP indicates an expected call of VerifyAdminOTP.
+func (mr *MockClientMockRecorder) VerifyAdminOTP(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAdminOTP", reflect.TypeOf((*MockClient)(nil).VerifyAdminOTP), ctx, req)
+}
@@ -206,6 +206,20 @@ func (mr *MockClientMockRecorder) GetUsername(ctx, accessToken interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsername", reflect.TypeOf((*MockClient)(nil).GetUsername), ctx, accessToken)
 }
 
+// Ping mocks base method.
+func (m *MockClient) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockClientMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockClient)(nil).Ping), ctx)
+}
+
 // RefreshToken mocks base method.
 func (m *MockClient) RefreshToken(ctx context.Context, refreshToken string) (*cognito.AuthResult, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +235,21 @@ func (mr *MockClientMockRecorder) RefreshToken(ctx, refreshToken interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockClient)(nil).RefreshToken), ctx, refreshToken)
 }
 
+// RespondCustomAuthChallenge mocks base method.
+func (m *MockClient) RespondCustomAuthChallenge(ctx context.Context, params *cognito.RespondCustomAuthChallengeParams) (*cognito.CustomAuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondCustomAuthChallenge", ctx, params)
+	ret0, _ := ret[0].(*cognito.CustomAuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondCustomAuthChallenge indicates an expected call of RespondCustomAuthChallenge.
+func (mr *MockClientMockRecorder) RespondCustomAuthChallenge(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondCustomAuthChallenge", reflect.TypeOf((*MockClient)(nil).RespondCustomAuthChallenge), ctx, params)
+}
+
 // SignIn mocks base method.
 func (m *MockClient) SignIn(ctx context.Context, username, password string) (*cognito.AuthResult, error) {
 	m.ctrl.T.Helper()
@@ -263,3 +292,18 @@ func (mr *MockClientMockRecorder) SignUp(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockClient)(nil).SignUp), ctx, params)
 }
+
+// StartCustomAuth mocks base method.
+func (m *MockClient) StartCustomAuth(ctx context.Context, params *cognito.StartCustomAuthParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCustomAuth", ctx, params)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCustomAuth indicates an expected call of StartCustomAuth.
+func (mr *MockClientMockRecorder) StartCustomAuth(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCustomAuth", reflect.TypeOf((*MockClient)(nil).StartCustomAuth), ctx, params)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+
+// Package mock_webauthn is a generated GoMock package.
+package mock_webauthn
+
+import (
+	reflect "reflect"
+
+	webauthn "github.com/and-period/furumane/pkg/webauthn"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockClient) BeginLogin(allows []*webauthn.Credential) (*webauthn.RequestOptions, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginLogin", allows)
+	ret0, _ := ret[0].(*webauthn.RequestOptions)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockClientMockRecorder) BeginLogin(allows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockClient)(nil).BeginLogin), allows)
+}
+
+// BeginRegistration mocks base method.
+func (m *MockClient) BeginRegistration(user *webauthn.User, exclusions []*webauthn.Credential) (*webauthn.CreationOptions, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRegistration", user, exclusions)
+	ret0, _ := ret[0].(*webauthn.CreationOptions)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginRegistration indicates an expected call of BeginRegistration.
+func (mr *MockClientMockRecorder) BeginRegistration(user, exclusions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRegistration", reflect.TypeOf((*MockClient)(nil).BeginRegistration), user, exclusions)
+}
+
+// FinishLogin mocks base method.
+func (m *MockClient) FinishLogin(session string, credential *webauthn.Credential, res *webauthn.AssertionResponse) (*webauthn.AssertionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishLogin", session, credential, res)
+	ret0, _ := ret[0].(*webauthn.AssertionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishLogin indicates an expected call of FinishLogin.
+func (mr *MockClientMockRecorder) FinishLogin(session, credential, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishLogin", reflect.TypeOf((*MockClient)(nil).FinishLogin), session, credential, res)
+}
+
+// FinishRegistration mocks base method.
+func (m *MockClient) FinishRegistration(session string, user *webauthn.User, res *webauthn.AttestationResponse) (*webauthn.Credential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRegistration", session, user, res)
+	ret0, _ := ret[0].(*webauthn.Credential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishRegistration indicates an expected call of FinishRegistration.
+func (mr *MockClientMockRecorder) FinishRegistration(session, user, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRegistration", reflect.TypeOf((*MockClient)(nil).FinishRegistration), session, user, res)
+}
@@ -0,0 +1,64 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+func (c *client) SignUpAdmin(ctx context.Context, req *request.SignUpAdminRequest) (*response.SignUpAdminResponse, error) {
+	res := &response.SignUpAdminResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) VerifyAdmin(ctx context.Context, req *request.VerifyAdminRequest) error {
+	return c.call(ctx, &call{method: http.MethodPost, path: "/admin/verified", in: req})
+}
+
+func (c *client) SignUpAdminWithOAuth(ctx context.Context) (*response.SignUpAdminWithOAuthResponse, error) {
+	res := &response.SignUpAdminWithOAuthResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/oauth", out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) GetAdmin(ctx context.Context, adminID string) (*response.GetAdminResponse, error) {
+	res := &response.GetAdminResponse{}
+	path := "/admin/" + url.PathEscape(adminID)
+	if err := c.call(ctx, &call{method: http.MethodGet, path: path, out: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) UpdateAdminEmail(ctx context.Context, req *request.UpdateAdminEmailRequest) error {
+	return c.call(ctx, &call{method: http.MethodPut, path: "/admin/email", in: req, auth: true})
+}
+
+func (c *client) VerifyAdminEmail(ctx context.Context, req *request.VerifyAdminEmailRequest) error {
+	return c.call(ctx, &call{method: http.MethodPost, path: "/admin/email/verified", in: req, auth: true})
+}
+
+func (c *client) UpdateAdminPassword(ctx context.Context, req *request.UpdateAdminPasswordRequest) error {
+	return c.call(ctx, &call{method: http.MethodPut, path: "/admin/password", in: req, auth: true})
+}
+
+func (c *client) ForgotAdminPassword(ctx context.Context, req *request.ForgotAdminPasswordRequest) error {
+	return c.call(ctx, &call{method: http.MethodPost, path: "/admin/password/forgot", in: req})
+}
+
+func (c *client) ResetAdminPassword(ctx context.Context, req *request.ResetAdminPasswordRequest) error {
+	return c.call(ctx, &call{method: http.MethodPut, path: "/admin/password/reset", in: req})
+}
+
+func (c *client) DeleteAdmin(ctx context.Context, adminID string) error {
+	path := "/admin/" + url.PathEscape(adminID)
+	return c.call(ctx, &call{method: http.MethodDelete, path: path})
+}
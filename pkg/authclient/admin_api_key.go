@@ -0,0 +1,42 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+func (c *client) ListAdminAPIKeys(ctx context.Context) (*response.AdminAPIKeysResponse, error) {
+	res := &response.AdminAPIKeysResponse{}
+	if err := c.call(ctx, &call{method: http.MethodGet, path: "/admin/api-keys", out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) CreateAdminAPIKey(
+	ctx context.Context, req *request.CreateAdminAPIKeyRequest,
+) (*response.CreateAdminAPIKeyResponse, error) {
+	res := &response.CreateAdminAPIKeyResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/api-keys", in: req, out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) RotateAdminAPIKey(ctx context.Context, apiKeyID string) (*response.RotateAdminAPIKeyResponse, error) {
+	res := &response.RotateAdminAPIKeyResponse{}
+	path := "/admin/api-keys/" + url.PathEscape(apiKeyID) + "/rotate"
+	if err := c.call(ctx, &call{method: http.MethodPost, path: path, out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) RevokeAdminAPIKey(ctx context.Context, apiKeyID string) error {
+	path := "/admin/api-keys/" + url.PathEscape(apiKeyID)
+	return c.call(ctx, &call{method: http.MethodDelete, path: path, auth: true})
+}
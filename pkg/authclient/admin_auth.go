@@ -0,0 +1,85 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+// SignInAdmin - サインインし、以降のリクエストでは取得したアクセストークンを使用する
+func (c *client) SignInAdmin(ctx context.Context, req *request.SignInAdminRequest) (*response.SignInAdminResponse, error) {
+	res := &response.SignInAdminResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	c.setAuth(res.AdminAuth)
+	return res, nil
+}
+
+// SignOutAdmin - サインアウトし、保持しているトークンを破棄する
+func (c *client) SignOutAdmin(ctx context.Context) error {
+	if err := c.call(ctx, &call{method: http.MethodDelete, path: "/admin/auth", auth: true}); err != nil {
+		return err
+	}
+	c.SetToken(nil)
+	return nil
+}
+
+func (c *client) GetAdminAuth(ctx context.Context) (*response.GetAdminAuthResponse, error) {
+	res := &response.GetAdminAuthResponse{}
+	if err := c.call(ctx, &call{method: http.MethodGet, path: "/admin/auth", out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) RefreshAdminToken(
+	ctx context.Context, req *request.RefreshAdminTokenRequest,
+) (*response.RefreshAdminTokenResponse, error) {
+	res := &response.RefreshAdminTokenResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth/refresh", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) StartAdminOTP(ctx context.Context, req *request.StartAdminOTPRequest) (*response.StartAdminOTPResponse, error) {
+	res := &response.StartAdminOTPResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth/otp", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// VerifyAdminOTP - ワンタイムコードでサインインし、以降のリクエストでは取得したアクセストークンを使用する
+// コードが誤っていて再試行が可能な場合は、次の検証で使用するセッションを Error.Session で返す
+func (c *client) VerifyAdminOTP(ctx context.Context, req *request.VerifyAdminOTPRequest) (*response.VerifyAdminOTPResponse, error) {
+	res := &response.VerifyAdminOTPResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth/otp/verify", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	c.setAuth(res.AdminAuth)
+	return res, nil
+}
+
+func (c *client) BeginAdminPasskeySignIn(ctx context.Context) (*response.BeginAdminPasskeySignInResponse, error) {
+	res := &response.BeginAdminPasskeySignInResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth/passkey/options", out: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SignInAdminWithPasskey - パスキーでサインインし、以降のリクエストでは取得したアクセストークンを使用する
+func (c *client) SignInAdminWithPasskey(
+	ctx context.Context, req *request.SignInAdminWithPasskeyRequest,
+) (*response.SignInAdminWithPasskeyResponse, error) {
+	res := &response.SignInAdminWithPasskeyResponse{}
+	if err := c.call(ctx, &call{method: http.MethodPost, path: "/admin/auth/passkey", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	c.setAuth(res.AdminAuth)
+	return res, nil
+}
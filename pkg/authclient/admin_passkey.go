@@ -0,0 +1,48 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+func (c *client) ListAdminPasskeys(ctx context.Context) (*response.AdminPasskeysResponse, error) {
+	res := &response.AdminPasskeysResponse{}
+	if err := c.call(ctx, &call{method: http.MethodGet, path: "/admin/passkeys", out: res, auth: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) BeginAdminPasskeyRegistration(ctx context.Context) (*response.BeginAdminPasskeyRegistrationResponse, error) {
+	res := &response.BeginAdminPasskeyRegistrationResponse{}
+	cl := &call{method: http.MethodPost, path: "/admin/passkeys/registration/options", out: res, auth: true}
+	if err := c.call(ctx, cl); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) RegisterAdminPasskey(
+	ctx context.Context, req *request.RegisterAdminPasskeyRequest,
+) (*response.AdminPasskeyResponse, error) {
+	res := &response.AdminPasskeyResponse{}
+	cl := &call{method: http.MethodPost, path: "/admin/passkeys/registration", in: req, out: res, auth: true}
+	if err := c.call(ctx, cl); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) UpdateAdminPasskey(ctx context.Context, passkeyID string, req *request.UpdateAdminPasskeyRequest) error {
+	path := "/admin/passkeys/" + url.PathEscape(passkeyID)
+	return c.call(ctx, &call{method: http.MethodPatch, path: path, in: req, auth: true})
+}
+
+func (c *client) DeleteAdminPasskey(ctx context.Context, passkeyID string) error {
+	path := "/admin/passkeys/" + url.PathEscape(passkeyID)
+	return c.call(ctx, &call{method: http.MethodDelete, path: path, auth: true})
+}
@@ -0,0 +1,372 @@
+//go:generate mockgen -source=$GOFILE -package mock_$GOPACKAGE -destination=./../../mock/pkg/$GOPACKAGE/$GOFILE
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/jst"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	// tokenExpirySkew - 有効期限の直前に失効することを防ぐため、早めにトークンを更新する
+	tokenExpirySkew = 30 * time.Second
+)
+
+type Client interface {
+	// 管理者
+	SignUpAdmin(ctx context.Context, req *request.SignUpAdminRequest) (*response.SignUpAdminResponse, error)
+	VerifyAdmin(ctx context.Context, req *request.VerifyAdminRequest) error
+	SignUpAdminWithOAuth(ctx context.Context) (*response.SignUpAdminWithOAuthResponse, error)
+	GetAdmin(ctx context.Context, adminID string) (*response.GetAdminResponse, error)
+	UpdateAdminEmail(ctx context.Context, req *request.UpdateAdminEmailRequest) error
+	VerifyAdminEmail(ctx context.Context, req *request.VerifyAdminEmailRequest) error
+	UpdateAdminPassword(ctx context.Context, req *request.UpdateAdminPasswordRequest) error
+	ForgotAdminPassword(ctx context.Context, req *request.ForgotAdminPasswordRequest) error
+	ResetAdminPassword(ctx context.Context, req *request.ResetAdminPasswordRequest) error
+	DeleteAdmin(ctx context.Context, adminID string) error
+	// 管理者認証
+	SignInAdmin(ctx context.Context, req *request.SignInAdminRequest) (*response.SignInAdminResponse, error)
+	SignOutAdmin(ctx context.Context) error
+	GetAdminAuth(ctx context.Context) (*response.GetAdminAuthResponse, error)
+	RefreshAdminToken(ctx context.Context, req *request.RefreshAdminTokenRequest) (*response.RefreshAdminTokenResponse, error)
+	StartAdminOTP(ctx context.Context, req *request.StartAdminOTPRequest) (*response.StartAdminOTPResponse, error)
+	VerifyAdminOTP(ctx context.Context, req *request.VerifyAdminOTPRequest) (*response.VerifyAdminOTPResponse, error)
+	BeginAdminPasskeySignIn(ctx context.Context) (*response.BeginAdminPasskeySignInResponse, error)
+	SignInAdminWithPasskey(
+		ctx context.Context, req *request.SignInAdminWithPasskeyRequest,
+	) (*response.SignInAdminWithPasskeyResponse, error)
+	// 管理者パスキー
+	ListAdminPasskeys(ctx context.Context) (*response.AdminPasskeysResponse, error)
+	BeginAdminPasskeyRegistration(ctx context.Context) (*response.BeginAdminPasskeyRegistrationResponse, error)
+	RegisterAdminPasskey(ctx context.Context, req *request.RegisterAdminPasskeyRequest) (*response.AdminPasskeyResponse, error)
+	UpdateAdminPasskey(ctx context.Context, passkeyID string, req *request.UpdateAdminPasskeyRequest) error
+	DeleteAdminPasskey(ctx context.Context, passkeyID string) error
+	// 管理者APIキー
+	ListAdminAPIKeys(ctx context.Context) (*response.AdminAPIKeysResponse, error)
+	CreateAdminAPIKey(ctx context.Context, req *request.CreateAdminAPIKeyRequest) (*response.CreateAdminAPIKeyResponse, error)
+	RotateAdminAPIKey(ctx context.Context, apiKeyID string) (*response.RotateAdminAPIKeyResponse, error)
+	RevokeAdminAPIKey(ctx context.Context, apiKeyID string) error
+	// トークン
+	Token() *Token
+	SetToken(token *Token)
+}
+
+// Token - 管理者の認証トークン
+type Token struct {
+	AccessToken  string    // アクセストークン
+	RefreshToken string    // リフレッシュトークン
+	ExpiresAt    time.Time // アクセストークンの有効期限 (ゼロ値の場合は期限切れを事前に判定しない)
+}
+
+type Params struct {
+	BaseURL string // 認証APIのURL (e.g. https://auth.example.com)
+}
+
+type client struct {
+	now           func() time.Time
+	client        *http.Client
+	baseURL       string
+	apiKey        string
+	maxRetries    int64
+	retryInterval time.Duration
+	onRefresh     func(token *Token)
+	mu            sync.Mutex
+	token         *Token
+}
+
+type options struct {
+	httpClient    *http.Client
+	timeout       time.Duration
+	apiKey        string
+	token         *Token
+	maxRetries    int64
+	retryInterval time.Duration
+	onRefresh     func(token *Token)
+}
+
+type Option func(*options)
+
+// WithHTTPClient - HTTPクライアントを指定
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithTimeout - リクエストごとのタイムアウト (リトライを含まない)
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.timeout = timeout
+	}
+}
+
+// WithAPIKey - APIキーで認証する (アクセストークンより優先する)
+func WithAPIKey(key string) Option {
+	return func(opts *options) {
+		opts.apiKey = key
+	}
+}
+
+// WithToken - アクセストークンで認証する
+func WithToken(token *Token) Option {
+	return func(opts *options) {
+		opts.token = token
+	}
+}
+
+// WithMaxRetries - 一時的なエラーの場合の最大リトライ回数 (冪等なリクエストのみ)
+func WithMaxRetries(retries int64) Option {
+	return func(opts *options) {
+		opts.maxRetries = retries
+	}
+}
+
+// WithRetryInterval - リトライ間隔の初期値 (リトライごとに2倍にする)
+func WithRetryInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.retryInterval = interval
+	}
+}
+
+// WithTokenRefreshed - アクセストークンを更新した際に呼び出す関数 (トークンの永続化用)
+func WithTokenRefreshed(fn func(token *Token)) Option {
+	return func(opts *options) {
+		opts.onRefresh = fn
+	}
+}
+
+// NewClient - 認証APIのクライアントの生成
+func NewClient(params *Params, opts ...Option) Client {
+	dopts := &options{
+		timeout:       10 * time.Second,
+		maxRetries:    2,
+		retryInterval: 100 * time.Millisecond,
+		onRefresh:     func(*Token) {},
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	httpClient := dopts.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	if dopts.timeout > 0 {
+		c := *httpClient
+		c.Timeout = dopts.timeout
+		httpClient = &c
+	}
+	return &client{
+		now:           jst.Now,
+		client:        httpClient,
+		baseURL:       strings.TrimSuffix(params.BaseURL, "/"),
+		apiKey:        dopts.apiKey,
+		maxRetries:    dopts.maxRetries,
+		retryInterval: dopts.retryInterval,
+		onRefresh:     dopts.onRefresh,
+		token:         dopts.token,
+	}
+}
+
+func (c *client) Token() *Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil {
+		return nil
+	}
+	token := *c.token
+	return &token
+}
+
+func (c *client) SetToken(token *Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// setAuth - 認証結果からトークンを保持する
+func (c *client) setAuth(auth *response.AdminAuth) {
+	if auth == nil {
+		return
+	}
+	c.mu.Lock()
+	token := c.newToken(auth, c.token)
+	c.token = token
+	c.mu.Unlock()
+	c.onRefresh(token)
+}
+
+func (c *client) newToken(auth *response.AdminAuth, current *Token) *Token {
+	token := &Token{
+		AccessToken:  auth.AccessToken,
+		RefreshToken: auth.RefreshToken,
+	}
+	// トークンの更新時はリフレッシュトークンが返されないため、現在の値を引き継ぐ
+	if token.RefreshToken == "" && current != nil {
+		token.RefreshToken = current.RefreshToken
+	}
+	if auth.ExpiresIn > 0 {
+		token.ExpiresAt = c.now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	}
+	return token
+}
+
+// accessToken - 有効なアクセストークンを取得 (期限切れの場合は更新する)
+func (c *client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+	if token == nil {
+		return "", nil
+	}
+	if token.ExpiresAt.IsZero() || c.now().Add(tokenExpirySkew).Before(token.ExpiresAt) {
+		return token.AccessToken, nil
+	}
+	token, err := c.refresh(ctx, token.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// refresh - アクセストークンの更新
+// 他のリクエストですでに更新済みの場合は、更新後のトークンを返す
+func (c *client) refresh(ctx context.Context, accessToken string) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil || c.token.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: refresh token is not found", ErrUnauthenticated)
+	}
+	if c.token.AccessToken != accessToken {
+		return c.token, nil
+	}
+	req := &request.RefreshAdminTokenRequest{RefreshToken: c.token.RefreshToken}
+	res := &response.RefreshAdminTokenResponse{}
+	if err := c.do(ctx, &call{method: http.MethodPost, path: "/admin/auth/refresh", in: req, out: res}); err != nil {
+		return nil, err
+	}
+	c.token = c.newToken(res.AdminAuth, c.token)
+	c.onRefresh(c.token)
+	return c.token, nil
+}
+
+// call - APIの呼び出し内容
+type call struct {
+	method string
+	path   string
+	in     interface{}
+	out    interface{}
+	auth   bool   // 認証が必要か
+	token  string // アクセストークン
+}
+
+func (c *client) call(ctx context.Context, cl *call) error {
+	if !cl.auth || c.apiKey != "" {
+		return c.do(ctx, cl)
+	}
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.do(ctx, cl.withToken(token))
+	if !errors.Is(err, ErrUnauthenticated) || token == "" {
+		return err
+	}
+	// 認証エラーの場合は、アクセストークンを更新して再実行する
+	refreshed, rerr := c.refresh(ctx, token)
+	if rerr != nil {
+		return err
+	}
+	return c.do(ctx, cl.withToken(refreshed.AccessToken))
+}
+
+// do - リクエストの送信 (一時的なエラーの場合は冪等なリクエストのみリトライする)
+func (c *client) do(ctx context.Context, cl *call) error {
+	var body []byte
+	if cl.in != nil {
+		var err error
+		if body, err = json.Marshal(cl.in); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+		}
+	}
+	interval := c.retryInterval
+	for retries := int64(0); ; retries++ {
+		err := c.send(ctx, cl, body)
+		if err == nil || ctx.Err() != nil || retries >= c.maxRetries || !cl.idempotent() || !retryable(err) {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %s", ErrCanceled, ctx.Err().Error())
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+func (c *client) send(ctx context.Context, cl *call, body []byte) error {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, cl.method, c.baseURL+cl.path, r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case cl.auth && c.apiKey != "":
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	case cl.auth && cl.token != "":
+		req.Header.Set("Authorization", "Bearer "+cl.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newError(res)
+	}
+	if cl.out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(cl.out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %s", ErrUnknown, err.Error())
+	}
+	return nil
+}
+
+func (cl *call) withToken(token string) *call {
+	res := *cl
+	res.token = token
+	return &res
+}
+
+// idempotent - 同じリクエストを複数回送信しても結果が変わらないか
+func (cl *call) idempotent() bool {
+	switch cl.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
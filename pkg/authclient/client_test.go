@@ -0,0 +1,263 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/and-period/furumane/pkg/authclient/request"
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/and-period/furumane/pkg/jst"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *client {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	opts = append([]Option{WithRetryInterval(time.Millisecond)}, opts...)
+	return NewClient(&Params{BaseURL: ts.URL + "/"}, opts...).(*client)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, body interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+func TestClient_GetAdmin(t *testing.T) {
+	t.Parallel()
+	now := jst.Date(2023, 10, 1, 18, 30, 0, 0)
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/admin/admin-id", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		res := &response.GetAdminResponse{
+			Admin: &response.Admin{ID: "admin-id", Email: "test@example.com", CreatedAt: now, UpdatedAt: now},
+		}
+		writeJSON(t, w, http.StatusOK, res)
+	})
+	res, err := c.GetAdmin(context.Background(), "admin-id")
+	require.NoError(t, err)
+	assert.Equal(t, "admin-id", res.Admin.ID)
+	assert.Equal(t, "test@example.com", res.Admin.Email)
+	assert.True(t, now.Equal(res.Admin.CreatedAt))
+}
+
+func TestClient_SignInAdmin(t *testing.T) {
+	t.Parallel()
+	var refreshed *Token
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/auth":
+			if r.Method == http.MethodPost {
+				req := &request.SignInAdminRequest{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+				assert.Equal(t, &request.SignInAdminRequest{Key: "username", Password: "password"}, req)
+				res := &response.SignInAdminResponse{
+					AdminAuth: &response.AdminAuth{AdminID: "admin-id", AccessToken: "access-token", RefreshToken: "refresh-token", ExpiresIn: 3600},
+				}
+				writeJSON(t, w, http.StatusOK, res)
+				return
+			}
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}, WithTokenRefreshed(func(token *Token) { refreshed = token }))
+	now := jst.Date(2023, 10, 1, 18, 30, 0, 0)
+	c.now = func() time.Time { return now }
+
+	req := &request.SignInAdminRequest{Key: "username", Password: "password"}
+	_, err := c.SignInAdmin(context.Background(), req)
+	require.NoError(t, err)
+	expect := &Token{AccessToken: "access-token", RefreshToken: "refresh-token", ExpiresAt: now.Add(time.Hour)}
+	assert.Equal(t, expect, c.Token())
+	assert.Equal(t, expect, refreshed)
+
+	require.NoError(t, c.SignOutAdmin(context.Background()))
+	assert.Nil(t, c.Token())
+}
+
+func TestClient_Refresh(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		token    *Token
+		expired  bool // 初回リクエストで期限切れを返すか
+		refresh  int32
+		expect   *Token
+		hasErr   bool
+		expectFn func(t *testing.T, err error)
+	}{
+		{
+			name:    "refresh before expiry",
+			token:   &Token{AccessToken: "old-token", RefreshToken: "refresh-token", ExpiresAt: jst.Date(2023, 10, 1, 18, 30, 10, 0)},
+			expired: false,
+			refresh: 1,
+			expect:  &Token{AccessToken: "new-token", RefreshToken: "refresh-token", ExpiresAt: jst.Date(2023, 10, 1, 19, 30, 0, 0)},
+		},
+		{
+			name:    "refresh on unauthenticated",
+			token:   &Token{AccessToken: "old-token", RefreshToken: "refresh-token"},
+			expired: true,
+			refresh: 1,
+			expect:  &Token{AccessToken: "new-token", RefreshToken: "refresh-token", ExpiresAt: jst.Date(2023, 10, 1, 19, 30, 0, 0)},
+		},
+		{
+			name:    "no refresh token",
+			token:   &Token{AccessToken: "old-token"},
+			expired: true,
+			refresh: 0,
+			expect:  &Token{AccessToken: "old-token"},
+			hasErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var refresh int32
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/admin/auth/refresh":
+					atomic.AddInt32(&refresh, 1)
+					req := &request.RefreshAdminTokenRequest{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+					assert.Equal(t, "refresh-token", req.RefreshToken)
+					res := &response.RefreshAdminTokenResponse{
+						AdminAuth: &response.AdminAuth{AdminID: "admin-id", AccessToken: "new-token", ExpiresIn: 3600},
+					}
+					writeJSON(t, w, http.StatusOK, res)
+				case "/admin/passkeys":
+					if tt.expired && r.Header.Get("Authorization") == "Bearer old-token" {
+						writeJSON(t, w, http.StatusUnauthorized, &response.ErrorResponse{Status: http.StatusUnauthorized})
+						return
+					}
+					assert.Equal(t, "Bearer new-token", r.Header.Get("Authorization"))
+					writeJSON(t, w, http.StatusOK, &response.AdminPasskeysResponse{})
+				default:
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+			}, WithToken(tt.token))
+			c.now = func() time.Time { return jst.Date(2023, 10, 1, 18, 30, 0, 0) }
+
+			_, err := c.ListAdminPasskeys(context.Background())
+			if tt.hasErr {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.refresh, atomic.LoadInt32(&refresh))
+			assert.Equal(t, tt.expect, c.Token())
+		})
+	}
+}
+
+func TestClient_APIKey(t *testing.T) {
+	t.Parallel()
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/api-keys/api-key-id/rotate", r.URL.Path)
+		assert.Equal(t, "api-key", r.Header.Get(apiKeyHeader))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		writeJSON(t, w, http.StatusOK, &response.RotateAdminAPIKeyResponse{Key: "new-key"})
+	}, WithAPIKey("api-key"), WithToken(&Token{AccessToken: "access-token"}))
+	res, err := c.RotateAdminAPIKey(context.Background(), "api-key-id")
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", res.Key)
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		call   func(ctx context.Context, c Client) error
+		status int
+		expect int32
+		hasErr bool
+	}{
+		{
+			name: "retry idempotent request",
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.GetAdmin(ctx, "admin-id")
+				return err
+			},
+			status: http.StatusServiceUnavailable,
+			expect: 2,
+			hasErr: false,
+		},
+		{
+			name: "exceeded max retries",
+			call: func(ctx context.Context, c Client) error {
+				return c.DeleteAdmin(ctx, "admin-id")
+			},
+			status: http.StatusBadGateway,
+			expect: 3,
+			hasErr: true,
+		},
+		{
+			name: "not retry non-idempotent request",
+			call: func(ctx context.Context, c Client) error {
+				return c.ForgotAdminPassword(ctx, &request.ForgotAdminPasswordRequest{})
+			},
+			status: http.StatusServiceUnavailable,
+			expect: 1,
+			hasErr: true,
+		},
+		{
+			name: "not retry client error",
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.GetAdmin(ctx, "admin-id")
+				return err
+			},
+			status: http.StatusNotFound,
+			expect: 1,
+			hasErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var count int32
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				// 2回目のリクエストで成功させる (最大リトライ回数の検証時は常に失敗させる)
+				if n := atomic.AddInt32(&count, 1); n == 1 || tt.hasErr {
+					writeJSON(t, w, tt.status, &response.ErrorResponse{Status: tt.status})
+					return
+				}
+				writeJSON(t, w, http.StatusOK, &response.GetAdminResponse{Admin: &response.Admin{}})
+			})
+			err := tt.call(context.Background(), c)
+			assert.Equal(t, tt.hasErr, err != nil, err)
+			assert.Equal(t, tt.expect, atomic.LoadInt32(&count))
+		})
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	t.Parallel()
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}, WithTimeout(10*time.Millisecond), WithMaxRetries(0))
+	_, err := c.GetAdmin(context.Background(), "admin-id")
+	assert.ErrorIs(t, err, ErrTimeout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetAdmin(ctx, "admin-id")
+	assert.ErrorIs(t, err, ErrCanceled)
+}
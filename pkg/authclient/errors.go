@@ -0,0 +1,139 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/and-period/furumane/pkg/authclient/response"
+)
+
+// authSessionHeader - ワンタイムコードの再試行時に使用するセッション
+const authSessionHeader = "X-Auth-Session"
+
+var (
+	ErrInvalidArgument    = errors.New("authclient: invalid argument")
+	ErrUnauthenticated    = errors.New("authclient: unauthenticated")
+	ErrPermissionDenied   = errors.New("authclient: permission denied")
+	ErrNotFound           = errors.New("authclient: not found")
+	ErrAlreadyExists      = errors.New("authclient: already exists")
+	ErrFailedPrecondition = errors.New("authclient: failed precondition")
+	ErrResourceExhausted  = errors.New("authclient: resource exhausted")
+	ErrCanceled           = errors.New("authclient: canceled")
+	ErrInternal           = errors.New("authclient: internal")
+	ErrUnavailable        = errors.New("authclient: unavailable")
+	ErrTimeout            = errors.New("authclient: timeout")
+	ErrUnknown            = errors.New("authclient: unknown")
+)
+
+// Error - 認証APIのエラーレスポンス
+// errors.Isでステータスコードに対応するエラー (e.g. ErrNotFound) と比較できる
+type Error struct {
+	Status  int                    // ステータスコード
+	Code    response.ErrorCode     // エラーコード
+	Message string                 // エラー概要
+	Detail  string                 // エラー詳細
+	Errors  []*response.FieldError // 入力値の検証エラー一覧
+	Session string                 // ワンタイムコードの再試行時に使用するセッション
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("authclient: status=%d, code=%s, detail=%s", e.Status, e.Code, e.Detail)
+}
+
+func (e *Error) Is(target error) bool {
+	return errors.Is(statusError(e.Status), target)
+}
+
+func newError(res *http.Response) error {
+	e := &Error{
+		Status:  res.StatusCode,
+		Message: http.StatusText(res.StatusCode),
+		Session: res.Header.Get(authSessionHeader),
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return e
+	}
+	// application/problem+json形式の場合もstatus, code, detail, errorsは共通のため同じ型で扱う
+	out := &struct {
+		response.ErrorResponse
+		Title string `json:"title"`
+	}{}
+	if err := json.Unmarshal(body, out); err != nil {
+		return e
+	}
+	e.Code = out.Code
+	e.Detail = out.Detail
+	e.Errors = out.Errors
+	switch {
+	case out.Message != "":
+		e.Message = out.Message
+	case out.Title != "":
+		e.Message = out.Title
+	}
+	return e
+}
+
+//nolint:gocyclo
+func statusError(status int) error {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidArgument
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusPreconditionFailed:
+		return ErrFailedPrecondition
+	case http.StatusTooManyRequests:
+		return ErrResourceExhausted
+	case response.StatusClientClosedRequest:
+		return ErrCanceled
+	case http.StatusInternalServerError, http.StatusNotImplemented:
+		return ErrInternal
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return ErrUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrTimeout
+	default:
+		return ErrUnknown
+	}
+}
+
+// requestError - 通信エラーの変換
+func requestError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("%w: %s", ErrCanceled, err.Error())
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", ErrTimeout, err.Error())
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return fmt.Errorf("%w: %s", ErrTimeout, err.Error())
+	}
+	return fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+}
+
+// retryable - リトライにより成功する可能性があるか
+func retryable(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		switch e.Status {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+	return errors.Is(err, ErrUnavailable) || errors.Is(err, ErrTimeout)
+}
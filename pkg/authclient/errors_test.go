@@ -0,0 +1,115 @@
+package authclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/and-period/furumane/pkg/authclient/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		expect *Error
+		target error
+	}{
+		{
+			name:   "error response",
+			status: http.StatusNotFound,
+			header: http.Header{},
+			body:   `{"status":404,"code":"NOT_FOUND","message":"Not Found","detail":"admin is not found"}`,
+			expect: &Error{
+				Status:  http.StatusNotFound,
+				Code:    response.ErrorCodeNotFound,
+				Message: "Not Found",
+				Detail:  "admin is not found",
+			},
+			target: ErrNotFound,
+		},
+		{
+			name:   "problem details",
+			status: http.StatusBadRequest,
+			header: http.Header{},
+			body:   `{"type":"about:blank","title":"Bad Request","status":400,"code":"VALIDATION_FAILED","errors":[{"field":"email","message":"required"}]}`,
+			expect: &Error{
+				Status:  http.StatusBadRequest,
+				Code:    response.ErrorCodeValidationFailed,
+				Message: "Bad Request",
+				Errors:  []*response.FieldError{{Field: "email", Message: "required"}},
+			},
+			target: ErrInvalidArgument,
+		},
+		{
+			name:   "with session",
+			status: http.StatusUnauthorized,
+			header: http.Header{authSessionHeader: []string{"session"}},
+			body:   `{"status":401,"code":"UNAUTHENTICATED","message":"Unauthorized"}`,
+			expect: &Error{
+				Status:  http.StatusUnauthorized,
+				Code:    response.ErrorCodeUnauthenticated,
+				Message: "Unauthorized",
+				Session: "session",
+			},
+			target: ErrUnauthenticated,
+		},
+		{
+			name:   "invalid body",
+			status: http.StatusBadGateway,
+			header: http.Header{},
+			body:   `<html>Bad Gateway</html>`,
+			expect: &Error{
+				Status:  http.StatusBadGateway,
+				Message: "Bad Gateway",
+			},
+			target: ErrUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := &http.Response{
+				StatusCode: tt.status,
+				Header:     tt.header,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			err := newError(res)
+			assert.Equal(t, tt.expect, err)
+			assert.ErrorIs(t, err, tt.target)
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{name: "too many requests", err: &Error{Status: http.StatusTooManyRequests}, expect: true},
+		{name: "service unavailable", err: &Error{Status: http.StatusServiceUnavailable}, expect: true},
+		{name: "gateway timeout", err: &Error{Status: http.StatusGatewayTimeout}, expect: true},
+		{name: "internal", err: &Error{Status: http.StatusInternalServerError}, expect: false},
+		{name: "not found", err: &Error{Status: http.StatusNotFound}, expect: false},
+		{name: "connection error", err: ErrUnavailable, expect: true},
+		{name: "timeout", err: ErrTimeout, expect: true},
+		{name: "canceled", err: ErrCanceled, expect: false},
+		{name: "other", err: errors.New("some error"), expect: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, retryable(tt.err))
+		})
+	}
+}
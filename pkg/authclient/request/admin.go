@@ -0,0 +1,41 @@
+// Package request - 認証APIのリクエストボディ
+//
+// 認証APIのサーバーとクライアント (authclient) で共有するため、モジュール外からも参照できるpkg配下に配置する
+package request
+
+type SignUpAdminRequest struct {
+	Email                string `json:"email" validate:"required,max=256,email" log:"email"`                    // メールアドレス
+	PhoneNumber          string `json:"phoneNumber" validate:"required" log:"phone"`                            // 電話番号
+	Password             string `json:"password" validate:"min=8,max=32,password" log:"secret"`                 // パスワード
+	PasswordConfirmation string `json:"passwordConfirmation" validate:"required,eqfield=Password" log:"secret"` // パスワード（確認用）
+}
+
+type VerifyAdminRequest struct {
+	AdminID    string `json:"adminId" validate:"required"`                 // 管理者ID
+	VerifyCode string `json:"verifyCode" validate:"required" log:"secret"` // 検証コード
+}
+
+type UpdateAdminEmailRequest struct {
+	Email string `json:"email" validate:"required,max=256,email" log:"email"` // メールアドレス
+}
+
+type VerifyAdminEmailRequest struct {
+	VerifyCode string `json:"verifyCode" validate:"required" log:"secret"` // 検証コード
+}
+
+type UpdateAdminPasswordRequest struct {
+	OldPassword          string `json:"oldPassword" log:"secret"`                      // 現在のパスワード
+	NewPassword          string `validate:"min=8,max=32,password" log:"secret"`        // 新しいパスワード
+	PasswordConfirmation string `validate:"required,eqfield=NewPassword" log:"secret"` // パスワード（確認用）
+}
+
+type ForgotAdminPasswordRequest struct {
+	Email string `json:"email" validate:"required" log:"email"` // メールアドレス
+}
+
+type ResetAdminPasswordRequest struct {
+	Email                string `json:"email" validate:"required" log:"email"`       // メールアドレス
+	VerifyCode           string `json:"verifyCode" validate:"required" log:"secret"` // 検証コード
+	Password             string `validate:"min=8,max=32,password" log:"secret"`      // 新しいパスワード
+	PasswordConfirmation string `validate:"required,eqfield=Password" log:"secret"`  // パスワード（確認用）
+}
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type CreateAdminAPIKeyRequest struct {
+	Name       string    `json:"name" validate:"required,max=64"`                                                                                              // APIキー名
+	Scopes     []string  `json:"scopes" validate:"required,min=1,dive,oneof=passkey:read passkey:write token:introspect organization:read organization:write"` // 許可するスコープ一覧
+	AllowedIPs []string  `json:"allowedIps" validate:"max=32,dive,ip|cidr"`                                                                                    // 接続を許可するIPアドレス一覧
+	ExpiresAt  time.Time `json:"expiresAt"`                                                                                                                    // 有効期限 (未指定の場合は無期限)
+}
@@ -0,0 +1,20 @@
+package request
+
+type SignInAdminRequest struct {
+	Key      string `json:"key" validate:"required" log:"email"`       // キー
+	Password string `json:"password" validate:"required" log:"secret"` // パスワード
+}
+
+type RefreshAdminTokenRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required" log:"secret"` // リフレッシュトークン
+}
+
+type StartAdminOTPRequest struct {
+	Email string `json:"email" validate:"required,email" log:"email"` // メールアドレス
+}
+
+type VerifyAdminOTPRequest struct {
+	Email   string `json:"email" validate:"required,email" log:"email"`         // メールアドレス
+	Session string `json:"session" validate:"required" log:"secret"`            // セッション
+	Code    string `json:"code" validate:"required,len=6,numeric" log:"secret"` // ワンタイムコード
+}
@@ -0,0 +1,32 @@
+package request
+
+type RegisterAdminPasskeyRequest struct {
+	Session    string                   `json:"session" validate:"required" log:"secret"` // セッション
+	Name       string                   `json:"name" validate:"required,max=64"`          // パスキー名
+	Credential *AdminPasskeyAttestation `json:"credential" validate:"required"`           // 認証器の応答
+}
+
+// AdminPasskeyAttestation - 登録時の認証器の応答 (各値はbase64url形式)
+type AdminPasskeyAttestation struct {
+	ID                string   `json:"id" validate:"required"`                // 認証情報ID
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required"`    // クライアントデータ
+	AttestationObject string   `json:"attestationObject" validate:"required"` // アテステーションオブジェクト
+	Transports        []string `json:"transports"`                            // 認証器との通信方法
+}
+
+type UpdateAdminPasskeyRequest struct {
+	Name string `json:"name" validate:"required,max=64"` // パスキー名
+}
+
+type SignInAdminWithPasskeyRequest struct {
+	Session    string                 `json:"session" validate:"required" log:"secret"` // セッション
+	Credential *AdminPasskeyAssertion `json:"credential" validate:"required"`           // 認証器の応答
+}
+
+// AdminPasskeyAssertion - 認証時の認証器の応答 (各値はbase64url形式)
+type AdminPasskeyAssertion struct {
+	ID                string `json:"id" validate:"required"`                // 認証情報ID
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required"`    // クライアントデータ
+	AuthenticatorData string `json:"authenticatorData" validate:"required"` // 認証器データ
+	Signature         string `json:"signature" validate:"required"`         // 署名
+}
@@ -0,0 +1,8 @@
+package request
+
+// IntrospectTokenRequest - トークンの検証 (RFC 7662)
+// RFC 7662に合わせて、application/x-www-form-urlencoded形式で受け取る
+type IntrospectTokenRequest struct {
+	Token         string `json:"token" form:"token" validate:"required" log:"secret"`                                          // 検証するトークン
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint" validate:"omitempty,oneof=access_token refresh_token"` // トークン種別のヒント
+}
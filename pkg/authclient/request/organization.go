@@ -0,0 +1,15 @@
+package request
+
+type CreateOrganizationRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"` // 地方公共団体コード
+	Name string `json:"name" validate:"required,max=64"`        // 組織名
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email" log:"email"` // 追加する管理者のメールアドレス
+	Role  int32  `json:"role" validate:"required,oneof=1 2 3"`        // 組織内の権限
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role int32 `json:"role" validate:"required,oneof=1 2 3"` // 組織内の権限
+}
@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type Admin struct {
+	ID           string    `json:"id"`                      // 管理者ID
+	ProviderType int32     `json:"providerType"`            // 認証種別 (1: メールアドレス, 2: OAuth)
+	Email        string    `json:"email" log:"email"`       // メールアドレス
+	PhoneNumber  string    `json:"phoneNumber" log:"phone"` // 電話番号
+	CreatedAt    time.Time `json:"createdAt"`               // 登録日時
+	UpdatedAt    time.Time `json:"updatedAt"`               // 更新日時
+}
+
+type SignUpAdminResponse struct {
+	AdminID string `json:"adminId"` // 管理者ID
+}
+
+type SignUpAdminWithOAuthResponse struct {
+	Admin *Admin `json:"admin"` // 管理者情報
+}
+
+type GetAdminResponse struct {
+	Admin *Admin `json:"admin"` // 管理者情報
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// AdminAPIKey 管理者のAPIキー
+type AdminAPIKey struct {
+	ID         string    `json:"id"`         // APIキーID
+	Name       string    `json:"name"`       // APIキー名
+	Prefix     string    `json:"prefix"`     // APIキーの接頭辞
+	Scopes     []string  `json:"scopes"`     // 許可するスコープ一覧
+	AllowedIPs []string  `json:"allowedIps"` // 接続を許可するIPアドレス一覧
+	ExpiresAt  time.Time `json:"expiresAt"`  // 有効期限
+	LastUsedAt time.Time `json:"lastUsedAt"` // 最終利用日時
+	RevokedAt  time.Time `json:"revokedAt"`  // 失効日時
+	CreatedAt  time.Time `json:"createdAt"`  // 登録日時
+	UpdatedAt  time.Time `json:"updatedAt"`  // 更新日時
+}
+
+type AdminAPIKeysResponse struct {
+	APIKeys []*AdminAPIKey `json:"apiKeys"` // APIキー一覧
+}
+
+type CreateAdminAPIKeyResponse struct {
+	APIKey *AdminAPIKey `json:"apiKey"`           // APIキー
+	Key    string       `json:"key" log:"secret"` // APIキー (発行時のみ参照可能)
+}
+
+type RotateAdminAPIKeyResponse struct {
+	APIKey *AdminAPIKey `json:"apiKey"`           // APIキー
+	Key    string       `json:"key" log:"secret"` // APIキー (再発行時のみ参照可能)
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+
+	"github.com/and-period/furumane/pkg/webauthn"
+)
+
+// AdminPasskey 管理者のパスキー
+type AdminPasskey struct {
+	ID         string    `json:"id"`         // パスキーID
+	Name       string    `json:"name"`       // パスキー名
+	Transports []string  `json:"transports"` // 認証器との通信方法
+	CreatedAt  time.Time `json:"createdAt"`  // 登録日時
+	UpdatedAt  time.Time `json:"updatedAt"`  // 更新日時
+	LastUsedAt time.Time `json:"lastUsedAt"` // 最終利用日時
+}
+
+type AdminPasskeyResponse struct {
+	Passkey *AdminPasskey `json:"passkey"` // パスキー
+}
+
+type AdminPasskeysResponse struct {
+	Passkeys []*AdminPasskey `json:"passkeys"` // パスキー一覧
+}
+
+type BeginAdminPasskeyRegistrationResponse struct {
+	Session string                    `json:"session" log:"secret"` // セッション (登録時に使用)
+	Options *webauthn.CreationOptions `json:"options"`              // navigator.credentials.create()のオプション
+}
+
+type BeginAdminPasskeySignInResponse struct {
+	Session string                   `json:"session" log:"secret"` // セッション (サインイン時に使用)
+	Options *webauthn.RequestOptions `json:"options"`              // navigator.credentials.get()のオプション
+}
+
+type SignInAdminWithPasskeyResponse struct {
+	AdminAuth *AdminAuth `json:"auth"` // 管理者認証情報
+}
@@ -0,0 +1,140 @@
+package response
+
+import (
+	"context"
+	"errors"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCode - エラーコード
+// クライアントでエラー内容を判定するための値のため、一度定義した値は変更しないこと
+type ErrorCode string
+
+const (
+	// 共通
+	ErrorCodeValidationFailed   ErrorCode = "VALIDATION_FAILED"   // 入力値の検証エラー
+	ErrorCodeInvalidArgument    ErrorCode = "INVALID_ARGUMENT"    // 不正なリクエスト
+	ErrorCodeUnauthenticated    ErrorCode = "UNAUTHENTICATED"     // 認証エラー
+	ErrorCodePermissionDenied   ErrorCode = "PERMISSION_DENIED"   // 権限エラー
+	ErrorCodeNotFound           ErrorCode = "NOT_FOUND"           // 対象が存在しない
+	ErrorCodeAlreadyExists      ErrorCode = "ALREADY_EXISTS"      // 対象がすでに存在する
+	ErrorCodeFailedPrecondition ErrorCode = "FAILED_PRECONDITION" // 前提条件エラー
+	ErrorCodeResourceExhausted  ErrorCode = "RESOURCE_EXHAUSTED"  // リクエスト過多
+	ErrorCodeCanceled           ErrorCode = "CANCELED"            // リクエストのキャンセル
+	ErrorCodeDeadlineExceeded   ErrorCode = "DEADLINE_EXCEEDED"   // タイムアウト
+	ErrorCodeInternal           ErrorCode = "INTERNAL"            // 内部エラー
+	ErrorCodeUnimplemented      ErrorCode = "UNIMPLEMENTED"       // 未実装
+	ErrorCodeUnavailable        ErrorCode = "UNAVAILABLE"         // 利用不可
+	ErrorCodeUnknown            ErrorCode = "UNKNOWN"             // 不明なエラー
+	// 認証 (Amazon Cognito)
+	ErrorCodeAuthInvalidArgument       ErrorCode = "AUTH_INVALID_ARGUMENT"         // 不正な入力値
+	ErrorCodeAuthCodeMismatch          ErrorCode = "AUTH_CODE_MISMATCH"            // 検証コードの不一致
+	ErrorCodeAuthCodeExpired           ErrorCode = "AUTH_CODE_EXPIRED"             // 検証コードの有効期限切れ
+	ErrorCodeAuthCodeDeliveryFailure   ErrorCode = "AUTH_CODE_DELIVERY_FAILURE"    // 検証コードの送信失敗
+	ErrorCodeAuthInvalidPassword       ErrorCode = "AUTH_INVALID_PASSWORD"         // パスワードポリシー違反
+	ErrorCodeAuthUnauthenticated       ErrorCode = "AUTH_UNAUTHENTICATED"          // 認証エラー
+	ErrorCodeAuthNotAuthorized         ErrorCode = "AUTH_NOT_AUTHORIZED"           // 認証情報の誤り・トークンの失効
+	ErrorCodeAuthPasswordResetRequired ErrorCode = "AUTH_PASSWORD_RESET_REQUIRED"  // パスワードのリセットが必要
+	ErrorCodeAuthUserNotConfirmed      ErrorCode = "AUTH_USER_NOT_CONFIRMED"       // メールアドレスの確認が未完了
+	ErrorCodeAuthNotFound              ErrorCode = "AUTH_NOT_FOUND"                // 対象が存在しない
+	ErrorCodeAuthUserNotFound          ErrorCode = "AUTH_USER_NOT_FOUND"           // ユーザーが存在しない
+	ErrorCodeAuthAlreadyExists         ErrorCode = "AUTH_ALREADY_EXISTS"           // ユーザーがすでに存在する
+	ErrorCodeAuthTooManyFailedAttempts ErrorCode = "AUTH_TOO_MANY_FAILED_ATTEMPTS" // 認証の失敗回数が上限超過
+	ErrorCodeAuthTooManyRequests       ErrorCode = "AUTH_TOO_MANY_REQUESTS"        // リクエスト過多
+	ErrorCodeAuthInternal              ErrorCode = "AUTH_INTERNAL"                 // 認証サービスの内部エラー
+	ErrorCodeAuthCanceled              ErrorCode = "AUTH_CANCELED"                 // 認証処理のキャンセル
+	ErrorCodeAuthTimeout               ErrorCode = "AUTH_TIMEOUT"                  // 認証処理のタイムアウト
+	ErrorCodeAuthUnknown               ErrorCode = "AUTH_UNKNOWN"                  // 認証サービスの不明なエラー
+	// データベース
+	ErrorCodeDBInvalidArgument    ErrorCode = "DB_INVALID_ARGUMENT"    // 不正な値
+	ErrorCodeDBNotFound           ErrorCode = "DB_NOT_FOUND"           // 対象が存在しない
+	ErrorCodeDBAlreadyExists      ErrorCode = "DB_ALREADY_EXISTS"      // 一意制約違反
+	ErrorCodeDBFailedPrecondition ErrorCode = "DB_FAILED_PRECONDITION" // 前提条件エラー
+	ErrorCodeDBCanceled           ErrorCode = "DB_CANCELED"            // 処理のキャンセル
+	ErrorCodeDBDeadlineExceeded   ErrorCode = "DB_DEADLINE_EXCEEDED"   // 処理のタイムアウト
+	ErrorCodeDBUnavailable        ErrorCode = "DB_UNAVAILABLE"         // 一時的に利用不可 (接続エラー・読み取り専用・デッドロック)
+	ErrorCodeDBInternal           ErrorCode = "DB_INTERNAL"            // 内部エラー
+	ErrorCodeDBUnknown            ErrorCode = "DB_UNKNOWN"             // 不明なエラー
+)
+
+// errorCodes - エラーとエラーコードの対応表
+// 詳細なエラー理由を先に判定するため、定義順に評価する
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	// 認証 - エラー理由
+	{err: cognito.ErrCodeMismatch, code: ErrorCodeAuthCodeMismatch},
+	{err: cognito.ErrCodeExpired, code: ErrorCodeAuthCodeExpired},
+	{err: cognito.ErrCodeDeliveryFailure, code: ErrorCodeAuthCodeDeliveryFailure},
+	{err: cognito.ErrInvalidPassword, code: ErrorCodeAuthInvalidPassword},
+	{err: cognito.ErrNotAuthorized, code: ErrorCodeAuthNotAuthorized},
+	{err: cognito.ErrPasswordResetRequired, code: ErrorCodeAuthPasswordResetRequired},
+	{err: cognito.ErrUserNotConfirmed, code: ErrorCodeAuthUserNotConfirmed},
+	{err: cognito.ErrUserNotFound, code: ErrorCodeAuthUserNotFound},
+	{err: cognito.ErrUserAlreadyExists, code: ErrorCodeAuthAlreadyExists},
+	{err: cognito.ErrTooManyFailedAttempts, code: ErrorCodeAuthTooManyFailedAttempts},
+	{err: cognito.ErrTooManyRequests, code: ErrorCodeAuthTooManyRequests},
+	// 認証 - エラー種別
+	{err: cognito.ErrInvalidArgument, code: ErrorCodeAuthInvalidArgument},
+	{err: cognito.ErrUnauthenticated, code: ErrorCodeAuthUnauthenticated},
+	{err: cognito.ErrNotFound, code: ErrorCodeAuthNotFound},
+	{err: cognito.ErrAlreadyExists, code: ErrorCodeAuthAlreadyExists},
+	{err: cognito.ErrResourceExhausted, code: ErrorCodeAuthTooManyRequests},
+	{err: cognito.ErrInternal, code: ErrorCodeAuthInternal},
+	{err: cognito.ErrCanceled, code: ErrorCodeAuthCanceled},
+	{err: cognito.ErrTimeout, code: ErrorCodeAuthTimeout},
+	{err: cognito.ErrUnknown, code: ErrorCodeAuthUnknown},
+	// データベース
+	{err: database.ErrInvalidArgument, code: ErrorCodeDBInvalidArgument},
+	{err: database.ErrNotFound, code: ErrorCodeDBNotFound},
+	{err: database.ErrAlreadyExists, code: ErrorCodeDBAlreadyExists},
+	{err: database.ErrFailedPrecondition, code: ErrorCodeDBFailedPrecondition},
+	{err: database.ErrCanceled, code: ErrorCodeDBCanceled},
+	{err: database.ErrDeadlineExceeded, code: ErrorCodeDBDeadlineExceeded},
+	{err: database.ErrUnavailable, code: ErrorCodeDBUnavailable},
+	{err: database.ErrInternal, code: ErrorCodeDBInternal},
+	{err: database.ErrUnknown, code: ErrorCodeDBUnknown},
+	// その他
+	{err: context.Canceled, code: ErrorCodeCanceled},
+	{err: context.DeadlineExceeded, code: ErrorCodeDeadlineExceeded},
+}
+
+// grpcErrorCodes - gRPCのステータスコードとエラーコードの対応表
+var grpcErrorCodes = map[codes.Code]ErrorCode{
+	codes.InvalidArgument:    ErrorCodeInvalidArgument,
+	codes.OutOfRange:         ErrorCodeInvalidArgument,
+	codes.Unauthenticated:    ErrorCodeUnauthenticated,
+	codes.PermissionDenied:   ErrorCodePermissionDenied,
+	codes.NotFound:           ErrorCodeNotFound,
+	codes.AlreadyExists:      ErrorCodeAlreadyExists,
+	codes.Aborted:            ErrorCodeAlreadyExists,
+	codes.FailedPrecondition: ErrorCodeFailedPrecondition,
+	codes.ResourceExhausted:  ErrorCodeResourceExhausted,
+	codes.Canceled:           ErrorCodeCanceled,
+	codes.Internal:           ErrorCodeInternal,
+	codes.DataLoss:           ErrorCodeInternal,
+	codes.Unimplemented:      ErrorCodeUnimplemented,
+	codes.Unavailable:        ErrorCodeUnavailable,
+	codes.DeadlineExceeded:   ErrorCodeDeadlineExceeded,
+}
+
+// errorCode - エラーに対応するエラーコードを返す
+func errorCode(err error) ErrorCode {
+	if err == nil {
+		return ErrorCodeUnknown
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	if code, ok := grpcErrorCodes[status.Code(err)]; ok {
+		return code
+	}
+	return ErrorCodeUnknown
+}
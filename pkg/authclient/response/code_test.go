@@ -0,0 +1,292 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestErrorCode - エラーとエラーコード、ステータスコードの対応表
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	// pkg/cognitoと同様の形式でラップしたエラーを生成
+	authErr := func(kind, reason error) error {
+		if reason == nil {
+			return fmt.Errorf("%w: %s", kind, "some error")
+		}
+		return fmt.Errorf("%w: %w: %s", kind, reason, "some error")
+	}
+	dbErr := func(kind error) error {
+		return fmt.Errorf("%w: %s", kind, "some error")
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		code   ErrorCode
+		status int
+	}{
+		// 認証
+		{
+			name:   "code mismatch",
+			err:    authErr(cognito.ErrInvalidArgument, cognito.ErrCodeMismatch),
+			code:   ErrorCodeAuthCodeMismatch,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid password",
+			err:    authErr(cognito.ErrInvalidArgument, cognito.ErrInvalidPassword),
+			code:   ErrorCodeAuthInvalidPassword,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid parameter",
+			err:    authErr(cognito.ErrInvalidArgument, nil),
+			code:   ErrorCodeAuthInvalidArgument,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "code expired",
+			err:    authErr(cognito.ErrUnauthenticated, cognito.ErrCodeExpired),
+			code:   ErrorCodeAuthCodeExpired,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "not authorized",
+			err:    authErr(cognito.ErrUnauthenticated, cognito.ErrNotAuthorized),
+			code:   ErrorCodeAuthNotAuthorized,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "password reset required",
+			err:    authErr(cognito.ErrUnauthenticated, cognito.ErrPasswordResetRequired),
+			code:   ErrorCodeAuthPasswordResetRequired,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "user not confirmed",
+			err:    authErr(cognito.ErrUnauthenticated, cognito.ErrUserNotConfirmed),
+			code:   ErrorCodeAuthUserNotConfirmed,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "auth unauthenticated",
+			err:    authErr(cognito.ErrUnauthenticated, nil),
+			code:   ErrorCodeAuthUnauthenticated,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "user not found",
+			err:    authErr(cognito.ErrNotFound, cognito.ErrUserNotFound),
+			code:   ErrorCodeAuthUserNotFound,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "auth not found",
+			err:    authErr(cognito.ErrNotFound, nil),
+			code:   ErrorCodeAuthNotFound,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "user already exists",
+			err:    authErr(cognito.ErrAlreadyExists, cognito.ErrUserAlreadyExists),
+			code:   ErrorCodeAuthAlreadyExists,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "too many failed attempts",
+			err:    authErr(cognito.ErrResourceExhausted, cognito.ErrTooManyFailedAttempts),
+			code:   ErrorCodeAuthTooManyFailedAttempts,
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "too many requests",
+			err:    authErr(cognito.ErrResourceExhausted, cognito.ErrTooManyRequests),
+			code:   ErrorCodeAuthTooManyRequests,
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "code delivery failure",
+			err:    authErr(cognito.ErrInternal, cognito.ErrCodeDeliveryFailure),
+			code:   ErrorCodeAuthCodeDeliveryFailure,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "auth internal",
+			err:    authErr(cognito.ErrInternal, nil),
+			code:   ErrorCodeAuthInternal,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "auth canceled",
+			err:    authErr(cognito.ErrCanceled, nil),
+			code:   ErrorCodeAuthCanceled,
+			status: StatusClientClosedRequest,
+		},
+		{
+			name:   "auth timeout",
+			err:    authErr(cognito.ErrTimeout, nil),
+			code:   ErrorCodeAuthTimeout,
+			status: http.StatusGatewayTimeout,
+		},
+		{
+			name:   "auth unknown",
+			err:    authErr(cognito.ErrUnknown, nil),
+			code:   ErrorCodeAuthUnknown,
+			status: http.StatusInternalServerError,
+		},
+		// データベース
+		{
+			name:   "db invalid argument",
+			err:    dbErr(database.ErrInvalidArgument),
+			code:   ErrorCodeDBInvalidArgument,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "db not found",
+			err:    dbErr(database.ErrNotFound),
+			code:   ErrorCodeDBNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "db already exists",
+			err:    dbErr(database.ErrAlreadyExists),
+			code:   ErrorCodeDBAlreadyExists,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "db failed precondition",
+			err:    dbErr(database.ErrFailedPrecondition),
+			code:   ErrorCodeDBFailedPrecondition,
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "db canceled",
+			err:    dbErr(database.ErrCanceled),
+			code:   ErrorCodeDBCanceled,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "db deadline exceeded",
+			err:    dbErr(database.ErrDeadlineExceeded),
+			code:   ErrorCodeDBDeadlineExceeded,
+			status: http.StatusGatewayTimeout,
+		},
+		{
+			name:   "db unavailable",
+			err:    dbErr(database.ErrUnavailable),
+			code:   ErrorCodeDBUnavailable,
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "db internal",
+			err:    dbErr(database.ErrInternal),
+			code:   ErrorCodeDBInternal,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "db unknown",
+			err:    dbErr(database.ErrUnknown),
+			code:   ErrorCodeDBUnknown,
+			status: http.StatusInternalServerError,
+		},
+		// gRPC
+		{
+			name:   "grpc invalid argument",
+			err:    status.Error(codes.InvalidArgument, "some error"),
+			code:   ErrorCodeInvalidArgument,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "grpc unauthenticated",
+			err:    status.Error(codes.Unauthenticated, "some error"),
+			code:   ErrorCodeUnauthenticated,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "grpc permission denied",
+			err:    status.Error(codes.PermissionDenied, "some error"),
+			code:   ErrorCodePermissionDenied,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "grpc not found",
+			err:    status.Error(codes.NotFound, "some error"),
+			code:   ErrorCodeNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "grpc already exists",
+			err:    status.Error(codes.AlreadyExists, "some error"),
+			code:   ErrorCodeAlreadyExists,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "grpc failed precondition",
+			err:    status.Error(codes.FailedPrecondition, "some error"),
+			code:   ErrorCodeFailedPrecondition,
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "grpc resource exhausted",
+			err:    status.Error(codes.ResourceExhausted, "some error"),
+			code:   ErrorCodeResourceExhausted,
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "grpc internal",
+			err:    status.Error(codes.Internal, "some error"),
+			code:   ErrorCodeInternal,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "grpc unimplemented",
+			err:    status.Error(codes.Unimplemented, "some error"),
+			code:   ErrorCodeUnimplemented,
+			status: http.StatusNotImplemented,
+		},
+		{
+			name:   "grpc unavailable",
+			err:    status.Error(codes.Unavailable, "some error"),
+			code:   ErrorCodeUnavailable,
+			status: http.StatusBadGateway,
+		},
+		// その他
+		{
+			name:   "context canceled",
+			err:    context.Canceled,
+			code:   ErrorCodeCanceled,
+			status: StatusClientClosedRequest,
+		},
+		{
+			name:   "context deadline exceeded",
+			err:    context.DeadlineExceeded,
+			code:   ErrorCodeDeadlineExceeded,
+			status: http.StatusGatewayTimeout,
+		},
+		{
+			name:   "unknown",
+			err:    assert.AnError,
+			code:   ErrorCodeUnknown,
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, status := NewErrorResponse(tt.err)
+			assert.Equal(t, tt.code, res.Code)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.status, res.Status)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/and-period/furumane/pkg/i18n"
+)
+
+const unknownMessageKey = "unknown"
+
+// messages - APIエラーのメッセージ一覧
+var messages = i18n.Catalog{
+	// エラー概要
+	"status.400": {
+		i18n.LocaleJA: "リクエストの内容が正しくありません",
+		i18n.LocaleEN: "Bad Request",
+	},
+	"status.401": {
+		i18n.LocaleJA: "認証に失敗しました",
+		i18n.LocaleEN: "Unauthorized",
+	},
+	"status.403": {
+		i18n.LocaleJA: "この操作を行う権限がありません",
+		i18n.LocaleEN: "Forbidden",
+	},
+	"status.404": {
+		i18n.LocaleJA: "対象が見つかりません",
+		i18n.LocaleEN: "Not Found",
+	},
+	"status.409": {
+		i18n.LocaleJA: "すでに登録されています",
+		i18n.LocaleEN: "Conflict",
+	},
+	"status.412": {
+		i18n.LocaleJA: "現在の状態ではこの操作を実行できません",
+		i18n.LocaleEN: "Precondition Failed",
+	},
+	"status.429": {
+		i18n.LocaleJA: "リクエストが多すぎます",
+		i18n.LocaleEN: "Too Many Requests",
+	},
+	"status.499": {
+		i18n.LocaleJA: "リクエストがキャンセルされました",
+		i18n.LocaleEN: "Client Closed Request",
+	},
+	"status.500": {
+		i18n.LocaleJA: "サーバーでエラーが発生しました",
+		i18n.LocaleEN: "Internal Server Error",
+	},
+	"status.501": {
+		i18n.LocaleJA: "この機能は実装されていません",
+		i18n.LocaleEN: "Not Implemented",
+	},
+	"status.502": {
+		i18n.LocaleJA: "外部サービスとの通信に失敗しました",
+		i18n.LocaleEN: "Bad Gateway",
+	},
+	"status.503": {
+		i18n.LocaleJA: "サービスが一時的に利用できません。しばらく時間をおいてから再度お試しください",
+		i18n.LocaleEN: "Service Unavailable",
+	},
+	"status.504": {
+		i18n.LocaleJA: "処理がタイムアウトしました",
+		i18n.LocaleEN: "Gateway Timeout",
+	},
+	unknownMessageKey: {
+		i18n.LocaleJA: "不明なエラーが発生しました",
+		i18n.LocaleEN: "unknown error code",
+	},
+	// エラー詳細 (エラーコードごと)
+	string(ErrorCodeAuthInvalidArgument): {
+		i18n.LocaleJA: "入力内容に誤りがあります",
+		i18n.LocaleEN: "The input is incorrect",
+	},
+	string(ErrorCodeAuthCodeMismatch): {
+		i18n.LocaleJA: "検証コードが正しくありません",
+		i18n.LocaleEN: "The verification code is incorrect",
+	},
+	string(ErrorCodeAuthCodeExpired): {
+		i18n.LocaleJA: "検証コードの有効期限が切れています。再度コードを発行してください",
+		i18n.LocaleEN: "The verification code has expired. Please request a new code",
+	},
+	string(ErrorCodeAuthCodeDeliveryFailure): {
+		i18n.LocaleJA: "検証コードの送信に失敗しました",
+		i18n.LocaleEN: "Failed to deliver the verification code",
+	},
+	string(ErrorCodeAuthInvalidPassword): {
+		i18n.LocaleJA: "パスワードが要件を満たしていません",
+		i18n.LocaleEN: "The password does not meet the requirements",
+	},
+	string(ErrorCodeAuthUnauthenticated): {
+		i18n.LocaleJA: "認証情報が正しくないか、有効期限が切れています",
+		i18n.LocaleEN: "The credentials are incorrect or have expired",
+	},
+	string(ErrorCodeAuthNotAuthorized): {
+		i18n.LocaleJA: "メールアドレスまたはパスワードが正しくないか、トークンの有効期限が切れています",
+		i18n.LocaleEN: "The email address or password is incorrect, or the token has expired",
+	},
+	string(ErrorCodeAuthPasswordResetRequired): {
+		i18n.LocaleJA: "パスワードの再設定が必要です",
+		i18n.LocaleEN: "A password reset is required",
+	},
+	string(ErrorCodeAuthUserNotConfirmed): {
+		i18n.LocaleJA: "メールアドレスの確認が完了していません",
+		i18n.LocaleEN: "Please verify your email address first",
+	},
+	string(ErrorCodeAuthNotFound): {
+		i18n.LocaleJA: "認証情報が見つかりません",
+		i18n.LocaleEN: "The authentication resource was not found",
+	},
+	string(ErrorCodeAuthUserNotFound): {
+		i18n.LocaleJA: "ユーザーが見つかりません",
+		i18n.LocaleEN: "The user was not found",
+	},
+	string(ErrorCodeAuthAlreadyExists): {
+		i18n.LocaleJA: "このユーザーはすでに登録されています",
+		i18n.LocaleEN: "The user already exists",
+	},
+	string(ErrorCodeAuthTooManyFailedAttempts): {
+		i18n.LocaleJA: "認証の失敗回数が上限に達しました。しばらく時間をおいてから再度お試しください",
+		i18n.LocaleEN: "Too many failed attempts. Please try again later",
+	},
+	string(ErrorCodeAuthTooManyRequests): {
+		i18n.LocaleJA: "試行回数が上限に達しました。しばらく時間をおいてから再度お試しください",
+		i18n.LocaleEN: "Too many attempts. Please try again later",
+	},
+	string(ErrorCodeAuthInternal): {
+		i18n.LocaleJA: "認証サービスでエラーが発生しました",
+		i18n.LocaleEN: "An error occurred in the authentication service",
+	},
+	string(ErrorCodeAuthCanceled): {
+		i18n.LocaleJA: "認証処理がキャンセルされました",
+		i18n.LocaleEN: "The authentication request was canceled",
+	},
+	string(ErrorCodeAuthTimeout): {
+		i18n.LocaleJA: "認証サービスとの通信がタイムアウトしました",
+		i18n.LocaleEN: "The authentication service timed out",
+	},
+	string(ErrorCodeDBInvalidArgument): {
+		i18n.LocaleJA: "保存する値が正しくありません",
+		i18n.LocaleEN: "The value to be stored is invalid",
+	},
+	string(ErrorCodeDBNotFound): {
+		i18n.LocaleJA: "対象のデータが見つかりません",
+		i18n.LocaleEN: "The requested data was not found",
+	},
+	string(ErrorCodeDBAlreadyExists): {
+		i18n.LocaleJA: "対象のデータはすでに登録されています",
+		i18n.LocaleEN: "The data already exists",
+	},
+	string(ErrorCodeDBFailedPrecondition): {
+		i18n.LocaleJA: "対象のデータは現在の状態では更新できません",
+		i18n.LocaleEN: "The data cannot be changed in its current state",
+	},
+	string(ErrorCodeDBCanceled): {
+		i18n.LocaleJA: "データベースの処理がキャンセルされました",
+		i18n.LocaleEN: "The database operation was canceled",
+	},
+	string(ErrorCodeDBDeadlineExceeded): {
+		i18n.LocaleJA: "データベースとの通信がタイムアウトしました",
+		i18n.LocaleEN: "The database operation timed out",
+	},
+	// ドライバーのエラー (デッドロック・ロック待ちなど) は内部情報を含むため、固定のメッセージを返す
+	string(ErrorCodeDBUnavailable): {
+		i18n.LocaleJA: "データベースが一時的に利用できません。しばらく時間をおいてから再度お試しください",
+		i18n.LocaleEN: "The database is temporarily unavailable. Please try again later",
+	},
+}
+
+func statusMessage(status int, locale i18n.Locale) string {
+	key := fmt.Sprintf("status.%d", status)
+	if !messages.Has(key) {
+		key = unknownMessageKey
+	}
+	return messages.Message(locale, key)
+}
+
+// detailMessage - エラー詳細を返す
+// - 利用者向けのメッセージが定義されていないエラーは、エラー内容をそのまま返す
+func detailMessage(err error, code ErrorCode, locale i18n.Locale) string {
+	if messages.Has(string(code)) {
+		return messages.Message(locale, string(code))
+	}
+	return err.Error()
+}
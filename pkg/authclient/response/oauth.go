@@ -0,0 +1,16 @@
+package response
+
+// IntrospectTokenResponse - トークンの検証結果 (RFC 7662)
+// RFC 7662に合わせて、フィールド名はスネークケースとする
+// 無効なトークンの場合は、activeのみを返す
+type IntrospectTokenResponse struct {
+	Active    bool   `json:"active"`               // 有効なトークンか
+	Scope     string `json:"scope,omitempty"`      // スコープ (スペース区切り)
+	ClientID  string `json:"client_id,omitempty"`  // トークンを発行したクライアントID
+	Username  string `json:"username,omitempty"`   // ユーザー名
+	TokenType string `json:"token_type,omitempty"` // トークン種別
+	Exp       int64  `json:"exp,omitempty"`        // 有効期限 (UNIX時間)
+	Iat       int64  `json:"iat,omitempty"`        // 発行日時 (UNIX時間)
+	Sub       string `json:"sub,omitempty"`        // ユーザーの識別子
+	AdminID   string `json:"admin_id,omitempty"`   // 管理者ID
+}
@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+// Organization 組織
+type Organization struct {
+	ID        string    `json:"id"`        // 組織ID
+	Code      string    `json:"code"`      // 地方公共団体コード
+	Name      string    `json:"name"`      // 組織名
+	Role      int32     `json:"role"`      // 組織内の権限 (リクエストした管理者、1: オーナー, 2: 管理者, 3: メンバー)
+	CreatedAt time.Time `json:"createdAt"` // 登録日時
+	UpdatedAt time.Time `json:"updatedAt"` // 更新日時
+}
+
+// OrganizationMember 組織のメンバー
+type OrganizationMember struct {
+	AdminID   string    `json:"adminId"`           // 管理者ID
+	Email     string    `json:"email" log:"email"` // メールアドレス
+	Role      int32     `json:"role"`              // 組織内の権限 (1: オーナー, 2: 管理者, 3: メンバー)
+	CreatedAt time.Time `json:"createdAt"`         // 登録日時
+	UpdatedAt time.Time `json:"updatedAt"`         // 更新日時
+}
+
+type OrganizationResponse struct {
+	Organization *Organization `json:"organization"` // 組織
+}
+
+type OrganizationsResponse struct {
+	Organizations []*Organization `json:"organizations"` // 所属する組織一覧
+}
+
+type OrganizationMemberResponse struct {
+	Member *OrganizationMember `json:"member"` // メンバー
+}
+
+type OrganizationMembersResponse struct {
+	Members    []*OrganizationMember `json:"members"`    // メンバー一覧
+	NextCursor string                `json:"nextCursor"` // 次ページのカーソル (次ページが存在しない場合は空文字)
+}
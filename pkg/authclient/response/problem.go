@@ -0,0 +1,69 @@
+package response
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ProblemContentType - RFC 7807形式のエラーレスポンスのContent-Type
+	ProblemContentType = "application/problem+json"
+	// ProblemTypeBlank - エラー種別に固有の意味を持たせない場合のtype
+	ProblemTypeBlank = "about:blank"
+)
+
+// ProblemDetails - RFC 7807形式のエラーレスポンス
+type ProblemDetails struct {
+	Type      string        `json:"type"`                // エラー種別を識別するURI
+	Title     string        `json:"title"`               // エラー概要
+	Status    int           `json:"status"`              // ステータスコード
+	Detail    string        `json:"detail"`              // エラー詳細
+	Instance  string        `json:"instance,omitempty"`  // エラーが発生したリソースのURI
+	Code      ErrorCode     `json:"code"`                // エラーコード (拡張メンバー)
+	RequestID string        `json:"requestId,omitempty"` // リクエストID (拡張メンバー)
+	Errors    []*FieldError `json:"errors,omitempty"`    // 入力値の検証エラー一覧 (拡張メンバー)
+}
+
+// NewProblemDetails - RFC 7807形式のエラーレスポンスを生成
+func NewProblemDetails(err error, opts ...Option) (*ProblemDetails, int) {
+	dopts := newOptions(opts...)
+	res, status := NewErrorResponse(err, opts...)
+	return &ProblemDetails{
+		Type:      problemType(dopts.typeBaseURL, res.Code),
+		Title:     res.Message,
+		Status:    res.Status,
+		Detail:    res.Detail,
+		Instance:  dopts.instance,
+		Code:      res.Code,
+		RequestID: dopts.requestID,
+		Errors:    res.Errors,
+	}, status
+}
+
+// problemType - エラーコードからエラー種別を識別するURIを生成
+// e.g.) https://example.com/problems + AUTH_CODE_MISMATCH -> https://example.com/problems/auth-code-mismatch
+func problemType(baseURL string, code ErrorCode) string {
+	if baseURL == "" {
+		return ProblemTypeBlank
+	}
+	name := strings.ToLower(strings.ReplaceAll(string(code), "_", "-"))
+	return strings.TrimSuffix(baseURL, "/") + "/" + name
+}
+
+// AcceptsProblem - AcceptヘッダーでRFC 7807形式のレスポンスが要求されているか
+func AcceptsProblem(accept string) bool {
+	for _, v := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		q, ok := params["q"]
+		if !ok {
+			return true
+		}
+		quality, err := strconv.ParseFloat(q, 64)
+		return err == nil && quality > 0
+	}
+	return false
+}
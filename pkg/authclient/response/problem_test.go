@@ -0,0 +1,123 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/i18n"
+	"github.com/and-period/furumane/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	validationErr := validator.NewValidator().Struct(&input{})
+
+	tests := []struct {
+		name   string
+		err    error
+		opts   []Option
+		expect *ProblemDetails
+		status int
+	}{
+		{
+			name: "default",
+			err:  fmt.Errorf("%w: %w: %s", cognito.ErrInvalidArgument, cognito.ErrCodeMismatch, "CodeMismatchException"),
+			expect: &ProblemDetails{
+				Type:   ProblemTypeBlank,
+				Title:  "リクエストの内容が正しくありません",
+				Status: http.StatusBadRequest,
+				Detail: "検証コードが正しくありません",
+				Code:   ErrorCodeAuthCodeMismatch,
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "with options",
+			err:  fmt.Errorf("%w: %w: %s", cognito.ErrInvalidArgument, cognito.ErrCodeMismatch, "CodeMismatchException"),
+			opts: []Option{
+				WithLocale(i18n.LocaleEN),
+				WithInstance("/admin/auth"),
+				WithRequestID("request-id"),
+				WithTypeBaseURL("https://example.com/problems/"),
+			},
+			expect: &ProblemDetails{
+				Type:      "https://example.com/problems/auth-code-mismatch",
+				Title:     "Bad Request",
+				Status:    http.StatusBadRequest,
+				Detail:    "The verification code is incorrect",
+				Instance:  "/admin/auth",
+				Code:      ErrorCodeAuthCodeMismatch,
+				RequestID: "request-id",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "database unavailable",
+			err:  fmt.Errorf("%w: %s", database.ErrUnavailable, "Error 1213 (40001): Deadlock found when trying to get lock"),
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ProblemDetails{
+				Type:   ProblemTypeBlank,
+				Title:  "Service Unavailable",
+				Status: http.StatusServiceUnavailable,
+				Detail: "The database is temporarily unavailable. Please try again later",
+				Code:   ErrorCodeDBUnavailable,
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name: "validation error",
+			err:  validationErr,
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ProblemDetails{
+				Type:   ProblemTypeBlank,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "email is required",
+				Code:   ErrorCodeValidationFailed,
+				Errors: []*FieldError{{Field: "email", Message: "email is required"}},
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, status := NewProblemDetails(tt.err, tt.opts...)
+			assert.Equal(t, tt.expect, actual)
+			assert.Equal(t, tt.status, status)
+		})
+	}
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		accept string
+		expect bool
+	}{
+		{name: "empty", accept: "", expect: false},
+		{name: "json", accept: "application/json", expect: false},
+		{name: "any", accept: "*/*", expect: false},
+		{name: "problem", accept: "application/problem+json", expect: true},
+		{name: "multiple", accept: "application/json, application/problem+json;q=0.9", expect: true},
+		{name: "refused", accept: "application/problem+json;q=0", expect: false},
+		{name: "invalid", accept: "application/problem+json;q", expect: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, AcceptsProblem(tt.accept))
+		})
+	}
+}
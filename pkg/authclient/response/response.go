@@ -0,0 +1,273 @@
+// Package response - 認証APIのレスポンスボディとエラーレスポンス
+//
+// 認証APIのサーバーとクライアント (authclient) で共有するため、モジュール外からも参照できるpkg配下に配置する
+// (列挙値は内部のエンティティの型に依存させず、数値・文字列で返す)
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/i18n"
+	"github.com/and-period/furumane/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	StatusClientClosedRequest = 499
+)
+
+type ErrorResponse struct {
+	Status    int           `json:"status"`              // ステータスコード
+	Code      ErrorCode     `json:"code"`                // エラーコード
+	Message   string        `json:"message"`             // エラー概要
+	Detail    string        `json:"detail"`              // エラー詳細
+	Errors    []*FieldError `json:"errors,omitempty"`    // 入力値の検証エラー一覧
+	RequestID string        `json:"requestId,omitempty"` // リクエストID
+}
+
+// FieldError - フィールドごとの入力値の検証エラー
+type FieldError struct {
+	Field   string `json:"field"`   // フィールド名
+	Message string `json:"message"` // エラー内容
+}
+
+type options struct {
+	locale      i18n.Locale
+	instance    string
+	requestID   string
+	typeBaseURL string
+}
+
+type Option func(*options)
+
+// WithLocale - エラーメッセージの言語を指定
+func WithLocale(locale i18n.Locale) Option {
+	return func(opts *options) {
+		opts.locale = locale
+	}
+}
+
+// WithInstance - エラーが発生したリソースのURIを指定 (RFC 7807形式のみ)
+func WithInstance(instance string) Option {
+	return func(opts *options) {
+		opts.instance = instance
+	}
+}
+
+// WithRequestID - リクエストIDを指定
+func WithRequestID(requestID string) Option {
+	return func(opts *options) {
+		opts.requestID = requestID
+	}
+}
+
+// WithTypeBaseURL - エラー種別を識別するURIの接頭辞を指定 (RFC 7807形式のみ)
+func WithTypeBaseURL(url string) Option {
+	return func(opts *options) {
+		opts.typeBaseURL = url
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	dopts := &options{
+		locale: i18n.DefaultLocale,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return dopts
+}
+
+func NewErrorResponse(err error, opts ...Option) (*ErrorResponse, int) {
+	dopts := newOptions(opts...)
+
+	res, status := newErrorResponseWithOptions(err, dopts)
+	res.RequestID = dopts.requestID
+	return res, status
+}
+
+func newErrorResponseWithOptions(err error, dopts *options) (*ErrorResponse, int) {
+	if res, ok := validationError(err, dopts.locale); ok {
+		return res, res.Status
+	}
+	if status, ok := internalError(err); ok {
+		return newErrorResponse(status, err, dopts.locale), status
+	}
+	if status, ok := authError(err); ok {
+		return newErrorResponse(status, err, dopts.locale), status
+	}
+	if status, ok := dbError(err); ok {
+		return newErrorResponse(status, err, dopts.locale), status
+	}
+	if status, ok := grpcError(err); ok {
+		return newErrorResponse(status, err, dopts.locale), status
+	}
+
+	code := errorCode(err)
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+	res := &ErrorResponse{
+		Status:  http.StatusInternalServerError,
+		Code:    code,
+		Message: messages.Message(dopts.locale, unknownMessageKey),
+		Detail:  detailMessage(err, code, dopts.locale),
+	}
+	return res, http.StatusInternalServerError
+}
+
+func newErrorResponse(status int, err error, locale i18n.Locale) *ErrorResponse {
+	code := errorCode(err)
+	return &ErrorResponse{
+		Status:  status,
+		Code:    code,
+		Message: statusMessage(status, locale),
+		Detail:  detailMessage(err, code, locale),
+	}
+}
+
+func validationError(err error, locale i18n.Locale) (*ErrorResponse, bool) {
+	errs, ok := validator.Translate(err, locale)
+	if !ok {
+		return nil, false
+	}
+	details := make([]string, len(errs))
+	fields := make([]*FieldError, len(errs))
+	for i := range errs {
+		details[i] = errs[i].Message
+		fields[i] = &FieldError{
+			Field:   errs[i].Field,
+			Message: errs[i].Message,
+		}
+	}
+	res := &ErrorResponse{
+		Status:  http.StatusBadRequest,
+		Code:    ErrorCodeValidationFailed,
+		Message: statusMessage(http.StatusBadRequest, locale),
+		Detail:  strings.Join(details, "\n"),
+		Errors:  fields,
+	}
+	return res, true
+}
+
+func internalError(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var s int
+	switch {
+	// 4xx
+	case errors.Is(err, context.Canceled):
+		s = StatusClientClosedRequest
+	// 5xx
+	case errors.Is(err, context.DeadlineExceeded):
+		s = http.StatusGatewayTimeout
+	default:
+		return 0, false
+	}
+
+	return s, true
+}
+
+func authError(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var s int
+	switch {
+	// 4xx
+	case errors.Is(err, cognito.ErrInvalidArgument):
+		s = http.StatusBadRequest
+	case errors.Is(err, cognito.ErrUnauthenticated), errors.Is(err, cognito.ErrNotFound):
+		s = http.StatusUnauthorized
+	case errors.Is(err, cognito.ErrAlreadyExists):
+		s = http.StatusConflict
+	case errors.Is(err, cognito.ErrResourceExhausted):
+		s = http.StatusTooManyRequests
+	case errors.Is(err, cognito.ErrCanceled):
+		s = StatusClientClosedRequest
+	// 5xx
+	case errors.Is(err, cognito.ErrTimeout):
+		s = http.StatusGatewayTimeout
+	default:
+		return 0, false
+	}
+
+	return s, true
+}
+
+func dbError(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var s int
+	switch {
+	// 4xx
+	case errors.Is(err, database.ErrInvalidArgument):
+		s = http.StatusBadRequest
+	case errors.Is(err, database.ErrNotFound):
+		s = http.StatusNotFound
+	case errors.Is(err, database.ErrFailedPrecondition):
+		s = http.StatusPreconditionFailed
+	case errors.Is(err, database.ErrAlreadyExists):
+		s = http.StatusConflict
+	// 5xx
+	case errors.Is(err, database.ErrUnavailable):
+		s = http.StatusServiceUnavailable
+	case errors.Is(err, database.ErrDeadlineExceeded):
+		s = http.StatusGatewayTimeout
+	default:
+		return 0, false
+	}
+
+	return s, true
+}
+
+func grpcError(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var s int
+	switch status.Code(err) {
+	// 4xx
+	case codes.InvalidArgument, codes.OutOfRange:
+		s = http.StatusBadRequest
+	case codes.Unauthenticated:
+		s = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		s = http.StatusForbidden
+	case codes.NotFound:
+		s = http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		s = http.StatusConflict
+	case codes.FailedPrecondition:
+		s = http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		s = http.StatusTooManyRequests
+	case codes.Canceled:
+		s = StatusClientClosedRequest
+	// 5xx
+	case codes.Internal, codes.DataLoss:
+		s = http.StatusInternalServerError
+	case codes.Unimplemented:
+		s = http.StatusNotImplemented
+	case codes.Unavailable:
+		s = http.StatusBadGateway
+	case codes.DeadlineExceeded:
+		s = http.StatusGatewayTimeout
+	default:
+		return 0, false
+	}
+
+	return s, true
+}
@@ -0,0 +1,201 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/and-period/furumane/internal/auth/database"
+	"github.com/and-period/furumane/pkg/cognito"
+	"github.com/and-period/furumane/pkg/i18n"
+	"github.com/and-period/furumane/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	validationErr := validator.NewValidator().Struct(&input{})
+
+	tests := []struct {
+		name   string
+		err    error
+		opts   []Option
+		expect *ErrorResponse
+		status int
+	}{
+		{
+			name: "validation error (default locale)",
+			err:  validationErr,
+			expect: &ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Code:    ErrorCodeValidationFailed,
+				Message: "リクエストの内容が正しくありません",
+				Detail:  "emailは必須です",
+				Errors:  []*FieldError{{Field: "email", Message: "emailは必須です"}},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "validation error (english)",
+			err:  validationErr,
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Code:    ErrorCodeValidationFailed,
+				Message: "Bad Request",
+				Detail:  "email is required",
+				Errors:  []*FieldError{{Field: "email", Message: "email is required"}},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "validation error with request id",
+			err:  validationErr,
+			opts: []Option{WithRequestID("request-id")},
+			expect: &ErrorResponse{
+				Status:    http.StatusBadRequest,
+				Code:      ErrorCodeValidationFailed,
+				Message:   "リクエストの内容が正しくありません",
+				Detail:    "emailは必須です",
+				Errors:    []*FieldError{{Field: "email", Message: "emailは必須です"}},
+				RequestID: "request-id",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "context canceled",
+			err:  context.Canceled,
+			opts: []Option{WithLocale(i18n.LocaleJA)},
+			expect: &ErrorResponse{
+				Status:  StatusClientClosedRequest,
+				Code:    ErrorCodeCanceled,
+				Message: "リクエストがキャンセルされました",
+				Detail:  "context canceled",
+			},
+			status: StatusClientClosedRequest,
+		},
+		{
+			name: "cognito error (japanese)",
+			err:  fmt.Errorf("%w: %s", cognito.ErrUnauthenticated, "NotAuthorizedException"),
+			opts: []Option{WithLocale(i18n.LocaleJA)},
+			expect: &ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Code:    ErrorCodeAuthUnauthenticated,
+				Message: "認証に失敗しました",
+				Detail:  "認証情報が正しくないか、有効期限が切れています",
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "cognito error (english)",
+			err:  fmt.Errorf("%w: %s", cognito.ErrResourceExhausted, "LimitExceededException"),
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ErrorResponse{
+				Status:  http.StatusTooManyRequests,
+				Code:    ErrorCodeAuthTooManyRequests,
+				Message: "Too Many Requests",
+				Detail:  "Too many attempts. Please try again later",
+			},
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name: "database error",
+			err:  fmt.Errorf("%w: %s", database.ErrNotFound, "record not found"),
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ErrorResponse{
+				Status:  http.StatusNotFound,
+				Code:    ErrorCodeDBNotFound,
+				Message: "Not Found",
+				Detail:  "The requested data was not found",
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "database invalid argument",
+			err:  fmt.Errorf("%w: %s", database.ErrInvalidArgument, "Error 1406 (22001): Data too long for column 'name' at row 1"),
+			opts: []Option{WithLocale(i18n.LocaleJA)},
+			expect: &ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Code:    ErrorCodeDBInvalidArgument,
+				Message: "リクエストの内容が正しくありません",
+				Detail:  "保存する値が正しくありません",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "database unavailable (japanese)",
+			err:  fmt.Errorf("%w: %s", database.ErrUnavailable, "Error 1213 (40001): Deadlock found when trying to get lock"),
+			opts: []Option{WithLocale(i18n.LocaleJA)},
+			expect: &ErrorResponse{
+				Status:  http.StatusServiceUnavailable,
+				Code:    ErrorCodeDBUnavailable,
+				Message: "サービスが一時的に利用できません。しばらく時間をおいてから再度お試しください",
+				Detail:  "データベースが一時的に利用できません。しばらく時間をおいてから再度お試しください",
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name: "database unavailable (english)",
+			err:  fmt.Errorf("%w: %s", database.ErrUnavailable, "Error 1205 (HY000): Lock wait timeout exceeded"),
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ErrorResponse{
+				Status:  http.StatusServiceUnavailable,
+				Code:    ErrorCodeDBUnavailable,
+				Message: "Service Unavailable",
+				Detail:  "The database is temporarily unavailable. Please try again later",
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name: "grpc error",
+			err:  status.Error(codes.FailedPrecondition, "this admin is already verified"),
+			opts: []Option{WithLocale(i18n.LocaleJA)},
+			expect: &ErrorResponse{
+				Status:  http.StatusPreconditionFailed,
+				Code:    ErrorCodeFailedPrecondition,
+				Message: "現在の状態ではこの操作を実行できません",
+				Detail:  "rpc error: code = FailedPrecondition desc = this admin is already verified",
+			},
+			status: http.StatusPreconditionFailed,
+		},
+		{
+			name: "unknown error",
+			err:  assert.AnError,
+			opts: []Option{WithLocale(i18n.LocaleEN)},
+			expect: &ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Code:    ErrorCodeUnknown,
+				Message: "unknown error code",
+				Detail:  assert.AnError.Error(),
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "nil error",
+			err:  nil,
+			expect: &ErrorResponse{
+				Status:  http.StatusInternalServerError,
+				Code:    ErrorCodeUnknown,
+				Message: "不明なエラーが発生しました",
+				Detail:  "unknown error",
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, status := NewErrorResponse(tt.err, tt.opts...)
+			assert.Equal(t, tt.expect, actual)
+			assert.Equal(t, tt.status, status)
+		})
+	}
+}
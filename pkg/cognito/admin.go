@@ -40,7 +40,7 @@ func (c *client) AdminCreateUser(ctx context.Context, params *AdminCreateUserPar
 	if params.Password == "" {
 		// 一時的なパスワードを付与し、メール通知 (初回ログイン時にパスワード変更要求)
 		_, err := c.cognito.AdminCreateUser(ctx, in)
-		return c.authError(err)
+		return c.authError(ctx, err)
 	}
 	// 恒久的なパスワードを付与 (未通知、かつ初回ログイン時のパスワード変更要求も不要)
 	attr := types.AttributeType{
@@ -51,7 +51,7 @@ func (c *client) AdminCreateUser(ctx context.Context, params *AdminCreateUserPar
 	in.MessageAction = types.MessageActionTypeSuppress
 	in.UserAttributes = append(in.UserAttributes, attr)
 	if _, err := c.cognito.AdminCreateUser(ctx, in); err != nil {
-		return c.authError(err)
+		return c.authError(ctx, err)
 	}
 	passIn := &AdminChangePasswordParams{
 		Username:  params.Username,
@@ -59,7 +59,7 @@ func (c *client) AdminCreateUser(ctx context.Context, params *AdminCreateUserPar
 		Permanent: true,
 	}
 	err := c.AdminChangePassword(ctx, passIn)
-	return c.authError(err)
+	return c.authError(ctx, err)
 }
 
 func (c *client) AdminChangeEmail(ctx context.Context, params *AdminChangeEmailParams) error {
@@ -78,7 +78,7 @@ func (c *client) AdminChangeEmail(ctx context.Context, params *AdminChangeEmailP
 		},
 	}
 	_, err := c.cognito.AdminUpdateUserAttributes(ctx, in)
-	return c.authError(err)
+	return c.authError(ctx, err)
 }
 
 func (c *client) AdminChangePassword(ctx context.Context, params *AdminChangePasswordParams) error {
@@ -89,5 +89,5 @@ func (c *client) AdminChangePassword(ctx context.Context, params *AdminChangePas
 		Permanent:  *aws.Bool(params.Permanent),
 	}
 	_, err := c.cognito.AdminSetUserPassword(ctx, in)
-	return c.authError(err)
+	return c.authError(ctx, err)
 }
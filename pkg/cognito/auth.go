@@ -2,6 +2,7 @@ package cognito
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	cognito "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
@@ -32,7 +33,7 @@ func (c *client) SignIn(ctx context.Context, username, password string) (*AuthRe
 	}
 	out, err := c.cognito.InitiateAuth(ctx, in)
 	if err != nil {
-		return nil, c.authError(err)
+		return nil, c.authError(ctx, err)
 	}
 	auth := &AuthResult{
 		IDToken:      aws.ToString(out.AuthenticationResult.IdToken),
@@ -48,7 +49,7 @@ func (c *client) SignOut(ctx context.Context, accessToken string) error {
 		AccessToken: aws.String(accessToken),
 	}
 	_, err := c.cognito.GlobalSignOut(ctx, in)
-	return c.authError(err)
+	return c.authError(ctx, err)
 }
 
 func (c *client) GetUser(ctx context.Context, accessToken string) (*AuthUser, error) {
@@ -57,7 +58,7 @@ func (c *client) GetUser(ctx context.Context, accessToken string) (*AuthUser, er
 	}
 	out, err := c.cognito.GetUser(ctx, in)
 	if err != nil {
-		return nil, c.authError(err)
+		return nil, c.authError(ctx, err)
 	}
 	var email, phoneNumber string
 	for i := range out.UserAttributes {
@@ -83,7 +84,7 @@ func (c *client) GetUsername(ctx context.Context, accessToken string) (string, e
 	}
 	out, err := c.cognito.GetUser(ctx, in)
 	if err != nil {
-		return "", c.authError(err)
+		return "", c.authError(ctx, err)
 	}
 	return aws.ToString(out.Username), nil
 }
@@ -98,7 +99,7 @@ func (c *client) RefreshToken(ctx context.Context, refreshToken string) (*AuthRe
 	}
 	out, err := c.cognito.InitiateAuth(ctx, in)
 	if err != nil {
-		return nil, c.authError(err)
+		return nil, c.authError(ctx, err)
 	}
 	auth := &AuthResult{
 		IDToken:      aws.ToString(out.AuthenticationResult.IdToken),
@@ -108,3 +109,70 @@ func (c *client) RefreshToken(ctx context.Context, refreshToken string) (*AuthRe
 	}
 	return auth, nil
 }
+
+type StartCustomAuthParams struct {
+	Username string            // ユーザー名 (メールアドレス)
+	Metadata map[string]string // 認証チャレンジのトリガーに渡す値
+}
+
+type RespondCustomAuthChallengeParams struct {
+	Username string            // ユーザー名 (メールアドレス)
+	Session  string            // セッション
+	Answer   string            // チャレンジへの回答
+	Metadata map[string]string // 認証チャレンジのトリガーに渡す値
+}
+
+// CustomAuthResult - カスタム認証の結果
+// 認証が完了した場合はAuthResult、追加のチャレンジが必要な場合はSessionを返す
+type CustomAuthResult struct {
+	Session    string      // 次のチャレンジのセッション
+	AuthResult *AuthResult // 認証結果
+}
+
+func (c *client) StartCustomAuth(ctx context.Context, params *StartCustomAuthParams) (string, error) {
+	in := &cognito.InitiateAuthInput{
+		ClientId: c.appClientID,
+		AuthFlow: types.AuthFlowTypeCustomAuth,
+		AuthParameters: map[string]string{
+			"USERNAME": params.Username,
+		},
+		ClientMetadata: params.Metadata,
+	}
+	out, err := c.cognito.InitiateAuth(ctx, in)
+	if err != nil {
+		return "", c.authError(ctx, err)
+	}
+	if out.ChallengeName != types.ChallengeNameTypeCustomChallenge {
+		return "", fmt.Errorf("%w: unexpected challenge name %s", ErrInternal, out.ChallengeName)
+	}
+	return aws.ToString(out.Session), nil
+}
+
+func (c *client) RespondCustomAuthChallenge(
+	ctx context.Context, params *RespondCustomAuthChallengeParams,
+) (*CustomAuthResult, error) {
+	in := &cognito.RespondToAuthChallengeInput{
+		ClientId:      c.appClientID,
+		ChallengeName: types.ChallengeNameTypeCustomChallenge,
+		ChallengeResponses: map[string]string{
+			"USERNAME": params.Username,
+			"ANSWER":   params.Answer,
+		},
+		Session:        aws.String(params.Session),
+		ClientMetadata: params.Metadata,
+	}
+	out, err := c.cognito.RespondToAuthChallenge(ctx, in)
+	if err != nil {
+		return nil, c.authError(ctx, err)
+	}
+	if out.AuthenticationResult == nil {
+		return &CustomAuthResult{Session: aws.ToString(out.Session)}, nil
+	}
+	auth := &AuthResult{
+		IDToken:      aws.ToString(out.AuthenticationResult.IdToken),
+		AccessToken:  aws.ToString(out.AuthenticationResult.AccessToken),
+		RefreshToken: aws.ToString(out.AuthenticationResult.RefreshToken),
+		ExpiresIn:    aws.ToInt32(&out.AuthenticationResult.ExpiresIn),
+	}
+	return &CustomAuthResult{AuthResult: auth}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/and-period/furumane/pkg/log"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	cognito "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
@@ -28,6 +29,10 @@ type Client interface {
 	GetUsername(ctx context.Context, accessToken string) (string, error)
 	// トークンの更新 (更新トークン使用)
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthResult, error)
+	// カスタム認証の開始 (セッションを返す)
+	StartCustomAuth(ctx context.Context, params *StartCustomAuthParams) (string, error)
+	// カスタム認証のチャレンジへの回答
+	RespondCustomAuthChallenge(ctx context.Context, params *RespondCustomAuthChallengeParams) (*CustomAuthResult, error)
 
 	// #############################################
 	// ユーザー関連
@@ -58,6 +63,12 @@ type Client interface {
 	AdminChangeEmail(ctx context.Context, params *AdminChangeEmailParams) error
 	// パスワード更新
 	AdminChangePassword(ctx context.Context, params *AdminChangePasswordParams) error
+
+	// #############################################
+	// 死活監視
+	// #############################################
+	// ユーザープールへの疎通確認
+	Ping(ctx context.Context) error
 }
 
 var (
@@ -81,6 +92,21 @@ var (
 	errNotFoundEmail     = errors.New("cognito: not found requested email")
 )
 
+// エラー理由 (エラー種別と合わせてラップして返す)
+var (
+	ErrCodeMismatch          = errors.New("cognito: code mismatch")
+	ErrCodeExpired           = errors.New("cognito: code expired")
+	ErrCodeDeliveryFailure   = errors.New("cognito: code delivery failure")
+	ErrInvalidPassword       = errors.New("cognito: invalid password")
+	ErrNotAuthorized         = errors.New("cognito: not authorized")
+	ErrPasswordResetRequired = errors.New("cognito: password reset required")
+	ErrUserNotConfirmed      = errors.New("cognito: user not confirmed")
+	ErrUserNotFound          = errors.New("cognito: user not found")
+	ErrUserAlreadyExists     = errors.New("cognito: user already exists")
+	ErrTooManyFailedAttempts = errors.New("cognito: too many failed attempts")
+	ErrTooManyRequests       = errors.New("cognito: too many requests")
+)
+
 type Params struct {
 	UserPoolID      string
 	AppClientID     string
@@ -135,6 +161,7 @@ func NewClient(cfg aws.Config, params *Params, opts ...Option) Client {
 			o.MaxAttempts = dopts.maxRetries
 			o.MaxBackoff = dopts.interval
 		})
+		o.APIOptions = append(o.APIOptions, metricsMiddleware)
 	})
 	return &client{
 		cognito:         cli,
@@ -145,11 +172,23 @@ func NewClient(cfg aws.Config, params *Params, opts ...Option) Client {
 	}
 }
 
-func (c *client) authError(err error) error {
+// Ping - ユーザープールの情報を取得し、Cognitoへの疎通と認証情報を確認する
+func (c *client) Ping(ctx context.Context) error {
+	in := &cognito.DescribeUserPoolInput{
+		UserPoolId: c.userPoolID,
+	}
+	_, err := c.cognito.DescribeUserPool(ctx, in)
+	return c.authError(ctx, err)
+}
+
+func (c *client) authError(ctx context.Context, err error) error {
 	if err == nil {
 		return nil
 	}
-	c.logger.Debug("Failed to cognito api", zap.Error(err))
+	if isAuthError(err) {
+		return err // 変換済み
+	}
+	log.FromContext(ctx, c.logger).Debug("Failed to cognito api", zap.Error(err))
 
 	switch {
 	case errors.Is(err, context.Canceled):
@@ -165,6 +204,7 @@ func (c *client) authError(err error) error {
 		ece *types.ExpiredCodeException
 		iee *types.InternalErrorException
 		ipe *types.InvalidParameterException
+		iwe *types.InvalidPasswordException
 		lee *types.LimitExceededException
 		nae *types.NotAuthorizedException
 		pre *types.PasswordResetRequiredException
@@ -177,19 +217,47 @@ func (c *client) authError(err error) error {
 	)
 
 	switch {
-	case errors.As(err, &cme), errors.As(err, &ipe):
+	case errors.As(err, &cme):
+		return fmt.Errorf("%w: %w: %s", ErrInvalidArgument, ErrCodeMismatch, err.Error())
+	case errors.As(err, &iwe):
+		return fmt.Errorf("%w: %w: %s", ErrInvalidArgument, ErrInvalidPassword, err.Error())
+	case errors.As(err, &ipe):
 		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
-	case errors.As(err, &ece), errors.As(err, &nae), errors.As(err, &pre), errors.As(err, &uce):
-		return fmt.Errorf("%w: %s", ErrUnauthenticated, err.Error())
-	case errors.As(err, &rne), errors.As(err, &une):
+	case errors.As(err, &ece):
+		return fmt.Errorf("%w: %w: %s", ErrUnauthenticated, ErrCodeExpired, err.Error())
+	case errors.As(err, &nae):
+		return fmt.Errorf("%w: %w: %s", ErrUnauthenticated, ErrNotAuthorized, err.Error())
+	case errors.As(err, &pre):
+		return fmt.Errorf("%w: %w: %s", ErrUnauthenticated, ErrPasswordResetRequired, err.Error())
+	case errors.As(err, &uce):
+		return fmt.Errorf("%w: %w: %s", ErrUnauthenticated, ErrUserNotConfirmed, err.Error())
+	case errors.As(err, &une):
+		return fmt.Errorf("%w: %w: %s", ErrNotFound, ErrUserNotFound, err.Error())
+	case errors.As(err, &rne):
 		return fmt.Errorf("%w: %s", ErrNotFound, err.Error())
 	case errors.As(err, &aee), errors.As(err, &uee):
-		return fmt.Errorf("%w: %s", ErrAlreadyExists, err.Error())
-	case errors.As(err, &lee), errors.As(err, &tfe), errors.As(err, &tre):
-		return fmt.Errorf("%w: %s", ErrResourceExhausted, err.Error())
-	case errors.As(err, &cfe), errors.As(err, &iee):
+		return fmt.Errorf("%w: %w: %s", ErrAlreadyExists, ErrUserAlreadyExists, err.Error())
+	case errors.As(err, &tfe):
+		return fmt.Errorf("%w: %w: %s", ErrResourceExhausted, ErrTooManyFailedAttempts, err.Error())
+	case errors.As(err, &lee), errors.As(err, &tre):
+		return fmt.Errorf("%w: %w: %s", ErrResourceExhausted, ErrTooManyRequests, err.Error())
+	case errors.As(err, &cfe):
+		return fmt.Errorf("%w: %w: %s", ErrInternal, ErrCodeDeliveryFailure, err.Error())
+	case errors.As(err, &iee):
 		return fmt.Errorf("%w: %s", ErrInternal, err.Error())
 	default:
 		return fmt.Errorf("%w: %s", ErrUnknown, err.Error())
 	}
 }
+
+func isAuthError(err error) bool {
+	return errors.Is(err, ErrInvalidArgument) ||
+		errors.Is(err, ErrUnauthenticated) ||
+		errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrAlreadyExists) ||
+		errors.Is(err, ErrInternal) ||
+		errors.Is(err, ErrCanceled) ||
+		errors.Is(err, ErrResourceExhausted) ||
+		errors.Is(err, ErrUnknown) ||
+		errors.Is(err, ErrTimeout)
+}
@@ -2,11 +2,15 @@ package cognito
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,56 +35,133 @@ func TestAuthError(t *testing.T) {
 		name   string
 		err    error
 		expect error
+		reason error
 	}{
 		{
 			name:   "not error",
 			err:    nil,
 			expect: nil,
+			reason: nil,
 		},
 		{
-			name:   "invalid argument",
+			name:   "code mismatch",
 			err:    &types.CodeMismatchException{Message: aws.String("some error")},
 			expect: ErrInvalidArgument,
+			reason: ErrCodeMismatch,
 		},
 		{
-			name:   "unauthenticated",
+			name:   "invalid password",
+			err:    &types.InvalidPasswordException{Message: aws.String("some error")},
+			expect: ErrInvalidArgument,
+			reason: ErrInvalidPassword,
+		},
+		{
+			name:   "invalid parameter",
+			err:    &types.InvalidParameterException{Message: aws.String("some error")},
+			expect: ErrInvalidArgument,
+			reason: nil,
+		},
+		{
+			name:   "expired code",
 			err:    &types.ExpiredCodeException{Message: aws.String("some error")},
 			expect: ErrUnauthenticated,
+			reason: ErrCodeExpired,
+		},
+		{
+			name:   "not authorized",
+			err:    &types.NotAuthorizedException{Message: aws.String("some error")},
+			expect: ErrUnauthenticated,
+			reason: ErrNotAuthorized,
+		},
+		{
+			name:   "password reset required",
+			err:    &types.PasswordResetRequiredException{Message: aws.String("some error")},
+			expect: ErrUnauthenticated,
+			reason: ErrPasswordResetRequired,
+		},
+		{
+			name:   "user not confirmed",
+			err:    &types.UserNotConfirmedException{Message: aws.String("some error")},
+			expect: ErrUnauthenticated,
+			reason: ErrUserNotConfirmed,
+		},
+		{
+			name:   "user not found",
+			err:    &types.UserNotFoundException{Message: aws.String("some error")},
+			expect: ErrNotFound,
+			reason: ErrUserNotFound,
 		},
 		{
-			name:   "not found",
+			name:   "resource not found",
 			err:    &types.ResourceNotFoundException{Message: aws.String("some error")},
 			expect: ErrNotFound,
+			reason: nil,
 		},
 		{
-			name:   "already exists",
+			name:   "username exists",
 			err:    &types.UsernameExistsException{Message: aws.String("some error")},
 			expect: ErrAlreadyExists,
+			reason: ErrUserAlreadyExists,
+		},
+		{
+			name:   "alias exists",
+			err:    &types.AliasExistsException{Message: aws.String("some error")},
+			expect: ErrAlreadyExists,
+			reason: ErrUserAlreadyExists,
 		},
 		{
-			name:   "resource exhausted",
+			name:   "too many failed attempts",
+			err:    &types.TooManyFailedAttemptsException{Message: aws.String("some error")},
+			expect: ErrResourceExhausted,
+			reason: ErrTooManyFailedAttempts,
+		},
+		{
+			name:   "limit exceeded",
 			err:    &types.LimitExceededException{Message: aws.String("some error")},
 			expect: ErrResourceExhausted,
+			reason: ErrTooManyRequests,
+		},
+		{
+			name:   "too many requests",
+			err:    &types.TooManyRequestsException{Message: aws.String("some error")},
+			expect: ErrResourceExhausted,
+			reason: ErrTooManyRequests,
+		},
+		{
+			name:   "code delivery failure",
+			err:    &types.CodeDeliveryFailureException{Message: aws.String("some error")},
+			expect: ErrInternal,
+			reason: ErrCodeDeliveryFailure,
 		},
 		{
 			name:   "internal",
 			err:    &types.InternalErrorException{Message: aws.String("some error")},
 			expect: ErrInternal,
+			reason: nil,
 		},
 		{
 			name:   "canceled",
 			err:    context.Canceled,
 			expect: ErrCanceled,
+			reason: nil,
 		},
 		{
 			name:   "timeout",
 			err:    context.DeadlineExceeded,
 			expect: ErrTimeout,
+			reason: nil,
+		},
+		{
+			name:   "already converted",
+			err:    fmt.Errorf("%w: %w: %s", ErrUnauthenticated, ErrCodeExpired, "some error"),
+			expect: ErrUnauthenticated,
+			reason: ErrCodeExpired,
 		},
 		{
 			name:   "unknown",
 			err:    assert.AnError,
 			expect: ErrUnknown,
+			reason: nil,
 		},
 	}
 	for _, tt := range tests {
@@ -88,7 +169,63 @@ func TestAuthError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			cli := &client{logger: zap.NewNop()}
-			err := cli.authError(tt.err)
+			err := cli.authError(context.Background(), tt.err)
+			assert.ErrorIs(t, err, tt.expect)
+			if tt.reason != nil {
+				assert.ErrorIs(t, err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		expect error
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"UserPool":{"Id":"user-pool-id"}}`,
+			expect: nil,
+		},
+		{
+			name:   "user pool not found",
+			status: http.StatusBadRequest,
+			body:   `{"__type":"ResourceNotFoundException","message":"User pool does not exist"}`,
+			expect: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "AWSCognitoIdentityProviderService.DescribeUserPool", r.Header.Get("X-Amz-Target"))
+				w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer ts.Close()
+
+			cfg := aws.Config{
+				Region:      "ap-northeast-1",
+				Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+				EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+					func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+						return aws.Endpoint{URL: ts.URL}, nil
+					},
+				),
+			}
+			auth := NewClient(cfg, &Params{UserPoolID: "user-pool-id"}, WithMaxRetries(1))
+			err := auth.Ping(context.Background())
+			if tt.expect == nil {
+				assert.NoError(t, err)
+				return
+			}
 			assert.ErrorIs(t, err, tt.expect)
 		})
 	}
@@ -0,0 +1,58 @@
+package cognito
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cognito_request_duration_seconds",
+		Help:    "Histogram of latency (seconds) of Amazon Cognito API calls, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+	requestErrorCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cognito_request_errors_total",
+		Help: "Total number of Amazon Cognito API calls that returned an error.",
+	}, []string{"operation", "code"})
+)
+
+// metricsMiddleware - Amazon Cognito APIの呼び出し単位 (リトライを含む) でのメトリクスの計測
+func metricsMiddleware(stack *middleware.Stack) error {
+	fn := func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		start := time.Now()
+		out, metadata, err := next.HandleInitialize(ctx, in)
+
+		operation := awsmiddleware.GetOperationName(ctx)
+		requestHistogram.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		if err != nil {
+			requestErrorCounter.WithLabelValues(operation, errorCode(err)).Inc()
+		}
+		return out, metadata, err
+	}
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Metrics", fn), middleware.After)
+}
+
+// errorCode - メトリクスのラベルとして使用するエラーコード (e.g. NotAuthorizedException)
+func errorCode(err error) string {
+	var ae smithy.APIError
+	switch {
+	case errors.As(err, &ae):
+		return ae.ErrorCode()
+	case errors.Is(err, context.Canceled):
+		return "Canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "DeadlineExceeded"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,82 @@
+package cognito
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Header().Set("X-Amzn-ErrorType", "NotAuthorizedException")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"__type":"NotAuthorizedException","message":"Invalid Access Token"}`)
+	}))
+	defer ts.Close()
+
+	cfg := aws.Config{
+		Region:      "ap-northeast-1",
+		Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{URL: ts.URL}, nil
+			},
+		),
+	}
+	auth := NewClient(cfg, &Params{}, WithMaxRetries(1))
+
+	counter := requestErrorCounter.WithLabelValues("GetUser", "NotAuthorizedException")
+	before := testutil.ToFloat64(counter)
+	_, err := auth.GetUsername(context.Background(), "access-token")
+	require.ErrorIs(t, err, ErrUnauthenticated)
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		err    error
+		expect string
+	}{
+		{
+			name:   "api error",
+			err:    &types.TooManyRequestsException{Message: aws.String("some error")},
+			expect: "TooManyRequestsException",
+		},
+		{
+			name:   "canceled",
+			err:    context.Canceled,
+			expect: "Canceled",
+		},
+		{
+			name:   "deadline exceeded",
+			err:    fmt.Errorf("operation error: %w", context.DeadlineExceeded),
+			expect: "DeadlineExceeded",
+		},
+		{
+			name:   "unknown",
+			err:    assert.AnError,
+			expect: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, errorCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cognito
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AccessTokenClaims - アクセストークンのクレーム
+type AccessTokenClaims struct {
+	Subject   string    // ユーザーの識別子 (sub)
+	Username  string    // ユーザー名
+	ClientID  string    // アプリクライアントID
+	Scope     string    // スコープ (スペース区切り)
+	TokenUse  string    // トークン種別 (access)
+	IssuedAt  time.Time // 発行日時
+	ExpiresAt time.Time // 有効期限
+}
+
+type accessTokenPayload struct {
+	Sub      string `json:"sub"`
+	Username string `json:"username"`
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"`
+	TokenUse string `json:"token_use"`
+	Iat      int64  `json:"iat"`
+	Exp      int64  `json:"exp"`
+}
+
+// ParseAccessToken - アクセストークンからクレームを取得
+// 署名は検証しないため、GetUser等でトークンが有効であることを確認した後に使用すること
+func ParseAccessToken(token string) (*AccessTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: access token is malformed", ErrInvalidArgument)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+	}
+	payload := &accessTokenPayload{}
+	if err := json.Unmarshal(buf, payload); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+	}
+	if payload.TokenUse != "access" {
+		return nil, fmt.Errorf("%w: token is not access token: %s", ErrInvalidArgument, payload.TokenUse)
+	}
+	claims := &AccessTokenClaims{
+		Subject:   payload.Sub,
+		Username:  payload.Username,
+		ClientID:  payload.ClientID,
+		Scope:     payload.Scope,
+		TokenUse:  payload.TokenUse,
+		IssuedAt:  time.Unix(payload.Iat, 0),
+		ExpiresAt: time.Unix(payload.Exp, 0),
+	}
+	return claims, nil
+}
@@ -0,0 +1,77 @@
+package cognito
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testToken(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"kid"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestParseAccessToken(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		token  string
+		expect *AccessTokenClaims
+		hasErr bool
+	}{
+		{
+			name: "success",
+			token: testToken(`{"sub":"sub","username":"username","client_id":"client-id",` +
+				`"scope":"aws.cognito.signin.user.admin","token_use":"access","iat":1696152600,"exp":1696156200}`),
+			expect: &AccessTokenClaims{
+				Subject:   "sub",
+				Username:  "username",
+				ClientID:  "client-id",
+				Scope:     "aws.cognito.signin.user.admin",
+				TokenUse:  "access",
+				IssuedAt:  time.Unix(1696152600, 0),
+				ExpiresAt: time.Unix(1696156200, 0),
+			},
+			hasErr: false,
+		},
+		{
+			name:   "malformed token",
+			token:  "access-token",
+			expect: nil,
+			hasErr: true,
+		},
+		{
+			name:   "invalid payload",
+			token:  "header.!!!.signature",
+			expect: nil,
+			hasErr: true,
+		},
+		{
+			name:   "invalid json",
+			token:  testToken(`{"sub":`),
+			expect: nil,
+			hasErr: true,
+		},
+		{
+			name:   "id token",
+			token:  testToken(`{"sub":"sub","token_use":"id"}`),
+			expect: nil,
+			hasErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseAccessToken(tt.token)
+			if tt.hasErr {
+				assert.ErrorIs(t, err, ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Package config - 設定値の読み込み
+//
+// 設定値は構造体のタグで定義し、デフォルト値 < 設定ファイル (YAML) < 環境変数 < コマンドライン引数 の順に上書きする
+//   - envconfig: 環境変数名 (e.g. DB_HOST)。設定ファイルのキーは小文字 (db_host)、フラグ名は小文字のハイフン区切り (--db-host)
+//   - default: デフォルト値
+//   - required: "true"の場合は必須
+//   - log: "secret"の場合は、設定値の出力時にマスクする
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/and-period/furumane/pkg/redact"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidConfig   = errors.New("config: invalid config")
+	ErrUnsupportedType = errors.New("config: unsupported type")
+)
+
+// Source - 設定値の読み込み元
+type Source string
+
+const (
+	SourceDefault Source = "default" // デフォルト値
+	SourceFile    Source = "file"    // 設定ファイル
+	SourceEnv     Source = "env"     // 環境変数
+	SourceFlag    Source = "flag"    // コマンドライン引数
+)
+
+// Field - 読み込んだ設定値
+type Field struct {
+	Name   string // 環境変数名
+	Value  string // 設定値 (文字列表現)
+	Source Source // 読み込み元
+	Secret bool   // 秘匿情報か
+}
+
+// String - 設定値の文字列表現 (秘匿情報はマスクする)
+func (f *Field) String() string {
+	if f.Secret {
+		return redact.Mask(redact.ModeSecret, f.Value)
+	}
+	return f.Value
+}
+
+// Fields - 読み込んだ設定値の一覧 (構造体のフィールド順)
+type Fields []*Field
+
+type options struct {
+	file      string
+	flags     *pflag.FlagSet
+	lookupEnv func(string) (string, bool)
+}
+
+type Option func(*options)
+
+// WithFile - 設定ファイル (YAML) のパス (空文字の場合は読み込まない)
+func WithFile(path string) Option {
+	return func(opts *options) {
+		opts.file = path
+	}
+}
+
+// WithFlags - RegisterFlagsでフラグを登録したFlagSet (解析済みのもの)
+func WithFlags(flags *pflag.FlagSet) Option {
+	return func(opts *options) {
+		opts.flags = flags
+	}
+}
+
+// WithLookupEnv - 環境変数の参照方法 (テスト用)
+func WithLookupEnv(fn func(string) (string, bool)) Option {
+	return func(opts *options) {
+		opts.lookupEnv = fn
+	}
+}
+
+type field struct {
+	name     string
+	value    reflect.Value
+	def      string
+	required bool
+	secret   bool
+}
+
+// FlagName - 環境変数名に対応するフラグ名 (e.g. DB_HOST -> db-host)
+func FlagName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// FileKey - 環境変数名に対応する設定ファイルのキー (e.g. DB_HOST -> db_host)
+func FileKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// RegisterFlags - 設定値を上書きするフラグの登録 (specが構造体のポインタでない場合は何もしない)
+// フラグは文字列として登録し、Loadで設定値と同様に変換する
+func RegisterFlags(flags *pflag.FlagSet, spec interface{}) {
+	fields, err := parseSpec(spec)
+	if err != nil {
+		return
+	}
+	for _, f := range fields {
+		usage := fmt.Sprintf("overrides %s", f.name)
+		if f.def != "" && !f.secret {
+			usage = fmt.Sprintf("%s (default %q)", usage, f.def)
+		}
+		flag := flags.VarPF(&stringValue{}, FlagName(f.name), "", usage)
+		if f.value.Kind() == reflect.Bool {
+			flag.NoOptDefVal = "true"
+		}
+	}
+}
+
+// Load - 設定値を読み込み、構造体に設定する
+// 変換に失敗した設定値と未設定の必須項目は、すべてまとめてエラーとして返す
+func Load(spec interface{}, opts ...Option) (Fields, error) {
+	dopts := &options{
+		lookupEnv: os.LookupEnv,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	fields, err := parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	file, err := readFile(dopts.file)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(Fields, 0, len(fields))
+	errs := make([]error, 0)
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[FileKey(f.name)] = true
+		value, source := f.def, SourceDefault
+		if v, ok := file[FileKey(f.name)]; ok {
+			value, source = v, SourceFile
+		}
+		if v, ok := dopts.lookupEnv(f.name); ok {
+			value, source = v, SourceEnv
+		}
+		if dopts.flags != nil && dopts.flags.Changed(FlagName(f.name)) {
+			value, source = dopts.flags.Lookup(FlagName(f.name)).Value.String(), SourceFlag
+		}
+		field := &Field{Name: f.name, Value: value, Source: source, Secret: f.secret}
+		if f.required && value == "" {
+			errs = append(errs, fmt.Errorf("%w: %s is required", ErrInvalidConfig, f.name))
+		}
+		if err := setValue(f.value, value); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s=%q (%s): %s", ErrInvalidConfig, f.name, field, source, err))
+		}
+		res = append(res, field)
+	}
+	keys := make([]string, 0, len(file))
+	for key := range file {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("%w: unknown key in %s: %s", ErrInvalidConfig, dopts.file, key))
+		}
+	}
+	return res, errors.Join(errs...)
+}
+
+func parseSpec(spec interface{}) ([]*field, error) {
+	rv := reflect.ValueOf(spec)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: spec must be a pointer to a struct", ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	fields := make([]*field, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		name := sf.Tag.Get("envconfig")
+		if name == "" || !sf.IsExported() {
+			continue
+		}
+		fields = append(fields, &field{
+			name:     name,
+			value:    rv.Field(i),
+			def:      sf.Tag.Get("default"),
+			required: sf.Tag.Get("required") == "true",
+			secret:   redact.Mode(sf.Tag.Get(redact.TagName)) == redact.ModeSecret,
+		})
+	}
+	return fields, nil
+}
+
+// readFile - 設定ファイルを読み込み、キーごとの文字列表現に変換する
+// 配列はカンマ区切りの文字列として扱う
+func readFile(path string) (map[string]string, error) {
+	if path == "" {
+		return map[string]string{}, nil
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read file: %w", err)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(buf, &values); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidConfig, path, err)
+	}
+	res := make(map[string]string, len(values))
+	for key, value := range values {
+		str, err := toString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s: %s", ErrInvalidConfig, path, key, err)
+		}
+		res[strings.ToLower(key)] = str
+	}
+	return res, nil
+}
+
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i := range v {
+			str, err := toString(v[i])
+			if err != nil {
+				return "", err
+			}
+			strs[i] = str
+		}
+		return strings.Join(strs, ","), nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedType, value)
+	}
+}
+
+// setValue - 文字列表現の設定値を変換して設定 (envconfigと同じ形式)
+func setValue(rv reflect.Value, value string) error {
+	if rv.Type() == reflect.TypeOf(time.Duration(0)) {
+		if value == "" {
+			rv.SetInt(0)
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		if value == "" {
+			rv.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value == "" {
+			rv.SetInt(0)
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			rv.SetFloat(0)
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, rv.Type())
+		}
+		if strings.TrimSpace(value) == "" {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		rv.Set(reflect.ValueOf(strings.Split(value, ",")))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, rv.Type())
+	}
+	return nil
+}
+
+// stringValue - 型によらず文字列として受け取るフラグの値
+type stringValue struct {
+	value string
+}
+
+func (v *stringValue) String() string {
+	return v.value
+}
+
+func (v *stringValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *stringValue) Type() string {
+	return "string"
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Port     int64         `envconfig:"PORT" default:"8080"`
+	Host     string        `envconfig:"DB_HOST" default:"127.0.0.1"`
+	Password string        `envconfig:"DB_PASSWORD" default:"" log:"secret"`
+	TLS      bool          `envconfig:"DB_ENABLED_TLS" default:"false"`
+	Ratio    float64       `envconfig:"SAMPLE_RATIO" default:"1"`
+	Origins  []string      `envconfig:"ORIGINS" default:""`
+	Timeout  time.Duration `envconfig:"TIMEOUT" default:"2s"`
+	PoolID   string        `envconfig:"POOL_ID" required:"true"`
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		file   string
+		env    map[string]string
+		args   []string
+		expect *testConfig
+		source map[string]Source
+		isErr  []string
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"POOL_ID": "pool-id"},
+			expect: &testConfig{
+				Port:    8080,
+				Host:    "127.0.0.1",
+				Ratio:   1,
+				Timeout: 2 * time.Second,
+				PoolID:  "pool-id",
+			},
+			source: map[string]Source{"PORT": SourceDefault, "POOL_ID": SourceEnv},
+		},
+		{
+			name: "layered",
+			file: `
+port: 9000
+db_host: mysql
+db_enabled_tls: true
+origins:
+  - http://localhost:3000
+  - https://example.com
+pool_id: file-pool-id
+`,
+			env:  map[string]string{"DB_HOST": "env-host", "POOL_ID": "env-pool-id"},
+			args: []string{"--pool-id=flag-pool-id", "--sample-ratio", "0.5"},
+			expect: &testConfig{
+				Port:    9000,
+				Host:    "env-host",
+				TLS:     true,
+				Ratio:   0.5,
+				Origins: []string{"http://localhost:3000", "https://example.com"},
+				Timeout: 2 * time.Second,
+				PoolID:  "flag-pool-id",
+			},
+			source: map[string]Source{
+				"PORT":         SourceFile,
+				"DB_HOST":      SourceEnv,
+				"SAMPLE_RATIO": SourceFlag,
+				"POOL_ID":      SourceFlag,
+				"TIMEOUT":      SourceDefault,
+			},
+		},
+		{
+			name: "bool flag without value",
+			env:  map[string]string{"POOL_ID": "pool-id"},
+			args: []string{"--db-enabled-tls"},
+			expect: &testConfig{
+				Port:    8080,
+				Host:    "127.0.0.1",
+				TLS:     true,
+				Ratio:   1,
+				Timeout: 2 * time.Second,
+				PoolID:  "pool-id",
+			},
+			source: map[string]Source{"DB_ENABLED_TLS": SourceFlag},
+		},
+		{
+			name: "aggregated errors",
+			file: `
+port: http
+unknown_key: value
+`,
+			env: map[string]string{"TIMEOUT": "2", "DB_PASSWORD": "password"},
+			isErr: []string{
+				`config: invalid config: PORT="http" (file)`,
+				`config: invalid config: TIMEOUT="2" (env)`,
+				`config: invalid config: POOL_ID is required`,
+				`config: invalid config: unknown key in`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var path string
+			if tt.file != "" {
+				path = filepath.Join(t.TempDir(), "config.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.file), 0o600))
+			}
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			RegisterFlags(flags, &testConfig{})
+			require.NoError(t, flags.Parse(tt.args))
+			lookupEnv := func(key string) (string, bool) {
+				v, ok := tt.env[key]
+				return v, ok
+			}
+
+			actual := &testConfig{}
+			fields, err := Load(actual, WithFile(path), WithFlags(flags), WithLookupEnv(lookupEnv))
+			if len(tt.isErr) > 0 {
+				require.ErrorIs(t, err, ErrInvalidConfig)
+				for _, msg := range tt.isErr {
+					assert.Contains(t, err.Error(), msg)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+			require.Len(t, fields, 8)
+			for _, f := range fields {
+				if source, ok := tt.source[f.Name]; ok {
+					assert.Equal(t, source, f.Source, f.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_File(t *testing.T) {
+	t.Parallel()
+	_, err := Load(&testConfig{}, WithFile(filepath.Join(t.TempDir(), "not-found.yaml")))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load(testConfig{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestField_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		field  *Field
+		expect string
+	}{
+		{
+			name:   "plain",
+			field:  &Field{Name: "DB_HOST", Value: "127.0.0.1"},
+			expect: "127.0.0.1",
+		},
+		{
+			name:   "secret",
+			field:  &Field{Name: "DB_PASSWORD", Value: "password", Secret: true},
+			expect: "[REDACTED]",
+		},
+		{
+			name:   "empty secret",
+			field:  &Field{Name: "DB_PASSWORD", Value: "", Secret: true},
+			expect: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, tt.field.String())
+		})
+	}
+}
+
+func TestFlagName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "cognito-admin-pool-id", FlagName("COGNITO_ADMIN_POOL_ID"))
+	assert.Equal(t, "cognito_admin_pool_id", FileKey("COGNITO_ADMIN_POOL_ID"))
+}
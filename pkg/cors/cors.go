@@ -24,10 +24,20 @@ func NewOptions() cors.Options {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"If-Match",
+			"If-None-Match",
 			"User-Agent",
 			"X-Forwarded-For",
 			"X-Forwarded-Proto",
 			"X-Real-Ip",
+			"X-Request-ID",
+			"traceparent",
+			"tracestate",
+		},
+		ExposedHeaders: []string{
+			"ETag",
+			"X-Auth-Session",
+			"X-Request-ID",
 		},
 		AllowCredentials:   true,
 		MaxAge:             1440, // 60m * 24h
@@ -0,0 +1,276 @@
+// Package encryption - エンベロープ暗号化によるフィールド単位の暗号化
+//
+// 値ごとにデータキー (DEK) で暗号化し、データキーはマスターキー (KEK) で暗号化して値と一緒に保存する
+// マスターキーの管理はKeyProviderに委譲するため、ローカルの鍵ファイルとKMSを切り替えて使用できる
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidCiphertext = errors.New("encryption: invalid ciphertext")
+	ErrKeyNotFound       = errors.New("encryption: key not found")
+	ErrInvalidKey        = errors.New("encryption: invalid key")
+)
+
+const (
+	// 暗号化済みの値の接頭辞 (enc:v1:<キーID>:<暗号化済みのデータキー>:<暗号文>)
+	ciphertextPrefix = "enc:v1:"
+	// データキーの長さ (AES-256)
+	dataKeySize = 32
+	// 1つのデータキーで暗号化する回数の上限 (GCMのランダムなナンスの衝突を避けるため)
+	maxDataKeyUsage = 1 << 24
+)
+
+// KeyProvider - マスターキーによるデータキーの生成・復号 (AWS KMSのGenerateDataKey・Decryptに相当)
+type KeyProvider interface {
+	// PrimaryKeyID - 新たに暗号化する際に使用するマスターキーのID
+	PrimaryKeyID() string
+	// GenerateDataKey - プライマリのマスターキーでデータキーを生成
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+	// DecryptDataKey - 暗号化済みのデータキーを復号
+	DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error)
+}
+
+// DataKey - データキー
+type DataKey struct {
+	KeyID     string // 暗号化に使用したマスターキーのID
+	Plaintext []byte // 平文のデータキー (永続化しないこと)
+	Encrypted []byte // マスターキーで暗号化したデータキー
+}
+
+// Cipher - フィールドの暗号化・復号とブラインドインデックスの生成
+type Cipher struct {
+	provider  KeyProvider
+	indexKey  []byte
+	now       func() time.Time
+	lifetime  time.Duration
+	cacheSize int
+	mu        sync.Mutex
+	current   *dataKey
+	cache     map[string]cipher.AEAD // 暗号化済みのデータキーと復号済みのデータキーの対応
+}
+
+type dataKey struct {
+	keyID     string
+	encrypted string
+	aead      cipher.AEAD
+	createdAt time.Time
+	usage     int
+}
+
+type options struct {
+	now       func() time.Time
+	lifetime  time.Duration
+	cacheSize int
+}
+
+type Option func(opts *options)
+
+func WithNow(now func() time.Time) Option {
+	return func(opts *options) {
+		opts.now = now
+	}
+}
+
+// WithDataKeyLifetime - 暗号化に使用するデータキーを再生成するまでの時間
+func WithDataKeyLifetime(d time.Duration) Option {
+	return func(opts *options) {
+		opts.lifetime = d
+	}
+}
+
+// WithCacheSize - 復号済みのデータキーを保持する件数
+func WithCacheSize(size int) Option {
+	return func(opts *options) {
+		opts.cacheSize = size
+	}
+}
+
+// NewCipher - 暗号化用の構造体を生成
+// indexKeyはブラインドインデックスの生成に使用するため、マスターキーとは別の鍵を指定すること
+func NewCipher(provider KeyProvider, indexKey []byte, opts ...Option) (*Cipher, error) {
+	if len(indexKey) < dataKeySize {
+		return nil, fmt.Errorf("%w: index key must be at least %d bytes", ErrInvalidKey, dataKeySize)
+	}
+	dopts := &options{
+		now:       time.Now,
+		lifetime:  24 * time.Hour,
+		cacheSize: 1000,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	c := &Cipher{
+		provider:  provider,
+		indexKey:  indexKey,
+		now:       dopts.now,
+		lifetime:  dopts.lifetime,
+		cacheSize: dopts.cacheSize,
+		cache:     make(map[string]cipher.AEAD, dopts.cacheSize),
+	}
+	return c, nil
+}
+
+// Encrypt - 値の暗号化 (空文字の場合は空文字を返す)
+func (c *Cipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	key, err := c.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := key.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	strs := []string{key.keyID, key.encrypted, base64.RawURLEncoding.EncodeToString(sealed)}
+	return ciphertextPrefix + strings.Join(strs, ":"), nil
+}
+
+// Decrypt - 値の復号
+// 暗号化されていない値 (暗号化の導入前に保存された値) は、そのまま返す
+func (c *Cipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	keyID, encrypted, sealed, err := parseCiphertext(value)
+	if err != nil {
+		return "", err
+	}
+	aead, err := c.decryptDataKey(ctx, keyID, encrypted)
+	if err != nil {
+		return "", err
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil || len(buf) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed ciphertext", ErrInvalidCiphertext)
+	}
+	nonce, body := buf[:aead.NonceSize()], buf[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, body, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidCiphertext, err.Error())
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption - 再暗号化が必要な値か (未暗号化、またはプライマリ以外のマスターキーで暗号化されている)
+func (c *Cipher) NeedsReencryption(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, _, err := parseCiphertext(value)
+	return err != nil || keyID != c.provider.PrimaryKeyID()
+}
+
+// BlindIndex - 検索用のブラインドインデックスを生成 (空文字の場合は空文字を返す)
+// 同じ値からは常に同じインデックスを生成するため、等価検索と一意制約に使用できる
+func (c *Cipher) BlindIndex(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted - 暗号化済みの値か
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+func parseCiphertext(value string) (keyID, encrypted, sealed string, err error) {
+	strs := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if len(strs) != 3 || strs[0] == "" || strs[1] == "" || strs[2] == "" {
+		return "", "", "", fmt.Errorf("%w: malformed ciphertext", ErrInvalidCiphertext)
+	}
+	return strs[0], strs[1], strs[2], nil
+}
+
+// dataKey - 暗号化に使用するデータキーの取得
+// マスターキーの呼び出し回数を抑えるため、有効期限内は同じデータキーを使い回す
+func (c *Cipher) dataKey(ctx context.Context) (*dataKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := c.current
+	if key == nil ||
+		key.keyID != c.provider.PrimaryKeyID() ||
+		key.usage >= maxDataKeyUsage ||
+		c.now().Sub(key.createdAt) >= c.lifetime {
+		dk, err := c.provider.GenerateDataKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newAEAD(dk.Plaintext)
+		if err != nil {
+			return nil, err
+		}
+		key = &dataKey{
+			keyID:     dk.KeyID,
+			encrypted: base64.RawURLEncoding.EncodeToString(dk.Encrypted),
+			aead:      aead,
+			createdAt: c.now(),
+		}
+		c.current = key
+	}
+	key.usage++
+	return key, nil
+}
+
+func (c *Cipher) decryptDataKey(ctx context.Context, keyID, encrypted string) (cipher.AEAD, error) {
+	cacheKey := keyID + ":" + encrypted
+	c.mu.Lock()
+	aead, ok := c.cache[cacheKey]
+	c.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed data key", ErrInvalidCiphertext)
+	}
+	plaintext, err := c.provider.DecryptDataKey(ctx, keyID, buf)
+	if err != nil {
+		return nil, err
+	}
+	aead, err = newAEAD(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.cache) >= c.cacheSize {
+		c.cache = make(map[string]cipher.AEAD, c.cacheSize)
+	}
+	c.cache[cacheKey] = aead
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("%w: key must be %d bytes", ErrInvalidKey, dataKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
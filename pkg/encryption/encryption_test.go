@@ -0,0 +1,196 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider - データキーの生成・復号の呼び出し回数を記録する
+type countingProvider struct {
+	*Keyring
+	generated int
+	decrypted int
+}
+
+func (p *countingProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	p.generated++
+	return p.Keyring.GenerateDataKey(ctx)
+}
+
+func (p *countingProvider) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	p.decrypted++
+	return p.Keyring.DecryptDataKey(ctx, keyID, encrypted)
+}
+
+func newTestKeyring(t *testing.T, primary string, keyIDs ...string) *Keyring {
+	keys := make(map[string][]byte, len(keyIDs))
+	for i, keyID := range keyIDs {
+		keys[keyID] = bytes.Repeat([]byte{byte(i + 1)}, dataKeySize)
+	}
+	keyring, err := NewKeyring(primary, keys)
+	require.NoError(t, err)
+	return keyring
+}
+
+var testIndexKey = bytes.Repeat([]byte{0xff}, dataKeySize)
+
+func TestNewCipher(t *testing.T) {
+	t.Parallel()
+	keyring := newTestKeyring(t, "key-1", "key-1")
+	_, err := NewCipher(keyring, testIndexKey, WithNow(time.Now), WithDataKeyLifetime(time.Hour), WithCacheSize(10))
+	assert.NoError(t, err)
+	_, err = NewCipher(keyring, []byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestCipher_EncryptAndDecrypt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	provider := &countingProvider{Keyring: newTestKeyring(t, "key-1", "key-1")}
+	c, err := NewCipher(provider, testIndexKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "email", value: "test@example.com"},
+		{name: "phone number", value: "09012345678"},
+		{name: "multibyte", value: "テスト"},
+	}
+	for _, tt := range tests {
+		encrypted, err := c.Encrypt(ctx, tt.value)
+		require.NoError(t, err, tt.name)
+		assert.True(t, IsEncrypted(encrypted), tt.name)
+		assert.NotContains(t, encrypted, tt.value, tt.name)
+		actual, err := c.Decrypt(ctx, encrypted)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.value, actual, tt.name)
+	}
+	// データキーは使い回し、復号済みのデータキーはキャッシュする
+	assert.Equal(t, 1, provider.generated)
+	assert.Equal(t, 1, provider.decrypted)
+
+	// 同じ値でも暗号文は毎回異なる
+	first, err := c.Encrypt(ctx, "test@example.com")
+	require.NoError(t, err)
+	second, err := c.Encrypt(ctx, "test@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		encrypted, err := c.Encrypt(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, encrypted)
+		decrypted, err := c.Decrypt(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, decrypted)
+	})
+	t.Run("plaintext", func(t *testing.T) {
+		t.Parallel()
+		actual, err := c.Decrypt(ctx, "test@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", actual)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		t.Parallel()
+		sealed := first[strings.LastIndex(first, ":")+1:]
+		other := second[:strings.LastIndex(second, ":")+1] + sealed[:len(sealed)-2] + "AA"
+		_, err := c.Decrypt(ctx, other)
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		_, err := c.Decrypt(ctx, "enc:v1:key-1")
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+		other, err := NewCipher(newTestKeyring(t, "key-2", "key-2"), testIndexKey)
+		require.NoError(t, err)
+		_, err = other.Decrypt(ctx, first)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestCipher_DataKeyLifetime(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+	provider := &countingProvider{Keyring: newTestKeyring(t, "key-1", "key-1")}
+	c, err := NewCipher(provider, testIndexKey,
+		WithNow(func() time.Time { return now }),
+		WithDataKeyLifetime(time.Hour),
+	)
+	require.NoError(t, err)
+
+	_, err = c.Encrypt(ctx, "value")
+	require.NoError(t, err)
+	now = now.Add(30 * time.Minute)
+	_, err = c.Encrypt(ctx, "value")
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.generated)
+	now = now.Add(30 * time.Minute)
+	_, err = c.Encrypt(ctx, "value")
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.generated)
+}
+
+func TestCipher_NeedsReencryption(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	old, err := NewCipher(newTestKeyring(t, "key-1", "key-1"), testIndexKey)
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt(ctx, "test@example.com")
+	require.NoError(t, err)
+
+	// マスターキーのローテーション後も、古いマスターキーで暗号化された値を復号できる
+	rotated, err := NewCipher(newTestKeyring(t, "key-2", "key-1", "key-2"), testIndexKey)
+	require.NoError(t, err)
+	decrypted, err := rotated.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", decrypted)
+	reencrypted, err := rotated.Encrypt(ctx, decrypted)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		value  string
+		expect bool
+	}{
+		{name: "empty", value: "", expect: false},
+		{name: "plaintext", value: "test@example.com", expect: true},
+		{name: "old key", value: encrypted, expect: true},
+		{name: "primary key", value: reencrypted, expect: false},
+		{name: "malformed", value: "enc:v1:", expect: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, rotated.NeedsReencryption(tt.value))
+		})
+	}
+}
+
+func TestCipher_BlindIndex(t *testing.T) {
+	t.Parallel()
+	c, err := NewCipher(newTestKeyring(t, "key-1", "key-1"), testIndexKey)
+	require.NoError(t, err)
+	other, err := NewCipher(newTestKeyring(t, "key-1", "key-1"), bytes.Repeat([]byte{0x01}, dataKeySize))
+	require.NoError(t, err)
+
+	index := c.BlindIndex("test@example.com")
+	assert.Len(t, index, 64)
+	assert.Equal(t, index, c.BlindIndex("test@example.com"))
+	assert.NotEqual(t, index, c.BlindIndex("other@example.com"))
+	assert.NotEqual(t, index, other.BlindIndex("test@example.com"))
+	assert.Empty(t, c.BlindIndex(""))
+}
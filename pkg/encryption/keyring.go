@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Keyring - アプリケーションで保持するマスターキーによるKeyProvider
+// 開発・テスト環境では鍵ファイル、本番環境ではAWS Secrets Managerなどから読み込んだ鍵を使用する
+// マスターキーのローテーション時は、新しい鍵をプライマリにした上で古い鍵も復号用に残しておくこと
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// KeyFile - 鍵ファイルの形式
+type KeyFile struct {
+	PrimaryKeyID string            `json:"primaryKeyId"` // 暗号化に使用するマスターキーのID
+	Keys         map[string]string `json:"keys"`         // マスターキーの一覧 (キーID: Base64エンコードした32バイトの鍵)
+	IndexKey     string            `json:"indexKey"`     // ブラインドインデックスの生成に使用する鍵 (Base64エンコード)
+}
+
+// NewKeyring - マスターキーの一覧からKeyProviderを生成
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary key is not found: %s", ErrKeyNotFound, primary)
+	}
+	k := &Keyring{
+		primary: primary,
+		keys:    make(map[string]cipher.AEAD, len(keys)),
+	}
+	for keyID, key := range keys {
+		if keyID == "" || strings.Contains(keyID, ":") {
+			return nil, fmt.Errorf("%w: invalid key id: %q", ErrInvalidKey, keyID)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[keyID] = aead
+	}
+	return k, nil
+}
+
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+func (k *Keyring) GenerateDataKey(_ context.Context) (*DataKey, error) {
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, err
+	}
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := &DataKey{
+		KeyID:     k.primary,
+		Plaintext: plaintext,
+		Encrypted: aead.Seal(nonce, nonce, plaintext, nil),
+	}
+	return key, nil
+}
+
+func (k *Keyring) DecryptDataKey(_ context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	if len(encrypted) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: malformed data key", ErrInvalidCiphertext)
+	}
+	nonce, body := encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCiphertext, err.Error())
+	}
+	return plaintext, nil
+}
+
+// ReadKeyFile - 鍵ファイル (JSON) の読み込み
+func ReadKeyFile(path string) (*KeyFile, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &KeyFile{}
+	if err := json.Unmarshal(buf, file); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err.Error())
+	}
+	return file, nil
+}
+
+// ParseKeys - マスターキーの一覧を変換 (e.g. key-id:base64-key)
+func ParseKeys(keys []string) map[string]string {
+	res := make(map[string]string, len(keys))
+	for _, key := range keys {
+		id, value, ok := strings.Cut(key, ":")
+		if !ok || id == "" || value == "" {
+			continue
+		}
+		res[id] = value
+	}
+	return res
+}
+
+// NewCipher - 鍵ファイルの内容から暗号化用の構造体を生成
+func (f *KeyFile) NewCipher(opts ...Option) (*Cipher, error) {
+	keys := make(map[string][]byte, len(f.Keys))
+	for keyID, str := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to decode key: %s", ErrInvalidKey, keyID)
+		}
+		keys[keyID] = key
+	}
+	keyring, err := NewKeyring(f.PrimaryKeyID, keys)
+	if err != nil {
+		return nil, err
+	}
+	indexKey, err := base64.StdEncoding.DecodeString(f.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode index key", ErrInvalidKey)
+	}
+	return NewCipher(keyring, indexKey, opts...)
+}
@@ -0,0 +1,127 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyring(t *testing.T) {
+	t.Parallel()
+	key := bytes.Repeat([]byte{0x01}, dataKeySize)
+	tests := []struct {
+		name    string
+		primary string
+		keys    map[string][]byte
+		expect  error
+	}{
+		{
+			name:    "success",
+			primary: "key-1",
+			keys:    map[string][]byte{"key-1": key},
+			expect:  nil,
+		},
+		{
+			name:    "primary key not found",
+			primary: "key-2",
+			keys:    map[string][]byte{"key-1": key},
+			expect:  ErrKeyNotFound,
+		},
+		{
+			name:    "invalid key id",
+			primary: "key-1",
+			keys:    map[string][]byte{"key-1": key, "key:2": key},
+			expect:  ErrInvalidKey,
+		},
+		{
+			name:    "invalid key size",
+			primary: "key-1",
+			keys:    map[string][]byte{"key-1": []byte("short")},
+			expect:  ErrInvalidKey,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewKeyring(tt.primary, tt.keys)
+			assert.ErrorIs(t, err, tt.expect)
+		})
+	}
+}
+
+func TestKeyring_DataKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	keyring := newTestKeyring(t, "key-1", "key-1", "key-2")
+	assert.Equal(t, "key-1", keyring.PrimaryKeyID())
+
+	key, err := keyring.GenerateDataKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key.KeyID)
+	assert.Len(t, key.Plaintext, dataKeySize)
+	assert.NotContains(t, string(key.Encrypted), string(key.Plaintext))
+
+	actual, err := keyring.DecryptDataKey(ctx, "key-1", key.Encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, key.Plaintext, actual)
+
+	_, err = keyring.DecryptDataKey(ctx, "key-2", key.Encrypted)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	_, err = keyring.DecryptDataKey(ctx, "key-3", key.Encrypted)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = keyring.DecryptDataKey(ctx, "key-1", []byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestKeyFile(t *testing.T) {
+	t.Parallel()
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x01}, dataKeySize))
+	indexKey := base64.StdEncoding.EncodeToString(testIndexKey)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keyring.json")
+	body := `{"primaryKeyId":"key-1","keys":{"key-1":"` + key + `"},"indexKey":"` + indexKey + `"}`
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		file, err := ReadKeyFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", file.PrimaryKeyID)
+		c, err := file.NewCipher()
+		require.NoError(t, err)
+		encrypted, err := c.Encrypt(context.Background(), "value")
+		require.NoError(t, err)
+		assert.True(t, IsEncrypted(encrypted))
+	})
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		_, err := ReadKeyFile(filepath.Join(dir, "not-found.json"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+		file := &KeyFile{PrimaryKeyID: "key-1", Keys: map[string]string{"key-1": "!"}, IndexKey: indexKey}
+		_, err := file.NewCipher()
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+	t.Run("invalid index key", func(t *testing.T) {
+		t.Parallel()
+		file := &KeyFile{PrimaryKeyID: "key-1", Keys: map[string]string{"key-1": key}, IndexKey: "!"}
+		_, err := file.NewCipher()
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+	keys := ParseKeys([]string{"key-1:value-1", "key-2:value-2", "invalid", ":value", ""})
+	assert.Equal(t, map[string]string{"key-1": "value-1", "key-2": "value-2"}, keys)
+}
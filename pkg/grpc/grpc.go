@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Server interface {
+	Serve() error
+	Stop(ctx context.Context) error
+}
+
+// RegisterFunc - gRPCサービスの登録
+type RegisterFunc func(s ggrpc.ServiceRegistrar)
+
+type grpcServer struct {
+	server   *ggrpc.Server
+	health   *health.Server
+	port     int64
+	listener net.Listener
+}
+
+type options struct {
+	logger        *zap.Logger
+	serviceTokens []string
+	reflection    bool
+	listener      net.Listener
+}
+
+type Option func(*options)
+
+func WithLogger(logger *zap.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithServiceTokens - 呼び出し元のサービスの認証に使用するトークン一覧
+func WithServiceTokens(tokens ...string) Option {
+	return func(opts *options) {
+		opts.serviceTokens = tokens
+	}
+}
+
+// WithReflection - サーバーリフレクションの有効化
+func WithReflection(enabled bool) Option {
+	return func(opts *options) {
+		opts.reflection = enabled
+	}
+}
+
+// WithListener - 指定したリスナーで待ち受ける (テスト用)
+func WithListener(lis net.Listener) Option {
+	return func(opts *options) {
+		opts.listener = lis
+	}
+}
+
+// NewGRPCServer - gRPCサーバーの生成
+// ロギング、リカバリー、メトリクス、認証のインターセプターとヘルスチェックを設定する
+func NewGRPCServer(register RegisterFunc, port int64, opts ...Option) Server {
+	dopts := &options{
+		logger: zap.NewNop(),
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+
+	s := ggrpc.NewServer(
+		ggrpc.ChainUnaryInterceptor(
+			RecoveryUnaryInterceptor(dopts.logger),
+			LoggingUnaryInterceptor(dopts.logger),
+			MetricsUnaryInterceptor(),
+			AuthUnaryInterceptor(dopts.serviceTokens...),
+		),
+	)
+	register(s)
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	if dopts.reflection {
+		reflection.Register(s)
+	}
+	return &grpcServer{
+		server:   s,
+		health:   hs,
+		port:     port,
+		listener: dopts.listener,
+	}
+}
+
+// Serve - サーバーの起動
+func (s *grpcServer) Serve() error {
+	lis := s.listener
+	if lis == nil {
+		var err error
+		lis, err = net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+		if err != nil {
+			return err
+		}
+	}
+	return s.server.Serve(lis)
+}
+
+// Stop - サーバーの停止
+// 処理中のリクエストの完了を待ち、期限を過ぎた場合は強制的に停止する
+func (s *grpcServer) Stop(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
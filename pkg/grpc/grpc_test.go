@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCServer(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	lis := bufconn.Listen(1024 * 1024)
+	register := func(s ggrpc.ServiceRegistrar) {}
+	server := NewGRPCServer(register, 0, WithListener(lis), WithReflection(true), WithServiceTokens("token"))
+	go server.Serve() //nolint:errcheck
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+	conn, err := ggrpc.DialContext(ctx, "bufnet",
+		ggrpc.WithContextDialer(dialer),
+		ggrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// ヘルスチェック (サービストークン不要)
+	health := healthpb.NewHealthClient(conn)
+	res, err := health.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	// リフレクション
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	req := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}
+	require.NoError(t, stream.Send(req))
+	out, err := stream.Recv()
+	require.NoError(t, err)
+	services := make([]string, 0)
+	for _, s := range out.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	assert.Contains(t, services, "grpc.health.v1.Health")
+	require.NoError(t, stream.CloseSend())
+
+	// 停止
+	require.NoError(t, server.Stop(ctx))
+	_, err = health.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 認証を必要としないサービス (ヘルスチェック、リフレクション)
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+var (
+	handledCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	handledHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency (seconds) of gRPC that had been application-level handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// RecoveryUnaryInterceptor - panicを捕捉してInternalエラーを返す
+func RecoveryUnaryInterceptor(logger *zap.Logger) ggrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler,
+	) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Recovered from panic",
+					zap.String("method", info.FullMethod),
+					zap.Any("panic", r),
+					zap.ByteString("stacktrace", debug.Stack()),
+				)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// LoggingUnaryInterceptor - アクセスログの出力
+func LoggingUnaryInterceptor(logger *zap.Logger) ggrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		end := time.Now()
+
+		code := status.Code(err)
+		fields := []zapcore.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Int64("latency", end.Sub(start).Milliseconds()),
+			zap.String("time", end.Format("2006-01-02 15:04:05")),
+		}
+		switch code {
+		case codes.OK:
+			logger.Info(info.FullMethod, fields...)
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+			logger.Error(info.FullMethod, append(fields, zap.Error(err))...)
+		default:
+			logger.Warn(info.FullMethod, append(fields, zap.Error(err))...)
+		}
+		return res, err
+	}
+}
+
+// MetricsUnaryInterceptor - Prometheus形式のメトリクスの計測
+func MetricsUnaryInterceptor() ggrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+
+		service, method := splitMethodName(info.FullMethod)
+		handledCounter.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		handledHistogram.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		return res, err
+	}
+}
+
+// AuthUnaryInterceptor - 呼び出し元のサービスの認証
+// authorizationメタデータのBearerトークンが、指定したトークンのいずれかと一致する場合のみ許可する
+func AuthUnaryInterceptor(tokens ...string) ggrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler,
+	) (interface{}, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		token, err := getServiceToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "service token is invalid")
+	}
+}
+
+func getServiceToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "metadata is not found")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization is not found")
+	}
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	if token == values[0] || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization is not bearer token")
+	}
+	return token, nil
+}
+
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitMethodName - /package.Service/Method形式のメソッド名をサービス名とメソッド名に分割
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		handler ggrpc.UnaryHandler
+		expect  codes.Code
+	}{
+		{
+			name: "success",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+			expect: codes.OK,
+		},
+		{
+			name: "panic",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("unexpected")
+			},
+			expect: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			interceptor := RecoveryUnaryInterceptor(zap.NewNop())
+			info := &ggrpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Test"}
+			_, err := interceptor(context.Background(), nil, info, tt.handler)
+			assert.Equal(t, tt.expect, status.Code(err))
+		})
+	}
+}
+
+func TestLoggingUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		handler ggrpc.UnaryHandler
+		expect  codes.Code
+	}{
+		{
+			name: "success",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+			expect: codes.OK,
+		},
+		{
+			name: "client error",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.NotFound, "not found")
+			},
+			expect: codes.NotFound,
+		},
+		{
+			name: "server error",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, assert.AnError
+			},
+			expect: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			interceptor := LoggingUnaryInterceptor(zap.NewNop())
+			info := &ggrpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Test"}
+			_, err := interceptor(context.Background(), nil, info, tt.handler)
+			assert.Equal(t, tt.expect, status.Code(err))
+		})
+	}
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		tokens []string
+		method string
+		expect codes.Code
+	}{
+		{
+			name:   "success",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token02")),
+			tokens: []string{"token01", "token02"},
+			method: "/test.v1.TestService/Test",
+			expect: codes.OK,
+		},
+		{
+			name:   "public method",
+			ctx:    context.Background(),
+			tokens: []string{"token01"},
+			method: "/grpc.health.v1.Health/Check",
+			expect: codes.OK,
+		},
+		{
+			name:   "empty metadata",
+			ctx:    context.Background(),
+			tokens: []string{"token01"},
+			method: "/test.v1.TestService/Test",
+			expect: codes.Unauthenticated,
+		},
+		{
+			name:   "empty authorization",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs()),
+			tokens: []string{"token01"},
+			method: "/test.v1.TestService/Test",
+			expect: codes.Unauthenticated,
+		},
+		{
+			name:   "not bearer token",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "token01")),
+			tokens: []string{"token01"},
+			method: "/test.v1.TestService/Test",
+			expect: codes.Unauthenticated,
+		},
+		{
+			name:   "invalid token",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token02")),
+			tokens: []string{"token01"},
+			method: "/test.v1.TestService/Test",
+			expect: codes.Unauthenticated,
+		},
+		{
+			name:   "no tokens",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token01")),
+			tokens: nil,
+			method: "/test.v1.TestService/Test",
+			expect: codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			interceptor := AuthUnaryInterceptor(tt.tokens...)
+			info := &ggrpc.UnaryServerInfo{FullMethod: tt.method}
+			_, err := interceptor(tt.ctx, nil, info, handler)
+			assert.Equal(t, tt.expect, status.Code(err))
+		})
+	}
+}
+
+func TestSplitMethodName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		method  string
+		service string
+		expect  string
+	}{
+		{
+			name:    "success",
+			method:  "/test.v1.TestService/Test",
+			service: "test.v1.TestService",
+			expect:  "Test",
+		},
+		{
+			name:    "invalid format",
+			method:  "Test",
+			service: "unknown",
+			expect:  "Test",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service, method := splitMethodName(tt.method)
+			assert.Equal(t, tt.service, service)
+			assert.Equal(t, tt.expect, method)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Package health - 死活監視 (liveness) と受付可否 (readiness) の判定
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Status - 判定結果
+type Status string
+
+const (
+	StatusOK          Status = "ok"          // 正常
+	StatusUnavailable Status = "unavailable" // 異常 (リクエストを受け付けない)
+	StatusDegraded    Status = "degraded"    // 一部の任意の依存先が異常 (リクエストは受け付ける)
+)
+
+var errShuttingDown = errors.New("health: shutting down")
+
+// CheckFunc - 依存先の状態を確認する処理 (異常時はエラーを返す)
+type CheckFunc func(ctx context.Context) error
+
+// Health - 依存先の状態確認の管理
+type Health struct {
+	now      func() time.Time
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checks   []*check
+	shutdown atomic.Bool
+}
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	cacheTTL time.Duration
+	optional bool
+	mu       sync.Mutex
+	result   *CheckResult
+}
+
+// Result - 受付可否の判定結果
+type Result struct {
+	Status Status                  `json:"status"`           // 判定結果
+	Checks map[string]*CheckResult `json:"checks,omitempty"` // 依存先ごとの判定結果
+}
+
+// CheckResult - 依存先ごとの判定結果
+type CheckResult struct {
+	Status    Status    `json:"status"`          // 判定結果
+	Optional  bool      `json:"optional"`        // 異常時もリクエストを受け付けるか
+	Error     string    `json:"error,omitempty"` // 異常時のエラー内容
+	LatencyMs int64     `json:"latencyMs"`       // 確認に要した時間 (ミリ秒)
+	CheckedAt time.Time `json:"checkedAt"`       // 確認日時
+}
+
+type options struct {
+	now      func() time.Time
+	timeout  time.Duration
+	cacheTTL time.Duration
+}
+
+type Option func(*options)
+
+func WithNow(now func() time.Time) Option {
+	return func(opts *options) {
+		opts.now = now
+	}
+}
+
+// WithTimeout - 依存先ごとの確認のタイムアウト (デフォルト値)
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.timeout = timeout
+	}
+}
+
+// WithCacheTTL - 依存先ごとの確認結果を再利用する期間 (デフォルト値)
+// ロードバランサーからの頻繁な確認で、依存先に負荷をかけないようにする
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(opts *options) {
+		opts.cacheTTL = ttl
+	}
+}
+
+// NewHealth - 依存先の状態確認の管理構造体の生成
+func NewHealth(opts ...Option) *Health {
+	dopts := &options{
+		now:      time.Now,
+		timeout:  2 * time.Second,
+		cacheTTL: 5 * time.Second,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &Health{
+		now:      dopts.now,
+		timeout:  dopts.timeout,
+		cacheTTL: dopts.cacheTTL,
+	}
+}
+
+type checkOptions struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	optional bool
+}
+
+type CheckOption func(*checkOptions)
+
+// WithCheckTimeout - 確認のタイムアウト
+func WithCheckTimeout(timeout time.Duration) CheckOption {
+	return func(opts *checkOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithCheckCacheTTL - 確認結果を再利用する期間
+func WithCheckCacheTTL(ttl time.Duration) CheckOption {
+	return func(opts *checkOptions) {
+		opts.cacheTTL = ttl
+	}
+}
+
+// WithOptional - 異常時もリクエストを受け付ける (判定結果はdegradedになる)
+func WithOptional() CheckOption {
+	return func(opts *checkOptions) {
+		opts.optional = true
+	}
+}
+
+// Register - 依存先の状態確認の登録 (リクエストの受付開始前に登録すること)
+func (h *Health) Register(name string, fn CheckFunc, opts ...CheckOption) {
+	dopts := &checkOptions{
+		timeout:  h.timeout,
+		cacheTTL: h.cacheTTL,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	h.checks = append(h.checks, &check{
+		name:     name,
+		fn:       fn,
+		timeout:  dopts.timeout,
+		cacheTTL: dopts.cacheTTL,
+		optional: dopts.optional,
+	})
+}
+
+// Shutdown - 停止処理の開始 (以降はリクエストを受け付けないと判定する)
+// ロードバランサーから切り離されるまでの待機前に呼び出す
+func (h *Health) Shutdown() {
+	h.shutdown.Store(true)
+}
+
+// Ready - リクエストの受付可否の判定
+// 依存先の確認は並列に実行し、必須の依存先が1つでも異常な場合は受け付けないと判定する
+func (h *Health) Ready(ctx context.Context) *Result {
+	if h.shutdown.Load() {
+		return &Result{Status: StatusUnavailable, Checks: map[string]*CheckResult{
+			"shutdown": {Status: StatusUnavailable, Error: errShuttingDown.Error(), CheckedAt: h.now()},
+		}}
+	}
+	results := make([]*CheckResult, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, h.now)
+		}(i, c)
+	}
+	wg.Wait()
+
+	res := &Result{Status: StatusOK, Checks: make(map[string]*CheckResult, len(h.checks))}
+	for i, c := range h.checks {
+		res.Checks[c.name] = results[i]
+		switch {
+		case results[i].Status == StatusOK:
+		case !c.optional:
+			res.Status = StatusUnavailable
+		case res.Status == StatusOK:
+			res.Status = StatusDegraded
+		}
+	}
+	return res
+}
+
+// LivenessHandler - プロセスの死活監視 (依存先の状態によらず、応答できれば正常と判定する)
+func (h *Health) LivenessHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, &Result{Status: StatusOK})
+	}
+}
+
+// ReadinessHandler - リクエストの受付可否 (受け付けない場合は503を返す)
+func (h *Health) ReadinessHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res := h.Ready(ctx.Request.Context())
+		if res.Status == StatusUnavailable {
+			ctx.JSON(http.StatusServiceUnavailable, res)
+			return
+		}
+		ctx.JSON(http.StatusOK, res)
+	}
+}
+
+// run - 依存先の状態確認 (有効期間内の確認結果がある場合は再利用する)
+func (c *check) run(ctx context.Context, now func() time.Time) *CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.result != nil && now().Sub(c.result.CheckedAt) < c.cacheTTL {
+		return c.result
+	}
+
+	// リクエストの切断で確認が中断され、異常として再利用されないようにする
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeout)
+	defer cancel()
+	start := time.Now()
+	// コンテキストを考慮しない確認処理でも、タイムアウトで打ち切る
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.fn(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res := &CheckResult{
+		Status:    StatusOK,
+		Optional:  c.optional,
+		LatencyMs: time.Since(start).Milliseconds(),
+		CheckedAt: now(),
+	}
+	if err != nil {
+		res.Status = StatusUnavailable
+		res.Error = err.Error()
+	}
+	c.result = res
+	return res
+}
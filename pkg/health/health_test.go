@@ -0,0 +1,166 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth_Ready(t *testing.T) {
+	t.Parallel()
+	errCheck := errors.New("some error")
+	success := func(ctx context.Context) error { return nil }
+	failure := func(ctx context.Context) error { return errCheck }
+	tests := []struct {
+		name   string
+		setup  func(h *Health)
+		expect Status
+		errors map[string]string
+	}{
+		{
+			name:   "no checks",
+			setup:  func(h *Health) {},
+			expect: StatusOK,
+			errors: map[string]string{},
+		},
+		{
+			name: "all checks succeeded",
+			setup: func(h *Health) {
+				h.Register("mysql", success)
+				h.Register("cognito", success)
+			},
+			expect: StatusOK,
+			errors: map[string]string{"mysql": "", "cognito": ""},
+		},
+		{
+			name: "required check failed",
+			setup: func(h *Health) {
+				h.Register("mysql", failure)
+				h.Register("cognito", success)
+			},
+			expect: StatusUnavailable,
+			errors: map[string]string{"mysql": errCheck.Error(), "cognito": ""},
+		},
+		{
+			name: "optional check failed",
+			setup: func(h *Health) {
+				h.Register("mysql", success)
+				h.Register("secret", failure, WithOptional())
+			},
+			expect: StatusDegraded,
+			errors: map[string]string{"mysql": "", "secret": errCheck.Error()},
+		},
+		{
+			name: "timeout",
+			setup: func(h *Health) {
+				h.Register("mysql", func(ctx context.Context) error {
+					time.Sleep(time.Second) // コンテキストを考慮しない処理
+					return nil
+				}, WithCheckTimeout(10*time.Millisecond))
+			},
+			expect: StatusUnavailable,
+			errors: map[string]string{"mysql": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "shutting down",
+			setup: func(h *Health) {
+				h.Register("mysql", success)
+				h.Shutdown()
+			},
+			expect: StatusUnavailable,
+			errors: map[string]string{"shutdown": errShuttingDown.Error()},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := NewHealth()
+			tt.setup(h)
+			actual := h.Ready(context.Background())
+			assert.Equal(t, tt.expect, actual.Status)
+			require.Len(t, actual.Checks, len(tt.errors))
+			for name, msg := range tt.errors {
+				require.Contains(t, actual.Checks, name)
+				assert.Equal(t, msg, actual.Checks[name].Error)
+			}
+		})
+	}
+}
+
+func TestHealth_Cache(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 10, 6, 18, 30, 0, 0, time.UTC)
+	h := NewHealth(
+		WithNow(func() time.Time { return now }),
+		WithCacheTTL(5*time.Second),
+	)
+	var count int32
+	h.Register("mysql", func(ctx context.Context) error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.Ready(ctx)
+	h.Ready(ctx)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	// リクエストの切断は確認結果に影響しない
+	cancel()
+	now = now.Add(5 * time.Second)
+	res := h.Ready(ctx)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+	assert.Equal(t, StatusOK, res.Status)
+	assert.Equal(t, now, res.Checks["mysql"].CheckedAt)
+}
+
+func TestHealth_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	h := NewHealth()
+	var failed atomic.Bool
+	h.Register("mysql", func(ctx context.Context) error {
+		if failed.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, WithCheckCacheTTL(0))
+	rt := gin.New()
+	rt.GET("/livez", h.LivenessHandler())
+	rt.GET("/readyz", h.ReadinessHandler())
+
+	serve := func(path string) (int, *Result) {
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var res *Result
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		return w.Code, res
+	}
+
+	code, res := serve("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, res.Status)
+	assert.Equal(t, StatusOK, res.Checks["mysql"].Status)
+
+	failed.Store(true)
+	code, res = serve("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusUnavailable, res.Status)
+	assert.Equal(t, "connection refused", res.Checks["mysql"].Error)
+
+	// 依存先が異常でもプロセスは正常と判定する
+	code, res = serve("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, res.Status)
+	assert.Empty(t, res.Checks)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// unmatchedRoute - ルーティングに一致しないリクエストのラベル (パスをそのまま使用するとカーディナリティが増えるため)
+const unmatchedRoute = "unmatched"
+
+var (
+	requestCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_server_requests_total",
+		Help: "Total number of HTTP requests completed on the server, regardless of success or failure.",
+	}, []string{"method", "route", "code"})
+	requestHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_server_request_duration_seconds",
+		Help:    "Histogram of response latency (seconds) of HTTP requests handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// NewGinMetricsMiddleware - Prometheus形式のメトリクス (リクエスト数、エラー数、レイテンシ) の計測
+// パスパラメータごとに分かれないよう、ルーティングのテンプレート (e.g. /admins/:adminId) をラベルとする
+func NewGinMetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := ctx.Request.Method
+		code := strconv.Itoa(ctx.Writer.Status())
+		requestCounter.WithLabelValues(method, route, code).Inc()
+		requestHistogram.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
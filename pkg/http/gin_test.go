@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGinMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewGinMetricsMiddleware())
+	r.GET("/metrics-test/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		route  string
+		code   string
+		expect int
+	}{
+		{
+			name:   "matched route",
+			path:   "/metrics-test/1",
+			route:  "/metrics-test/:id",
+			code:   "204",
+			expect: http.StatusNoContent,
+		},
+		{
+			name:   "unmatched route",
+			path:   "/metrics-test",
+			route:  unmatchedRoute,
+			code:   "404",
+			expect: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			counter := requestCounter.WithLabelValues(http.MethodGet, tt.route, tt.code)
+			before := testutil.ToFloat64(counter)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.expect, w.Code)
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
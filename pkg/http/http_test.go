@@ -62,3 +62,20 @@ func TestHTTPServer(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPServer_Stop(t *testing.T) {
+	server := NewHTTPServer(http.NewServeMux(), 20081)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, server.Stop(context.Background()))
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err) // 停止による終了はエラーとしない
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,9 +25,12 @@ func NewMetricsServer(port int64) Server {
 	return &metricsServer{server: s}
 }
 
-// Serve - サーバーの起動
+// Serve - サーバーの起動 (Stopによる停止時はnilを返す)
 func (s *metricsServer) Serve() error {
-	return s.server.ListenAndServe()
+	if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
 // Stop - サーバーの停止
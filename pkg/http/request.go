@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/uuid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader - リクエストIDを受け渡すヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength - 受け付けるリクエストIDの最大文字数
+const maxRequestIDLength = 128
+
+type requestIDContextKey struct{}
+
+// NewRequestIDContext - リクエストIDをコンテキストに保持
+func NewRequestIDContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID - コンテキストに保持したリクエストIDを取得
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// NewGinRequestMiddleware - リクエストIDの払い出しとリクエスト単位のロガーの生成
+// X-Request-IDヘッダーの値を引き継ぎ (未指定・不正な値の場合は生成)、レスポンスヘッダーにも同じ値を返す
+// ロガーはリクエストID、ルーティングのテンプレートを付与してリクエストのコンテキストに保持する (log.FromContextで取得)
+func NewGinRequestMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New()
+		}
+		ctx.Header(RequestIDHeader, requestID)
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		c := NewRequestIDContext(ctx.Request.Context(), requestID)
+		c = log.NewContext(c, logger, zap.String("requestId", requestID), zap.String("route", route))
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
+
+// validRequestID - ログへの不正な文字列の混入を防ぐため、英数字と一部の記号のみ許可する
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':', r == '/', r == '+', r == '=':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestGinRequestMiddleware(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		path      string
+		requestID string
+		route     string
+		generated bool
+	}{
+		{
+			name:      "inherit request id",
+			path:      "/request-test/1",
+			requestID: "request-id",
+			route:     "/request-test/:id",
+			generated: false,
+		},
+		{
+			name:      "generate request id",
+			path:      "/request-test/1",
+			requestID: "",
+			route:     "/request-test/:id",
+			generated: true,
+		},
+		{
+			name:      "invalid request id",
+			path:      "/request-test/1",
+			requestID: "request-id\n{\"level\":\"error\"}",
+			route:     "/request-test/:id",
+			generated: true,
+		},
+		{
+			name:      "too long request id",
+			path:      "/request-test/1",
+			requestID: strings.Repeat("a", 129),
+			route:     "/request-test/:id",
+			generated: true,
+		},
+		{
+			name:      "unmatched route",
+			path:      "/request-test",
+			requestID: "request-id",
+			route:     unmatchedRoute,
+			generated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			core, logs := observer.New(zapcore.InfoLevel)
+			var requestID string
+			handler := func(ctx *gin.Context) {
+				requestID = RequestID(ctx.Request.Context())
+				log.FromContext(ctx.Request.Context(), zap.NewNop()).Info("message")
+				ctx.Status(http.StatusNoContent)
+			}
+			r := gin.New()
+			r.Use(NewGinRequestMiddleware(zap.New(core)))
+			r.GET("/request-test/:id", handler)
+			r.NoRoute(handler)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, tt.requestID)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, requestID, w.Header().Get(RequestIDHeader))
+			if tt.generated {
+				assert.NotEqual(t, tt.requestID, requestID)
+				assert.NotEmpty(t, requestID)
+			} else {
+				assert.Equal(t, tt.requestID, requestID)
+			}
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			expect := map[string]interface{}{"requestId": requestID, "route": tt.route}
+			assert.Equal(t, expect, entries[0].ContextMap())
+		})
+	}
+}
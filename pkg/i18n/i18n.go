@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+type Locale string // ロケール
+
+const (
+	LocaleJA Locale = "ja" // 日本語
+	LocaleEN Locale = "en" // 英語
+)
+
+// DefaultLocale - ロケールが判定できない場合に使用するロケール
+const DefaultLocale = LocaleJA
+
+var (
+	supportedLocales = []Locale{LocaleJA, LocaleEN}
+	matcher          = language.NewMatcher([]language.Tag{language.Japanese, language.English})
+)
+
+// ParseAcceptLanguage - Accept-Languageヘッダーから使用するロケールを判定
+func ParseAcceptLanguage(header string) Locale {
+	if header == "" {
+		return DefaultLocale
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supportedLocales[index]
+}
+
+// Messages - ロケールごとのメッセージ
+type Messages map[Locale]string
+
+// Catalog - キーごとのメッセージ一覧
+type Catalog map[string]Messages
+
+// Has - キーに対応するメッセージが定義されているか
+func (c Catalog) Has(key string) bool {
+	_, ok := c[key]
+	return ok
+}
+
+// Message - キーに対応するメッセージを返す
+// - 指定したロケールのメッセージが未定義の場合、デフォルトロケールのメッセージを返す
+// - キーが未定義の場合、空文字を返す
+func (c Catalog) Message(locale Locale, key string, args ...interface{}) string {
+	messages, ok := c[key]
+	if !ok {
+		return ""
+	}
+	message, ok := messages[locale]
+	if !ok {
+		message = messages[DefaultLocale]
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
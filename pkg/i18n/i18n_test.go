@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		header string
+		expect Locale
+	}{
+		{
+			name:   "empty",
+			header: "",
+			expect: LocaleJA,
+		},
+		{
+			name:   "japanese",
+			header: "ja-JP,ja;q=0.9",
+			expect: LocaleJA,
+		},
+		{
+			name:   "english",
+			header: "en-US,en;q=0.9",
+			expect: LocaleEN,
+		},
+		{
+			name:   "weighted",
+			header: "en;q=0.5,ja;q=0.8",
+			expect: LocaleJA,
+		},
+		{
+			name:   "unsupported language",
+			header: "fr-FR",
+			expect: LocaleJA,
+		},
+		{
+			name:   "invalid header",
+			header: "!!!",
+			expect: LocaleJA,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+	catalog := Catalog{
+		"hello": {
+			LocaleJA: "こんにちは、%sさん",
+			LocaleEN: "Hello, %s",
+		},
+		"only-ja": {
+			LocaleJA: "日本語のみ",
+		},
+	}
+	tests := []struct {
+		name   string
+		locale Locale
+		key    string
+		args   []interface{}
+		expect string
+	}{
+		{
+			name:   "japanese",
+			locale: LocaleJA,
+			key:    "hello",
+			args:   []interface{}{"ふるマネ"},
+			expect: "こんにちは、ふるマネさん",
+		},
+		{
+			name:   "english",
+			locale: LocaleEN,
+			key:    "hello",
+			args:   []interface{}{"furumane"},
+			expect: "Hello, furumane",
+		},
+		{
+			name:   "fallback to default locale",
+			locale: LocaleEN,
+			key:    "only-ja",
+			expect: "日本語のみ",
+		},
+		{
+			name:   "not found",
+			locale: LocaleJA,
+			key:    "unknown",
+			expect: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, catalog.Message(tt.locale, tt.key, tt.args...))
+			assert.Equal(t, tt.expect != "", catalog.Has(tt.key))
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Package lifecycle - コンポーネントの起動・停止順序の管理
+//
+// コンポーネントは起動・常駐・起動確認・停止の処理と依存先を宣言し、依存先から順に起動、逆順に停止する
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDuplicateComponent = errors.New("lifecycle: duplicate component")
+	ErrUnknownDependency  = errors.New("lifecycle: unknown dependency")
+	ErrCyclicDependency   = errors.New("lifecycle: cyclic dependency")
+)
+
+// Hook - コンポーネントの起動・停止に伴う処理
+type Hook func(ctx context.Context) error
+
+// Hooks - コンポーネントの起動・停止に伴う処理 (未指定の処理は実行しない)
+type Hooks struct {
+	Start Hook // 起動処理 (依存先の起動完了後に実行する)
+	Run   Hook // 常駐処理 (サーバーの待ち受けなど、停止するまで返らない処理をゴルーチンで実行する)
+	Ready Hook // 起動完了の確認 (成功するまで再試行し、完了後に依存元を起動する)
+	Stop  Hook // 停止処理 (依存元の停止後に実行する)
+}
+
+// Manager - コンポーネントの起動・停止順序の管理
+type Manager struct {
+	logger       *zap.Logger
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	components   []*component
+	mu           sync.Mutex
+	started      []*component
+	errCh        chan error
+}
+
+type component struct {
+	name      string
+	hooks     Hooks
+	dependsOn []string
+	retry     bool
+	cancel    context.CancelFunc // 常駐処理の停止
+}
+
+type options struct {
+	logger       *zap.Logger
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	backoff      time.Duration
+	maxBackoff   time.Duration
+}
+
+type Option func(*options)
+
+func WithLogger(logger *zap.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithStartTimeout - すべてのコンポーネントの起動 (再試行を含む) の期限
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.startTimeout = timeout
+	}
+}
+
+// WithStopTimeout - すべてのコンポーネントの停止の期限 (期限を過ぎた場合は停止処理の完了を待たない)
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.stopTimeout = timeout
+	}
+}
+
+// WithBackoff - 起動処理の再試行までの待機時間 (試行ごとに倍にし、maxBackoffを上限とする)
+func WithBackoff(backoff, maxBackoff time.Duration) Option {
+	return func(opts *options) {
+		opts.backoff = backoff
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// NewManager - コンポーネントの起動・停止順序の管理構造体の生成
+func NewManager(opts ...Option) *Manager {
+	dopts := &options{
+		logger:       zap.NewNop(),
+		startTimeout: time.Minute,
+		stopTimeout:  30 * time.Second,
+		backoff:      500 * time.Millisecond,
+		maxBackoff:   10 * time.Second,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &Manager{
+		logger:       dopts.logger,
+		startTimeout: dopts.startTimeout,
+		stopTimeout:  dopts.stopTimeout,
+		backoff:      dopts.backoff,
+		maxBackoff:   dopts.maxBackoff,
+	}
+}
+
+type componentOptions struct {
+	dependsOn []string
+	retry     bool
+}
+
+type ComponentOption func(*componentOptions)
+
+// WithDependsOn - 依存先のコンポーネント (依存先の起動完了後に起動し、停止後に停止する)
+func WithDependsOn(names ...string) ComponentOption {
+	return func(opts *componentOptions) {
+		opts.dependsOn = append(opts.dependsOn, names...)
+	}
+}
+
+// WithRetry - 起動処理の失敗時に、起動の期限まで待機時間を延ばしながら再試行する
+// データベースなど、起動直後は接続できない可能性がある依存先に指定する
+func WithRetry() ComponentOption {
+	return func(opts *componentOptions) {
+		opts.retry = true
+	}
+}
+
+// Register - コンポーネントの登録 (Startの呼び出し前に登録すること)
+func (m *Manager) Register(name string, hooks Hooks, opts ...ComponentOption) {
+	dopts := &componentOptions{}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	m.components = append(m.components, &component{
+		name:      name,
+		hooks:     hooks,
+		dependsOn: dopts.dependsOn,
+		retry:     dopts.retry,
+	})
+}
+
+// Start - 依存先から順にコンポーネントを起動
+// 起動に失敗した場合は、起動済みのコンポーネントを逆順に停止してエラーを返す
+func (m *Manager) Start(ctx context.Context) error {
+	components, err := m.sort()
+	if err != nil {
+		return err
+	}
+	m.errCh = make(chan error, len(components))
+
+	sctx, cancel := context.WithTimeout(ctx, m.startTimeout)
+	defer cancel()
+	for _, c := range components {
+		if err := m.start(sctx, ctx, c); err != nil {
+			err = fmt.Errorf("lifecycle: failed to start %s: %w", c.name, err)
+			return errors.Join(err, m.Stop(ctx))
+		}
+	}
+	return nil
+}
+
+// Err - 常駐処理の異常終了の通知
+func (m *Manager) Err() <-chan error {
+	return m.errCh
+}
+
+// Stop - 起動済みのコンポーネントを起動とは逆順に停止
+// 呼び出し元のコンテキストがキャンセル済みでも、停止の期限までは停止処理を実行する
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.stopTimeout)
+	defer cancel()
+	errs := make([]error, 0, len(started))
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if err := m.stop(ctx, c); err != nil {
+			errs = append(errs, fmt.Errorf("lifecycle: failed to stop %s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) start(ctx, parent context.Context, c *component) error {
+	logger := m.logger.With(zap.String("component", c.name))
+	start := time.Now()
+	if c.hooks.Start != nil {
+		if err := m.run(ctx, logger, c.hooks.Start, c.retry); err != nil {
+			return err
+		}
+	}
+	m.mu.Lock()
+	m.started = append(m.started, c)
+	m.mu.Unlock()
+
+	if c.hooks.Run != nil {
+		// 常駐処理は起動の期限によらず、停止するまで実行する
+		rctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+		c.cancel = cancel
+		go func() {
+			if err := c.hooks.Run(rctx); err != nil {
+				m.errCh <- fmt.Errorf("lifecycle: %s exited: %w", c.name, err)
+			}
+		}()
+	}
+	if c.hooks.Ready != nil {
+		if err := m.run(ctx, logger, c.hooks.Ready, true); err != nil {
+			return err
+		}
+	}
+	logger.Info("Started component", zap.Duration("latency", time.Since(start)))
+	return nil
+}
+
+// run - 起動に伴う処理の実行 (再試行する場合は、成功するか期限を過ぎるまで待機時間を延ばしながら再実行する)
+func (m *Manager) run(ctx context.Context, logger *zap.Logger, hook Hook, retry bool) error {
+	backoff := m.backoff
+	for attempt := 1; ; attempt++ {
+		err := hook(ctx)
+		if err == nil || !retry {
+			return err
+		}
+		logger.Warn("Failed to start component, retrying",
+			zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, m.maxBackoff)
+	}
+}
+
+func (m *Manager) stop(ctx context.Context, c *component) error {
+	logger := m.logger.With(zap.String("component", c.name))
+	if c.cancel != nil {
+		defer c.cancel()
+	}
+	if c.hooks.Stop == nil {
+		return nil
+	}
+	// コンテキストを考慮しない停止処理でも、期限を過ぎた場合は完了を待たずに次のコンポーネントを停止する
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.hooks.Stop(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Stopped component", zap.Duration("latency", time.Since(start)))
+	return nil
+}
+
+// sort - 依存先が先になるようにコンポーネントを並び替え (依存関係がない場合は登録順)
+func (m *Manager) sort() ([]*component, error) {
+	registered := make(map[string]bool, len(m.components))
+	for _, c := range m.components {
+		if registered[c.name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateComponent, c.name)
+		}
+		registered[c.name] = true
+	}
+	for _, c := range m.components {
+		for _, name := range c.dependsOn {
+			if !registered[name] {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, c.name, name)
+			}
+		}
+	}
+
+	res := make([]*component, 0, len(m.components))
+	sorted := make(map[string]bool, len(m.components))
+	for len(res) < len(m.components) {
+		var progressed bool
+		for _, c := range m.components {
+			if sorted[c.name] || !resolved(c, sorted) {
+				continue
+			}
+			res = append(res, c)
+			sorted[c.name] = true
+			progressed = true
+		}
+		if !progressed {
+			return nil, ErrCyclicDependency
+		}
+	}
+	return res, nil
+}
+
+func resolved(c *component, sorted map[string]bool) bool {
+	for _, name := range c.dependsOn {
+		if !sorted[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,247 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) hook(event string, err error) Hook {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+		return err
+	}
+}
+
+func (r *recorder) hooks(name string) Hooks {
+	return Hooks{
+		Start: r.hook("start "+name, nil),
+		Stop:  r.hook("stop "+name, nil),
+	}
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.events...)
+}
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+	errStart := errors.New("some error")
+	tests := []struct {
+		name   string
+		setup  func(m *Manager, r *recorder)
+		expect []string
+		isErr  error
+	}{
+		{
+			name: "registration order",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("mysql", r.hooks("mysql"))
+				m.Register("http", r.hooks("http"))
+			},
+			expect: []string{"start mysql", "start http", "stop http", "stop mysql"},
+		},
+		{
+			name: "dependency order",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("http", r.hooks("http"), WithDependsOn("service"))
+				m.Register("service", r.hooks("service"), WithDependsOn("mysql"))
+				m.Register("mysql", r.hooks("mysql"))
+			},
+			expect: []string{
+				"start mysql", "start service", "start http",
+				"stop http", "stop service", "stop mysql",
+			},
+		},
+		{
+			name: "failed to start",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("mysql", r.hooks("mysql"))
+				m.Register("service", Hooks{
+					Start: r.hook("start service", errStart),
+					Stop:  r.hook("stop service", nil),
+				}, WithDependsOn("mysql"))
+				m.Register("http", r.hooks("http"), WithDependsOn("service"))
+			},
+			expect: []string{"start mysql", "start service", "stop mysql"},
+			isErr:  errStart,
+		},
+		{
+			name: "duplicate component",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("mysql", r.hooks("mysql"))
+				m.Register("mysql", r.hooks("mysql"))
+			},
+			expect: []string{},
+			isErr:  ErrDuplicateComponent,
+		},
+		{
+			name: "unknown dependency",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("http", r.hooks("http"), WithDependsOn("service"))
+			},
+			expect: []string{},
+			isErr:  ErrUnknownDependency,
+		},
+		{
+			name: "cyclic dependency",
+			setup: func(m *Manager, r *recorder) {
+				m.Register("mysql", r.hooks("mysql"))
+				m.Register("service", r.hooks("service"), WithDependsOn("mysql", "http"))
+				m.Register("http", r.hooks("http"), WithDependsOn("service"))
+			},
+			expect: []string{},
+			isErr:  ErrCyclicDependency,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			r := &recorder{}
+			m := NewManager()
+			tt.setup(m, r)
+			err := m.Start(ctx)
+			if tt.isErr != nil {
+				assert.ErrorIs(t, err, tt.isErr)
+				assert.Equal(t, tt.expect, r.list())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, m.Stop(ctx))
+			assert.Equal(t, tt.expect, r.list())
+		})
+	}
+}
+
+func TestManager_Retry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	errConn := errors.New("connection refused")
+
+	var attempts int
+	m := NewManager(WithBackoff(time.Millisecond, 2*time.Millisecond))
+	m.Register("mysql", Hooks{
+		Start: func(ctx context.Context) error {
+			if attempts++; attempts < 3 {
+				return errConn
+			}
+			return nil
+		},
+	}, WithRetry())
+	require.NoError(t, m.Start(ctx))
+	assert.Equal(t, 3, attempts)
+
+	// 起動の期限を過ぎた場合は、最後のエラーを返す
+	m = NewManager(
+		WithStartTimeout(20*time.Millisecond),
+		WithBackoff(time.Millisecond, 5*time.Millisecond),
+	)
+	m.Register("mysql", Hooks{
+		Start: func(ctx context.Context) error { return errConn },
+	}, WithRetry())
+	err := m.Start(ctx)
+	assert.ErrorIs(t, err, errConn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 再試行しない場合は、初回の失敗でエラーを返す
+	attempts = 0
+	m = NewManager(WithBackoff(time.Millisecond, time.Millisecond))
+	m.Register("mysql", Hooks{
+		Start: func(ctx context.Context) error {
+			attempts++
+			return errConn
+		},
+	})
+	assert.ErrorIs(t, m.Start(ctx), errConn)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestManager_RunAndReady(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	errServe := errors.New("address already in use")
+
+	var mu sync.Mutex
+	var listening bool
+	stopped := make(chan struct{})
+	m := NewManager(WithBackoff(time.Millisecond, time.Millisecond))
+	m.Register("http", Hooks{
+		Run: func(ctx context.Context) error {
+			mu.Lock()
+			listening = true
+			mu.Unlock()
+			<-ctx.Done()
+			close(stopped)
+			return nil
+		},
+		Ready: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if !listening {
+				return errors.New("not listening")
+			}
+			return nil
+		},
+	})
+	m.Register("grpc", Hooks{
+		Run: func(ctx context.Context) error { return errServe },
+	}, WithDependsOn("http"))
+	require.NoError(t, m.Start(ctx))
+
+	// 常駐処理の異常終了を通知する
+	select {
+	case err := <-m.Err():
+		assert.ErrorIs(t, err, errServe)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	// 停止時に常駐処理のコンテキストをキャンセルする
+	require.NoError(t, m.Stop(ctx))
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestManager_StopTimeout(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	m := NewManager(WithStopTimeout(10 * time.Millisecond))
+	m.Register("mysql", r.hooks("mysql"))
+	m.Register("http", Hooks{
+		Stop: func(ctx context.Context) error {
+			time.Sleep(time.Second) // コンテキストを考慮しない処理
+			return nil
+		},
+	}, WithDependsOn("mysql"))
+
+	// 呼び出し元のコンテキストがキャンセル済みでも停止処理を実行する
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, m.Start(ctx))
+	cancel()
+	err := m.Stop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 期限を過ぎた後も、残りのコンポーネントの停止処理は実行する
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"start mysql", "stop mysql"}, r.list())
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+type contextLogger struct {
+	logger *zap.Logger
+	fields []zap.Field
+}
+
+// NewContext - フィールドを追加したロガーをコンテキストに保持
+// リクエスト単位の情報 (リクエストID、ルーティングなど) をログに含める場合に使用する
+func NewContext(ctx context.Context, logger *zap.Logger, fields ...zap.Field) context.Context {
+	cl := &contextLogger{
+		logger: logger.With(fields...),
+		fields: fields,
+	}
+	return context.WithValue(ctx, contextKey{}, cl)
+}
+
+// With - コンテキストに保持したロガーにフィールドを追加 (ロガーを保持していない場合は何もしない)
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	current, ok := ctx.Value(contextKey{}).(*contextLogger)
+	if !ok {
+		return ctx
+	}
+	cl := &contextLogger{
+		logger: current.logger.With(fields...),
+		fields: append(current.fields[:len(current.fields):len(current.fields)], fields...),
+	}
+	return context.WithValue(ctx, contextKey{}, cl)
+}
+
+// FromContext - コンテキストに保持したロガーを取得 (保持していない場合はfallbackを返す)
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if ctx == nil {
+		return fallback
+	}
+	cl, ok := ctx.Value(contextKey{}).(*contextLogger)
+	if !ok {
+		return fallback
+	}
+	return cl.logger
+}
+
+// Fields - コンテキストに保持したロガーに追加したフィールドの一覧
+// 独自のロガーを持つライブラリ (e.g. zapgorm2) にリクエスト単位の情報を渡す場合に使用する
+func Fields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	cl, ok := ctx.Value(contextKey{}).(*contextLogger)
+	if !ok {
+		return nil
+	}
+	return cl.fields
+}
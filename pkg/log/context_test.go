@@ -0,0 +1,36 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.InfoLevel)
+	fallback := zap.NewNop()
+
+	ctx := context.Background()
+	assert.Equal(t, fallback, FromContext(ctx, fallback))
+	assert.Empty(t, Fields(ctx))
+	assert.Equal(t, ctx, With(ctx, zap.String("adminId", "admin-id")))
+
+	ctx = NewContext(ctx, zap.New(core), zap.String("requestId", "request-id"))
+	parent := ctx
+	ctx = With(ctx, zap.String("adminId", "admin-id"))
+	FromContext(ctx, fallback).Info("message")
+	FromContext(parent, fallback).Info("parent")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{"requestId": "request-id", "adminId": "admin-id"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"requestId": "request-id"}, entries[1].ContextMap())
+	assert.Equal(t, []zap.Field{zap.String("requestId", "request-id"), zap.String("adminId", "admin-id")}, Fields(ctx))
+	assert.Equal(t, []zap.Field{zap.String("requestId", "request-id")}, Fields(parent))
+}
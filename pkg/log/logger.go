@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/and-period/furumane/pkg/redact"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -11,6 +12,7 @@ import (
 type options struct {
 	logLevel   string
 	outputPath string
+	redactor   *redact.Redactor
 }
 
 type Option func(opts *options)
@@ -27,11 +29,19 @@ func WithOutput(path string) Option {
 	}
 }
 
+// WithRedactor - 出力するフィールドのマスキング処理 (nilの場合はマスキングしない)
+func WithRedactor(r *redact.Redactor) Option {
+	return func(opts *options) {
+		opts.redactor = r
+	}
+}
+
 // NewLogger - ログ出力用クライアントの生成
 func NewLogger(opts ...Option) (*zap.Logger, error) {
 	dopts := &options{
 		logLevel:   "info",
 		outputPath: "",
+		redactor:   redact.Default(),
 	}
 	for i := range opts {
 		opts[i](dopts)
@@ -61,7 +71,7 @@ func NewLogger(opts ...Option) (*zap.Logger, error) {
 
 	// Path==""のとき、標準出力のみ
 	if dopts.outputPath == "" {
-		logger := zap.New(zapcore.NewTee(consoleCore))
+		logger := zap.New(newCore(dopts, consoleCore))
 		return logger, nil
 	}
 
@@ -79,10 +89,18 @@ func NewLogger(opts ...Option) (*zap.Logger, error) {
 		level,
 	)
 
-	logger := zap.New(zapcore.NewTee(consoleCore, logCore))
+	logger := zap.New(newCore(dopts, consoleCore, logCore))
 	return logger, nil
 }
 
+func newCore(opts *options, cores ...zapcore.Core) zapcore.Core {
+	core := zapcore.NewTee(cores...)
+	if opts.redactor == nil {
+		return core
+	}
+	return redact.NewCore(core, opts.redactor)
+}
+
 func getLogLevel(level string) zapcore.Level {
 	switch level {
 	case "debug":
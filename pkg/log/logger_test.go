@@ -3,6 +3,7 @@ package log
 import (
 	"testing"
 
+	"github.com/and-period/furumane/pkg/redact"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 )
@@ -22,6 +23,27 @@ func TestLogger(t *testing.T) {
 			},
 			isErr: false,
 		},
+		{
+			name: "success with redactor",
+			options: []Option{
+				WithRedactor(redact.Default()),
+			},
+			isErr: false,
+		},
+		{
+			name: "success without redactor",
+			options: []Option{
+				WithRedactor(nil),
+			},
+			isErr: false,
+		},
+		{
+			name: "failed to open file",
+			options: []Option{
+				WithOutput("/not-found/directory"),
+			},
+			isErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,73 @@
+//go:generate mockgen -source=$GOFILE -package mock_$GOPACKAGE -destination=./../../mock/pkg/$GOPACKAGE/$GOFILE
+package mailer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+var errInvalidParams = errors.New("mailer: invalid params")
+
+type Client interface {
+	// メール送信 (テキスト形式)
+	Send(ctx context.Context, params *SendParams) error
+}
+
+type Params struct {
+	FromAddress string // 送信元メールアドレス
+	FromName    string // 送信元名
+}
+
+type SendParams struct {
+	To      []string // 送信先メールアドレス
+	Subject string   // 件名
+	Body    string   // 本文
+}
+
+type client struct {
+	ses  *sesv2.Client
+	from *string
+}
+
+func NewClient(cfg aws.Config, params *Params) Client {
+	from := params.FromAddress
+	if params.FromName != "" {
+		from = params.FromName + " <" + params.FromAddress + ">"
+	}
+	return &client{
+		ses:  sesv2.NewFromConfig(cfg),
+		from: aws.String(from),
+	}
+}
+
+func (c *client) Send(ctx context.Context, params *SendParams) error {
+	if len(params.To) == 0 {
+		return errInvalidParams
+	}
+	in := &sesv2.SendEmailInput{
+		FromEmailAddress: c.from,
+		Destination: &types.Destination{
+			ToAddresses: params.To,
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{
+					Data:    aws.String(params.Subject),
+					Charset: aws.String("UTF-8"),
+				},
+				Body: &types.Body{
+					Text: &types.Content{
+						Data:    aws.String(params.Body),
+						Charset: aws.String("UTF-8"),
+					},
+				},
+			},
+		},
+	}
+	_, err := c.ses.SendEmail(ctx, in)
+	return err
+}
@@ -3,19 +3,26 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/tracing"
 	dmysql "github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 	"moul.io/zapgorm2"
 )
 
 // Client - DB操作用のクライアント構造体
 type Client struct {
-	DB *gorm.DB
+	DB    *gorm.DB
+	conns []*sql.DB // プライマリレプリカとリードレプリカの接続 (切断用)
+	retry retryPolicy
 }
 
 type Params struct {
@@ -36,6 +43,14 @@ type options struct {
 	enabledTLS           bool
 	allowNativePasswords bool
 	maxAllowedPacket     int
+	replicas             []*Params
+	maxOpenConns         int
+	maxIdleConns         int
+	connMaxLifetime      time.Duration
+	connMaxIdleTime      time.Duration
+	metrics              bool
+	tracerProvider       trace.TracerProvider
+	retry                retryPolicy
 }
 
 type Option func(opts *options)
@@ -88,6 +103,55 @@ func WithMaxAllowedPacket(size int) Option {
 	}
 }
 
+// WithReplicas - 参照用のリードレプリカ (未指定の場合はプライマリレプリカのみを使用)
+func WithReplicas(replicas ...*Params) Option {
+	return func(opts *options) {
+		opts.replicas = replicas
+	}
+}
+
+// WithMaxOpenConns - 最大接続数 (0以下の場合は無制限)
+func WithMaxOpenConns(size int) Option {
+	return func(opts *options) {
+		opts.maxOpenConns = size
+	}
+}
+
+// WithMaxIdleConns - アイドル状態の最大接続数 (0以下の場合は保持しない)
+func WithMaxIdleConns(size int) Option {
+	return func(opts *options) {
+		opts.maxIdleConns = size
+	}
+}
+
+// WithConnMaxLifetime - 接続の最大再利用時間 (0以下の場合は無制限)
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(opts *options) {
+		opts.connMaxLifetime = d
+	}
+}
+
+// WithConnMaxIdleTime - 接続の最大アイドル時間 (0以下の場合は無制限)
+func WithConnMaxIdleTime(d time.Duration) Option {
+	return func(opts *options) {
+		opts.connMaxIdleTime = d
+	}
+}
+
+// WithMetrics - Prometheus形式のメトリクス (コネクションプール、クエリのレイテンシ) の計測
+func WithMetrics(enable bool) Option {
+	return func(opts *options) {
+		opts.metrics = enable
+	}
+}
+
+// WithTracerProvider - OpenTelemetryによるクエリ単位のトレース (nilの場合は出力しない)
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(opts *options) {
+		opts.tracerProvider = tp
+	}
+}
+
 // NewClient - DBクライアントの構造体
 func NewClient(params *Params, opts ...Option) (*Client, error) {
 	dopts := &options{
@@ -99,6 +163,12 @@ func NewClient(params *Params, opts ...Option) (*Client, error) {
 		enabledTLS:           false,
 		allowNativePasswords: true,
 		maxAllowedPacket:     4194304, // 4MiB
+		maxIdleConns:         2,       // database/sqlのデフォルト値
+		retry: retryPolicy{
+			maxRetries: defaultMaxRetries,
+			backoff:    defaultRetryBackoff,
+			maxWait:    defaultRetryMaxWait,
+		},
 	}
 	for i := range opts {
 		opts[i](dopts)
@@ -109,13 +179,82 @@ func NewClient(params *Params, opts ...Option) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	primary, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	setConnPool(primary, dopts)
+	conns := []*sql.DB{primary}
+	if dopts.metrics {
+		if err := db.Use(&metricsPlugin{}); err != nil {
+			return nil, err
+		}
+		if err := registerDBStats(primary, params.Database); err != nil {
+			return nil, err
+		}
+	}
+	if dopts.tracerProvider != nil {
+		plugin := &tracingPlugin{
+			tracer:   tracing.Tracer(dopts.tracerProvider),
+			database: params.Database,
+		}
+		if err := db.Use(plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	// リードレプリカの登録
+	// 参照クエリはリードレプリカ、更新クエリとトランザクション内のクエリはプライマリレプリカで実行される
+	if len(dopts.replicas) > 0 {
+		resolver, replicas, err := newResolver(dopts)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, replicas...)
+		if err := db.Use(resolver); err != nil {
+			return nil, err
+		}
+	}
 
 	c := &Client{
-		DB: db,
+		DB:    db,
+		conns: conns,
+		retry: dopts.retry,
 	}
 	return c, nil
 }
 
+type primaryContextKey struct{}
+
+// ReadFromPrimary - 参照クエリをプライマリレプリカで実行するコンテキストを生成
+// 登録直後の参照など、リードレプリカへの反映遅延の影響を受けないようにする場合に使用する
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+func readFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryContextKey{}).(bool)
+	return primary
+}
+
+// Ping - プライマリレプリカへの疎通確認
+func (c *Client) Ping(ctx context.Context) error {
+	db, err := c.DB.DB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
+// Disconnect - すべての接続の切断 (実行中のクエリの完了を待つ)
+func (c *Client) Disconnect() error {
+	errs := make([]error, 0, len(c.conns))
+	for _, conn := range c.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
 // Begin - トランザクションの開始処理
 func (c *Client) Begin(ctx context.Context, opts ...*sql.TxOptions) (*gorm.DB, error) {
 	tx := c.DB.WithContext(ctx).Begin(opts...)
@@ -135,7 +274,51 @@ func (c *Client) Close(tx *gorm.DB) func() {
 }
 
 // Transaction - トランザクション処理
-func (c *Client) Transaction(ctx context.Context, f func(tx *gorm.DB) error) (err error) {
+// コンテキストにトランザクションが存在する場合は、そのトランザクション内のセーブポイントとして実行する
+func (c *Client) Transaction(ctx context.Context, f func(tx *gorm.DB) error) error {
+	return c.RunInTransaction(ctx, func(ctx context.Context) error {
+		return f(c.WithContext(ctx))
+	})
+}
+
+type txContextKey struct{}
+
+type txContext struct {
+	tx    *gorm.DB
+	depth int      // セーブポイントの階層
+	state *txState // セーブポイントを含めたトランザクション全体で共有する状態
+}
+
+type txState struct {
+	nonRetryable bool // ロールバックできない副作用を伴う処理が実行されたか
+}
+
+// RunInTransaction - コンテキストにトランザクションを保持して処理を実行
+// fn内でコンテキストを引き継いだクエリは、すべて同じトランザクション内で実行される
+// 入れ子で呼び出した場合はセーブポイントを作成し、エラー時はセーブポイントまでロールバックする
+// デッドロック・ロック待ちタイムアウト時は、最も外側のトランザクションごとfnを再実行する (MarkNonRetryableで抑止できる)
+func (c *Client) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if current, ok := ctx.Value(txContextKey{}).(*txContext); ok {
+		return c.savepoint(ctx, current, fn)
+	}
+	for attempt := 0; ; attempt++ {
+		state := &txState{}
+		err := c.transaction(ctx, state, fn)
+		if err == nil || state.nonRetryable || attempt >= c.retry.maxRetries {
+			return err
+		}
+		reason, ok := retryReason(err)
+		if !ok {
+			return err
+		}
+		transactionRetryCounter.WithLabelValues(reason).Inc()
+		if werr := c.retry.wait(ctx, attempt); werr != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) transaction(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
 	tx, err := c.Begin(ctx)
 	if err != nil {
 		return err
@@ -151,13 +334,50 @@ func (c *Client) Transaction(ctx context.Context, f func(tx *gorm.DB) error) (er
 		}
 		err = tx.Commit().Error
 	}()
-	err = f(tx)
+	err = fn(context.WithValue(ctx, txContextKey{}, &txContext{tx: tx, state: state}))
+	return
+}
+
+func (c *Client) savepoint(ctx context.Context, current *txContext, fn func(ctx context.Context) error) (err error) {
+	nested := &txContext{tx: current.tx, depth: current.depth + 1, state: current.state}
+	name := fmt.Sprintf("sp%d", nested.depth)
+	tx := current.tx.WithContext(ctx)
+	if err := tx.SavePoint(name).Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.RollbackTo(name)
+			panic(r) // 外側のトランザクションもロールバックさせる
+		}
+		if err != nil {
+			tx.RollbackTo(name)
+			return
+		}
+		err = tx.Exec("RELEASE SAVEPOINT " + name).Error
+	}()
+	err = fn(context.WithValue(ctx, txContextKey{}, nested))
 	return
 }
 
+// InTransaction - コンテキストにトランザクションが保持されているか
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txContextKey{}).(*txContext)
+	return ok
+}
+
+// WithContext - クエリの実行に使用するDBクライアントを取得
+// コンテキストにトランザクションが保持されている場合は、そのトランザクションを返す
+func (c *Client) WithContext(ctx context.Context) *gorm.DB {
+	if current, ok := ctx.Value(txContextKey{}).(*txContext); ok {
+		return current.tx.WithContext(ctx)
+	}
+	return c.DB.WithContext(ctx)
+}
+
 // Statement - セレクトクエリの生成
 func (c *Client) Statement(ctx context.Context, tx *gorm.DB, table string, fields ...string) *gorm.DB {
-	stmt := tx.WithContext(ctx).Table(table)
+	stmt := c.withResolver(ctx, tx).Table(table)
 	if len(fields) == 0 {
 		stmt = stmt.Select("*")
 	} else {
@@ -170,16 +390,67 @@ func (c *Client) Statement(ctx context.Context, tx *gorm.DB, table string, field
 func (c *Client) Count(ctx context.Context, tx *gorm.DB, model interface{}, fn func(*gorm.DB) *gorm.DB) (int64, error) {
 	var total int64
 
-	stmt := tx.WithContext(ctx).Model(model).Select("COUNT(*)")
+	stmt := c.withResolver(ctx, tx).Model(model).Select("COUNT(*)")
 	if fn != nil {
 		stmt = fn(stmt)
 	}
 	return total, stmt.Find(&total).Error
 }
 
+// withResolver - 参照クエリの実行先の指定
+// トランザクション外のクライアントが指定された場合も、コンテキストにトランザクションが保持されていればそれを使用する
+func (c *Client) withResolver(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	var stmt *gorm.DB
+	if tx == c.DB {
+		stmt = c.WithContext(ctx)
+	} else {
+		stmt = tx.WithContext(ctx)
+	}
+	if readFromPrimary(ctx) {
+		stmt = stmt.Clauses(dbresolver.Write)
+	}
+	return stmt
+}
+
+func newResolver(opts *options) (*dbresolver.DBResolver, []*sql.DB, error) {
+	replicas := make([]gorm.Dialector, len(opts.replicas))
+	conns := make([]*sql.DB, len(opts.replicas))
+	for i, params := range opts.replicas {
+		conn, err := sql.Open("mysql", newDSN(params, opts))
+		if err != nil {
+			return nil, nil, err
+		}
+		setConnPool(conn, opts)
+		if opts.metrics {
+			name := fmt.Sprintf("%s:replica-%d", params.Database, i)
+			if err := registerDBStats(conn, name); err != nil {
+				return nil, nil, err
+			}
+		}
+		replicas[i] = mysql.New(mysql.Config{Conn: conn})
+		conns[i] = conn
+	}
+	conf := dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}
+	return dbresolver.Register(conf), conns, nil
+}
+
+// setConnPool - コネクションプールの設定
+func setConnPool(db *sql.DB, opts *options) {
+	db.SetMaxOpenConns(opts.maxOpenConns)
+	db.SetMaxIdleConns(opts.maxIdleConns)
+	db.SetConnMaxLifetime(opts.connMaxLifetime)
+	db.SetConnMaxIdleTime(opts.connMaxIdleTime)
+}
+
 func newDBClient(params *Params, opts *options) (*gorm.DB, error) {
+	// コンテキストに保持したリクエスト単位の情報 (リクエストIDなど) をクエリのログに付与する
+	logger := zapgorm2.New(opts.logger)
+	logger.Context = log.Fields
 	conf := &gorm.Config{
-		Logger:  zapgorm2.New(opts.logger),
+		Logger:  logger,
 		NowFunc: opts.now,
 	}
 	dsn := newDSN(params, opts)
@@ -6,8 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -58,6 +65,10 @@ func TestNewClient(t *testing.T) {
 				WithTLS(false),
 				WithNativePasswords(true),
 				WithMaxAllowedPacket(4194304),
+				WithMaxOpenConns(10),
+				WithMaxIdleConns(5),
+				WithConnMaxLifetime(5*time.Minute),
+				WithConnMaxIdleTime(time.Minute),
 			)
 			if tt.isErr {
 				assert.Error(t, err)
@@ -90,6 +101,27 @@ func TestBeginAndClose(t *testing.T) {
 	require.NotNil(t, f)
 }
 
+func TestPing(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params)
+	require.NoError(t, err)
+	assert.NoError(t, client.Ping(ctx))
+
+	// 切断後は疎通できない
+	require.NoError(t, client.Disconnect())
+	assert.Error(t, client.Ping(ctx))
+}
+
 func TestTransaction(t *testing.T) {
 	setEnv()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -118,6 +150,252 @@ func TestTransaction(t *testing.T) {
 	})
 }
 
+func TestRunInTransaction(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params)
+	require.NoError(t, err)
+
+	type transactionItem struct {
+		ID string `gorm:"primaryKey"`
+	}
+	const table = "transaction_items"
+	err = client.DB.Table(table).Migrator().CreateTable(&transactionItem{})
+	require.NoError(t, err)
+	defer client.DB.Migrator().DropTable(table) //nolint:errcheck
+
+	create := func(ctx context.Context, id string) error {
+		return client.WithContext(ctx).Table(table).Create(&transactionItem{ID: id}).Error
+	}
+	list := func(t *testing.T) []string {
+		var items []*transactionItem
+		err := client.Statement(ctx, client.DB, table).Order("id").Find(&items).Error
+		require.NoError(t, err)
+		ids := make([]string, len(items))
+		for i := range items {
+			ids[i] = items[i].ID
+		}
+		return ids
+	}
+	cleanup := func(t *testing.T) {
+		err := client.DB.Exec("DELETE FROM " + table).Error
+		require.NoError(t, err)
+	}
+	// テスト用のインメモリDBはセーブポイントに対応していないため、その場合は入れ子のトランザクションの検証をスキップする
+	savepoint := func(t *testing.T) {
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			return client.RunInTransaction(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		if err != nil {
+			t.Skipf("savepoint is not supported: %s", err)
+		}
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		defer cleanup(t)
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			assert.True(t, InTransaction(ctx))
+			if err := create(ctx, "a"); err != nil {
+				return err
+			}
+			// トランザクション外のクライアントを指定しても、コンテキストのトランザクションで実行される
+			var count int64
+			err := client.Statement(ctx, client.DB, table).Count(&count).Error
+			assert.Equal(t, int64(1), count)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, list(t))
+	})
+	t.Run("rollback", func(t *testing.T) {
+		defer cleanup(t)
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			if err := create(ctx, "a"); err != nil {
+				return err
+			}
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, list(t))
+	})
+	t.Run("rollback to savepoint", func(t *testing.T) {
+		savepoint(t)
+		defer cleanup(t)
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			if err := create(ctx, "a"); err != nil {
+				return err
+			}
+			err := client.Transaction(ctx, func(tx *gorm.DB) error {
+				if err := tx.Table(table).Create(&transactionItem{ID: "b"}).Error; err != nil {
+					return err
+				}
+				return assert.AnError
+			})
+			assert.ErrorIs(t, err, assert.AnError)
+			return client.RunInTransaction(ctx, func(ctx context.Context) error {
+				return create(ctx, "c")
+			})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "c"}, list(t))
+	})
+	t.Run("rollback outer transaction", func(t *testing.T) {
+		savepoint(t)
+		defer cleanup(t)
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+				return create(ctx, "a")
+			})
+			if err != nil {
+				return err
+			}
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, list(t))
+	})
+	t.Run("outside transaction", func(t *testing.T) {
+		assert.False(t, InTransaction(ctx))
+	})
+}
+
+func TestReadReplica(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	// 参照先を判別できるよう、テーブルの存在しないデータベースをリードレプリカとする
+	replica := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: "information_schema",
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params, WithReplicas(replica))
+	require.NoError(t, err)
+	t.Run("read from replica", func(t *testing.T) {
+		_, err := client.Count(ctx, client.DB, &struct{}{}, func(db *gorm.DB) *gorm.DB {
+			return db.Table("admins")
+		})
+		assert.Error(t, err)
+	})
+	t.Run("read from primary", func(t *testing.T) {
+		_, err := client.Count(ReadFromPrimary(ctx), client.DB, &struct{}{}, func(db *gorm.DB) *gorm.DB {
+			return db.Table("admins")
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("read in transaction", func(t *testing.T) {
+		err := client.Transaction(ctx, func(tx *gorm.DB) error {
+			var admins []map[string]interface{}
+			return client.Statement(ctx, tx, "admins").Find(&admins).Error
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("failed to connect replica", func(t *testing.T) {
+		replica := &Params{Socket: "tcp", Host: "127.0.0.1", Port: "80"}
+		client, err := NewClient(params, WithReplicas(replica))
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params, WithMetrics(true), WithMaxOpenConns(10))
+	require.NoError(t, err)
+	// 同じデータベースに対して複数のクライアントを生成できる
+	_, err = NewClient(params, WithMetrics(true))
+	require.NoError(t, err)
+
+	var admins []map[string]interface{}
+	err = client.Statement(ctx, client.DB, "admins").Find(&admins).Error
+	require.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "go_sql_max_open_connections")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+	count, err = testutil.GatherAndCount(prometheus.DefaultGatherer, "mysql_query_duration_seconds")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+}
+
+func TestTracing(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client, err := NewClient(params, WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	ctx, parent := tp.Tracer("test").Start(ctx, "parent")
+	var admins []map[string]interface{}
+	err = client.Statement(ctx, client.DB, "admins").Where("id = ?", "admin-id").Find(&admins).Error
+	require.NoError(t, err)
+	err = client.Statement(ctx, client.DB, "not_found").Find(&admins).Error
+	require.Error(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "mysql.query admins", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Contains(t, spans[0].Attributes, semconv.DBSystemMySQL)
+	assert.Contains(t, spans[0].Attributes, semconv.DBName(params.Database))
+	assert.Contains(t, spans[0].Attributes, semconv.DBSQLTable("admins"))
+	assert.Contains(t, spans[0].Attributes, semconv.DBStatement("SELECT * FROM `admins` WHERE id = ?"))
+	assert.Equal(t, "mysql.query not_found", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
+
+func TestReadFromPrimary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.False(t, readFromPrimary(ctx))
+	assert.True(t, readFromPrimary(ReadFromPrimary(ctx)))
+}
+
 func TestNewDSN(t *testing.T) {
 	t.Parallel()
 	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+const (
+	metricsStartedAtKey = "metrics:started_at"
+	unknownTable        = "unknown"
+)
+
+var queryHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "mysql_query_duration_seconds",
+	Help:    "Histogram of latency (seconds) of queries executed on MySQL.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"table", "operation"})
+
+// metricsPlugin - クエリのレイテンシをテーブル・操作単位で計測するGORMのプラグイン
+type metricsPlugin struct{}
+
+type registerFunc func(name string, fn func(*gorm.DB)) error
+
+func (p *metricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *metricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	operations := []struct {
+		name   string
+		before registerFunc
+		after  registerFunc
+	}{
+		{name: "create", before: cb.Create().Before("*").Register, after: cb.Create().After("*").Register},
+		{name: "query", before: cb.Query().Before("*").Register, after: cb.Query().After("*").Register},
+		{name: "update", before: cb.Update().Before("*").Register, after: cb.Update().After("*").Register},
+		{name: "delete", before: cb.Delete().Before("*").Register, after: cb.Delete().After("*").Register},
+		{name: "row", before: cb.Row().Before("*").Register, after: cb.Row().After("*").Register},
+		{name: "raw", before: cb.Raw().Before("*").Register, after: cb.Raw().After("*").Register},
+	}
+	for _, op := range operations {
+		if err := op.before("metrics:before_"+op.name, p.before); err != nil {
+			return err
+		}
+		if err := op.after("metrics:after_"+op.name, p.after(op.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *metricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartedAtKey, time.Now())
+}
+
+func (p *metricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(metricsStartedAtKey)
+		if !ok {
+			return
+		}
+		startedAt, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = unknownTable
+		}
+		queryHistogram.WithLabelValues(table, operation).Observe(time.Since(startedAt).Seconds())
+	}
+}
+
+// registerDBStats - コネクションプールの統計情報を登録
+func registerDBStats(db *sql.DB, name string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, name))
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil // 同じデータベースに対して複数のクライアントを生成した場合
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidMigration      = errors.New("mysql: invalid migration")
+	ErrMigrationModified     = errors.New("mysql: applied migration has been modified")
+	ErrMigrationNotFound     = errors.New("mysql: migration not found")
+	ErrMigrationLocked       = errors.New("mysql: failed to acquire migration lock")
+	ErrMigrationDirty        = errors.New("mysql: migration has been interrupted")
+	ErrMigrationIrreversible = errors.New("mysql: migration is irreversible")
+)
+
+const (
+	migrationUpDir   = "up"   // 適用用のスクリプトを配置するディレクトリ
+	migrationDownDir = "down" // ロールバック用のスクリプトを配置するディレクトリ
+)
+
+// migrationFilePattern - マイグレーションファイルの命名規則 (e.g. 2023091302-create-admin.sql)
+var migrationFilePattern = regexp.MustCompile(`^(\d+)-([0-9A-Za-z_-]+)\.sql$`)
+
+// Migration - スキーマのマイグレーション
+type Migration struct {
+	Version  string // バージョン (ファイル名の接頭辞)
+	Name     string // マイグレーション名
+	Up       string // 適用用のスクリプト
+	Down     string // ロールバック用のスクリプト
+	Checksum string // 適用用のスクリプトのハッシュ値 (SHA-256)
+	HasDown  bool   // ロールバック用のスクリプトの有無
+	// 取り消し不可 (ロールバック用のスクリプトがコメントのみで、実行するステートメントを含まない)
+	Irreversible bool
+}
+
+type Migrations []*Migration
+
+// LoadMigrations - マイグレーションファイルの読み込み
+// 適用用のスクリプトはupディレクトリ、ロールバック用のスクリプトはdownディレクトリに同じファイル名で配置する
+// 取り消しできないマイグレーションは、理由をコメントで記載したステートメントのないロールバック用のスクリプトを配置する
+func LoadMigrations(fsys fs.FS) (Migrations, error) {
+	entries, err := fs.ReadDir(fsys, migrationUpDir)
+	if err != nil {
+		return nil, err
+	}
+	res := make(Migrations, 0, len(entries))
+	versions := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("%w: invalid file name: %s", ErrInvalidMigration, entry.Name())
+		}
+		if name, ok := versions[matches[1]]; ok {
+			return nil, fmt.Errorf("%w: duplicate version: %s, %s", ErrInvalidMigration, name, entry.Name())
+		}
+		versions[matches[1]] = entry.Name()
+		up, err := fs.ReadFile(fsys, path.Join(migrationUpDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration := &Migration{
+			Version:  matches[1],
+			Name:     matches[2],
+			Up:       string(up),
+			Checksum: checksum(up),
+		}
+		down, err := fs.ReadFile(fsys, path.Join(migrationDownDir, entry.Name()))
+		switch {
+		case err == nil:
+			migration.Down = string(down)
+			migration.HasDown = true
+			migration.Irreversible = len(splitStatements(migration.Down)) == 0
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+		res = append(res, migration)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+	return res, nil
+}
+
+func (m *Migration) String() string {
+	return fmt.Sprintf("%s-%s", m.Version, m.Name)
+}
+
+func (ms Migrations) Map() map[string]*Migration {
+	res := make(map[string]*Migration, len(ms))
+	for _, m := range ms {
+		res[m.Version] = m
+	}
+	return res
+}
+
+func checksum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// splitStatements - スクリプトをステートメント単位に分割
+// 文字列・識別子・コメント内のセミコロンは区切り文字として扱わず、コメントは除外する
+func splitStatements(script string) []string {
+	var (
+		res   []string
+		buf   strings.Builder
+		quote rune
+	)
+	flush := func() {
+		if stmt := strings.TrimSpace(buf.String()); stmt != "" {
+			res = append(res, stmt)
+		}
+		buf.Reset()
+	}
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if quote != 0 {
+			buf.WriteRune(r)
+			switch {
+			case r == '\\' && quote != '`' && i+1 < len(runes):
+				i++
+				buf.WriteRune(runes[i])
+			case r == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			buf.WriteRune(r)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			buf.WriteRune('\n')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/') {
+				i++
+			}
+			i++
+			buf.WriteRune(' ')
+		case r == ';':
+			flush()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	flush()
+	return res
+}
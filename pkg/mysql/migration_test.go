@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		expect Migrations
+		err    error
+	}{
+		{
+			name: "success",
+			fsys: fstest.MapFS{
+				"up/2023100203-setup.sql":          {Data: []byte("SET @a = 1;")},
+				"down/2023100203-setup.sql":        {Data: []byte("-- irreversible\n")},
+				"up/2023100202-add-column.sql":     {Data: []byte("ALTER TABLE `t` ADD COLUMN `c` INT;")},
+				"up/2023100201-create-table.sql":   {Data: []byte("CREATE TABLE `t` (`id` INT);")},
+				"down/2023100201-create-table.sql": {Data: []byte("DROP TABLE `t`;")},
+				"up/README.md":                     {Data: []byte("readme")},
+			},
+			expect: Migrations{
+				{
+					Version:  "2023100201",
+					Name:     "create-table",
+					Up:       "CREATE TABLE `t` (`id` INT);",
+					Down:     "DROP TABLE `t`;",
+					Checksum: checksum([]byte("CREATE TABLE `t` (`id` INT);")),
+					HasDown:  true,
+				},
+				{
+					Version:  "2023100202",
+					Name:     "add-column",
+					Up:       "ALTER TABLE `t` ADD COLUMN `c` INT;",
+					Checksum: checksum([]byte("ALTER TABLE `t` ADD COLUMN `c` INT;")),
+				},
+				{
+					Version:      "2023100203",
+					Name:         "setup",
+					Up:           "SET @a = 1;",
+					Down:         "-- irreversible\n",
+					Checksum:     checksum([]byte("SET @a = 1;")),
+					HasDown:      true,
+					Irreversible: true,
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid file name",
+			fsys: fstest.MapFS{
+				"up/create-table.sql": {Data: []byte("CREATE TABLE `t` (`id` INT);")},
+			},
+			expect: nil,
+			err:    ErrInvalidMigration,
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"up/2023100201-create-table.sql": {Data: []byte("CREATE TABLE `t` (`id` INT);")},
+				"up/2023100201-add-column.sql":   {Data: []byte("ALTER TABLE `t` ADD COLUMN `c` INT;")},
+			},
+			expect: nil,
+			err:    ErrInvalidMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := LoadMigrations(tt.fsys)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		script string
+		expect []string
+	}{
+		{
+			name: "multiple statements",
+			script: "CREATE TABLE `t` (\n" +
+				"  `id` INT NOT NULL, -- ID; 主キー\n" +
+				"  PRIMARY KEY(`id`)\n" +
+				");\n\n" +
+				"CREATE INDEX `idx_t_id` ON `t` (`id` ASC);\n",
+			expect: []string{
+				"CREATE TABLE `t` (\n  `id` INT NOT NULL, \n  PRIMARY KEY(`id`)\n)",
+				"CREATE INDEX `idx_t_id` ON `t` (`id` ASC)",
+			},
+		},
+		{
+			name:   "semicolon in string",
+			script: "INSERT INTO `t` (`name`) VALUES ('a;b'), (\"c\\\";d\");",
+			expect: []string{"INSERT INTO `t` (`name`) VALUES ('a;b'), (\"c\\\";d\")"},
+		},
+		{
+			name:   "block comment",
+			script: "/* comment; */ DROP TABLE `t`; # comment;",
+			expect: []string{"DROP TABLE `t`"},
+		},
+		{
+			name:   "without trailing semicolon",
+			script: "DROP TABLE `t`",
+			expect: []string{"DROP TABLE `t`"},
+		},
+		{
+			name:   "only comments",
+			script: "-- nothing to do\n",
+			expect: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, splitStatements(tt.script))
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// MigrationState - マイグレーションの適用状況
+type MigrationState string
+
+const (
+	MigrationStatePending  MigrationState = "pending"  // 未適用
+	MigrationStateApplied  MigrationState = "applied"  // 適用済み
+	MigrationStateModified MigrationState = "modified" // 適用済み (適用後にスクリプトが変更された)
+	MigrationStateMissing  MigrationState = "missing"  // 適用済み (スクリプトが存在しない)
+	MigrationStateDirty    MigrationState = "dirty"    // 適用・取り消しの途中で中断された
+)
+
+// SchemaDiffKind - エンティティとスキーマの差異の種別
+type SchemaDiffKind string
+
+const (
+	SchemaDiffMissingTable   SchemaDiffKind = "missing_table"   // テーブルが存在しない
+	SchemaDiffMissingColumn  SchemaDiffKind = "missing_column"  // エンティティのカラムが存在しない
+	SchemaDiffUnmappedColumn SchemaDiffKind = "unmapped_column" // エンティティに対応しないカラムが存在する
+)
+
+// MigrationStatus - マイグレーションの適用状況
+type MigrationStatus struct {
+	Version   string
+	Name      string
+	State     MigrationState
+	AppliedAt time.Time
+}
+
+// SchemaDiff - エンティティとスキーマの差異
+type SchemaDiff struct {
+	Table  string
+	Column string
+	Kind   SchemaDiffKind
+}
+
+// Migrator - スキーマのマイグレーションを行う構造体
+type Migrator struct {
+	client      *Client
+	migrations  Migrations
+	logger      *zap.Logger
+	now         func() time.Time
+	table       string
+	lockName    string
+	lockTimeout time.Duration
+}
+
+type appliedMigration struct {
+	Version   string    `gorm:"primaryKey"`
+	Name      string    `gorm:""`
+	Checksum  string    `gorm:""`
+	Dirty     bool      `gorm:""`
+	AppliedAt time.Time `gorm:""`
+}
+
+type migratorOptions struct {
+	logger      *zap.Logger
+	now         func() time.Time
+	table       string
+	lockTimeout time.Duration
+}
+
+type MigratorOption func(opts *migratorOptions)
+
+func WithMigrationLogger(logger *zap.Logger) MigratorOption {
+	return func(opts *migratorOptions) {
+		opts.logger = logger
+	}
+}
+
+func WithMigrationNow(now func() time.Time) MigratorOption {
+	return func(opts *migratorOptions) {
+		opts.now = now
+	}
+}
+
+// WithMigrationTable - 適用履歴を管理するテーブル名
+func WithMigrationTable(table string) MigratorOption {
+	return func(opts *migratorOptions) {
+		opts.table = table
+	}
+}
+
+// WithMigrationLockTimeout - ロックの取得を待機する時間
+func WithMigrationLockTimeout(timeout time.Duration) MigratorOption {
+	return func(opts *migratorOptions) {
+		opts.lockTimeout = timeout
+	}
+}
+
+// NewMigrator - マイグレーション用の構造体
+func NewMigrator(client *Client, migrations Migrations, opts ...MigratorOption) *Migrator {
+	dopts := &migratorOptions{
+		logger:      zap.NewNop(),
+		now:         time.Now,
+		table:       "schema_migrations",
+		lockTimeout: time.Minute,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &Migrator{
+		client:      client,
+		migrations:  migrations,
+		logger:      dopts.logger,
+		now:         dopts.now,
+		table:       dopts.table,
+		lockName:    dopts.table,
+		lockTimeout: dopts.lockTimeout,
+	}
+}
+
+// Up - 未適用のマイグレーションを適用 (steps: 適用する件数、0以下の場合はすべて)
+// MySQLのDDLはトランザクションで取り消せないため、実行前に適用中として記録し、成功した時点で適用済みに更新する
+func (m *Migrator) Up(ctx context.Context, steps int) (Migrations, error) {
+	var res Migrations
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.validate(applied); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if steps > 0 && len(res) >= steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.insert(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			if err := m.exec(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("mysql: failed to apply migration %s: %w", migration, err)
+			}
+			if err := m.markDirty(ctx, conn, migration.Version, false); err != nil {
+				return err
+			}
+			m.logger.Info("Applied migration", zap.String("version", migration.Version), zap.String("name", migration.Name))
+			res = append(res, migration)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Down - 適用済みのマイグレーションを新しいものから順に取り消し (steps: 取り消す件数、0以下の場合は1件)
+// 取り消し不可のマイグレーションが含まれる場合は、いずれも実行せずにエラーを返す
+func (m *Migrator) Down(ctx context.Context, steps int) (Migrations, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	var res Migrations
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.validate(applied); err != nil {
+			return err
+		}
+		versions := make([]string, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+		if len(versions) > steps {
+			versions = versions[:steps]
+		}
+		migrations := m.migrations.Map()
+		targets := make(Migrations, 0, len(versions))
+		for _, version := range versions {
+			migration, ok := migrations[version]
+			if !ok {
+				return fmt.Errorf("%w: version=%s", ErrMigrationNotFound, version)
+			}
+			if !migration.HasDown {
+				return fmt.Errorf("%w: down script is not found: %s", ErrMigrationNotFound, migration)
+			}
+			if migration.Irreversible {
+				return fmt.Errorf("%w: %s", ErrMigrationIrreversible, migration)
+			}
+			targets = append(targets, migration)
+		}
+		for _, migration := range targets {
+			if err := m.markDirty(ctx, conn, migration.Version, true); err != nil {
+				return err
+			}
+			if err := m.exec(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("mysql: failed to roll back migration %s: %w", migration, err)
+			}
+			if err := m.delete(ctx, conn, migration.Version); err != nil {
+				return err
+			}
+			m.logger.Info("Rolled back migration", zap.String("version", migration.Version), zap.String("name", migration.Name))
+			res = append(res, migration)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Baseline - 指定したバージョンまでのマイグレーションを、スクリプトを実行せずに適用済みとして記録
+// 適用履歴の管理を始める前に作成されたデータベースで使用する
+func (m *Migrator) Baseline(ctx context.Context, version string) (Migrations, error) {
+	if _, ok := m.migrations.Map()[version]; !ok {
+		return nil, fmt.Errorf("%w: version=%s", ErrMigrationNotFound, version)
+	}
+	var res Migrations
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.insert(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			res = append(res, migration)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Resolve - 中断されたマイグレーションの状態を確定
+// スキーマを手動で適用後の状態に揃えた場合はapplied=true、適用前の状態に戻した場合はapplied=falseを指定する
+func (m *Migrator) Resolve(ctx context.Context, version string, applied bool) (*Migration, error) {
+	migration, ok := m.migrations.Map()[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: version=%s", ErrMigrationNotFound, version)
+	}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		records, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if a, ok := records[version]; !ok || !a.Dirty {
+			return fmt.Errorf("%w: migration is not interrupted: %s", ErrMigrationNotFound, migration)
+		}
+		if !applied {
+			return m.delete(ctx, conn, version)
+		}
+		return m.markDirty(ctx, conn, version, false)
+	})
+	return migration, err
+}
+
+// Status - マイグレーションの適用状況の一覧
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	applied := map[string]*appliedMigration{}
+	if m.client.DB.WithContext(ctx).Migrator().HasTable(m.table) {
+		var migrations []*appliedMigration
+		if err := m.client.DB.WithContext(ctx).Table(m.table).Find(&migrations).Error; err != nil {
+			return nil, err
+		}
+		for _, migration := range migrations {
+			applied[migration.Version] = migration
+		}
+	}
+	res := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			State:   MigrationStatePending,
+		}
+		if a, ok := applied[migration.Version]; ok {
+			status.State = MigrationStateApplied
+			status.AppliedAt = a.AppliedAt
+			if a.Checksum != migration.Checksum {
+				status.State = MigrationStateModified
+			}
+			if a.Dirty {
+				status.State = MigrationStateDirty
+			}
+			delete(applied, migration.Version)
+		}
+		res = append(res, status)
+	}
+	for _, a := range applied {
+		state := MigrationStateMissing
+		if a.Dirty {
+			state = MigrationStateDirty
+		}
+		res = append(res, &MigrationStatus{
+			Version:   a.Version,
+			Name:      a.Name,
+			State:     state,
+			AppliedAt: a.AppliedAt,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+	return res, nil
+}
+
+// Verify - エンティティ (テーブル名: GORMのモデル) とデータベースのスキーマの差異の検証
+func (m *Migrator) Verify(ctx context.Context, models map[string]interface{}) ([]*SchemaDiff, error) {
+	tables := make([]string, 0, len(models))
+	for table := range models {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	db := m.client.DB.WithContext(ctx)
+	res := make([]*SchemaDiff, 0)
+	for _, table := range tables {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(models[table]); err != nil {
+			return nil, err
+		}
+		if !db.Migrator().HasTable(table) {
+			res = append(res, &SchemaDiff{Table: table, Kind: SchemaDiffMissingTable})
+			continue
+		}
+		columnTypes, err := db.Migrator().ColumnTypes(table)
+		if err != nil {
+			return nil, err
+		}
+		columns := make(map[string]bool, len(columnTypes))
+		for _, c := range columnTypes {
+			columns[c.Name()] = true
+		}
+		fields := make(map[string]bool, len(stmt.Schema.DBNames))
+		for _, name := range stmt.Schema.DBNames {
+			fields[name] = true
+			if !columns[name] {
+				res = append(res, &SchemaDiff{Table: table, Column: name, Kind: SchemaDiffMissingColumn})
+			}
+		}
+		for _, c := range columnTypes {
+			if !fields[c.Name()] {
+				res = append(res, &SchemaDiff{Table: table, Column: c.Name(), Kind: SchemaDiffUnmappedColumn})
+			}
+		}
+	}
+	return res, nil
+}
+
+// withLock - 複数のプロセスから同時に実行されないよう、ロックを取得して実行
+// ロックは接続単位のため、ロックの取得からマイグレーションの実行まで同じ接続を使用する
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	db, err := m.client.DB.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", m.lockName, int(m.lockTimeout.Seconds())).Scan(&locked)
+	if err != nil {
+		return err
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("%w: name=%s", ErrMigrationLocked, m.lockName)
+	}
+	defer func() {
+		var released sql.NullInt64
+		err := conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK(?)", m.lockName).Scan(&released)
+		if err != nil {
+			m.logger.Warn("Failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	const query = "CREATE TABLE IF NOT EXISTS `%s` (" +
+		"`version` VARCHAR(32) NOT NULL, " +
+		"`name` VARCHAR(256) NOT NULL, " +
+		"`checksum` CHAR(64) NOT NULL, " +
+		"`dirty` TINYINT(1) NOT NULL DEFAULT 0, " +
+		"`applied_at` DATETIME(3) NOT NULL, " +
+		"PRIMARY KEY(`version`))"
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(query, m.table)); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[string]*appliedMigration, error) {
+	query := fmt.Sprintf("SELECT `version`, `name`, `checksum`, `dirty`, `applied_at` FROM `%s`", m.table)
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make(map[string]*appliedMigration)
+	for rows.Next() {
+		a := &appliedMigration{}
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.Dirty, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		res[a.Version] = a
+	}
+	return res, rows.Err()
+}
+
+// validate - 中断されたマイグレーションがないか、適用済みのスクリプトが変更されていないかの検証
+// 中断されたマイグレーションは、スキーマを手動で修正したうえでResolveで状態を確定させるまで実行しない
+func (m *Migrator) validate(applied map[string]*appliedMigration) error {
+	for _, a := range applied {
+		if a.Dirty {
+			return fmt.Errorf("%w: version=%s, name=%s", ErrMigrationDirty, a.Version, a.Name)
+		}
+	}
+	for _, migration := range m.migrations {
+		a, ok := applied[migration.Version]
+		if !ok || a.Checksum == migration.Checksum {
+			continue
+		}
+		return fmt.Errorf("%w: %s", ErrMigrationModified, migration)
+	}
+	return nil
+}
+
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, stmt := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) insert(ctx context.Context, conn *sql.Conn, migration *Migration, dirty bool) error {
+	query := fmt.Sprintf("INSERT INTO `%s` (`version`, `name`, `checksum`, `dirty`, `applied_at`) VALUES (?, ?, ?, ?, ?)", m.table)
+	_, err := conn.ExecContext(ctx, query, migration.Version, migration.Name, migration.Checksum, dirty, m.now())
+	return err
+}
+
+func (m *Migrator) markDirty(ctx context.Context, conn *sql.Conn, version string, dirty bool) error {
+	query := fmt.Sprintf("UPDATE `%s` SET `dirty` = ?, `applied_at` = ? WHERE `version` = ?", m.table)
+	_, err := conn.ExecContext(ctx, query, dirty, m.now(), version)
+	return err
+}
+
+func (m *Migrator) delete(ctx context.Context, conn *sql.Conn, version string) error {
+	query := fmt.Sprintf("DELETE FROM `%s` WHERE `version` = ?", m.table)
+	_, err := conn.ExecContext(ctx, query, version)
+	return err
+}
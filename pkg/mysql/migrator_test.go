@@ -0,0 +1,218 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTestItem struct {
+	ID        string    `gorm:"primaryKey"`
+	Name      string    `gorm:""`
+	CreatedAt time.Time `gorm:""`
+}
+
+func TestMigrator(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params)
+	require.NoError(t, err)
+
+	const table = "test_schema_migrations"
+	cleanup := func() {
+		client.DB.Exec("DROP TABLE IF EXISTS `migration_test_items`")
+		client.DB.Exec("DROP TABLE IF EXISTS `" + table + "`")
+	}
+	cleanup()
+	defer cleanup()
+
+	fsys := fstest.MapFS{
+		"up/2023100201-create-items.sql": {Data: []byte(
+			"CREATE TABLE `migration_test_items` (\n" +
+				"  `id` VARCHAR(22) NOT NULL, -- ID\n" +
+				"  `created_at` DATETIME(3) NOT NULL, -- 登録日時\n" +
+				"  PRIMARY KEY(`id`)\n" +
+				");\n",
+		)},
+		"down/2023100201-create-items.sql": {Data: []byte("DROP TABLE `migration_test_items`;")},
+		"up/2023100202-add-items-name.sql": {Data: []byte(
+			"ALTER TABLE `migration_test_items` ADD COLUMN `name` VARCHAR(64) NULL DEFAULT NULL;\n" +
+				"ALTER TABLE `migration_test_items` ADD COLUMN `memo` TEXT NULL DEFAULT NULL;\n",
+		)},
+		"down/2023100202-add-items-name.sql": {Data: []byte(
+			"ALTER TABLE `migration_test_items` DROP COLUMN `memo`;\n" +
+				"ALTER TABLE `migration_test_items` DROP COLUMN `name`;\n",
+		)},
+	}
+	migrations, err := LoadMigrations(fsys)
+	require.NoError(t, err)
+	migrator := NewMigrator(client, migrations,
+		WithMigrationTable(table),
+		WithMigrationLockTimeout(time.Second),
+	)
+	models := map[string]interface{}{"migration_test_items": &migrationTestItem{}}
+	states := func(t *testing.T) []MigrationState {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		res := make([]MigrationState, len(statuses))
+		for i := range statuses {
+			res[i] = statuses[i].State
+		}
+		return res
+	}
+
+	// 適用履歴のテーブルが作成される前
+	assert.Equal(t, []MigrationState{MigrationStatePending, MigrationStatePending}, states(t))
+	diffs, err := migrator.Verify(ctx, models)
+	require.NoError(t, err)
+	assert.Equal(t, []*SchemaDiff{{Table: "migration_test_items", Kind: SchemaDiffMissingTable}}, diffs)
+
+	// 1件ずつ適用
+	applied, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "2023100201", applied[0].Version)
+	assert.Equal(t, []MigrationState{MigrationStateApplied, MigrationStatePending}, states(t))
+	diffs, err = migrator.Verify(ctx, models)
+	require.NoError(t, err)
+	assert.Equal(t, []*SchemaDiff{{Table: "migration_test_items", Column: "name", Kind: SchemaDiffMissingColumn}}, diffs)
+
+	// 残りをすべて適用
+	applied, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "2023100202", applied[0].Version)
+	assert.Equal(t, []MigrationState{MigrationStateApplied, MigrationStateApplied}, states(t))
+	diffs, err = migrator.Verify(ctx, models)
+	require.NoError(t, err)
+	assert.Equal(t, []*SchemaDiff{{Table: "migration_test_items", Column: "memo", Kind: SchemaDiffUnmappedColumn}}, diffs)
+
+	// 適用済みの場合は何もしない
+	applied, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// 他のプロセスがロックを取得している場合
+	t.Run("locked", func(t *testing.T) {
+		db, err := client.DB.DB()
+		require.NoError(t, err)
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		var locked sql.NullInt64
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", table).Scan(&locked))
+		defer func() {
+			_ = conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", table).Scan(&locked)
+		}()
+		migrator := NewMigrator(client, migrations, WithMigrationTable(table), WithMigrationLockTimeout(0))
+		_, err = migrator.Up(ctx, 0)
+		assert.ErrorIs(t, err, ErrMigrationLocked)
+	})
+
+	// 適用済みのスクリプトが変更された場合
+	t.Run("modified", func(t *testing.T) {
+		modified := fstest.MapFS{}
+		for name, file := range fsys {
+			modified[name] = file
+		}
+		modified["up/2023100201-create-items.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+		migrations, err := LoadMigrations(modified)
+		require.NoError(t, err)
+		migrator := NewMigrator(client, migrations, WithMigrationTable(table))
+		_, err = migrator.Up(ctx, 0)
+		assert.ErrorIs(t, err, ErrMigrationModified)
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationStateModified, statuses[0].State)
+	})
+
+	// 実行中に失敗した場合は中断されたものとして記録し、状態を確定させるまで実行しない
+	t.Run("interrupted", func(t *testing.T) {
+		broken := fstest.MapFS{}
+		for name, file := range fsys {
+			broken[name] = file
+		}
+		broken["up/2023100203-add-items-flag.sql"] = &fstest.MapFile{Data: []byte(
+			"ALTER TABLE `migration_test_items` ADD COLUMN `flag` INT NULL DEFAULT NULL;\n" +
+				"ALTER TABLE `migration_test_missing_items` ADD COLUMN `flag` INT NULL DEFAULT NULL;\n",
+		)}
+		broken["down/2023100203-add-items-flag.sql"] = &fstest.MapFile{Data: []byte(
+			"ALTER TABLE `migration_test_items` DROP COLUMN `flag`;\n",
+		)}
+		migrations, err := LoadMigrations(broken)
+		require.NoError(t, err)
+		migrator := NewMigrator(client, migrations, WithMigrationTable(table))
+		_, err = migrator.Up(ctx, 0)
+		assert.Error(t, err)
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationStateDirty, statuses[2].State)
+
+		_, err = migrator.Up(ctx, 0)
+		assert.ErrorIs(t, err, ErrMigrationDirty)
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorIs(t, err, ErrMigrationDirty)
+		_, err = migrator.Resolve(ctx, "2023100202", true)
+		assert.ErrorIs(t, err, ErrMigrationNotFound)
+
+		// 手動で適用前の状態に戻してから確定させる
+		require.NoError(t, client.DB.Exec("ALTER TABLE `migration_test_items` DROP COLUMN `flag`").Error)
+		resolved, err := migrator.Resolve(ctx, "2023100203", false)
+		require.NoError(t, err)
+		assert.Equal(t, "2023100203", resolved.Version)
+		statuses, err = migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationStatePending, statuses[2].State)
+	})
+
+	// 取り消し不可のマイグレーションが含まれる場合
+	t.Run("irreversible", func(t *testing.T) {
+		irreversible := fstest.MapFS{}
+		for name, file := range fsys {
+			irreversible[name] = file
+		}
+		irreversible["down/2023100202-add-items-name.sql"] = &fstest.MapFile{Data: []byte("-- 取り消しできない\n")}
+		migrations, err := LoadMigrations(irreversible)
+		require.NoError(t, err)
+		migrator := NewMigrator(client, migrations, WithMigrationTable(table))
+		rolledBack, err := migrator.Down(ctx, 2)
+		assert.ErrorIs(t, err, ErrMigrationIrreversible)
+		assert.Empty(t, rolledBack)
+	})
+	assert.Equal(t, []MigrationState{MigrationStateApplied, MigrationStateApplied}, states(t))
+
+	// 新しいものから1件ずつ取り消し
+	rolledBack, err := migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Equal(t, "2023100202", rolledBack[0].Version)
+	assert.Equal(t, []MigrationState{MigrationStateApplied, MigrationStatePending}, states(t))
+	rolledBack, err = migrator.Down(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Equal(t, []MigrationState{MigrationStatePending, MigrationStatePending}, states(t))
+
+	// スクリプトを実行せずに適用済みとして記録
+	recorded, err := migrator.Baseline(ctx, "2023100201")
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, []MigrationState{MigrationStateApplied, MigrationStatePending}, states(t))
+	assert.False(t, client.DB.Migrator().HasTable("migration_test_items"))
+	_, err = migrator.Baseline(ctx, "2023100203")
+	assert.ErrorIs(t, err, ErrMigrationNotFound)
+}
@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidKeyset = errors.New("mysql: invalid keyset")
+
+// defaultPrimaryKey - ソート順を一意にするために末尾に追加するカラムのデフォルト値
+const defaultPrimaryKey = "id"
+
+// Order - ソートキー
+type Order struct {
+	Column string // カラム名
+	Desc   bool   // 降順
+}
+
+// Keyset - キーセット (カーソル) ページングの条件
+// ソートキーの値が同じ行の順序を一意にするため、末尾に主キーを追加してソートする
+// ソートキーにNULLを含むカラムは指定できない
+type Keyset struct {
+	Orders     []*Order      // ソートキー
+	PrimaryKey string        // 主キー (未指定の場合はid)
+	After      []interface{} // 前ページの最終行のソートキーと主キーの値 (未指定の場合は先頭ページ)
+	Limit      int           // 取得上限 (0以下の場合は上限なし)
+}
+
+// Paginate - キーセットページングの条件 (WHERE, ORDER BY, LIMIT) を付与するスコープ
+// 次ページの有無を判定するため、取得上限より1件多く取得する
+func (c *Client) Paginate(ks *Keyset) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		orders := ks.orders()
+		if len(ks.After) > 0 {
+			if len(ks.After) != len(orders) {
+				_ = db.AddError(fmt.Errorf("%w: expected %d values, but got %d", ErrInvalidKeyset, len(orders), len(ks.After)))
+				return db
+			}
+			db = db.Where(keysetCondition(orders, ks.After))
+		}
+		for _, o := range orders {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: o.Column}, Desc: o.Desc})
+		}
+		if ks.Limit > 0 {
+			db = db.Limit(ks.Limit + 1)
+		}
+		return db
+	}
+}
+
+// NextKeyset - 取得結果が上限を超えている場合は超過分を除外し、次ページの開始位置 (最終行のソートキーと主キーの値) を返す
+// rowsには取得結果のスライスのポインタを指定し、次ページが存在しない場合はnilを返す
+func (c *Client) NextKeyset(ctx context.Context, ks *Keyset, rows interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: rows must be a pointer to slice", ErrInvalidKeyset)
+	}
+	slice := rv.Elem()
+	if ks.Limit <= 0 || slice.Len() <= ks.Limit {
+		return nil, nil
+	}
+	slice.Set(slice.Slice(0, ks.Limit))
+
+	last := slice.Index(ks.Limit - 1)
+	stmt := &gorm.Statement{DB: c.DB.WithContext(ctx)}
+	if err := stmt.Parse(last.Interface()); err != nil {
+		return nil, err
+	}
+	orders := ks.orders()
+	res := make([]interface{}, len(orders))
+	for i, o := range orders {
+		column := o.Column
+		if idx := strings.LastIndex(column, "."); idx >= 0 {
+			column = column[idx+1:]
+		}
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			return nil, fmt.Errorf("%w: unknown column: %s", ErrInvalidKeyset, o.Column)
+		}
+		res[i], _ = field.ValueOf(ctx, last)
+	}
+	return res, nil
+}
+
+// orders - 主キーを末尾に追加したソートキー (主キーは最後のソートキーと同じ順序とする)
+// ソートキーに主キーが含まれている場合は、それ以降のソートキーは順序に影響しないため除外する
+func (ks *Keyset) orders() []*Order {
+	primaryKey := ks.PrimaryKey
+	if primaryKey == "" {
+		primaryKey = defaultPrimaryKey
+	}
+	res := make([]*Order, 0, len(ks.Orders)+1)
+	var desc bool
+	for _, o := range ks.Orders {
+		res = append(res, o)
+		if o.Column == primaryKey {
+			return res
+		}
+		desc = o.Desc
+	}
+	return append(res, &Order{Column: primaryKey, Desc: desc})
+}
+
+// keysetCondition - 前ページの最終行より後ろの行を取得する条件
+// e.g. (a > ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND id < ?)
+func keysetCondition(orders []*Order, values []interface{}) clause.Expression {
+	ors := make([]clause.Expression, len(orders))
+	for i, o := range orders {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: clause.Column{Name: orders[j].Column}, Value: values[j]})
+		}
+		column := clause.Column{Name: o.Column}
+		if o.Desc {
+			ands = append(ands, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: column, Value: values[i]})
+		}
+		ors[i] = clause.And(ands...)
+	}
+	return clause.Or(ors...)
+}
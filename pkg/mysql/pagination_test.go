@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type paginationItem struct {
+	ID        string    `gorm:"primaryKey"`
+	Score     int64     `gorm:""`
+	CreatedAt time.Time `gorm:""`
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "root:@tcp(127.0.0.1:3306)/test",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	client := &Client{DB: db}
+	tests := []struct {
+		name   string
+		keyset *Keyset
+		expect string
+		isErr  bool
+	}{
+		{
+			name: "first page",
+			keyset: &Keyset{
+				Orders: []*Order{{Column: "score", Desc: true}},
+				Limit:  20,
+			},
+			expect: "SELECT * FROM `items` ORDER BY `score` DESC,`id` DESC LIMIT 21",
+		},
+		{
+			name: "next page",
+			keyset: &Keyset{
+				Orders: []*Order{{Column: "score"}, {Column: "created_at", Desc: true}},
+				After:  []interface{}{int64(10), "2023-10-05 00:00:00", "item-id"},
+				Limit:  20,
+			},
+			expect: "SELECT * FROM `items` WHERE (`score` > 10 OR (`score` = 10 AND `created_at` < '2023-10-05 00:00:00') OR " +
+				"(`score` = 10 AND `created_at` = '2023-10-05 00:00:00' AND `id` < 'item-id')) " +
+				"ORDER BY `score`,`created_at` DESC,`id` DESC LIMIT 21",
+		},
+		{
+			name: "order by primary key",
+			keyset: &Keyset{
+				Orders:     []*Order{{Column: "item_id", Desc: true}, {Column: "score"}},
+				PrimaryKey: "item_id",
+				After:      []interface{}{"item-id"},
+			},
+			expect: "SELECT * FROM `items` WHERE `item_id` < 'item-id' ORDER BY `item_id` DESC",
+		},
+		{
+			name: "invalid after values",
+			keyset: &Keyset{
+				Orders: []*Order{{Column: "score"}},
+				After:  []interface{}{int64(10)},
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var items []map[string]interface{}
+			stmt := client.DB.Table("items").Scopes(client.Paginate(tt.keyset)).Find(&items)
+			if tt.isErr {
+				assert.ErrorIs(t, stmt.Error, ErrInvalidKeyset)
+				return
+			}
+			require.NoError(t, stmt.Error)
+			assert.Equal(t, tt.expect, db.Dialector.Explain(stmt.Statement.SQL.String(), stmt.Statement.Vars...))
+		})
+	}
+}
+
+func TestNextKeyset(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params)
+	require.NoError(t, err)
+
+	const table = "pagination_items"
+	err = client.DB.Table(table).Migrator().CreateTable(&paginationItem{})
+	require.NoError(t, err)
+	defer client.DB.Migrator().DropTable(table) //nolint:errcheck
+
+	now := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+	items := []*paginationItem{
+		{ID: "a", Score: 2, CreatedAt: now},
+		{ID: "b", Score: 1, CreatedAt: now.Add(time.Hour)},
+		{ID: "c", Score: 2, CreatedAt: now},
+		{ID: "d", Score: 3, CreatedAt: now.Add(-time.Hour)},
+		{ID: "e", Score: 1, CreatedAt: now},
+	}
+	err = client.DB.Table(table).Create(&items).Error
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		orders []*Order
+		expect []string
+	}{
+		{
+			name:   "ascending",
+			orders: []*Order{{Column: "score"}, {Column: "created_at"}},
+			expect: []string{"e", "b", "a", "c", "d"},
+		},
+		{
+			name:   "descending",
+			orders: []*Order{{Column: "score", Desc: true}, {Column: "created_at", Desc: true}},
+			expect: []string{"d", "c", "a", "b", "e"},
+		},
+		{
+			name:   "mixed",
+			orders: []*Order{{Column: "score", Desc: true}, {Column: "created_at"}},
+			expect: []string{"d", "a", "c", "e", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &Keyset{Orders: tt.orders, Limit: 2}
+			actual := make([]string, 0, len(items))
+			for pages := 0; pages < len(items); pages++ {
+				var page []*paginationItem
+				err := client.Statement(ctx, client.DB, table).Scopes(client.Paginate(ks)).Find(&page).Error
+				require.NoError(t, err)
+				next, err := client.NextKeyset(ctx, ks, &page)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(page), ks.Limit)
+				for _, item := range page {
+					actual = append(actual, item.ID)
+				}
+				if next == nil {
+					break
+				}
+				ks.After = next
+			}
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+
+	t.Run("invalid rows", func(t *testing.T) {
+		ks := &Keyset{Limit: 1}
+		_, err := client.NextKeyset(ctx, ks, []*paginationItem{})
+		assert.ErrorIs(t, err, ErrInvalidKeyset)
+	})
+	t.Run("unknown column", func(t *testing.T) {
+		ks := &Keyset{Orders: []*Order{{Column: "unknown"}}, Limit: 1}
+		page := []*paginationItem{{ID: "a"}, {ID: "b"}}
+		_, err := client.NextKeyset(ctx, ks, &page)
+		assert.ErrorIs(t, err, ErrInvalidKeyset)
+	})
+}
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// MySQLのエラー番号
+// @see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	ErrNumberLockWaitTimeout uint16 = 1205 // ER_LOCK_WAIT_TIMEOUT
+	ErrNumberDeadlock        uint16 = 1213 // ER_LOCK_DEADLOCK
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 20 * time.Millisecond
+	defaultRetryMaxWait = time.Second
+)
+
+var transactionRetryCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "mysql_transaction_retries_total",
+	Help: "Total number of transactions retried due to deadlocks or lock wait timeouts.",
+}, []string{"reason"})
+
+// retryPolicy - トランザクションの再試行条件
+type retryPolicy struct {
+	maxRetries int           // 最大再試行回数 (0の場合は再試行しない)
+	backoff    time.Duration // 初回の待機時間の上限
+	maxWait    time.Duration // 待機時間の上限
+}
+
+// WithMaxRetries - デッドロック・ロック待ちタイムアウト時のトランザクションの最大再試行回数 (0以下の場合は再試行しない)
+func WithMaxRetries(retries int) Option {
+	return func(opts *options) {
+		opts.retry.maxRetries = retries
+	}
+}
+
+// WithRetryBackoff - トランザクションの再試行までの待機時間 (試行ごとに倍にし、maxWaitを上限にランダムに待機する)
+func WithRetryBackoff(backoff, maxWait time.Duration) Option {
+	return func(opts *options) {
+		opts.retry.backoff = backoff
+		opts.retry.maxWait = maxWait
+	}
+}
+
+// IsRetryable - トランザクションを再実行することで成功する可能性があるエラーか
+func IsRetryable(err error) bool {
+	_, ok := retryReason(err)
+	return ok
+}
+
+func retryReason(err error) (string, bool) {
+	var merr *dmysql.MySQLError
+	if !errors.As(err, &merr) {
+		return "", false
+	}
+	switch merr.Number {
+	case ErrNumberDeadlock:
+		return "deadlock", true
+	case ErrNumberLockWaitTimeout:
+		return "lock_wait_timeout", true
+	default:
+		return "", false
+	}
+}
+
+// MarkNonRetryable - トランザクションを再実行しないようにする (冪等性の保護)
+// 外部サービスの呼び出しなど、ロールバックできない副作用を伴う処理の前に呼び出す
+// トランザクション外のコンテキストが指定された場合は何もしない
+func MarkNonRetryable(ctx context.Context) {
+	if current, ok := ctx.Value(txContextKey{}).(*txContext); ok {
+		current.state.nonRetryable = true
+	}
+}
+
+// wait - 再試行までの待機 (Full Jitter)
+// @see https://aws.amazon.com/jp/blogs/architecture/exponential-backoff-and-jitter/
+func (p *retryPolicy) wait(ctx context.Context, attempt int) error {
+	limit := p.backoff << attempt
+	if limit <= 0 || limit > p.maxWait {
+		limit = p.maxWait
+	}
+	var d time.Duration
+	if limit > 0 {
+		d = time.Duration(rand.Int63n(int64(limit) + 1)) //nolint:gosec
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{
+			name:   "deadlock",
+			err:    &dmysql.MySQLError{Number: ErrNumberDeadlock},
+			expect: true,
+		},
+		{
+			name:   "lock wait timeout",
+			err:    &dmysql.MySQLError{Number: ErrNumberLockWaitTimeout},
+			expect: true,
+		},
+		{
+			name:   "wrapped deadlock",
+			err:    fmt.Errorf("wrapped: %w", &dmysql.MySQLError{Number: ErrNumberDeadlock}),
+			expect: true,
+		},
+		{
+			name:   "other mysql error",
+			err:    &dmysql.MySQLError{Number: 1062},
+			expect: false,
+		},
+		{
+			name:   "other error",
+			err:    assert.AnError,
+			expect: false,
+		},
+		{
+			name:   "nil",
+			err:    nil,
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	t.Parallel()
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		p := &retryPolicy{backoff: time.Millisecond, maxWait: 2 * time.Millisecond}
+		for attempt := 0; attempt < 64; attempt++ {
+			assert.NoError(t, p.wait(context.Background(), attempt))
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		p := &retryPolicy{backoff: time.Hour, maxWait: time.Hour}
+		assert.ErrorIs(t, p.wait(ctx, 0), context.Canceled)
+	})
+}
+
+func TestRunInTransaction_Retry(t *testing.T) {
+	setEnv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := &Params{
+		Socket:   "tcp",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_DATABASE"),
+		Username: os.Getenv("DB_USERNAME"),
+		Password: os.Getenv("DB_PASSWORD"),
+	}
+	client, err := NewClient(params, WithMaxRetries(2), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	deadlock := &dmysql.MySQLError{Number: ErrNumberDeadlock, Message: "Deadlock found when trying to get lock"}
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context, attempt int) error
+		attempt int
+		err     error
+	}{
+		{
+			name: "success after retry",
+			fn: func(ctx context.Context, attempt int) error {
+				if attempt < 2 {
+					return deadlock
+				}
+				return nil
+			},
+			attempt: 3,
+			err:     nil,
+		},
+		{
+			name: "exceeded max retries",
+			fn: func(ctx context.Context, attempt int) error {
+				return fmt.Errorf("wrapped: %w", deadlock)
+			},
+			attempt: 3,
+			err:     deadlock,
+		},
+		{
+			name: "not retryable error",
+			fn: func(ctx context.Context, attempt int) error {
+				return assert.AnError
+			},
+			attempt: 1,
+			err:     assert.AnError,
+		},
+		{
+			name: "marked as non retryable",
+			fn: func(ctx context.Context, attempt int) error {
+				MarkNonRetryable(ctx)
+				return deadlock
+			},
+			attempt: 1,
+			err:     deadlock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempt int
+			err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+				defer func() { attempt++ }()
+				return tt.fn(ctx, attempt)
+			})
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.attempt, attempt)
+		})
+	}
+
+	t.Run("retry disabled", func(t *testing.T) {
+		client := &Client{DB: client.DB}
+		var attempt int
+		err := client.RunInTransaction(ctx, func(ctx context.Context) error {
+			attempt++
+			return deadlock
+		})
+		assert.ErrorIs(t, err, deadlock)
+		assert.Equal(t, 1, attempt)
+	})
+}
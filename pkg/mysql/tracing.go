@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"errors"
+
+	"github.com/and-period/furumane/pkg/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// tracingPlugin - クエリ単位でOpenTelemetryのスパンを生成するGORMのプラグイン
+type tracingPlugin struct {
+	tracer   trace.Tracer
+	database string
+}
+
+func (p *tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	operations := []struct {
+		name   string
+		before registerFunc
+		after  registerFunc
+	}{
+		{name: "create", before: cb.Create().Before("*").Register, after: cb.Create().After("*").Register},
+		{name: "query", before: cb.Query().Before("*").Register, after: cb.Query().After("*").Register},
+		{name: "update", before: cb.Update().Before("*").Register, after: cb.Update().After("*").Register},
+		{name: "delete", before: cb.Delete().Before("*").Register, after: cb.Delete().After("*").Register},
+		{name: "row", before: cb.Row().Before("*").Register, after: cb.Row().After("*").Register},
+		{name: "raw", before: cb.Raw().Before("*").Register, after: cb.Raw().After("*").Register},
+	}
+	for _, op := range operations {
+		if err := op.before("tracing:before_"+op.name, p.before(op.name)); err != nil {
+			return err
+		}
+		if err := op.after("tracing:after_"+op.name, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		table := db.Statement.Table
+		if table == "" {
+			table = unknownTable
+		}
+		// スパンはafterで終了する
+		_, span := p.tracer.Start(db.Statement.Context, "mysql."+operation+" "+table,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemMySQL,
+				semconv.DBName(p.database),
+				semconv.DBOperation(operation),
+				semconv.DBSQLTable(table),
+			),
+		)
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (p *tracingPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	// 値はプレースホルダーのまま記録する (個人情報を含めないため)
+	span.SetAttributes(semconv.DBStatement(db.Statement.SQL.String()))
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil // 正常系として扱う
+	}
+	tracing.End(span, err)
+}
@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const contentTypeJSON = "application/json"
+
+// pathParamRegex - ginのパスパラメータ (e.g. /admin/:adminId)
+var pathParamRegex = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Endpoint - エンドポイントの定義
+type Endpoint struct {
+	OperationID string                // 操作ID (未指定の場合はHTTPメソッドとパスから生成)
+	Summary     string                // 概要
+	Tags        []string              // タグ一覧
+	Request     interface{}           // リクエストボディの型 (nilの場合はリクエストボディなし)
+	RequestType string                // リクエストボディのContent-Type (未指定の場合はapplication/json)
+	Response    interface{}           // 成功時のレスポンスボディの型 (nilの場合は204 No Content)
+	Parameters  []*Parameter          // パスパラメータ以外のパラメータ (e.g. ヘッダー、クエリ)
+	Security    []SecurityRequirement // 認証方式 (いずれかを満たす必要がある)
+}
+
+// ErrorResponse - エラー時のレスポンスの定義
+type ErrorResponse struct {
+	ContentType string      // Content-Type
+	Body        interface{} // レスポンスボディの型
+}
+
+type Generator interface {
+	// Add - エンドポイントの追加 (パスはginの形式で指定する)
+	Add(method, path string, endpoint *Endpoint) error
+	// Document - OpenAPIのドキュメントを生成
+	Document() *Document
+}
+
+type generator struct {
+	info            *Info
+	servers         []*Server
+	errors          []*ErrorResponse
+	securitySchemes map[string]*SecurityScheme
+	schemas         *schemas
+	paths           map[string]PathItem
+}
+
+type options struct {
+	servers         []*Server
+	errors          []*ErrorResponse
+	securitySchemes map[string]*SecurityScheme
+}
+
+type Option func(*options)
+
+// WithServers - 接続先サーバーの一覧
+func WithServers(servers ...*Server) Option {
+	return func(opts *options) {
+		opts.servers = servers
+	}
+}
+
+// WithErrorResponses - すべてのエンドポイントで共通のエラーレスポンス
+func WithErrorResponses(errs ...*ErrorResponse) Option {
+	return func(opts *options) {
+		opts.errors = errs
+	}
+}
+
+// WithSecurityScheme - 認証方式の追加
+func WithSecurityScheme(name string, scheme *SecurityScheme) Option {
+	return func(opts *options) {
+		opts.securitySchemes[name] = scheme
+	}
+}
+
+// NewGenerator - Goの型からOpenAPIのドキュメントを生成するジェネレーターの生成
+func NewGenerator(info *Info, opts ...Option) Generator {
+	dopts := &options{
+		securitySchemes: map[string]*SecurityScheme{},
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	return &generator{
+		info:            info,
+		servers:         dopts.servers,
+		errors:          dopts.errors,
+		securitySchemes: dopts.securitySchemes,
+		schemas:         newSchemas(),
+		paths:           map[string]PathItem{},
+	}
+}
+
+func (g *generator) Add(method, path string, endpoint *Endpoint) error {
+	method = strings.ToLower(method)
+	path, params := convertPath(path)
+	item, ok := g.paths[path]
+	if !ok {
+		item = PathItem{}
+		g.paths[path] = item
+	}
+	if _, ok := item[method]; ok {
+		return fmt.Errorf("openapi: duplicate endpoint: %s %s", strings.ToUpper(method), path)
+	}
+	for _, sr := range endpoint.Security {
+		for name := range sr {
+			if _, ok := g.securitySchemes[name]; !ok {
+				return fmt.Errorf("openapi: unknown security scheme: %s", name)
+			}
+		}
+	}
+
+	op := &Operation{
+		OperationID: endpoint.OperationID,
+		Summary:     endpoint.Summary,
+		Tags:        endpoint.Tags,
+		Responses:   map[string]*Response{},
+		Security:    endpoint.Security,
+	}
+	if op.OperationID == "" {
+		op.OperationID = operationID(method, path)
+	}
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	op.Parameters = append(op.Parameters, endpoint.Parameters...)
+	if endpoint.Request != nil {
+		contentType := endpoint.RequestType
+		if contentType == "" {
+			contentType = contentTypeJSON
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				contentType: {Schema: g.schemas.of(endpoint.Request)},
+			},
+		}
+	}
+	if endpoint.Response != nil {
+		op.Responses[strconv.Itoa(http.StatusOK)] = &Response{
+			Description: http.StatusText(http.StatusOK),
+			Content: map[string]*MediaType{
+				contentTypeJSON: {Schema: g.schemas.of(endpoint.Response)},
+			},
+		}
+	} else {
+		op.Responses[strconv.Itoa(http.StatusNoContent)] = &Response{
+			Description: http.StatusText(http.StatusNoContent),
+		}
+	}
+	if len(g.errors) > 0 {
+		res := &Response{
+			Description: "Error",
+			Content:     make(map[string]*MediaType, len(g.errors)),
+		}
+		for _, e := range g.errors {
+			res.Content[e.ContentType] = &MediaType{Schema: g.schemas.of(e.Body)}
+		}
+		op.Responses["default"] = res
+	}
+	item[method] = op
+	return nil
+}
+
+func (g *generator) Document() *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    g.info,
+		Servers: g.servers,
+		Paths:   g.paths,
+	}
+	if len(g.schemas.components) > 0 || len(g.securitySchemes) > 0 {
+		doc.Components = &Components{
+			Schemas:         g.schemas.components,
+			SecuritySchemes: g.securitySchemes,
+		}
+	}
+	return doc
+}
+
+// ConvertPath - ginのパスをOpenAPIのパスに変換 (e.g. /admin/:adminId -> /admin/{adminId})
+func ConvertPath(path string) string {
+	res, _ := convertPath(path)
+	return res
+}
+
+func convertPath(path string) (string, []string) {
+	params := make([]string, 0)
+	res := pathParamRegex.ReplaceAllStringFunc(path, func(s string) string {
+		name := s[1:]
+		params = append(params, name)
+		return "{" + name + "}"
+	})
+	return res, params
+}
+
+// operationID - HTTPメソッドとパスから操作IDを生成 (e.g. GET /admin/{adminId} -> getAdminByAdminId)
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") {
+			seg = "by-" + strings.Trim(seg, "{}")
+		}
+		for _, word := range strings.FieldsFunc(seg, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		}) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type testResponse struct {
+	ID string `json:"id"`
+}
+
+type testError struct {
+	Message string `json:"message"`
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+	info := &Info{Title: "test", Version: "1.0.0"}
+	g := NewGenerator(info,
+		WithServers(&Server{URL: "http://localhost:8080"}),
+		WithSecurityScheme("bearerAuth", &SecurityScheme{Type: "http", Scheme: "bearer"}),
+		WithErrorResponses(&ErrorResponse{ContentType: "application/json", Body: &testError{}}),
+	)
+	err := g.Add(http.MethodPost, "/users/:userId/items", &Endpoint{
+		Summary:  "create item",
+		Tags:     []string{"Item"},
+		Request:  &testRequest{},
+		Response: &testResponse{},
+		Security: []SecurityRequirement{{"bearerAuth": {}}},
+	})
+	require.NoError(t, err)
+	err = g.Add(http.MethodDelete, "/users/:userId/items/:itemId", &Endpoint{
+		OperationID: "DeleteItem",
+		Parameters:  []*Parameter{{Name: "X-Tenant-ID", In: "header", Required: true, Schema: &Schema{Type: "string"}}},
+	})
+	require.NoError(t, err)
+	err = g.Add(http.MethodPost, "/items/search", &Endpoint{
+		Request:     &testRequest{},
+		RequestType: "application/x-www-form-urlencoded",
+		Response:    &testResponse{},
+	})
+	require.NoError(t, err)
+
+	doc := g.Document()
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Equal(t, info, doc.Info)
+	assert.Equal(t, []string{
+		"DELETE /users/{userId}/items/{itemId}",
+		"POST /items/search",
+		"POST /users/{userId}/items",
+	}, doc.Endpoints())
+
+	create := doc.Paths["/users/{userId}/items"]["post"]
+	assert.Equal(t, "postUsersByUserIdItems", create.OperationID)
+	assert.Equal(t, []*Parameter{{Name: "userId", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, create.Parameters)
+	assert.Equal(t, "#/components/schemas/testRequest", create.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/testResponse", create.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/testError", create.Responses["default"].Content["application/json"].Schema.Ref)
+
+	remove := doc.Paths["/users/{userId}/items/{itemId}"]["delete"]
+	assert.Equal(t, "DeleteItem", remove.OperationID)
+	assert.Len(t, remove.Parameters, 3)
+	assert.Equal(t, "header", remove.Parameters[2].In)
+	assert.Nil(t, remove.RequestBody)
+	assert.Contains(t, remove.Responses, "204")
+
+	search := doc.Paths["/items/search"]["post"]
+	assert.Contains(t, search.RequestBody.Content, "application/x-www-form-urlencoded")
+	assert.NotContains(t, search.RequestBody.Content, "application/json")
+
+	assert.Contains(t, doc.Components.Schemas, "testRequest")
+	assert.Contains(t, doc.Components.SecuritySchemes, "bearerAuth")
+}
+
+func TestGenerator_Error(t *testing.T) {
+	t.Parallel()
+	g := NewGenerator(&Info{Title: "test", Version: "1.0.0"})
+	require.NoError(t, g.Add(http.MethodGet, "/items", &Endpoint{}))
+	assert.ErrorContains(t, g.Add(http.MethodGet, "/items", &Endpoint{}), "duplicate endpoint")
+	assert.ErrorContains(t, g.Add(http.MethodPost, "/items", &Endpoint{
+		Security: []SecurityRequirement{{"unknown": {}}},
+	}), "unknown security scheme")
+}
+
+func TestConvertPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		path   string
+		expect string
+	}{
+		{name: "no params", path: "/admin", expect: "/admin"},
+		{name: "single param", path: "/admin/:adminId", expect: "/admin/{adminId}"},
+		{name: "multiple params", path: "/admin/:adminId/keys/:keyId", expect: "/admin/{adminId}/keys/{keyId}"},
+		{name: "wildcard", path: "/files/*filepath", expect: "/files/{filepath}"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, ConvertPath(tt.path))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+	byteSliceType = reflect.TypeOf([]byte{})
+)
+
+// validateFormats - validateタグの検証ルールとformatの対応表
+var validateFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"cidr":     "cidr",
+	"hostname": "hostname",
+	"datetime": "date-time",
+}
+
+// validatePatterns - validateタグの検証ルールとpatternの対応表
+var validatePatterns = map[string]string{
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"base64":      `^(?:[A-Za-z0-9+\/]{4})*(?:[A-Za-z0-9+\/]{2}==|[A-Za-z0-9+\/]{3}=|[A-Za-z0-9+\/]{4})$`,
+	"base64url":   `^(?:[A-Za-z0-9-_]{4})*(?:[A-Za-z0-9-_]{2}==|[A-Za-z0-9-_]{3}=|[A-Za-z0-9-_]{4})$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+	"printascii":  `^[\x20-\x7E]*$`,
+}
+
+// schemas - 構造体のスキーマの生成 (components/schemasに登録し、参照を返す)
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+// of - 値の型からスキーマを生成
+func (s *schemas) of(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	case byteSliceType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case reflect.Struct:
+		return s.ref(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// ref - 構造体のスキーマを登録し、参照を返す
+func (s *schemas) ref(t reflect.Type) *Schema {
+	name, ok := s.names[t]
+	if !ok {
+		name = s.name(t)
+		s.names[t] = name
+		s.components[name] = &Schema{} // 循環参照の対策として先に登録する
+		s.components[name] = s.object(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// name - スキーマ名の生成 (別パッケージに同名の型がある場合はパッケージ名を付与する)
+func (s *schemas) name(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		name = "Object"
+	}
+	if _, ok := s.components[name]; !ok {
+		return name
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	for i := 2; ; i++ {
+		if _, ok := s.components[name]; !ok {
+			return name
+		}
+		name = strings.TrimRight(name, "0123456789") + strconv.Itoa(i)
+	}
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	s.fields(schema, t)
+	return schema
+}
+
+// fields - encoding/jsonと同じ規則でフィールドをプロパティに変換
+func (s *schemas) fields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.fields(schema, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := s.schema(f.Type)
+		if required := applyValidate(prop, f.Tag.Get("validate")); required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+}
+
+// applyValidate - validateタグの検証ルールをスキーマの制約に変換
+// 必須項目の場合はtrueを返す
+func applyValidate(schema *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	var required bool
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		if rule == "dive" {
+			// 以降の検証ルールは配列の要素に適用する
+			if schema.Items != nil {
+				applyValidate(schema.Items, strings.Join(rules[i+1:], ","))
+			}
+			break
+		}
+		if rule == "required" {
+			required = true
+			continue
+		}
+		applyRule(schema, rule)
+	}
+	return required
+}
+
+func applyRule(schema *Schema, rule string) {
+	// 複数の検証ルールのいずれかを満たす場合 (e.g. ip|cidr)
+	if alts := strings.Split(rule, "|"); len(alts) > 1 {
+		for _, alt := range alts {
+			s := &Schema{}
+			applyRule(s, alt)
+			schema.AnyOf = append(schema.AnyOf, s)
+		}
+		return
+	}
+
+	key, value, _ := strings.Cut(rule, "=")
+	if format, ok := validateFormats[key]; ok {
+		schema.Format = format
+		return
+	}
+	if pattern, ok := validatePatterns[key]; ok {
+		schema.Pattern = pattern
+		return
+	}
+	switch key {
+	case "oneof":
+		schema.Enum = strings.Fields(value)
+	case "len":
+		applyLength(schema, value, true, true)
+	case "min":
+		applyLength(schema, value, true, false)
+	case "max":
+		applyLength(schema, value, false, true)
+	case "gte":
+		applyBound(schema, value, &schema.Minimum)
+	case "lte":
+		applyBound(schema, value, &schema.Maximum)
+	case "gt":
+		applyBound(schema, value, &schema.ExclusiveMinimum)
+	case "lt":
+		applyBound(schema, value, &schema.ExclusiveMaximum)
+	}
+}
+
+// applyLength - 型に応じて文字数、要素数、値の範囲のいずれかを制約する
+func applyLength(schema *Schema, value string, isMin, isMax bool) {
+	switch schema.Type {
+	case "string":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return
+		}
+		if isMin {
+			schema.MinLength = &n
+		}
+		if isMax {
+			schema.MaxLength = &n
+		}
+	case "array":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return
+		}
+		if isMin {
+			schema.MinItems = &n
+		}
+		if isMax {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		if isMin {
+			applyBound(schema, value, &schema.Minimum)
+		}
+		if isMax {
+			applyBound(schema, value, &schema.Maximum)
+		}
+	}
+}
+
+func applyBound(schema *Schema, value string, field **float64) {
+	if schema.Type != "integer" && schema.Type != "number" {
+		return
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+	*field = &n
+}
@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEmbedded struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type testChild struct {
+	Name   string     `json:"name" validate:"required"`
+	Parent *testChild `json:"parent"`
+}
+
+type testSchema struct {
+	testEmbedded
+	ID       string            `json:"id" validate:"required,max=32"`
+	Email    string            `json:"email,omitempty" validate:"omitempty,email"`
+	Code     string            `json:"code" validate:"len=6,numeric"`
+	Age      int64             `json:"age" validate:"gte=0,lt=200"`
+	Score    float64           `json:"score" validate:"min=0,max=100"`
+	Enabled  bool              `json:"enabled"`
+	Status   string            `json:"status" validate:"oneof=active inactive"`
+	IPs      []string          `json:"ips" validate:"min=1,max=3,dive,ip|cidr"`
+	Data     []byte            `json:"data"`
+	Raw      json.RawMessage   `json:"raw"`
+	Labels   map[string]string `json:"labels"`
+	Child    *testChild        `json:"child"`
+	NoTag    string
+	Ignored  string `json:"-"`
+	internal string
+}
+
+func TestSchemas(t *testing.T) {
+	t.Parallel()
+	s := newSchemas()
+	ref := s.of(&testSchema{})
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testSchema"}, ref)
+
+	i64 := func(v int64) *int64 { return &v }
+	f64 := func(v float64) *float64 { return &v }
+	expect := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"createdAt": {Type: "string", Format: "date-time"},
+			"id":        {Type: "string", MaxLength: i64(32)},
+			"email":     {Type: "string", Format: "email"},
+			"code":      {Type: "string", MinLength: i64(6), MaxLength: i64(6), Pattern: validatePatterns["numeric"]},
+			"age":       {Type: "integer", Format: "int64", Minimum: f64(0), ExclusiveMaximum: f64(200)},
+			"score":     {Type: "number", Format: "double", Minimum: f64(0), Maximum: f64(100)},
+			"enabled":   {Type: "boolean"},
+			"status":    {Type: "string", Enum: []string{"active", "inactive"}},
+			"ips": {
+				Type:     "array",
+				MinItems: i64(1),
+				MaxItems: i64(3),
+				Items: &Schema{
+					Type:  "string",
+					AnyOf: []*Schema{{Format: "ip"}, {Format: "cidr"}},
+				},
+			},
+			"data":   {Type: "string", Format: "byte"},
+			"raw":    {},
+			"labels": {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+			"child":  {Ref: "#/components/schemas/testChild"},
+			"NoTag":  {Type: "string"},
+		},
+		Required: []string{"id"},
+	}
+	assert.Equal(t, expect, s.components["testSchema"])
+
+	// 循環参照
+	child := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"name":   {Type: "string"},
+			"parent": {Ref: "#/components/schemas/testChild"},
+		},
+		Required: []string{"name"},
+	}
+	assert.Equal(t, child, s.components["testChild"])
+	assert.Nil(t, s.of(nil))
+}
+
+func TestSchemas_Name(t *testing.T) {
+	t.Parallel()
+	type Schema struct {
+		Name string `json:"name"`
+	}
+	s := newSchemas()
+	assert.Equal(t, "#/components/schemas/Schema", s.of(&Schema{}).Ref)
+	assert.Equal(t, "#/components/schemas/Schema", s.of(Schema{}).Ref)
+	// 同名の型が登録済みの場合はパッケージ名を付与する
+	s.of(&testDocument{})
+	assert.Equal(t, "#/components/schemas/OpenapiSchema", s.components["testDocument"].Properties["schema"].Ref)
+}
+
+type testDocument struct {
+	Schema *Schema `json:"schema"`
+}
@@ -0,0 +1,54 @@
+package redact
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// core - 出力するフィールドをマスキングするzapcore.Core
+type core struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// NewCore - ログの出力前にフィールドをマスキングするzapcore.Coreを生成
+// 文字列のフィールドはキー名とJSONの内容、任意の値のフィールド (zap.Any) は構造体のタグとルールでマスキングする
+func NewCore(c zapcore.Core, r *Redactor) zapcore.Core {
+	return &core{Core: c, redactor: r}
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(c.fields(fields)), redactor: c.redactor}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.fields(fields))
+}
+
+func (c *core) fields(fields []zapcore.Field) []zapcore.Field {
+	res := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = c.redactor.String(f.Key, f.String)
+		case zapcore.ByteStringType:
+			if b, ok := f.Interface.([]byte); ok {
+				f.Interface = []byte(c.redactor.String(f.Key, string(b)))
+			}
+		case zapcore.ReflectType:
+			if mode, ok := c.redactor.match([]string{f.Key}); ok {
+				f.Interface = maskValue(mode, c.redactor.Value(f.Interface))
+			} else {
+				f.Interface = c.redactor.Value(f.Interface)
+			}
+		}
+		res[i] = f
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCore(t *testing.T) {
+	t.Parallel()
+	observed, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewCore(observed, Default()))
+
+	type request struct {
+		Email      string `json:"email"`
+		VerifyCode string `json:"verifyCode"`
+	}
+	logger.With(zap.String("email", "test@example.com")).Info("message",
+		zap.String("path", "/v1/admins"),
+		zap.String("request", `{"password":"12345678"}`),
+		zap.ByteString("body", []byte(`{"refreshToken":"token"}`)),
+		zap.Any("response", &request{Email: "test@example.com", VerifyCode: "123456"}),
+		zap.Any("password", map[string]string{"value": "12345678"}),
+		zap.Int("status", 400),
+	)
+	logger.Debug("debug", zap.String("password", "12345678"))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "t***@example.com", fields["email"])
+	assert.Equal(t, "/v1/admins", fields["path"])
+	assert.Equal(t, `{"password":"[REDACTED]"}`, fields["request"])
+	assert.Equal(t, `{"refreshToken":"[REDACTED]"}`, fields["body"])
+	assert.Equal(t, map[string]interface{}{"email": "t***@example.com", "verifyCode": Redacted}, fields["response"])
+	assert.Equal(t, Redacted, fields["password"])
+	assert.Equal(t, int64(400), fields["status"])
+}
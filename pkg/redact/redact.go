@@ -0,0 +1,389 @@
+// Package redact - ログに出力する値に含まれる秘匿情報・個人情報のマスキング
+//
+// マスキング対象はJSONのパス (ルール) と構造体のタグ (e.g. `log:"secret"`) で指定する
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Redacted - マスキング後の値
+const Redacted = "[REDACTED]"
+
+// TagName - マスキング方法を指定する構造体のタグ名
+const TagName = "log"
+
+var ErrInvalidRule = errors.New("redact: invalid rule")
+
+// Mode - マスキング方法
+type Mode string
+
+const (
+	ModeSecret Mode = "secret" // 値全体をマスクする
+	ModeEmail  Mode = "email"  // メールアドレスのローカル部の先頭1文字とドメイン以外をマスクする
+	ModePhone  Mode = "phone"  // 電話番号の末尾4桁以外をマスクする
+)
+
+// Rule - マスキング対象のJSONのパス
+// パスはキーをドットで区切って指定し、*は任意の1階層、**は任意の0階層以上に一致する (e.g. **.password)
+// 配列は階層として扱わず、各要素に同じパスを適用する。キーの大文字・小文字は区別しない
+type Rule struct {
+	Path string
+	Mode Mode
+}
+
+// DefaultRules - デフォルトのマスキング対象
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{Path: "**.password", Mode: ModeSecret},
+		{Path: "**.passwordConfirmation", Mode: ModeSecret},
+		{Path: "**.oldPassword", Mode: ModeSecret},
+		{Path: "**.newPassword", Mode: ModeSecret},
+		{Path: "**.verifyCode", Mode: ModeSecret},
+		{Path: "**.accessToken", Mode: ModeSecret},
+		{Path: "**.refreshToken", Mode: ModeSecret},
+		{Path: "**.idToken", Mode: ModeSecret},
+		{Path: "**.token", Mode: ModeSecret},
+		{Path: "**.session", Mode: ModeSecret},
+		{Path: "**.secret", Mode: ModeSecret},
+		{Path: "**.clientSecret", Mode: ModeSecret},
+		{Path: "**.client_secret", Mode: ModeSecret},
+		{Path: "**.authorization", Mode: ModeSecret},
+		{Path: "**.email", Mode: ModeEmail},
+		{Path: "**.phoneNumber", Mode: ModePhone},
+	}
+}
+
+// ParseRules - マスキング対象の一覧を変換 (e.g. **.password, **.email:email)
+// マスキング方法を省略した場合は値全体をマスクする
+func ParseRules(strs []string) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(strs))
+	for _, str := range strs {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		path, mode, ok := strings.Cut(str, ":")
+		if !ok {
+			mode = string(ModeSecret)
+		}
+		if !Mode(mode).valid() {
+			return nil, fmt.Errorf("%w: unsupported mode: %s", ErrInvalidRule, str)
+		}
+		rules = append(rules, &Rule{Path: path, Mode: Mode(mode)})
+	}
+	return rules, nil
+}
+
+// Redactor - マスキング処理
+type Redactor struct {
+	rules []*rule
+}
+
+type rule struct {
+	segments []string
+	mode     Mode
+}
+
+// New - マスキング処理の生成
+func New(rules ...*Rule) (*Redactor, error) {
+	r := &Redactor{rules: make([]*rule, len(rules))}
+	for i, ru := range rules {
+		if !ru.Mode.valid() {
+			return nil, fmt.Errorf("%w: unsupported mode: %s", ErrInvalidRule, ru.Mode)
+		}
+		segments := strings.Split(ru.Path, ".")
+		for _, s := range segments {
+			if s == "" {
+				return nil, fmt.Errorf("%w: invalid path: %q", ErrInvalidRule, ru.Path)
+			}
+		}
+		r.rules[i] = &rule{segments: segments, mode: ru.Mode}
+	}
+	return r, nil
+}
+
+// Default - デフォルトのマスキング対象によるマスキング処理
+func Default() *Redactor {
+	r, _ := New(DefaultRules()...)
+	return r
+}
+
+// Value - 値をマスキングしたJSON互換の値 (map, slice, プリミティブ型) に変換
+// 構造体のフィールドはjsonタグの名前で出力し、logタグが指定されたフィールドはルールによらずマスクする
+func (r *Redactor) Value(v interface{}) interface{} {
+	return r.walk(toTree(reflect.ValueOf(v)), nil)
+}
+
+// JSON - JSON文字列のマスキング (JSONとして解釈できない場合は内容を出力しない)
+func (r *Redactor) JSON(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	var tree interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return []byte(omitted(body))
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(r.walk(tree, nil)); err != nil {
+		return []byte(omitted(body))
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Body - HTTPのリクエスト・レスポンスボディのマスキング
+// JSONとフォーム形式以外は内容を出力しない
+func (r *Redactor) Body(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return omitted(body)
+		}
+		for key := range values {
+			mode, ok := r.match([]string{key})
+			if !ok {
+				continue
+			}
+			for i := range values[key] {
+				values[key][i] = Mask(mode, values[key][i])
+			}
+		}
+		return values.Encode()
+	case mediaType == "", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return string(r.JSON(body))
+	default:
+		return omitted(body)
+	}
+}
+
+// String - キーに対応するルールがあれば文字列をマスキング
+// JSON形式の文字列の場合は、内容をマスキングする
+func (r *Redactor) String(key, value string) string {
+	if mode, ok := r.match([]string{key}); ok {
+		return Mask(mode, value)
+	}
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if json.Valid([]byte(trimmed)) {
+			return string(r.JSON([]byte(trimmed)))
+		}
+	}
+	return value
+}
+
+// Mask - マスキング方法に応じて文字列をマスク
+func Mask(mode Mode, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch mode {
+	case ModeEmail:
+		local, domain, ok := strings.Cut(value, "@")
+		if !ok || local == "" {
+			return Redacted
+		}
+		if strings.HasSuffix(local, "***") {
+			return value // マスキング済み
+		}
+		return string([]rune(local)[:1]) + "***@" + domain
+	case ModePhone:
+		runes := []rune(value)
+		if len(runes) <= 4 {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+	default:
+		return Redacted
+	}
+}
+
+func (m Mode) valid() bool {
+	switch m {
+	case ModeSecret, ModeEmail, ModePhone:
+		return true
+	default:
+		return false
+	}
+}
+
+func omitted(body []byte) string {
+	return fmt.Sprintf("[OMITTED: %d bytes]", len(body))
+}
+
+// walk - JSON互換の値を走査してルールに一致する値をマスク
+func (r *Redactor) walk(node interface{}, path []string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			p := append(path[:len(path):len(path)], key)
+			if mode, ok := r.match(p); ok {
+				v[key] = maskValue(mode, child)
+				continue
+			}
+			v[key] = r.walk(child, p)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = r.walk(v[i], path)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) match(path []string) (Mode, bool) {
+	if r == nil {
+		return "", false
+	}
+	for _, ru := range r.rules {
+		if matchPath(ru.segments, path) {
+			return ru.mode, true
+		}
+	}
+	return "", false
+}
+
+func matchPath(segments, path []string) bool {
+	if len(segments) == 0 {
+		return len(path) == 0
+	}
+	switch segments[0] {
+	case "**":
+		for i := 0; i <= len(path); i++ {
+			if matchPath(segments[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(path) > 0 && matchPath(segments[1:], path[1:])
+	default:
+		return len(path) > 0 && strings.EqualFold(segments[0], path[0]) && matchPath(segments[1:], path[1:])
+	}
+}
+
+func maskValue(mode Mode, v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return Mask(mode, v)
+	default:
+		return Redacted
+	}
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// toTree - 値をJSON互換の値に変換 (logタグが指定されたフィールドはマスクする)
+func toTree(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.Type().Implements(jsonMarshalerType) {
+		return fromJSON(rv.Interface())
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return toTree(rv.Elem())
+	case reflect.Struct:
+		res := make(map[string]interface{}, rv.NumField())
+		structFields(rv, res)
+		return res
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Key().Kind() != reflect.String {
+			return fromJSON(rv.Interface())
+		}
+		res := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			res[iter.Key().String()] = toTree(iter.Value())
+		}
+		return res
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && (rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8) {
+			return fromJSON(rv.Interface())
+		}
+		res := make([]interface{}, rv.Len())
+		for i := range res {
+			res[i] = toTree(rv.Index(i))
+		}
+		return res
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil
+	default:
+		return rv.Interface()
+	}
+}
+
+func structFields(rv reflect.Value, res map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		value := rv.Field(i)
+		// 埋め込みの構造体はJSONと同様にフィールドを展開する
+		if field.Anonymous && name == "" {
+			for value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					break
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				structFields(value, res)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if mode := Mode(field.Tag.Get(TagName)); mode.valid() {
+			res[name] = maskValue(mode, toTree(value))
+			continue
+		}
+		res[name] = toTree(value)
+	}
+}
+
+func fromJSON(v interface{}) interface{} {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	var res interface{}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return string(buf)
+	}
+	return res
+}
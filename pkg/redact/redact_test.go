@@ -0,0 +1,261 @@
+package redact
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		strs   []string
+		expect []*Rule
+		isErr  bool
+	}{
+		{
+			name: "success",
+			strs: []string{"**.apiKey", " user.email:email ", "", "**.tel:phone"},
+			expect: []*Rule{
+				{Path: "**.apiKey", Mode: ModeSecret},
+				{Path: "user.email", Mode: ModeEmail},
+				{Path: "**.tel", Mode: ModePhone},
+			},
+		},
+		{
+			name:  "unsupported mode",
+			strs:  []string{"**.apiKey:unknown"},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseRules(tt.strs)
+			if tt.isErr {
+				assert.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, err := New(DefaultRules()...)
+	assert.NoError(t, err)
+	_, err = New(&Rule{Path: "**..password", Mode: ModeSecret})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+	_, err = New(&Rule{Path: "password", Mode: "unknown"})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+	assert.NotNil(t, Default())
+}
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		mode   Mode
+		value  string
+		expect string
+	}{
+		{name: "secret", mode: ModeSecret, value: "password", expect: Redacted},
+		{name: "empty", mode: ModeSecret, value: "", expect: ""},
+		{name: "email", mode: ModeEmail, value: "test@example.com", expect: "t***@example.com"},
+		{name: "multibyte email", mode: ModeEmail, value: "テスト@example.com", expect: "テ***@example.com"},
+		{name: "masked email", mode: ModeEmail, value: "t***@example.com", expect: "t***@example.com"},
+		{name: "invalid email", mode: ModeEmail, value: "example.com", expect: Redacted},
+		{name: "phone number", mode: ModePhone, value: "09012345678", expect: "*******5678"},
+		{name: "masked phone number", mode: ModePhone, value: "*******5678", expect: "*******5678"},
+		{name: "short phone number", mode: ModePhone, value: "110", expect: "***"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, Mask(tt.mode, tt.value))
+		})
+	}
+}
+
+func TestRedactor_JSON(t *testing.T) {
+	t.Parallel()
+	r, err := New(append(DefaultRules(),
+		&Rule{Path: "admin.*.note", Mode: ModeSecret},
+		&Rule{Path: "contact", Mode: ModePhone},
+	)...)
+	require.NoError(t, err)
+	tests := []struct {
+		name   string
+		body   string
+		expect string
+	}{
+		{
+			name:   "sign up",
+			body:   `{"email":"test@example.com","phoneNumber":"09012345678","password":"12345678","passwordConfirmation":"12345678"}`,
+			expect: `{"email":"t***@example.com","password":"[REDACTED]","passwordConfirmation":"[REDACTED]","phoneNumber":"*******5678"}`,
+		},
+		{
+			name:   "nested",
+			body:   `{"auth":{"adminId":"admin-id","accessToken":"token","refreshToken":"token","expiresIn":3600}}`,
+			expect: `{"auth":{"accessToken":"[REDACTED]","adminId":"admin-id","expiresIn":3600,"refreshToken":"[REDACTED]"}}`,
+		},
+		{
+			name:   "array",
+			body:   `[{"Password":"12345678"},{"secret":{"value":"secret"}}]`,
+			expect: `[{"Password":"[REDACTED]"},{"secret":"[REDACTED]"}]`,
+		},
+		{
+			name:   "wildcard",
+			body:   `{"admin":{"profile":{"note":"note","name":"name"}},"note":"note","contact":"0312345678"}`,
+			expect: `{"admin":{"profile":{"name":"name","note":"[REDACTED]"}},"contact":"******5678","note":"note"}`,
+		},
+		{
+			name:   "empty",
+			body:   ``,
+			expect: ``,
+		},
+		{
+			name:   "invalid json",
+			body:   `{"password":`,
+			expect: `[OMITTED: 12 bytes]`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, string(r.JSON([]byte(tt.body))))
+		})
+	}
+}
+
+func TestRedactor_Body(t *testing.T) {
+	t.Parallel()
+	r := Default()
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expect      string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"verifyCode":"123456"}`,
+			expect:      `{"verifyCode":"[REDACTED]"}`,
+		},
+		{
+			name:        "problem json",
+			contentType: "application/problem+json",
+			body:        `{"email":"test@example.com"}`,
+			expect:      `{"email":"t***@example.com"}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "token=access-token&token_type_hint=access_token",
+			expect:      "token=%5BREDACTED%5D&token_type_hint=access_token",
+		},
+		{
+			name:        "without content type",
+			contentType: "",
+			body:        `{"refreshToken":"token"}`,
+			expect:      `{"refreshToken":"[REDACTED]"}`,
+		},
+		{
+			name:        "other",
+			contentType: "text/plain",
+			body:        "password",
+			expect:      "[OMITTED: 8 bytes]",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, r.Body(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+type testCredential struct {
+	Name   string `json:"name"`
+	Secret string `json:"value" log:"secret"`
+}
+
+type testEmbedded struct {
+	Tenant string `json:"tenant"`
+}
+
+type testAdmin struct {
+	testEmbedded
+	ID          string            `json:"id"`
+	Email       string            `json:"email"`
+	Tel         string            `json:"tel" log:"phone"`
+	Contact     string            `json:"contact" log:"email"`
+	Password    string            `json:"-"`
+	Credentials []*testCredential `json:"credentials"`
+	Metadata    map[string]string `json:"metadata"`
+	Key         []byte            `json:"key" log:"secret"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Internal    string
+	private     string
+}
+
+func TestRedactor_Value(t *testing.T) {
+	t.Parallel()
+	r := Default()
+	now := time.Date(2023, 10, 6, 18, 30, 0, 0, time.UTC)
+	admin := &testAdmin{
+		testEmbedded: testEmbedded{Tenant: "tenant"},
+		ID:           "admin-id",
+		Email:        "test@example.com",
+		Tel:          "09012345678",
+		Contact:      "contact@example.com",
+		Password:     "12345678",
+		Credentials:  []*testCredential{{Name: "name", Secret: "secret"}},
+		Metadata:     map[string]string{"token": "token", "note": "note"},
+		Key:          []byte("key"),
+		CreatedAt:    now,
+		Internal:     "internal",
+		private:      "private",
+	}
+	expect := map[string]interface{}{
+		"tenant":  "tenant",
+		"id":      "admin-id",
+		"email":   "t***@example.com",
+		"tel":     "*******5678",
+		"contact": "c***@example.com",
+		"credentials": []interface{}{
+			map[string]interface{}{"name": "name", "value": Redacted},
+		},
+		"metadata":  map[string]interface{}{"token": Redacted, "note": "note"},
+		"key":       Redacted,
+		"createdAt": "2023-10-06T18:30:00Z",
+		"Internal":  "internal",
+	}
+	assert.Equal(t, expect, r.Value(admin))
+	// 元の値は変更しない
+	assert.Equal(t, "test@example.com", admin.Email)
+
+	assert.Nil(t, r.Value(nil))
+	assert.Nil(t, r.Value((*testAdmin)(nil)))
+	assert.Equal(t, "value", r.Value("value"))
+	assert.Equal(t, 1, r.Value(1))
+}
+
+func TestRedactor_String(t *testing.T) {
+	t.Parallel()
+	r := Default()
+	assert.Equal(t, "t***@example.com", r.String("email", "test@example.com"))
+	assert.Equal(t, `{"password":"[REDACTED]"}`, r.String("request", ` {"password":"12345678"} `))
+	assert.Equal(t, "{not json", r.String("request", "{not json"))
+	assert.Equal(t, "/v1/admins", r.String("path", "/v1/admins"))
+}
@@ -8,7 +8,13 @@ import (
 	"time"
 
 	"github.com/and-period/furumane/pkg/jst"
+	"github.com/and-period/furumane/pkg/log"
+	"github.com/and-period/furumane/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -21,6 +27,11 @@ var (
 	ErrUnknown         = errors.New("slack: unknown")
 )
 
+var failedCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "slack_message_failures_total",
+	Help: "Total number of Slack messages that failed to be delivered.",
+})
+
 type Client interface {
 	SendMessage(ctx context.Context, options ...slack.MsgOption) error
 }
@@ -34,11 +45,13 @@ type client struct {
 	now       func() time.Time
 	client    *slack.Client
 	logger    *zap.Logger
+	tracer    trace.Tracer
 	channelID string
 }
 
 type options struct {
-	logger *zap.Logger
+	logger         *zap.Logger
+	tracerProvider trace.TracerProvider
 }
 
 type Option func(*options)
@@ -49,6 +62,13 @@ func WithLogger(logger *zap.Logger) Option {
 	}
 }
 
+// WithTracerProvider - OpenTelemetryによるAPI呼び出し単位のトレース (未指定の場合は出力しない)
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(opts *options) {
+		opts.tracerProvider = tp
+	}
+}
+
 func NewClient(params *Params, opts ...Option) Client {
 	dopts := &options{
 		logger: zap.NewNop(),
@@ -60,21 +80,31 @@ func NewClient(params *Params, opts ...Option) Client {
 		now:       jst.Now,
 		client:    slack.New(params.Token),
 		logger:    dopts.logger,
+		tracer:    tracing.Tracer(dopts.tracerProvider),
 		channelID: params.ChannelID,
 	}
 }
 
-func (c *client) SendMessage(ctx context.Context, options ...slack.MsgOption) error {
+func (c *client) SendMessage(ctx context.Context, options ...slack.MsgOption) (err error) {
+	ctx, span := c.tracer.Start(ctx, "slack.SendMessage",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("slack.channel_id", c.channelID)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	//nolint:dogsled
-	_, _, _, err := c.client.SendMessageContext(ctx, c.channelID, options...)
-	return c.slackError(err)
+	_, _, _, err = c.client.SendMessageContext(ctx, c.channelID, options...)
+	if err != nil {
+		failedCounter.Inc()
+	}
+	return c.slackError(ctx, err)
 }
 
-func (c *client) slackError(err error) error {
+func (c *client) slackError(ctx context.Context, err error) error {
 	if err == nil {
 		return nil
 	}
-	c.logger.Error("Failed to send slack api", zap.Error(err))
+	log.FromContext(ctx, c.logger).Error("Failed to send slack api", zap.Error(err))
 
 	switch {
 	case errors.Is(err, slack.ErrParametersMissing),
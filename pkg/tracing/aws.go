@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rpcSystemAWS - AWS SDKの呼び出しを表すrpc.systemの値
+var rpcSystemAWS = semconv.RPCSystemKey.String("aws-api")
+
+// AWSMiddleware - AWS SDKのAPI呼び出し単位 (リトライを含む) でスパンを生成するミドルウェア
+// aws.ConfigのAPIOptionsに追加すると、同じ設定から生成したすべてのクライアント (Cognito、Secrets Managerなど) に適用される
+func AWSMiddleware(tp trace.TracerProvider) func(*middleware.Stack) error {
+	tracer := Tracer(tp)
+	return func(stack *middleware.Stack) error {
+		fn := func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
+			service := awsmiddleware.GetServiceID(ctx)
+			operation := awsmiddleware.GetOperationName(ctx)
+			ctx, span := tracer.Start(ctx, service+"."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					rpcSystemAWS,
+					semconv.RPCService(service),
+					semconv.RPCMethod(operation),
+					semconv.CloudRegion(awsmiddleware.GetRegion(ctx)),
+				),
+			)
+			defer func() { End(span, err) }()
+
+			out, metadata, err = next.HandleInitialize(ctx, in)
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+				span.SetAttributes(attribute.String("aws.request_id", requestID))
+			}
+			return out, metadata, err
+		}
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Tracing", fn), middleware.After)
+	}
+}
@@ -0,0 +1,140 @@
+// Package tracing - OpenTelemetryによる分散トレーシング
+//
+// トレースの送信先 (バックエンド) は設定で切り替え、計装 (HTTP、DB、AWS SDK、Slack) はTracerProviderを受け取って行う
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// InstrumentationName - 計装ライブラリの名前 (Tracerの取得に使用)
+const InstrumentationName = "github.com/and-period/furumane"
+
+// Backend - トレースの送信先
+type Backend string
+
+const (
+	BackendNone     Backend = "none"     // トレースを送信しない
+	BackendNewRelic Backend = "newrelic" // New Relic APMエージェント
+	BackendOTLP     Backend = "otlp"     // OpenTelemetry Protocol (OTLP/HTTP)
+)
+
+var ErrUnsupportedBackend = errors.New("tracing: unsupported backend")
+
+// ParseBackend - トレースの送信先を変換
+func ParseBackend(str string) (Backend, error) {
+	switch backend := Backend(str); backend {
+	case BackendNone, BackendNewRelic, BackendOTLP:
+		return backend, nil
+	case "":
+		return BackendNone, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedBackend, str)
+	}
+}
+
+type options struct {
+	serviceName string
+	environment string
+	sampleRatio float64
+	exporter    sdktrace.SpanExporter
+}
+
+type Option func(*options)
+
+// WithServiceName - トレースに付与するサービス名
+func WithServiceName(name string) Option {
+	return func(opts *options) {
+		opts.serviceName = name
+	}
+}
+
+// WithEnvironment - トレースに付与する実行環境名
+func WithEnvironment(env string) Option {
+	return func(opts *options) {
+		opts.environment = env
+	}
+}
+
+// WithSampleRatio - 新たに開始するトレースのサンプリング率 (0~1)
+// 上流からトレースコンテキストを受け取った場合は、上流のサンプリング結果に従う
+func WithSampleRatio(ratio float64) Option {
+	return func(opts *options) {
+		opts.sampleRatio = ratio
+	}
+}
+
+// WithExporter - トレースの出力先 (未指定の場合はOTLP/HTTP)
+// テストではtracetest.NewInMemoryExporterを指定する
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(opts *options) {
+		opts.exporter = exporter
+	}
+}
+
+// NewTracerProvider - トレースを出力するTracerProviderの生成
+// OTLPの送信先・認証情報は、OpenTelemetryの標準の環境変数 (e.g. OTEL_EXPORTER_OTLP_ENDPOINT) で指定する
+// 終了時はShutdownを呼び出し、送信待ちのトレースを出力すること
+func NewTracerProvider(ctx context.Context, opts ...Option) (*sdktrace.TracerProvider, error) {
+	dopts := &options{
+		sampleRatio: 1,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	exporter := dopts.exporter
+	if exporter == nil {
+		var err error
+		exporter, err = otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	attrs := resource.NewSchemaless(
+		semconv.ServiceName(dopts.serviceName),
+		semconv.DeploymentEnvironment(dopts.environment),
+	)
+	res, err := resource.Merge(resource.Default(), attrs)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(dopts.sampleRatio))),
+	)
+	return tp, nil
+}
+
+// NewPropagator - W3C Trace Context (traceparent, tracestate) とBaggageを伝播するPropagatorの生成
+func NewPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// Tracer - 計装に使用するTracerを取得 (nilの場合はトレースを出力しない)
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return tp.Tracer(InstrumentationName)
+}
+
+// End - エラーを記録してスパンを終了
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
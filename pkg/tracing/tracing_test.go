@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestParseBackend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		str    string
+		expect Backend
+		isErr  bool
+	}{
+		{name: "none", str: "none", expect: BackendNone},
+		{name: "empty", str: "", expect: BackendNone},
+		{name: "new relic", str: "newrelic", expect: BackendNewRelic},
+		{name: "otlp", str: "otlp", expect: BackendOTLP},
+		{name: "unsupported", str: "jaeger", isErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseBackend(tt.str)
+			if tt.isErr {
+				assert.ErrorIs(t, err, ErrUnsupportedBackend)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	tp, err := NewTracerProvider(ctx,
+		WithServiceName("auth"),
+		WithEnvironment("test"),
+		WithExporter(exporter),
+	)
+	require.NoError(t, err)
+	defer tp.Shutdown(ctx) //nolint:errcheck
+
+	_, span := Tracer(tp).Start(ctx, "success")
+	End(span, nil)
+	_, span = Tracer(tp).Start(ctx, "failure")
+	End(span, errors.New("some error"))
+	require.NoError(t, tp.ForceFlush(ctx))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "success", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "failure", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "some error", spans[1].Status.Description)
+	assert.Len(t, spans[1].Events, 1)
+	assert.Equal(t, InstrumentationName, spans[0].InstrumentationLibrary.Name)
+	attrs := spans[0].Resource.Attributes()
+	assert.Contains(t, attrs, semconv.ServiceName("auth"))
+	assert.Contains(t, attrs, semconv.DeploymentEnvironment("test"))
+}
+
+func TestNewTracerProvider_Sampling(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	tp, err := NewTracerProvider(ctx, WithSampleRatio(0), WithExporter(exporter))
+	require.NoError(t, err)
+	defer tp.Shutdown(ctx) //nolint:errcheck
+
+	// 新たに開始するトレースはサンプリング率に従う
+	_, span := Tracer(tp).Start(ctx, "root")
+	span.End()
+
+	// 上流でサンプリングされたトレースは出力する
+	carrier := propagation.MapCarrier{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+	pctx := NewPropagator().Extract(ctx, carrier)
+	_, span = Tracer(tp).Start(pctx, "child")
+	span.End()
+	require.NoError(t, tp.ForceFlush(ctx))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext.TraceID().String())
+}
+
+func TestPropagator(t *testing.T) {
+	t.Parallel()
+	traceID, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	spanID, _ := trace.SpanIDFromHex("b7ad6b7169203331")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	carrier := propagation.MapCarrier{}
+	NewPropagator().Inject(ctx, carrier)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", carrier.Get("traceparent"))
+}
+
+func TestTracer_Nil(t *testing.T) {
+	t.Parallel()
+	_, span := Tracer(nil).Start(context.Background(), "noop")
+	defer span.End()
+	assert.False(t, span.SpanContext().IsValid())
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/and-period/furumane/pkg/i18n"
+	validator "github.com/go-playground/validator/v10"
+)
+
+// FieldError - フィールドごとの検証エラー
+type FieldError struct {
+	Field   string // フィールド名
+	Tag     string // 検証ルール
+	Message string // エラーメッセージ
+}
+
+const defaultMessageKey = "default"
+
+// messages - 検証ルールごとのエラーメッセージ
+// - 1つ目の引数にフィールド名、2つ目の引数に検証ルールのパラメータを渡す
+// - min, maxはフィールドの型に応じて、文字数・件数・値の大きさのメッセージを使い分ける
+var messages = i18n.Catalog{
+	"required": {
+		i18n.LocaleJA: "%[1]sは必須です",
+		i18n.LocaleEN: "%[1]s is required",
+	},
+	"min": {
+		i18n.LocaleJA: "%[1]sは%[2]s文字以上で入力してください",
+		i18n.LocaleEN: "%[1]s must be at least %[2]s characters",
+	},
+	"max": {
+		i18n.LocaleJA: "%[1]sは%[2]s文字以内で入力してください",
+		i18n.LocaleEN: "%[1]s must be at most %[2]s characters",
+	},
+	"min.items": {
+		i18n.LocaleJA: "%[1]sは%[2]s件以上指定してください",
+		i18n.LocaleEN: "%[1]s must contain at least %[2]s items",
+	},
+	"max.items": {
+		i18n.LocaleJA: "%[1]sは%[2]s件以内で指定してください",
+		i18n.LocaleEN: "%[1]s must contain at most %[2]s items",
+	},
+	"min.number": {
+		i18n.LocaleJA: "%[1]sは%[2]s以上の値を入力してください",
+		i18n.LocaleEN: "%[1]s must be greater than or equal to %[2]s",
+	},
+	"max.number": {
+		i18n.LocaleJA: "%[1]sは%[2]s以下の値を入力してください",
+		i18n.LocaleEN: "%[1]s must be less than or equal to %[2]s",
+	},
+	"email": {
+		i18n.LocaleJA: "%[1]sはメールアドレスの形式で入力してください",
+		i18n.LocaleEN: "%[1]s must be a valid email address",
+	},
+	"eqfield": {
+		i18n.LocaleJA: "%[1]sが一致しません",
+		i18n.LocaleEN: "%[1]s does not match",
+	},
+	"hiragana": {
+		i18n.LocaleJA: "%[1]sはひらがなで入力してください",
+		i18n.LocaleEN: "%[1]s must contain only hiragana",
+	},
+	"password": {
+		i18n.LocaleJA: "%[1]sに使用できない文字が含まれています",
+		i18n.LocaleEN: "%[1]s contains characters that are not allowed",
+	},
+	"phone_number": {
+		i18n.LocaleJA: "%[1]sは電話番号の形式で入力してください",
+		i18n.LocaleEN: "%[1]s must be a valid phone number",
+	},
+	defaultMessageKey: {
+		i18n.LocaleJA: "%[1]sの値が不正です",
+		i18n.LocaleEN: "%[1]s is invalid",
+	},
+}
+
+// Translate - 検証エラーをロケールに応じたエラーメッセージへ変換
+// - 検証エラー以外の場合、第2戻り値にfalseを返す
+func Translate(err error, locale i18n.Locale) ([]*FieldError, bool) {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil, false
+	}
+	res := make([]*FieldError, len(verrs))
+	for i, verr := range verrs {
+		res[i] = &FieldError{
+			Field:   verr.Field(),
+			Tag:     verr.Tag(),
+			Message: translate(verr, locale),
+		}
+	}
+	return res, true
+}
+
+func translate(err validator.FieldError, locale i18n.Locale) string {
+	key := messageKey(err)
+	if !messages.Has(key) {
+		key = defaultMessageKey
+	}
+	return messages.Message(locale, key, err.Field(), err.Param())
+}
+
+// messageKey - 検証ルールとフィールドの型からエラーメッセージのキーを取得
+func messageKey(err validator.FieldError) string {
+	tag := err.Tag()
+	if tag != "min" && tag != "max" {
+		return tag
+	}
+	switch err.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return tag + ".items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return tag + ".number"
+	default:
+		return tag
+	}
+}
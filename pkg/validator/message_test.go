@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/and-period/furumane/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		Name                 string            `json:"name" validate:"required,max=4"`
+		Hiragana             string            `json:"hiragana" validate:"omitempty,hiragana"`
+		Password             string            `json:"password" validate:"omitempty,min=8,password"`
+		PasswordConfirmation string            `json:"passwordConfirmation" validate:"eqfield=Password"`
+		PhoneNumber          string            `json:"phoneNumber" validate:"omitempty,phone_number"`
+		Email                string            `json:"email" validate:"omitempty,email"`
+		Code                 string            `validate:"omitempty,len=6"`
+		Scopes               []string          `json:"scopes" validate:"omitempty,min=2,max=3"`
+		Labels               map[string]string `json:"labels" validate:"omitempty,max=1"`
+		Limit                int64             `json:"limit" validate:"omitempty,min=1,max=200"`
+		Ratio                float64           `json:"ratio" validate:"omitempty,max=1"`
+	}
+	tests := []struct {
+		name   string
+		input  *input
+		locale i18n.Locale
+		expect []*FieldError
+	}{
+		{
+			name: "japanese",
+			input: &input{
+				Name:                 "",
+				Hiragana:             "アイウエオ",
+				Password:             "[];:{}/",
+				PasswordConfirmation: "password",
+				PhoneNumber:          "090-1234-1234",
+				Email:                "test",
+				Code:                 "1234",
+				Scopes:               []string{"passkey:read"},
+				Labels:               map[string]string{"a": "1", "b": "2"},
+				Limit:                201,
+				Ratio:                1.5,
+			},
+			locale: i18n.LocaleJA,
+			expect: []*FieldError{
+				{Field: "name", Tag: "required", Message: "nameは必須です"},
+				{Field: "hiragana", Tag: "hiragana", Message: "hiraganaはひらがなで入力してください"},
+				{Field: "password", Tag: "min", Message: "passwordは8文字以上で入力してください"},
+				{Field: "passwordConfirmation", Tag: "eqfield", Message: "passwordConfirmationが一致しません"},
+				{Field: "phoneNumber", Tag: "phone_number", Message: "phoneNumberは電話番号の形式で入力してください"},
+				{Field: "email", Tag: "email", Message: "emailはメールアドレスの形式で入力してください"},
+				{Field: "Code", Tag: "len", Message: "Codeの値が不正です"},
+				{Field: "scopes", Tag: "min", Message: "scopesは2件以上指定してください"},
+				{Field: "labels", Tag: "max", Message: "labelsは1件以内で指定してください"},
+				{Field: "limit", Tag: "max", Message: "limitは200以下の値を入力してください"},
+				{Field: "ratio", Tag: "max", Message: "ratioは1以下の値を入力してください"},
+			},
+		},
+		{
+			name: "english",
+			input: &input{
+				Name:                 "12345",
+				Password:             "[];:{}/[]",
+				PasswordConfirmation: "[];:{}/[]",
+				Scopes:               []string{"a", "b", "c", "d"},
+				Limit:                -1,
+			},
+			locale: i18n.LocaleEN,
+			expect: []*FieldError{
+				{Field: "name", Tag: "max", Message: "name must be at most 4 characters"},
+				{Field: "password", Tag: "password", Message: "password contains characters that are not allowed"},
+				{Field: "scopes", Tag: "max", Message: "scopes must contain at most 3 items"},
+				{Field: "limit", Tag: "min", Message: "limit must be greater than or equal to 1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewValidator().Struct(tt.input)
+			actual, ok := Translate(err, tt.locale)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestTranslate_NotValidationError(t *testing.T) {
+	t.Parallel()
+	actual, ok := Translate(assert.AnError, i18n.LocaleJA)
+	assert.False(t, ok)
+	assert.Nil(t, actual)
+}
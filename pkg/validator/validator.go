@@ -2,7 +2,9 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
+	"strings"
 
 	validator "github.com/go-playground/validator/v10"
 )
@@ -28,6 +30,9 @@ var (
 func NewValidator() Validator {
 	v := validator.New()
 
+	// エラー時のフィールド名をJSONのキー名に揃える
+	v.RegisterTagNameFunc(jsonFieldName)
+
 	// hiragana - 正規表現を使用して平仮名のみであるかの検証
 	v.RegisterValidation("hiragana", validateHiragana)
 	// password - 正規表現を利用してパスワードに使用不可な文字を含んでいないかの検証
@@ -38,6 +43,14 @@ func NewValidator() Validator {
 	return v
 }
 
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
 func validateHiragana(fl validator.FieldLevel) bool {
 	return hiraganaRegex.MatchString(fl.Field().String())
 }
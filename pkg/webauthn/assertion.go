@@ -0,0 +1,72 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// AssertionResponse - 認証時の認証器の応答 (各値はbase64url形式)
+type AssertionResponse struct {
+	ID                string // 認証情報ID
+	ClientDataJSON    string // クライアントデータ
+	AuthenticatorData string // 認証器データ
+	Signature         string // 署名
+}
+
+// AssertionResult - 認証の検証結果
+type AssertionResult struct {
+	SignCount    uint32 // 署名回数
+	UserVerified bool   // ユーザー検証の有無
+	Challenge    string // 検証したチャレンジ (base64url形式、発行時のRequestOptions.Challengeと同じ値)
+}
+
+// FinishLogin - 認証器の応答を検証
+// 認証情報は応答の認証情報IDをもとに、呼び出し側で取得しておくこと
+func (c *client) FinishLogin(session string, credential *Credential, res *AssertionResponse) (*AssertionResult, error) {
+	data, err := c.session.verify(session, sessionTypeAuthentication, c.now())
+	if err != nil {
+		return nil, err
+	}
+	if id, err := decodeBase64(res.ID); err != nil || !bytes.Equal(id, credential.ID) {
+		return nil, fmt.Errorf("%w: credential id mismatch", ErrInvalidUser)
+	}
+	clientDataJSON, err := decodeBase64(res.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidClientData, err.Error())
+	}
+	if err := c.verifyClientData(clientDataJSON, "webauthn.get", data.Challenge); err != nil {
+		return nil, err
+	}
+	buf, err := decodeBase64(res.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAuthenticatorData, err.Error())
+	}
+	authData, err := parseAuthenticatorData(buf)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	sig, err := decodeBase64(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignature, err.Error())
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, buf...), clientDataHash[:]...)
+	if err := verifySignature(credential.PublicKey, signed, sig); err != nil {
+		return nil, err
+	}
+	// 署名回数が増加していない場合は、認証器が複製された可能性がある
+	if (authData.SignCount != 0 || credential.SignCount != 0) && authData.SignCount <= credential.SignCount {
+		return nil, ErrInvalidSignCount
+	}
+	result := &AssertionResult{
+		SignCount:    authData.SignCount,
+		UserVerified: authData.userVerified(),
+		Challenge:    base64.RawURLEncoding.EncodeToString(data.Challenge),
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_FinishLogin(t *testing.T) {
+	t.Parallel()
+	v := loadVector(t, "assertion")
+	registration := loadVector(t, "registration_none")
+	publicKey, err := base64.RawURLEncoding.DecodeString(v.PublicKey)
+	require.NoError(t, err)
+	credentialID, err := base64.RawURLEncoding.DecodeString(v.ID)
+	require.NoError(t, err)
+	credential := func(signCount uint32) *Credential {
+		return &Credential{
+			ID:        credentialID,
+			PublicKey: publicKey,
+			SignCount: signCount,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(c *client)
+		credential *Credential
+		modify     func(res *AssertionResponse)
+		expect     *AssertionResult
+		err        error
+	}{
+		{
+			name:       "success",
+			credential: credential(0),
+			expect: &AssertionResult{
+				SignCount:    1,
+				UserVerified: true,
+				Challenge:    v.Challenge,
+			},
+		},
+		{
+			name:       "sign count not increased",
+			credential: credential(1),
+			err:        ErrInvalidSignCount,
+		},
+		{
+			name:       "credential mismatch",
+			credential: &Credential{ID: []byte("credential-id"), PublicKey: publicKey},
+			err:        ErrInvalidUser,
+		},
+		{
+			name:       "unexpected client data type",
+			credential: credential(0),
+			modify: func(res *AssertionResponse) {
+				res.ClientDataJSON = registration.ClientDataJSON
+			},
+			err: ErrInvalidClientData,
+		},
+		{
+			name:       "user verification required",
+			credential: credential(0),
+			setup: func(c *client) {
+				c.userVerification = UserVerificationRequired
+			},
+			modify: func(res *AssertionResponse) {
+				buf, err := base64.RawURLEncoding.DecodeString(res.AuthenticatorData)
+				require.NoError(t, err)
+				buf[32] = flagUserPresent
+				res.AuthenticatorData = base64.RawURLEncoding.EncodeToString(buf)
+			},
+			err: ErrInvalidAuthenticatorData,
+		},
+		{
+			name:       "invalid signature",
+			credential: credential(0),
+			modify: func(res *AssertionResponse) {
+				buf, err := base64.RawURLEncoding.DecodeString(res.AuthenticatorData)
+				require.NoError(t, err)
+				buf[36] = 2 // 署名回数を改ざん
+				res.AuthenticatorData = base64.RawURLEncoding.EncodeToString(buf)
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name:       "invalid authenticator data",
+			credential: credential(0),
+			modify: func(res *AssertionResponse) {
+				res.AuthenticatorData = "AAAA"
+			},
+			err: ErrInvalidAuthenticatorData,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := newTestClient()
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+			session := newTestSession(t, c, sessionTypeAuthentication, v.Challenge, nil)
+			res := &AssertionResponse{
+				ID:                v.ID,
+				ClientDataJSON:    v.ClientDataJSON,
+				AuthenticatorData: v.AuthenticatorData,
+				Signature:         v.Signature,
+			}
+			if tt.modify != nil {
+				tt.modify(res)
+			}
+			actual, err := c.FinishLogin(session, tt.credential, res)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// 対応するアテステーション形式
+const (
+	attestationFormatNone   = "none"
+	attestationFormatPacked = "packed"
+)
+
+// AttestationResponse - 登録時の認証器の応答 (各値はbase64url形式)
+type AttestationResponse struct {
+	ID                string   // 認証情報ID
+	ClientDataJSON    string   // クライアントデータ
+	AttestationObject string   // アテステーションオブジェクト
+	Transports        []string // 認証器との通信方法
+}
+
+type attestationObject struct {
+	Format    string                     `cbor:"fmt"`
+	Statement map[string]cbor.RawMessage `cbor:"attStmt"`
+	AuthData  []byte                     `cbor:"authData"`
+}
+
+type packedStatement struct {
+	Alg int64    `cbor:"alg"`
+	Sig []byte   `cbor:"sig"`
+	X5C [][]byte `cbor:"x5c"`
+}
+
+func (c *client) FinishRegistration(session string, user *User, res *AttestationResponse) (*Credential, error) {
+	data, err := c.session.verify(session, sessionTypeRegistration, c.now())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(data.UserID, user.ID) {
+		return nil, ErrInvalidUser
+	}
+	clientDataJSON, err := decodeBase64(res.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidClientData, err.Error())
+	}
+	if err := c.verifyClientData(clientDataJSON, "webauthn.create", data.Challenge); err != nil {
+		return nil, err
+	}
+	buf, err := decodeBase64(res.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+	}
+	obj := &attestationObject{}
+	if err := cbor.Unmarshal(buf, obj); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+	}
+	authData, err := parseAuthenticatorData(obj.AuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	if authData.PublicKey == nil {
+		return nil, fmt.Errorf("%w: not found attested credential data", ErrInvalidAttestation)
+	}
+	if id, err := decodeBase64(res.ID); err != nil || !bytes.Equal(id, authData.CredentialID) {
+		return nil, fmt.Errorf("%w: credential id mismatch", ErrInvalidAttestation)
+	}
+	key, err := parseCOSEKey(authData.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := key.publicKey(); err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, obj.AuthData...), clientDataHash[:]...)
+	if err := verifyAttestationStatement(obj, key, authData, signed); err != nil {
+		return nil, err
+	}
+	credential := &Credential{
+		ID:         authData.CredentialID,
+		PublicKey:  authData.PublicKey,
+		SignCount:  authData.SignCount,
+		Transports: res.Transports,
+		AAGUID:     authData.AAGUID,
+	}
+	return credential, nil
+}
+
+// verifyAttestationStatement - アテステーションステートメントの検証
+// 認証器の真正性 (証明書チェーン) までは検証しない
+func verifyAttestationStatement(obj *attestationObject, key coseKey, authData *authenticatorData, signed []byte) error {
+	switch obj.Format {
+	case attestationFormatNone:
+		if len(obj.Statement) > 0 {
+			return fmt.Errorf("%w: none attestation has statement", ErrInvalidAttestation)
+		}
+		return nil
+	case attestationFormatPacked:
+		buf, err := cbor.Marshal(obj.Statement)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+		}
+		stmt := &packedStatement{}
+		if err := cbor.Unmarshal(buf, stmt); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+		}
+		if len(stmt.X5C) == 0 {
+			// 自己アテステーション
+			if stmt.Alg != key.alg() {
+				return fmt.Errorf("%w: algorithm mismatch", ErrInvalidAttestation)
+			}
+			return verifySignature(authData.PublicKey, signed, stmt.Sig)
+		}
+		cert, err := x509.ParseCertificate(stmt.X5C[0])
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+		}
+		if cert.Version != 3 || cert.IsCA {
+			return fmt.Errorf("%w: invalid attestation certificate", ErrInvalidAttestation)
+		}
+		return verifyCertificateSignature(cert, stmt.Alg, signed, stmt.Sig)
+	default:
+		return fmt.Errorf("%w: unsupported format %s", ErrInvalidAttestation, obj.Format)
+	}
+}
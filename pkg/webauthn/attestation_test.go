@@ -0,0 +1,145 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_FinishRegistration(t *testing.T) {
+	t.Parallel()
+	none := loadVector(t, "registration_none")
+	packed := loadVector(t, "registration_packed")
+	assertion := loadVector(t, "assertion")
+	user := &User{ID: []byte("admin-id")}
+	publicKey, err := base64.RawURLEncoding.DecodeString(assertion.PublicKey)
+	require.NoError(t, err)
+	credentialID, err := base64.RawURLEncoding.DecodeString(none.ID)
+	require.NoError(t, err)
+	expect := &Credential{
+		ID:         credentialID,
+		PublicKey:  publicKey,
+		SignCount:  0,
+		Transports: []string{"internal", "hybrid"},
+		AAGUID:     make([]byte, 16),
+	}
+	response := func(v *vector) *AttestationResponse {
+		return &AttestationResponse{
+			ID:                v.ID,
+			ClientDataJSON:    v.ClientDataJSON,
+			AttestationObject: v.AttestationObject,
+			Transports:        []string{"internal", "hybrid"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(c *client)
+		vector *vector
+		modify func(res *AttestationResponse)
+		userID []byte
+		expect *Credential
+		err    error
+	}{
+		{
+			name:   "none attestation",
+			vector: none,
+			expect: expect,
+		},
+		{
+			name:   "packed self attestation",
+			vector: packed,
+			expect: expect,
+		},
+		{
+			name:   "user mismatch",
+			vector: none,
+			userID: []byte("other-id"),
+			err:    ErrInvalidUser,
+		},
+		{
+			name:   "challenge mismatch",
+			vector: none,
+			modify: func(res *AttestationResponse) {
+				res.ClientDataJSON = packed.ClientDataJSON
+			},
+			err: ErrInvalidClientData,
+		},
+		{
+			name:   "origin mismatch",
+			vector: none,
+			setup: func(c *client) {
+				c.origins = map[string]struct{}{"https://example.com": {}}
+			},
+			err: ErrInvalidClientData,
+		},
+		{
+			name:   "rp id mismatch",
+			vector: none,
+			setup: func(c *client) {
+				c.rpID = "example.com"
+			},
+			err: ErrInvalidAuthenticatorData,
+		},
+		{
+			name:   "credential id mismatch",
+			vector: none,
+			modify: func(res *AttestationResponse) {
+				res.ID = "Y3JlZGVudGlhbC1pZA"
+			},
+			err: ErrInvalidAttestation,
+		},
+		{
+			name:   "invalid signature",
+			vector: packed,
+			modify: func(res *AttestationResponse) {
+				buf, err := base64.RawURLEncoding.DecodeString(res.AttestationObject)
+				require.NoError(t, err)
+				obj := &attestationObject{}
+				require.NoError(t, cbor.Unmarshal(buf, obj))
+				var sig []byte
+				require.NoError(t, cbor.Unmarshal(obj.Statement["sig"], &sig))
+				sig[len(sig)-1] ^= 0xff
+				obj.Statement["sig"], err = cbor.Marshal(sig)
+				require.NoError(t, err)
+				buf, err = cbor.Marshal(obj)
+				require.NoError(t, err)
+				res.AttestationObject = base64.RawURLEncoding.EncodeToString(buf)
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name:   "invalid attestation object",
+			vector: none,
+			modify: func(res *AttestationResponse) {
+				res.AttestationObject = "invalid"
+			},
+			err: ErrInvalidAttestation,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := newTestClient()
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+			session := newTestSession(t, c, sessionTypeRegistration, tt.vector.Challenge, user.ID)
+			res := response(tt.vector)
+			if tt.modify != nil {
+				tt.modify(res)
+			}
+			u := user
+			if tt.userID != nil {
+				u = &User{ID: tt.userID}
+			}
+			actual, err := c.FinishRegistration(session, u, res)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
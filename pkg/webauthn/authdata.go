@@ -0,0 +1,137 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// 認証器データのフラグ
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+	flagExtensionData          = 0x80
+)
+
+// clientData - クライアントデータ (CollectedClientData)
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// authenticatorData - 認証器データ
+type authenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+func (a *authenticatorData) userPresent() bool {
+	return a.Flags&flagUserPresent != 0
+}
+
+func (a *authenticatorData) userVerified() bool {
+	return a.Flags&flagUserVerified != 0
+}
+
+// parseAuthenticatorData - 認証器データを解析
+// https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+func parseAuthenticatorData(buf []byte) (*authenticatorData, error) {
+	const minLength = 37 // rpIdHash(32) + flags(1) + signCount(4)
+	if len(buf) < minLength {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidAuthenticatorData)
+	}
+	data := &authenticatorData{
+		RPIDHash:  buf[:32],
+		Flags:     buf[32],
+		SignCount: binary.BigEndian.Uint32(buf[33:37]),
+	}
+	rest := buf[minLength:]
+	if data.Flags&flagAttestedCredentialData != 0 {
+		const headerLength = 18 // aaguid(16) + credentialIdLength(2)
+		if len(rest) < headerLength {
+			return nil, fmt.Errorf("%w: too short attested credential data", ErrInvalidAuthenticatorData)
+		}
+		data.AAGUID = rest[:16]
+		length := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[headerLength:]
+		if len(rest) < length {
+			return nil, fmt.Errorf("%w: too short credential id", ErrInvalidAuthenticatorData)
+		}
+		data.CredentialID, rest = rest[:length], rest[length:]
+		var key cbor.RawMessage
+		remain, err := cbor.UnmarshalFirst(rest, &key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAuthenticatorData, err.Error())
+		}
+		data.PublicKey, rest = rest[:len(rest)-len(remain)], remain
+	}
+	if data.Flags&flagExtensionData != 0 {
+		var extensions cbor.RawMessage
+		remain, err := cbor.UnmarshalFirst(rest, &extensions)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAuthenticatorData, err.Error())
+		}
+		rest = remain
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: unexpected trailing bytes", ErrInvalidAuthenticatorData)
+	}
+	return data, nil
+}
+
+// verifyClientData - クライアントデータの検証
+func (c *client) verifyClientData(buf []byte, typ string, challenge []byte) error {
+	data := &clientData{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidClientData, err.Error())
+	}
+	if data.Type != typ {
+		return fmt.Errorf("%w: unexpected type %s", ErrInvalidClientData, data.Type)
+	}
+	actual, err := decodeBase64(data.Challenge)
+	if err != nil || !bytes.Equal(actual, challenge) {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidClientData)
+	}
+	if _, ok := c.origins[data.Origin]; !ok {
+		return fmt.Errorf("%w: unexpected origin %s", ErrInvalidClientData, data.Origin)
+	}
+	return nil
+}
+
+// verifyAuthenticatorData - 認証器データの検証
+func (c *client) verifyAuthenticatorData(data *authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+	if !bytes.Equal(data.RPIDHash, rpIDHash[:]) {
+		return fmt.Errorf("%w: rp id hash mismatch", ErrInvalidAuthenticatorData)
+	}
+	if !data.userPresent() {
+		return fmt.Errorf("%w: user not present", ErrInvalidAuthenticatorData)
+	}
+	if c.userVerification == UserVerificationRequired && !data.userVerified() {
+		return fmt.Errorf("%w: user not verified", ErrInvalidAuthenticatorData)
+	}
+	return nil
+}
+
+// decodeBase64 - base64url (パディングの有無を問わない) をデコード
+func decodeBase64(str string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(trimPadding(str))
+}
+
+func trimPadding(str string) string {
+	for len(str) > 0 && str[len(str)-1] == '=' {
+		str = str[:len(str)-1]
+	}
+	return str
+}
@@ -0,0 +1,116 @@
+//go:generate mockgen -source=$GOFILE -package mock_$GOPACKAGE -destination=./../../mock/pkg/$GOPACKAGE/$GOFILE
+package webauthn
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidSession           = errors.New("webauthn: invalid session")
+	ErrSessionExpired           = errors.New("webauthn: session expired")
+	ErrInvalidClientData        = errors.New("webauthn: invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("webauthn: invalid authenticator data")
+	ErrInvalidAttestation       = errors.New("webauthn: invalid attestation")
+	ErrUnsupportedAlgorithm     = errors.New("webauthn: unsupported algorithm")
+	ErrInvalidSignature         = errors.New("webauthn: invalid signature")
+	ErrInvalidSignCount         = errors.New("webauthn: invalid sign count")
+	ErrInvalidUser              = errors.New("webauthn: invalid user")
+)
+
+type Client interface {
+	// 登録 (オプションとセッションの生成)
+	BeginRegistration(user *User, exclusions []*Credential) (*CreationOptions, string, error)
+	// 登録 (認証器の応答の検証)
+	FinishRegistration(session string, user *User, res *AttestationResponse) (*Credential, error)
+	// 認証 (オプションとセッションの生成)
+	BeginLogin(allows []*Credential) (*RequestOptions, string, error)
+	// 認証 (認証器の応答の検証)
+	FinishLogin(session string, credential *Credential, res *AssertionResponse) (*AssertionResult, error)
+}
+
+type Params struct {
+	RPID          string   // Relying Party ID (ドメイン名)
+	RPName        string   // Relying Party名
+	Origins       []string // 許可するオリジン一覧
+	SessionSecret []byte   // セッションの署名に使用する秘密鍵
+}
+
+// User - 認証器に登録するユーザー情報
+type User struct {
+	ID          []byte // ユーザーID (個人情報を含めないこと)
+	Name        string // ユーザー名
+	DisplayName string // 表示名
+}
+
+// Credential - 登録済みの認証情報
+type Credential struct {
+	ID         []byte   // 認証情報ID
+	PublicKey  []byte   // 公開鍵 (COSE_Key形式)
+	SignCount  uint32   // 署名回数
+	Transports []string // 認証器との通信方法
+	AAGUID     []byte   // 認証器の種別
+}
+
+type client struct {
+	now              func() time.Time
+	rpID             string
+	rpName           string
+	origins          map[string]struct{}
+	session          *sessionSigner
+	timeout          time.Duration
+	userVerification string
+}
+
+type options struct {
+	timeout          time.Duration
+	userVerification string
+}
+
+type Option func(*options)
+
+// WithTimeout - セレモニーの制限時間 (セッションの有効期限)
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.timeout = timeout
+	}
+}
+
+// WithUserVerification - ユーザー検証の要求 (required, preferred, discouraged)
+func WithUserVerification(requirement string) Option {
+	return func(opts *options) {
+		opts.userVerification = requirement
+	}
+}
+
+func NewClient(params *Params, opts ...Option) Client {
+	dopts := &options{
+		timeout:          5 * time.Minute,
+		userVerification: UserVerificationPreferred,
+	}
+	for i := range opts {
+		opts[i](dopts)
+	}
+	origins := make(map[string]struct{}, len(params.Origins))
+	for _, origin := range params.Origins {
+		origins[origin] = struct{}{}
+	}
+	return &client{
+		now:              time.Now,
+		rpID:             params.RPID,
+		rpName:           params.RPName,
+		origins:          origins,
+		session:          &sessionSigner{secret: params.SessionSecret},
+		timeout:          dopts.timeout,
+		userVerification: dopts.userVerification,
+	}
+}
+
+func newChallenge() ([]byte, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
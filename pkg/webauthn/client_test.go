@@ -0,0 +1,111 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var current = time.Date(2023, 10, 1, 18, 30, 0, 0, time.UTC)
+
+// vector - 認証器の応答の記録 (testdata/vectors.json)
+type vector struct {
+	Challenge         string `json:"challenge"`
+	ID                string `json:"id"`
+	PublicKey         string `json:"publicKey"`
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+}
+
+func loadVector(t *testing.T, name string) *vector {
+	buf, err := os.ReadFile("testdata/vectors.json")
+	require.NoError(t, err)
+	vectors := map[string]*vector{}
+	require.NoError(t, json.Unmarshal(buf, &vectors))
+	require.Contains(t, vectors, name)
+	return vectors[name]
+}
+
+func newTestClient() *client {
+	params := &Params{
+		RPID:          "localhost",
+		RPName:        "furumane",
+		Origins:       []string{"http://localhost:3000"},
+		SessionSecret: []byte("secret"),
+	}
+	c := NewClient(params).(*client)
+	c.now = func() time.Time {
+		return current
+	}
+	return c
+}
+
+func newTestSession(t *testing.T, c *client, typ sessionType, challenge string, userID []byte) string {
+	buf, err := base64.RawURLEncoding.DecodeString(challenge)
+	require.NoError(t, err)
+	session, err := c.session.sign(&sessionData{
+		Type:      typ,
+		Challenge: buf,
+		UserID:    userID,
+		ExpiresAt: current.Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+	return session
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	c := NewClient(&Params{}, WithTimeout(time.Minute), WithUserVerification(UserVerificationRequired))
+	assert.NotNil(t, c)
+}
+
+func TestClient_BeginRegistration(t *testing.T) {
+	t.Parallel()
+	c := newTestClient()
+	user := &User{
+		ID:          []byte("admin-id"),
+		Name:        "test@example.com",
+		DisplayName: "test@example.com",
+	}
+	exclusions := []*Credential{{ID: []byte("credential-id"), Transports: []string{"internal"}}}
+	options, session, err := c.BeginRegistration(user, exclusions)
+	require.NoError(t, err)
+
+	data, err := c.session.verify(session, sessionTypeRegistration, current)
+	require.NoError(t, err)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(data.Challenge), options.Challenge)
+	assert.Equal(t, []byte("admin-id"), data.UserID)
+	assert.Equal(t, &RPEntity{ID: "localhost", Name: "furumane"}, options.RP)
+	assert.Equal(t, &UserEntity{ID: "YWRtaW4taWQ", Name: "test@example.com", DisplayName: "test@example.com"}, options.User)
+	assert.Equal(t, []*CredentialParameter{
+		{Type: "public-key", Alg: AlgES256},
+		{Type: "public-key", Alg: AlgEdDSA},
+		{Type: "public-key", Alg: AlgRS256},
+	}, options.PubKeyCredParams)
+	assert.Equal(t, []*CredentialDescriptor{
+		{Type: "public-key", ID: "Y3JlZGVudGlhbC1pZA", Transports: []string{"internal"}},
+	}, options.ExcludeCredentials)
+	assert.Equal(t, int64(300000), options.Timeout)
+	assert.Equal(t, "none", options.Attestation)
+}
+
+func TestClient_BeginLogin(t *testing.T) {
+	t.Parallel()
+	c := newTestClient()
+	options, session, err := c.BeginLogin(nil)
+	require.NoError(t, err)
+
+	data, err := c.session.verify(session, sessionTypeAuthentication, current)
+	require.NoError(t, err)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(data.Challenge), options.Challenge)
+	assert.Equal(t, "localhost", options.RPID)
+	assert.Equal(t, UserVerificationPreferred, options.UserVerification)
+	assert.Empty(t, options.AllowCredentials)
+}
@@ -0,0 +1,156 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSEアルゴリズム識別子
+const (
+	AlgES256 int64 = -7   // ECDSA w/ SHA-256
+	AlgEdDSA int64 = -8   // EdDSA
+	AlgRS256 int64 = -257 // RSASSA-PKCS1-v1_5 w/ SHA-256
+)
+
+// supportedAlgorithms - 対応する署名アルゴリズム (優先順)
+var supportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE_Keyのパラメータ
+const (
+	coseKeyKty   = 1
+	coseKeyAlg   = 3
+	coseKeyCrv   = -1 // EC2, OKP
+	coseKeyX     = -2 // EC2, OKP
+	coseKeyY     = -3 // EC2
+	coseKeyRSAN  = -1 // RSA
+	coseKeyRSAE  = -2 // RSA
+	coseKtyOKP   = 1
+	coseKtyEC2   = 2
+	coseKtyRSA   = 3
+	coseCrvP256  = 1
+	coseCrvEd255 = 6
+)
+
+type coseKey map[int64]interface{}
+
+func parseCOSEKey(buf []byte) (coseKey, error) {
+	key := coseKey{}
+	if err := cbor.Unmarshal(buf, &key); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttestation, err.Error())
+	}
+	return key, nil
+}
+
+func (k coseKey) int(label int64) (int64, bool) {
+	switch v := k[label].(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func (k coseKey) bytes(label int64) []byte {
+	v, _ := k[label].([]byte)
+	return v
+}
+
+func (k coseKey) alg() int64 {
+	alg, _ := k.int(coseKeyAlg)
+	return alg
+}
+
+// publicKey - COSE_Keyを公開鍵に変換
+func (k coseKey) publicKey() (crypto.PublicKey, error) {
+	kty, _ := k.int(coseKeyKty)
+	crv, _ := k.int(coseKeyCrv)
+	switch {
+	case kty == coseKtyEC2 && k.alg() == AlgES256 && crv == coseCrvP256:
+		x, y := k.bytes(coseKeyX), k.bytes(coseKeyY)
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: invalid ec2 key", ErrInvalidAttestation)
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("%w: point is not on curve", ErrInvalidAttestation)
+		}
+		return pub, nil
+	case kty == coseKtyOKP && k.alg() == AlgEdDSA && crv == coseCrvEd255:
+		x := k.bytes(coseKeyX)
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid okp key", ErrInvalidAttestation)
+		}
+		return ed25519.PublicKey(x), nil
+	case kty == coseKtyRSA && k.alg() == AlgRS256:
+		n, e := k.bytes(coseKeyRSAN), k.bytes(coseKeyRSAE)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("%w: invalid rsa key", ErrInvalidAttestation)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: kty=%d, alg=%d", ErrUnsupportedAlgorithm, kty, k.alg())
+	}
+}
+
+// verifySignature - COSE_Key形式の公開鍵で署名を検証
+func verifySignature(key []byte, data, sig []byte) error {
+	k, err := parseCOSEKey(key)
+	if err != nil {
+		return err
+	}
+	pub, err := k.publicKey()
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(data)
+	var ok bool
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(pub, digest[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(pub, data, sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyCertificateSignature - 証明書の公開鍵で署名を検証
+func verifyCertificateSignature(cert *x509.Certificate, alg int64, data, sig []byte) error {
+	var algorithm x509.SignatureAlgorithm
+	switch alg {
+	case AlgES256:
+		algorithm = x509.ECDSAWithSHA256
+	case AlgEdDSA:
+		algorithm = x509.PureEd25519
+	case AlgRS256:
+		algorithm = x509.SHA256WithRSA
+	default:
+		return fmt.Errorf("%w: alg=%d", ErrUnsupportedAlgorithm, alg)
+	}
+	if err := cert.CheckSignature(algorithm, data, sig); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err.Error())
+	}
+	return nil
+}
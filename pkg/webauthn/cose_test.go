@@ -0,0 +1,98 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+	data := []byte("signed data")
+	digest := sha256.Sum256(data)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	encode := func(key map[int64]interface{}) []byte {
+		buf, err := cbor.Marshal(key)
+		require.NoError(t, err)
+		return buf
+	}
+	ec2 := encode(map[int64]interface{}{
+		coseKeyKty: coseKtyEC2,
+		coseKeyAlg: AlgES256,
+		coseKeyCrv: coseCrvP256,
+		coseKeyX:   ecKey.X.FillBytes(make([]byte, 32)),
+		coseKeyY:   ecKey.Y.FillBytes(make([]byte, 32)),
+	})
+	okp := encode(map[int64]interface{}{
+		coseKeyKty: coseKtyOKP,
+		coseKeyAlg: AlgEdDSA,
+		coseKeyCrv: coseCrvEd255,
+		coseKeyX:   []byte(edPub),
+	})
+	rsa := encode(map[int64]interface{}{
+		coseKeyKty:  coseKtyRSA,
+		coseKeyAlg:  AlgRS256,
+		coseKeyRSAN: rsaKey.N.Bytes(),
+		coseKeyRSAE: big.NewInt(int64(rsaKey.E)).Bytes(),
+	})
+
+	tests := []struct {
+		name string
+		key  []byte
+		sig  []byte
+		err  error
+	}{
+		{name: "es256", key: ec2, sig: ecSig},
+		{name: "eddsa", key: okp, sig: ed25519.Sign(edKey, data)},
+		{name: "rs256", key: rsa, sig: rsaSig},
+		{name: "invalid signature", key: ec2, sig: rsaSig, err: ErrInvalidSignature},
+		{
+			name: "unsupported algorithm",
+			key:  encode(map[int64]interface{}{coseKeyKty: coseKtyEC2, coseKeyAlg: -35, coseKeyCrv: 2}),
+			sig:  ecSig,
+			err:  ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "point is not on curve",
+			key: encode(map[int64]interface{}{
+				coseKeyKty: coseKtyEC2,
+				coseKeyAlg: AlgES256,
+				coseKeyCrv: coseCrvP256,
+				coseKeyX:   make([]byte, 32),
+				coseKeyY:   make([]byte, 32),
+			}),
+			sig: ecSig,
+			err: ErrInvalidAttestation,
+		},
+		{name: "invalid key", key: []byte{0xff}, sig: ecSig, err: ErrInvalidAttestation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifySignature(tt.key, data, tt.sig)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package webauthn
+
+import (
+	"encoding/base64"
+)
+
+const publicKeyType = "public-key"
+
+// ユーザー検証の要求
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// CreationOptions - 登録時に認証器へ渡すオプション (PublicKeyCredentialCreationOptionsJSON)
+type CreationOptions struct {
+	Challenge              string                  `json:"challenge"`
+	RP                     *RPEntity               `json:"rp"`
+	User                   *UserEntity             `json:"user"`
+	PubKeyCredParams       []*CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                   `json:"timeout"`
+	ExcludeCredentials     []*CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection *AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                  `json:"attestation"`
+}
+
+// RequestOptions - 認証時に認証器へ渡すオプション (PublicKeyCredentialRequestOptionsJSON)
+type RequestOptions struct {
+	Challenge        string                  `json:"challenge"`
+	Timeout          int64                   `json:"timeout"`
+	RPID             string                  `json:"rpId"`
+	AllowCredentials []*CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                  `json:"userVerification"`
+}
+
+type RPEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+func (c *client) BeginRegistration(user *User, exclusions []*Credential) (*CreationOptions, string, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, "", err
+	}
+	session, err := c.session.sign(&sessionData{
+		Type:      sessionTypeRegistration,
+		Challenge: challenge,
+		UserID:    user.ID,
+		ExpiresAt: c.now().Add(c.timeout).Unix(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	params := make([]*CredentialParameter, len(supportedAlgorithms))
+	for i, alg := range supportedAlgorithms {
+		params[i] = &CredentialParameter{Type: publicKeyType, Alg: alg}
+	}
+	options := &CreationOptions{
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		RP: &RPEntity{
+			ID:   c.rpID,
+			Name: c.rpName,
+		},
+		User: &UserEntity{
+			ID:          base64.RawURLEncoding.EncodeToString(user.ID),
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            c.timeout.Milliseconds(),
+		ExcludeCredentials: newDescriptors(exclusions),
+		AuthenticatorSelection: &AuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: c.userVerification,
+		},
+		Attestation: "none",
+	}
+	return options, session, nil
+}
+
+func (c *client) BeginLogin(allows []*Credential) (*RequestOptions, string, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, "", err
+	}
+	session, err := c.session.sign(&sessionData{
+		Type:      sessionTypeAuthentication,
+		Challenge: challenge,
+		ExpiresAt: c.now().Add(c.timeout).Unix(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	options := &RequestOptions{
+		Challenge:        base64.RawURLEncoding.EncodeToString(challenge),
+		Timeout:          c.timeout.Milliseconds(),
+		RPID:             c.rpID,
+		AllowCredentials: newDescriptors(allows),
+		UserVerification: c.userVerification,
+	}
+	return options, session, nil
+}
+
+func newDescriptors(credentials []*Credential) []*CredentialDescriptor {
+	res := make([]*CredentialDescriptor, len(credentials))
+	for i := range credentials {
+		res[i] = &CredentialDescriptor{
+			Type:       publicKeyType,
+			ID:         base64.RawURLEncoding.EncodeToString(credentials[i].ID),
+			Transports: credentials[i].Transports,
+		}
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package webauthn
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type sessionType string
+
+const (
+	sessionTypeRegistration   sessionType = "registration"
+	sessionTypeAuthentication sessionType = "authentication"
+)
+
+// sessionData - セレモニーの状態
+// サーバー側で保持せず、署名した上でクライアントに渡す
+// 有効期限内は何度でも検証できるため、認証の再送を防ぐ場合は呼び出し側でチャレンジの利用済みを管理すること
+type sessionData struct {
+	Type      sessionType `json:"t"` // セレモニー種別
+	Challenge []byte      `json:"c"` // チャレンジ
+	UserID    []byte      `json:"u"` // ユーザーID (登録時のみ)
+	ExpiresAt int64       `json:"e"` // 有効期限 (UNIX時間)
+}
+
+type sessionSigner struct {
+	secret []byte
+}
+
+// sign - セッションを{payload}.{署名}の形式で返す
+func (s *sessionSigner) sign(data *sessionData) (string, error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(buf)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload)), nil
+}
+
+func (s *sessionSigner) verify(token string, typ sessionType, now time.Time) (*sessionData, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || len(s.secret) == 0 {
+		return nil, ErrInvalidSession
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.mac(payload)) {
+		return nil, ErrInvalidSession
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSession, err.Error())
+	}
+	data := &sessionData{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSession, err.Error())
+	}
+	if data.Type != typ {
+		return nil, fmt.Errorf("%w: unexpected session type %s", ErrInvalidSession, data.Type)
+	}
+	if !now.Before(time.Unix(data.ExpiresAt, 0)) {
+		return nil, ErrSessionExpired
+	}
+	return data, nil
+}
+
+func (s *sessionSigner) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
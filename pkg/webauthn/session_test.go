@@ -0,0 +1,98 @@
+package webauthn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionSigner(t *testing.T) {
+	t.Parallel()
+	signer := &sessionSigner{secret: []byte("secret")}
+	data := &sessionData{
+		Type:      sessionTypeRegistration,
+		Challenge: []byte("challenge"),
+		UserID:    []byte("admin-id"),
+		ExpiresAt: current.Add(time.Minute).Unix(),
+	}
+	session, err := signer.sign(data)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		signer  *sessionSigner
+		session string
+		typ     sessionType
+		now     time.Time
+		expect  *sessionData
+		err     error
+	}{
+		{
+			name:    "success",
+			signer:  signer,
+			session: session,
+			typ:     sessionTypeRegistration,
+			now:     current,
+			expect:  data,
+		},
+		{
+			name:    "other secret",
+			signer:  &sessionSigner{secret: []byte("other")},
+			session: session,
+			typ:     sessionTypeRegistration,
+			now:     current,
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "empty secret",
+			signer:  &sessionSigner{},
+			session: session,
+			typ:     sessionTypeRegistration,
+			now:     current,
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "tampered",
+			signer:  signer,
+			session: "e30." + session[len(session)-43:],
+			typ:     sessionTypeRegistration,
+			now:     current,
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "invalid format",
+			signer:  signer,
+			session: "session",
+			typ:     sessionTypeRegistration,
+			now:     current,
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "unexpected type",
+			signer:  signer,
+			session: session,
+			typ:     sessionTypeAuthentication,
+			now:     current,
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "expired",
+			signer:  signer,
+			session: session,
+			typ:     sessionTypeRegistration,
+			now:     current.Add(time.Minute),
+			err:     ErrSessionExpired,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := tt.signer.verify(tt.session, tt.typ, tt.now)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}